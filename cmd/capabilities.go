@@ -0,0 +1,70 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/adaryorg/nclip/internal/ui"
+)
+
+// printCapabilities probes the terminal, refreshes the cache and prints the results
+func printCapabilities() error {
+	caps, err := ui.LoadTerminalCapabilities(true)
+	if err != nil {
+		return err
+	}
+	detected := ui.DetectTerminalCapabilities(false)
+
+	fmt.Printf("Terminal:          %s\n", caps.Term)
+	if !caps.Responded {
+		fmt.Println("Probe:             no answer, using environment heuristics")
+	} else {
+		fmt.Printf("Device attributes: %s\n", caps.DeviceAttributes)
+	}
+	fmt.Printf("Kitty graphics:    %s\n", yesNo(caps.Kitty))
+	fmt.Printf("Truecolor:         %s\n", yesNo(caps.TrueColor))
+	fmt.Printf("Sixel:             %s\n", yesNo(caps.Sixel))
+	if caps.CellWidth > 0 && caps.CellHeight > 0 {
+		fmt.Printf("Cell size:         %dx%d pixels\n", caps.CellWidth, caps.CellHeight)
+	} else {
+		fmt.Println("Cell size:         not reported")
+	}
+	fmt.Printf("Unicode symbols:   %s\n", yesNo(detected.SupportsUnicode))
+	fmt.Printf("Colors:            %s\n", yesNo(detected.SupportsColor))
+
+	if path, err := ui.CapabilitiesPath(); err == nil {
+		fmt.Printf("\nCached in %s\n", path)
+	}
+	return nil
+}
+
+// yesNo formats a capability for display
+func yesNo(supported bool) string {
+	if supported {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,67 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// newCaptureFilter returns a monitor that never watches the clipboard, for text nclip adds
+// to the history itself. It runs the text through the same [capture] filters as the daemon
+// (ignore rules, length limits and blocked hashes) and hands what passes to accept; store
+// gets the daemon's normalization and one-time code expiry. Close the monitor when done.
+func newCaptureFilter(daemonConfig *config.DaemonConfig, store *storage.Storage, accept func(content string)) (*clipboard.Monitor, error) {
+	capture := daemonConfig.Capture
+	ignoreRules, err := clipboard.NewIgnoreRules(capture.Ignore.Patterns, capture.Ignore.Globs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load capture ignore rules: %w", err)
+	}
+
+	store.SetOTPExpiry(time.Duration(capture.OTPTTLSeconds) * time.Second)
+	store.SetNormalization(storage.NormalizeOptions{
+		TrimTrailingWhitespace: capture.TrimTrailingWhitespace,
+		NormalizeLineEndings:   capture.NormalizeLineEndings,
+		StripQuotes:            capture.StripQuotes,
+		StripTrackingParams:    capture.StripTrackingParams,
+	})
+
+	monitor := clipboard.NewMonitor(accept)
+	monitor.SetIgnoreRules(ignoreRules)
+	monitor.SetLengthLimits(capture.MinLength, capture.MaxLength)
+	if capture.Quarantine {
+		monitor.SetQuarantine(func(content string, imageData []byte, source clipboard.Source, reason string) {
+			if _, err := store.Quarantine(content, "text", nil, storage.Source(source), reason); err != nil {
+				logging.Error("Failed to quarantine blocked content: %v", err)
+			}
+		})
+	}
+	return monitor, nil
+}
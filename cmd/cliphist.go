@@ -0,0 +1,92 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/cliphist"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// invokedAsCliphist reports whether nclip was started through a "cliphist" symlink
+func invokedAsCliphist() bool {
+	return filepath.Base(os.Args[0]) == "cliphist"
+}
+
+// runCliphist runs a cliphist command (list, decode, delete, delete-query, store)
+// against the nclip history
+func runCliphist(args []string) error {
+	flags := flag.NewFlagSet("cliphist", flag.ContinueOnError)
+	previewWidth := flags.Int("preview-width", cliphist.DefaultPreviewWidth, "maximum number of characters to preview")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("missing command, expected one of: list, decode, delete, delete-query, store")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	command, rest := flags.Arg(0), flags.Args()[1:]
+	switch command {
+	case "list":
+		return cliphist.List(os.Stdout, store, *previewWidth)
+	case "decode":
+		line := strings.Join(rest, " ")
+		if line == "" {
+			line, err = bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+		}
+		return cliphist.Decode(os.Stdout, store, line)
+	case "delete":
+		return cliphist.Delete(store, os.Stdin)
+	case "delete-query":
+		if len(rest) == 0 {
+			return fmt.Errorf("delete-query needs a query")
+		}
+		_, err := cliphist.DeleteQuery(store, strings.Join(rest, " "))
+		return err
+	case "store":
+		return cliphist.Store(store, os.Stdin, os.Getenv("CLIPBOARD_STATE"))
+	}
+	return fmt.Errorf("unknown cliphist command %q", command)
+}
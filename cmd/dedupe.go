@@ -0,0 +1,103 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+	"github.com/adaryorg/nclip/internal/ui"
+)
+
+// duplicateKindTitles names each kind of cluster in the report, in report order
+var duplicateKindTitles = []struct{ kind, title string }{
+	{storage.DuplicateExact, "Exact duplicates"},
+	{storage.DuplicateWhitespace, "Whitespace variants"},
+	{storage.DuplicatePrefix, "Prefix variants (partial selections of a longer entry)"},
+}
+
+// reportDuplicates prints clusters of near-duplicate entries without deleting anything
+func reportDuplicates() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	fmt.Printf("[INFO] Scanning %d entries for near-duplicates (nothing is deleted)\n", store.GetItemCount())
+	clusters := store.DuplicateReport()
+	if len(clusters) == 0 {
+		fmt.Println("[OK] No duplicate entries found")
+		return nil
+	}
+
+	for _, kind := range duplicateKindTitles {
+		var matching []storage.DuplicateCluster
+		var entries int
+		var reclaimable int64
+		for _, cluster := range clusters {
+			if cluster.Kind == kind.kind {
+				matching = append(matching, cluster)
+				entries += len(cluster.Items)
+				reclaimable += cluster.Reclaimable()
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		fmt.Println()
+		fmt.Printf("%s: %d clusters, %d entries, %s reclaimable\n", kind.title, len(matching), entries, ui.FormatSize(reclaimable))
+		for _, cluster := range matching {
+			fmt.Printf("  %d entries, %s: %s\n", len(cluster.Items), ui.FormatSize(cluster.Size()), duplicatePreview(cluster.Items[0]))
+			var ids []string
+			for _, item := range cluster.Items {
+				ids = append(ids, item.ID)
+			}
+			fmt.Printf("    ids (kept first): %s\n", strings.Join(ids, ", "))
+		}
+	}
+	fmt.Println()
+	fmt.Println("[INFO] Run nclip --dedupe to remove exact duplicates")
+	return nil
+}
+
+// duplicatePreview shows the start of an entry on one line, hiding flagged secrets
+func duplicatePreview(item storage.ClipboardItemMeta) string {
+	if item.ThreatLevel == "high" && !item.SafeEntry {
+		return "(high-risk entry hidden)"
+	}
+	preview := strings.Join(strings.Fields(item.Content), " ")
+	if runes := []rune(preview); len(runes) > 60 {
+		preview = string(runes[:57]) + "..."
+	}
+	return fmt.Sprintf("%q", preview)
+}
@@ -0,0 +1,153 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// shellQuote quotes an argument for sh unless it only has characters sh leaves alone
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@%") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// splitExecArgs splits the command line at --exec, so that in nclip --exec ls -la the
+// -la goes to ls instead of being parsed as an nclip flag. It returns nclip's own
+// arguments and the command with its arguments, which is nil without --exec. Like the
+// flag package it stops looking at the first argument that isn't a flag, and it skips
+// the values of flags that take one, so --note --exec stays a note.
+func splitExecArgs(flags *flag.FlagSet, args []string) (own, command []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || arg == "-" || !strings.HasPrefix(arg, "-") {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+		if name == "exec" {
+			command = append([]string{}, args[i+1:]...)
+			if hasValue {
+				command = append([]string{value}, command...)
+			}
+			return args[:i], command
+		}
+		if f := flags.Lookup(name); f != nil && !hasValue && !isBoolFlag(f) {
+			i++ // The next argument is this flag's value
+		}
+	}
+	return args, nil
+}
+
+// isBoolFlag reports whether a flag is set without a value, like -d
+func isBoolFlag(f *flag.Flag) bool {
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+// execCommandLine joins the --exec command with any arguments after it, so both
+// nclip --exec "git log -5" and nclip --exec git log -5 work
+func execCommandLine(command string, args []string) string {
+	parts := []string{command}
+	for _, arg := range args {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// runExec runs a command through sh, stores its standard output in the history with the
+// command line as its source, and copies it. Standard error goes to the terminal as
+// usual. It returns the exit status of the command, so the output of a failing command
+// is kept too.
+func runExec(command string) (int, error) {
+	var stdout bytes.Buffer
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	status := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return 1, fmt.Errorf("failed to run command: %w", err)
+		}
+		status = exitErr.ExitCode()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return 1, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		return 1, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	output := stdout.String()
+	if _, err := store.AddCommandOutput(output, command); err != nil {
+		if errors.Is(err, storage.ErrEmptyOutput) {
+			fmt.Fprintln(os.Stderr, "[--] Command printed nothing, clipboard unchanged")
+			return status, nil
+		}
+		return 1, fmt.Errorf("failed to store output: %w", err)
+	}
+	// The daemon would otherwise see the clipboard change and store the output again
+	if err := store.RecordCopy(output, "text", nil); err != nil {
+		return 1, fmt.Errorf("failed to record copy: %w", err)
+	}
+
+	copied := "copied"
+	if clipboard.Headless() {
+		copied = "stored, no display to copy to,"
+	} else if storage.ThreatLevel(output, "text") == "high" {
+		err = clipboard.CopySensitive(output)
+	} else {
+		err = clipboard.Copy(output)
+	}
+	if err != nil {
+		return 1, fmt.Errorf("failed to copy output: %w", err)
+	}
+
+	lines := strings.Count(strings.TrimRight(output, "\n"), "\n") + 1
+	noun := "lines"
+	if lines == 1 {
+		noun = "line"
+	}
+	fmt.Fprintf(os.Stderr, "[OK] %d %s %s (search with tag:%s)\n", lines, noun, copied, storage.ExecTag)
+	return status, nil
+}
@@ -0,0 +1,85 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestSplitExecArgs(t *testing.T) {
+	flags := flag.NewFlagSet("nclip", flag.ContinueOnError)
+	flags.Bool("d", false, "")
+	flags.String("note", "", "")
+	flags.String("exec", "", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		own     []string
+		command []string
+	}{
+		{"no exec", []string{"-d"}, []string{"-d"}, nil},
+		{"quoted command", []string{"--exec", "git log -5"}, []string{}, []string{"git log -5"}},
+		{"command flags", []string{"--exec", "ls", "-la"}, []string{}, []string{"ls", "-la"}},
+		{"nclip flag after exec", []string{"--exec", "ls", "-d"}, []string{}, []string{"ls", "-d"}},
+		{"flags before exec", []string{"-d", "-exec", "ls"}, []string{"-d"}, []string{"ls"}},
+		{"equals form", []string{"--exec=ls", "-la"}, []string{}, []string{"ls", "-la"}},
+		{"missing command", []string{"--exec"}, []string{}, []string{}},
+		{"flag value", []string{"--note", "--exec"}, []string{"--note", "--exec"}, nil},
+		{"after positional", []string{"file", "--exec", "ls"}, []string{"file", "--exec", "ls"}, nil},
+		{"after terminator", []string{"--", "--exec", "ls"}, []string{"--", "--exec", "ls"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			own, command := splitExecArgs(flags, tt.args)
+			if !slices.Equal(own, tt.own) {
+				t.Errorf("Expected own arguments %q, got %q", tt.own, own)
+			}
+			if (command == nil) != (tt.command == nil) || !slices.Equal(command, tt.command) {
+				t.Errorf("Expected command %q, got %q", tt.command, command)
+			}
+		})
+	}
+}
+
+func TestExecCommandLine(t *testing.T) {
+	tests := []struct {
+		command string
+		args    []string
+		want    string
+	}{
+		{"git log -5", nil, "git log -5"},
+		{"ls", []string{"-la", "/tmp"}, "ls -la /tmp"},
+		{"echo", []string{"two words", "it's"}, `echo 'two words' 'it'\''s'`},
+		{"printf", []string{""}, "printf ''"},
+	}
+	for _, tt := range tests {
+		if got := execCommandLine(tt.command, tt.args); got != tt.want {
+			t.Errorf("execCommandLine(%q, %q) = %q, want %q", tt.command, tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+// hotkeyComment marks the keybinding written by --install-hotkey in compositor configs
+const hotkeyComment = "# nclip: open the clipboard picker (added by nclip --install-hotkey)"
+
+// gnomeKeybinding is the dconf path of the custom shortcut --install-hotkey creates
+const gnomeKeybinding = "/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/nclip/"
+
+// errHotkeyInstalled is returned when the keybinding is already in place
+var errHotkeyInstalled = errors.New("an nclip keybinding is already installed")
+
+// hotkeyEnvironment names the desktop nclip runs in, as far as --install-hotkey supports it
+func hotkeyEnvironment() (string, error) {
+	desktop := strings.ToUpper(os.Getenv("XDG_CURRENT_DESKTOP"))
+	switch {
+	case os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "":
+		return "hyprland", nil
+	case os.Getenv("SWAYSOCK") != "": // Sway sets I3SOCK as well
+		return "sway", nil
+	case os.Getenv("I3SOCK") != "" || desktop == "I3":
+		return "i3", nil
+	case strings.Contains(desktop, "KDE"):
+		return "kde", nil
+	case strings.Contains(desktop, "GNOME"):
+		return "gnome", nil
+	}
+	return "", errors.New("no supported desktop found (Hyprland, Sway, i3, KDE Plasma or GNOME)")
+}
+
+// installHotkey binds Super+Shift+V to nclip --toggle in the detected desktop. Super+V
+// itself is a default binding in Hyprland (togglefloating), sway and i3 (split vertically),
+// KDE (Klipper) and GNOME (notification list), so it is left alone.
+func installHotkey() error {
+	environment, err := hotkeyEnvironment()
+	if err != nil {
+		return err
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate nclip: %w", err)
+	}
+	// Desktop sessions often don't have ~/.local/bin on their PATH. Hyprland, sway, i3 and
+	// GNOME run the command through a shell or parse it like one.
+	command := shellQuote(self) + " --toggle"
+
+	switch environment {
+	case "hyprland":
+		path := wmConfigPath("hypr/hyprland.conf")
+		snippet := fmt.Sprintf("%s\nbind = SUPER SHIFT, V, exec, %s\nwindowrulev2 = float, class:^(nclip)$\n", hotkeyComment, command)
+		err = appendHotkey(path, environment, snippet)
+		if err == nil {
+			fmt.Printf("[OK] Added Super+Shift+V to %s; Hyprland reloads it automatically\n", path)
+		}
+	case "sway", "i3":
+		path := wmConfigPath(environment + "/config")
+		criteria := `[app_id="nclip"]`
+		if environment == "i3" {
+			criteria = `[class="nclip"]`
+		}
+		snippet := fmt.Sprintf("%s\nbindsym Mod4+Shift+v exec %s\nfor_window %s floating enable\n", hotkeyComment, command, criteria)
+		err = appendHotkey(path, environment, snippet)
+		if err == nil {
+			fmt.Printf("[OK] Added Super+Shift+V to %s\n", path)
+			reload := map[string]string{"sway": "swaymsg", "i3": "i3-msg"}[environment]
+			if exec.Command(reload, "reload").Run() != nil {
+				fmt.Printf("Reload the %s configuration to use it\n", environment)
+			}
+		}
+	case "kde":
+		err = installKDEHotkey(desktopExecQuote(self) + " --toggle")
+	case "gnome":
+		err = installGNOMEHotkey(command)
+	}
+	if errors.Is(err, errHotkeyInstalled) {
+		fmt.Printf("[OK] The %s keybinding for nclip is already installed\n", environment)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cfg, err := config.Load(); err == nil && cfg.Behavior.ToggleTerminal == "" && os.Getenv("TERMINAL") == "" {
+		fmt.Println("Set toggle_terminal in [behavior] of nclip.toml so the hotkey can open a terminal")
+	}
+	return nil
+}
+
+// wmConfigPath returns a compositor config file under $XDG_CONFIG_HOME, or ~/.config
+func wmConfigPath(name string) string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, _ := os.UserHomeDir()
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, name)
+}
+
+// appendHotkey adds snippet to the end of a compositor config, unless the config already
+// runs nclip --toggle or binds Super+Shift+V to something else
+func appendHotkey(path, environment, snippet string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if strings.Contains(string(existing), hotkeyComment) || strings.Contains(string(existing), "nclip --toggle") {
+		return errHotkeyInstalled
+	}
+	if hotkeyBound(environment, string(existing)) {
+		return fmt.Errorf("the key Super+Shift+V is already bound in %s; bind nclip --toggle to a free key there yourself", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n\n") {
+		snippet = "\n" + snippet
+		if !strings.HasSuffix(string(existing), "\n") {
+			snippet = "\n" + snippet
+		}
+	}
+	if _, err := file.WriteString(snippet); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// hotkeyCombo is the key --install-hotkey binds, in the form normalizeCombo returns
+const hotkeyCombo = "shift+super+v"
+
+// superNames are the names compositor configs use for the Super key
+var superNames = map[string]bool{"super": true, "mod4": true, "win": true, "logo": true, "meta": true}
+
+// hotkeyBound reports whether a Hyprland, sway or i3 config binds Super+Shift+V, with the
+// modifiers spelled out or through variables such as $mainMod or $mod
+func hotkeyBound(environment, config string) bool {
+	vars := make(map[string]string)
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimSpace(line)
+		var combo []string
+		if environment == "hyprland" {
+			name, value, ok := strings.Cut(line, "=")
+			if !ok {
+				continue
+			}
+			name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+			if strings.HasPrefix(name, "$") {
+				vars[name] = value
+				continue
+			}
+			fields := strings.Split(value, ",")
+			if !strings.HasPrefix(name, "bind") || len(fields) < 2 {
+				continue
+			}
+			modifiers := expandConfigVars(fields[0], vars)
+			combo = append(strings.FieldsFunc(modifiers, func(r rune) bool { return r == ' ' || r == '_' }), fields[1])
+		} else {
+			fields := strings.Fields(line)
+			if len(fields) >= 3 && fields[0] == "set" && strings.HasPrefix(fields[1], "$") {
+				vars[fields[1]] = fields[2]
+				continue
+			}
+			if len(fields) < 2 || fields[0] != "bindsym" {
+				continue
+			}
+			for _, field := range fields[1:] {
+				if !strings.HasPrefix(field, "--") {
+					combo = strings.Split(expandConfigVars(field, vars), "+")
+					break
+				}
+			}
+		}
+		if normalizeCombo(combo) == hotkeyCombo {
+			return true
+		}
+	}
+	return false
+}
+
+// expandConfigVars replaces the config variables in s, longest names first so $mod
+// doesn't match the start of $modifier
+func expandConfigVars(s string, vars map[string]string) string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	for _, name := range names {
+		s = strings.ReplaceAll(s, name, vars[name])
+	}
+	return s
+}
+
+// normalizeCombo writes a key combination in lower case with its keys sorted and the
+// Super key under one name, so different spellings of the same keys compare equal
+func normalizeCombo(keys []string) string {
+	var normalized []string
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if superNames[key] {
+			key = "super"
+		}
+		normalized = append(normalized, key)
+	}
+	sort.Strings(normalized)
+	return strings.Join(normalized, "+")
+}
+
+// desktopExecQuote quotes an argument for the Exec key of a desktop entry, whose value is
+// unescaped once as a string and then split by the entry's own quoting rules
+func desktopExecQuote(arg string) string {
+	if strings.ContainsAny(arg, " \t\n\"'\\><~|&;$*?#()`") {
+		arg = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`).Replace(arg) + `"`
+		arg = strings.ReplaceAll(arg, `\`, `\\`)
+	}
+	return strings.ReplaceAll(arg, "%", "%%") // % starts a field code such as %f
+}
+
+// installKDEHotkey registers a desktop entry with a global shortcut in KDE Plasma
+func installKDEHotkey(command string) error {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, _ := os.UserHomeDir()
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	entry := filepath.Join(dataHome, "applications", "nclip.desktop")
+	if _, err := os.Stat(entry); err == nil {
+		return errHotkeyInstalled
+	}
+
+	kwriteconfig := ""
+	for _, name := range []string{"kwriteconfig6", "kwriteconfig5"} {
+		if _, err := exec.LookPath(name); err == nil {
+			kwriteconfig = name
+			break
+		}
+	}
+	if kwriteconfig == "" {
+		return errors.New("kwriteconfig6 or kwriteconfig5 is needed to register the shortcut")
+	}
+
+	content := fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=nclip
+Comment=Clipboard history picker
+Exec=%s
+Terminal=false
+NoDisplay=true
+X-KDE-Shortcuts=Meta+Shift+V
+`, command)
+	if err := os.MkdirAll(filepath.Dir(entry), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(entry, []byte(content), 0644); err != nil {
+		return err
+	}
+	out, err := exec.Command(kwriteconfig, "--file", "kglobalshortcutsrc", "--group", "services",
+		"--group", "nclip.desktop", "--key", "_launch", "Meta+Shift+V").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %v: %s", kwriteconfig, err, strings.TrimSpace(string(out)))
+	}
+	fmt.Printf("[OK] Added Meta+Shift+V through %s; it works after logging in again\n", entry)
+	return nil
+}
+
+// installGNOMEHotkey adds a custom keyboard shortcut through gsettings
+func installGNOMEHotkey(command string) error {
+	const schema = "org.gnome.settings-daemon.plugins.media-keys"
+	out, err := exec.Command("gsettings", "get", schema, "custom-keybindings").Output()
+	if err != nil {
+		return fmt.Errorf("gsettings failed: %w", err)
+	}
+	paths := parseGSettingsList(string(out))
+	for _, path := range paths {
+		if path == gnomeKeybinding {
+			return errHotkeyInstalled
+		}
+	}
+	paths = append(paths, gnomeKeybinding)
+
+	keybinding := schema + ".custom-keybinding:" + gnomeKeybinding
+	for _, args := range [][]string{
+		{"set", keybinding, "name", "nclip"},
+		{"set", keybinding, "command", command},
+		{"set", keybinding, "binding", "<Super><Shift>v"},
+		{"set", schema, "custom-keybindings", formatGSettingsList(paths)},
+	} {
+		if out, err := exec.Command("gsettings", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("gsettings %s failed: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	fmt.Println("[OK] Added Super+Shift+V as a custom shortcut in GNOME Settings")
+	return nil
+}
+
+// parseGSettingsList reads a GVariant string array such as "['/a/', '/b/']" or "@as []"
+func parseGSettingsList(value string) []string {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "@as"))
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.Trim(strings.TrimSpace(item), `'"`); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatGSettingsList writes a GVariant string array for gsettings set
+func formatGSettingsList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "'" + item + "'"
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
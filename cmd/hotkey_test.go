@@ -0,0 +1,137 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseGSettingsList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"@as []\n", nil},
+		{"[]", nil},
+		{"['/a/']\n", []string{"/a/"}},
+		{"['/a/', '/b/']", []string{"/a/", "/b/"}},
+	}
+	for _, tt := range tests {
+		if got := parseGSettingsList(tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("parseGSettingsList(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	// A list written by formatGSettingsList reads back the same
+	paths := []string{"/a/", gnomeKeybinding}
+	if got := parseGSettingsList(formatGSettingsList(paths)); !slices.Equal(got, paths) {
+		t.Errorf("Expected %q after a round trip, got %q", paths, got)
+	}
+}
+
+func TestAppendHotkey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hypr", "hyprland.conf")
+	snippet := hotkeyComment + "\nbind = SUPER SHIFT, V, exec, nclip --toggle\n"
+
+	// A new config is created, and an existing one gets a blank line before the snippet
+	if err := appendHotkey(path, "hyprland", snippet); err != nil {
+		t.Fatalf("appendHotkey failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != snippet {
+		t.Errorf("Expected only the snippet in a new config, got %q", data)
+	}
+	if err := appendHotkey(path, "hyprland", snippet); !errors.Is(err, errHotkeyInstalled) {
+		t.Errorf("Expected errHotkeyInstalled the second time, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("monitor = ,preferred,auto,1"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := appendHotkey(path, "hyprland", snippet); err != nil {
+		t.Fatalf("appendHotkey failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "monitor = ,preferred,auto,1\n\n"+snippet {
+		t.Errorf("Expected the snippet after a blank line, got %q", data)
+	}
+
+	// A key bound to something else is left alone
+	taken := "$mainMod = SUPER\nbind = $mainMod SHIFT, V, togglesplit,\n"
+	if err := os.WriteFile(path, []byte(taken), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := appendHotkey(path, "hyprland", snippet); err == nil || errors.Is(err, errHotkeyInstalled) {
+		t.Errorf("Expected an error for a bound key, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != taken {
+		t.Errorf("Expected the config to stay unchanged, got %q", data)
+	}
+}
+
+func TestHotkeyBound(t *testing.T) {
+	tests := []struct {
+		environment string
+		config      string
+		want        bool
+	}{
+		// The default configs bind Super+V, not Super+Shift+V
+		{"hyprland", "$mainMod = SUPER\nbind = $mainMod, V, togglefloating,\n", false},
+		{"sway", "set $mod Mod4\nbindsym $mod+v splitv\n", false},
+		{"i3", "set $mod Mod4\nbindsym $mod+v split vertical\n", false},
+
+		{"hyprland", "bind = SUPER SHIFT, V, exec, cliphist\n", true},
+		{"hyprland", "bind = SHIFT_SUPER, v, exec, cliphist\n", true},
+		{"hyprland", "$mainMod = SUPER\nbinde = $mainMod SHIFT, V, exec, cliphist\n", true},
+		{"sway", "set $mod Mod4\nbindsym $mod+Shift+v move scratchpad\n", true},
+		{"sway", "bindsym --release Mod4+Shift+V exec cliphist\n", true},
+		{"i3", "set $mod Mod4\nset $modifier Mod1\nbindsym $modifier+Shift+v split\n", false},
+		{"i3", "bindsym Mod1+Shift+v split\n", false},
+	}
+	for _, tt := range tests {
+		if got := hotkeyBound(tt.environment, tt.config); got != tt.want {
+			t.Errorf("hotkeyBound(%s, %q) = %v, want %v", tt.environment, tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestHotkeyCommandQuoting(t *testing.T) {
+	if got := shellQuote("/home/me/My Tools/nclip"); got != "'/home/me/My Tools/nclip'" {
+		t.Errorf("Expected a path with spaces to be quoted for the shell, got %s", got)
+	}
+	if got := desktopExecQuote("/usr/bin/nclip"); got != "/usr/bin/nclip" {
+		t.Errorf("Expected a plain path to stay unquoted, got %s", got)
+	}
+	if got := desktopExecQuote("/home/me/My Tools/nclip"); got != `"/home/me/My Tools/nclip"` {
+		t.Errorf("Expected a path with spaces in double quotes, got %s", got)
+	}
+	// $ is escaped inside the quotes, and the backslash once more for the string value
+	if got := desktopExecQuote("/opt/$x/100%/nclip"); !strings.HasPrefix(got, `"/opt/\\$x/100%%/`) {
+		t.Errorf("Expected $ and %% to be escaped, got %s", got)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/importer"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// importHistory copies the history of another clipboard manager into the database
+func importHistory(source, path string) error {
+	entries, err := importer.Read(source, path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[INFO] Read %d entries from %s\n", len(entries), source)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	daemonConfig, err := config.LoadDaemonConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+	store.SetRetention(cfg.Database.TTL(), cfg.Database.ArchiveEvicted)
+	store.SetTypeLimits(cfg.Database.MaxTextEntries, cfg.Database.MaxImageEntries)
+
+	// Text is filtered like copies nclipd captures, so the import doesn't bring back
+	// what the ignore rules or blocked hashes keep out of the history
+	var passed bool
+	filter, err := newCaptureFilter(daemonConfig, store, func(string) { passed = true })
+	if err != nil {
+		return err
+	}
+	defer filter.Close()
+
+	// Import oldest first so ties in timestamps keep the original order
+	imported, duplicates, filtered := 0, 0, 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Type == "text" {
+			passed = false
+			filter.StoreText(entry.Content, clipboard.Source{})
+			if !passed {
+				filtered++
+				continue
+			}
+		}
+		added, err := store.Import(entry.Content, entry.Type, entry.ImageData, entry.Timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to import entry: %w", err)
+		}
+		if added {
+			imported++
+		} else {
+			duplicates++
+		}
+	}
+
+	evicted, err := store.EnforceRetention()
+	if err != nil {
+		return fmt.Errorf("failed to enforce retention: %w", err)
+	}
+
+	fmt.Printf("[OK] Imported %d entries from %s (%d already in history)\n", imported, source, duplicates)
+	if filtered > 0 {
+		fmt.Printf("[INFO] %d entries were skipped by the [capture] filters in nclipd.toml\n", filtered)
+	}
+	if evicted > 0 {
+		fmt.Printf("[INFO] %d old entries were evicted by max_entries or ttl_days\n", evicted)
+	}
+	return nil
+}
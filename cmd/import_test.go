@@ -0,0 +1,68 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestImportHistory_CaptureFilters(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configDir := filepath.Join(home, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	daemonConfig := "[capture]\ntrim_trailing_whitespace = true\n\n[capture.ignore]\npatterns = ['^secret']\n"
+	if err := os.WriteFile(filepath.Join(configDir, "nclipd.toml"), []byte(daemonConfig), 0644); err != nil {
+		t.Fatalf("Failed to write nclipd.toml: %v", err)
+	}
+	history := filepath.Join(t.TempDir(), "clipman.json")
+	if err := os.WriteFile(history, []byte(`["secret token", "kept line  "]`), 0644); err != nil {
+		t.Fatalf("Failed to write clipman history: %v", err)
+	}
+
+	if err := importHistory("clipman", history); err != nil {
+		t.Fatalf("importHistory failed: %v", err)
+	}
+
+	store, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to open storage: %v", err)
+	}
+	defer store.Close()
+	var stored []string
+	for _, item := range store.GetAllMeta() {
+		stored = append(stored, item.Content)
+	}
+	if !slices.Equal(stored, []string{"kept line"}) {
+		t.Errorf("Expected only the normalized line imported, got %q", stored)
+	}
+}
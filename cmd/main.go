@@ -25,35 +25,76 @@ SOFTWARE.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
 	"github.com/adaryorg/nclip/internal/security"
 	"github.com/adaryorg/nclip/internal/storage"
 	"github.com/adaryorg/nclip/internal/version"
 )
 
 func main() {
+	// cliphist compatibility takes over the command line, as cliphist has its own flags
+	if invokedAsCliphist() || (len(os.Args) > 1 && (os.Args[1] == "--cliphist" || os.Args[1] == "-cliphist")) {
+		args := os.Args[1:]
+		if !invokedAsCliphist() {
+			args = os.Args[2:]
+		}
+		if err := runCliphist(args); err != nil {
+			log.Fatalf("Failed to run cliphist command: %v", err)
+		}
+		return
+	}
+
 	// Define command line flags
 	removeSecurityInfo := flag.Bool("remove-security-information", false, "Clear all stored security hash information and start fresh")
 	deduplicate := flag.Bool("deduplicate", false, "Remove duplicate entries from clipboard history database")
 	deduplicateShort := flag.Bool("d", false, "Remove duplicate entries from clipboard history database")
+	dedupe := flag.Bool("dedupe", false, "Remove duplicate entries from clipboard history database")
+	report := flag.Bool("report", false, "With --dedupe, list near-duplicate clusters without deleting anything")
 	prune := flag.Bool("prune", false, "Remove entries with no data or single character data from database")
 	pruneShort := flag.Bool("p", false, "Remove entries with no data or single character data from database")
 	rescanSecurity := flag.Bool("rescan-security", false, "Re-scan all clipboard entries with updated security detection")
 	rescanSecurityShort := flag.Bool("r", false, "Re-scan all clipboard entries with updated security detection")
 	basicTerminal := flag.Bool("basic-terminal", false, "Disable advanced terminal features (Unicode symbols, colors)")
 	basicTerminalShort := flag.Bool("b", false, "Disable advanced terminal features (Unicode symbols, colors)")
+	panicFlag := flag.Bool("panic", false, "Clear the clipboard and wipe all unpinned history")
+	note := flag.String("note", "", "Add a note to self to the history (- reads it from stdin)")
+	flag.String("exec", "", "Run a command, store its output tagged exec and copy it") // Taken off by splitExecArgs
+	toggle := flag.Bool("toggle", false, "Focus the running TUI, or open one in a new terminal")
+	installHotkeyFlag := flag.Bool("install-hotkey", false, "Bind a key to nclip --toggle in the detected desktop")
+	provider := flag.String("provider", "", "Clipboard provider for editors: copy (from stdin) or paste (to stdout)")
+	list := flag.Bool("list", false, "Print the history for Nushell or fish pipelines (see --format)")
+	format := flag.String("format", "", "Output format of --list: nuon or fish")
+	importFrom := flag.String("import-from", "", "Import the history of klipper, gpaste, clipman or copyq")
+	archive := flag.Bool("archive", false, "Browse archived clipboard entries and restore them")
+	zle := flag.Bool("zle", false, "Pick an entry and print it for a shell widget to insert at the cursor")
+	stayOpen := flag.Bool("stay-open", false, "Keep the TUI open after copying an item")
+	accessible := flag.Bool("accessible", false, "Screen reader and high-contrast friendly display")
 	themeFile := flag.String("theme", "", "Use custom theme file instead of default theme.toml")
 	themeFileShort := flag.String("t", "", "Use custom theme file instead of default theme.toml")
+	profileStartupFlag := flag.Bool("profile-startup", false, "Record startup timings and write a CPU profile")
+	capabilitiesFlag := flag.Bool("capabilities", false, "Probe the terminal and print the detected capabilities")
+	listChromaThemesFlag := flag.Bool("list-chroma-themes", false, "List the Chroma syntax highlighting themes")
+	debug := flag.Bool("debug", false, "Log at debug level and enable the F12 debug overlay")
 	help := flag.Bool("help", false, "Show help information")
 	helpShort := flag.Bool("h", false, "Show help information")
 	versionFlag := flag.Bool("version", false, "Display version and build information")
 	versionShort := flag.Bool("v", false, "Display version and build information")
-	flag.Parse()
+	checkUpdateFlag := flag.Bool("check-update", false, "Report whether a newer release is available")
+	selfUpdateFlag := flag.Bool("self-update", false, "Replace the nclip binaries with the latest release")
+
+	// Everything after --exec belongs to the command, so its flags aren't read as nclip's
+	args, execArgs := splitExecArgs(flag.CommandLine, os.Args[1:])
+	flag.CommandLine.Parse(args)
 
 	// Show version if requested
 	if *versionFlag || *versionShort {
@@ -65,12 +106,43 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Look for, or install, a newer release
+	if *checkUpdateFlag {
+		err := checkUpdate()
+		if err != nil {
+			log.Fatalf("Failed to check for updates: %v", err)
+		}
+		return
+	}
+	if *selfUpdateFlag {
+		err := selfUpdate()
+		if err != nil {
+			log.Fatalf("Failed to update: %v", err)
+		}
+		return
+	}
+
 	// Show help if requested
 	if *help || *helpShort {
 		showHelp()
 		return
 	}
 
+	// List syntax highlighting themes
+	if *listChromaThemesFlag {
+		listChromaThemes()
+		return
+	}
+
+	// Probe the terminal again and show what it supports
+	if *capabilitiesFlag {
+		err := printCapabilities()
+		if err != nil {
+			log.Fatalf("Failed to probe terminal capabilities: %v", err)
+		}
+		return
+	}
+
 	// Handle security information removal
 	if *removeSecurityInfo {
 		err := clearSecurityInformation()
@@ -82,8 +154,18 @@ func main() {
 		return
 	}
 
-	// Handle database deduplication
-	if *deduplicate || *deduplicateShort {
+	// Handle database deduplication, or only report duplicates
+	if *report && !(*deduplicate || *deduplicateShort || *dedupe) {
+		log.Fatalf("--report is only valid with --dedupe")
+	}
+	if *report {
+		err := reportDuplicates()
+		if err != nil {
+			log.Fatalf("Failed to report duplicates: %v", err)
+		}
+		return
+	}
+	if *deduplicate || *deduplicateShort || *dedupe {
 		err := deduplicateDatabase()
 		if err != nil {
 			log.Fatalf("Failed to deduplicate database: %v", err)
@@ -91,6 +173,69 @@ func main() {
 		return
 	}
 
+	// Type a note straight into the history
+	if *note != "" {
+		err := addNote(*note)
+		if err != nil {
+			log.Fatalf("Failed to save note: %v", err)
+		}
+		return
+	}
+
+	// Capture a command's output with the command line as its source, like CMD | xclip
+	if execArgs != nil {
+		if len(execArgs) == 0 || execArgs[0] == "" {
+			log.Fatalf("--exec needs a command to run")
+		}
+		status, err := runExec(execCommandLine(execArgs[0], execArgs[1:]))
+		if err != nil {
+			log.Fatalf("Failed to capture command output: %v", err)
+		}
+		os.Exit(status)
+	}
+
+	// Raise the running TUI from a compositor keybinding instead of starting another
+	if *toggle {
+		err := toggleTUI()
+		if err != nil {
+			log.Fatalf("Failed to toggle the TUI: %v", err)
+		}
+		return
+	}
+
+	// Set up the keybinding that --toggle is meant for
+	if *installHotkeyFlag {
+		err := installHotkey()
+		if err != nil {
+			log.Fatalf("Failed to install the hotkey: %v", err)
+		}
+		return
+	}
+
+	// Yank or paste for an editor that uses nclip as its clipboard
+	if *provider != "" {
+		err := runProvider(*provider)
+		if err != nil {
+			log.Fatalf("Clipboard provider failed: %v", err)
+		}
+		return
+	}
+
+	// Print the history in a structured shell format
+	if *format != "" && !*list {
+		log.Fatalf("--format is only valid with --list")
+	}
+	if *list {
+		if *format == "" {
+			log.Fatalf("--list needs --format nuon or fish")
+		}
+		err := listHistory(*format)
+		if err != nil {
+			log.Fatalf("Failed to list history: %v", err)
+		}
+		return
+	}
+
 	// Handle database pruning
 	if *prune || *pruneShort {
 		err := pruneDatabase()
@@ -100,6 +245,24 @@ func main() {
 		return
 	}
 
+	// Clear the clipboard and wipe the history, e.g. from a desktop hotkey
+	if *panicFlag {
+		err := runPanic()
+		if err != nil {
+			log.Fatalf("Failed to wipe history: %v", err)
+		}
+		return
+	}
+
+	// Import another clipboard manager's history, optionally from a given file
+	if *importFrom != "" {
+		err := importHistory(*importFrom, flag.Arg(0))
+		if err != nil {
+			log.Fatalf("Failed to import history: %v", err)
+		}
+		return
+	}
+
 	// Handle security rescan
 	if *rescanSecurity || *rescanSecurityShort {
 		err := rescanSecurityThreats()
@@ -115,6 +278,15 @@ func main() {
 		customThemeFile = *themeFileShort
 	}
 	
+	// Handle startup profiling
+	if *profileStartupFlag {
+		err := profileStartup(customThemeFile, *basicTerminal || *basicTerminalShort)
+		if err != nil {
+			log.Fatalf("Failed to profile startup: %v", err)
+		}
+		return
+	}
+
 	cfg, err := config.LoadWithCustomTheme(customThemeFile)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
@@ -124,8 +296,66 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize storage: %v", err)
 	}
+	store.SetRetention(cfg.Database.TTL(), cfg.Database.ArchiveEvicted)
+	store.SetTypeLimits(cfg.Database.MaxTextEntries, cfg.Database.MaxImageEntries)
+
+	// The flag enables stay-open mode on top of nclip.toml
+	if *stayOpen {
+		cfg.Behavior.StayOpen = true
+	}
+	if *accessible {
+		cfg.Display.Accessible = true
+	}
+
+	// The TUI logs to a file only, as it owns the terminal
+	logLevel := cfg.Logging.Level
+	if *debug {
+		logLevel = "debug"
+	}
+	err = logging.InitFileLogger(
+		cfg.Logging.LogFile,
+		logLevel,
+		cfg.Logging.MaxAge,
+		cfg.Logging.MaxSize,
+		cfg.Logging.MaxBackups,
+	)
+	if err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+	logging.Info("Starting nclip %s with log level: %s", version.Version, logLevel)
+	for _, migration := range store.AppliedMigrations() {
+		logging.Info("Applied database migration %s", migration)
+	}
+	if writer := store.PreviousWriter(); writer != "" {
+		logging.Info("Database was last opened by nclip %s", writer)
+	}
+	if cfg.Database.SecurePermissions {
+		if err := store.SecurePermissions(); err != nil {
+			logging.Error("Failed to secure database permissions: %v", err)
+		}
+	}
+	for _, warning := range store.PermissionWarnings() {
+		logging.Warn("Database permissions: %s", warning)
+	}
+	if cfg.Database.ShredDeleted {
+		if err := store.SetShred(true); err != nil {
+			logging.Error("Failed to enable shredding of deleted entries: %v", err)
+		}
+	}
+
+	if *zle {
+		picked, err := runInsertPicker(store, cfg, *basicTerminal || *basicTerminalShort)
+		store.Close()
+		if err != nil {
+			log.Fatalf("Shell widget picker failed: %v", err)
+		}
+		if !picked {
+			os.Exit(1)
+		}
+		return
+	}
 
-	startTUI(store, cfg, *basicTerminal || *basicTerminalShort)
+	startTUI(store, cfg, *basicTerminal || *basicTerminalShort, *archive, *debug)
 }
 
 func showHelp() {
@@ -135,10 +365,30 @@ func showHelp() {
 	fmt.Println("  nclip                              Start the TUI clipboard manager")
 	fmt.Println("  nclip --remove-security-information Clear all stored security hash data")
 	fmt.Println("  nclip --deduplicate, -d            Remove duplicate entries from clipboard history")
+	fmt.Println("  nclip --dedupe --report            List near-duplicate entries without deleting them")
+	fmt.Println("  nclip --note TEXT                  Add a note to self to the history")
+	fmt.Println("  nclip --exec CMD [ARGS]            Run a command and copy its output")
+	fmt.Println("  nclip --toggle                     Focus the running TUI or open it in a terminal")
+	fmt.Println("  nclip --install-hotkey             Bind a key to --toggle in the current desktop")
+	fmt.Println("  nclip --provider copy|paste        Clipboard provider for Helix, Kakoune and others")
+	fmt.Println("  nclip --list --format nuon|fish    Print the history for Nushell or fish pipelines")
 	fmt.Println("  nclip --prune, -p                  Remove entries with no data or single character data")
 	fmt.Println("  nclip --rescan-security, -r        Re-scan all entries with updated security detection")
+	fmt.Println("  nclip --panic                      Clear the clipboard and wipe unpinned history")
+	fmt.Println("  nclip --import-from SOURCE [FILE]  Import history from klipper, gpaste, clipman, copyq")
+	fmt.Println("  nclip --cliphist COMMAND           Run a cliphist command (list, decode, ...)")
 	fmt.Println("  nclip --basic-terminal, -b         Disable advanced terminal features")
+	fmt.Println("  nclip --archive                    Browse and restore archived entries")
+	fmt.Println("  nclip --stay-open                  Keep the TUI open after copying an item")
+	fmt.Println("  nclip --zle                        Pick an entry for a shell widget to insert")
+	fmt.Println("  nclip --accessible                 Screen reader and high-contrast friendly display")
 	fmt.Println("  nclip --theme FILE, -t FILE        Use custom theme file instead of default")
+	fmt.Println("  nclip --profile-startup            Print startup timings and write a CPU profile")
+	fmt.Println("  nclip --debug                      Log at debug level and enable the F12 overlay")
+	fmt.Println("  nclip --list-chroma-themes         List syntax highlighting themes")
+	fmt.Println("  nclip --capabilities               Probe the terminal and print what it supports")
+	fmt.Println("  nclip --check-update               Report whether a newer release is available")
+	fmt.Println("  nclip --self-update                Install the latest release over these binaries")
 	fmt.Println("  nclip --version, -v                Display version and build information")
 	fmt.Println("  nclip --help, -h                   Show this help message")
 	fmt.Println()
@@ -154,6 +404,51 @@ func showHelp() {
 	fmt.Println("                                     of each unique item. Useful for cleaning up")
 	fmt.Println("                                     databases that accumulated duplicates before")
 	fmt.Println("                                     the automatic deduplication feature was added.")
+	fmt.Println("                                     --dedupe is the same flag.")
+	fmt.Println()
+	fmt.Println("  --dedupe --report                  Prints clusters of exact duplicates, entries")
+	fmt.Println("                                     differing only in whitespace, and partial")
+	fmt.Println("                                     selections of a longer entry, with counts and")
+	fmt.Println("                                     sizes. Nothing is deleted.")
+	fmt.Println()
+	fmt.Println("  --note TEXT                        Stores typed text in the history tagged")
+	fmt.Println("                                     'note', as N does in the TUI, so nclip works")
+	fmt.Println("                                     as a scratchpad. Find notes with tag:note.")
+	fmt.Println("                                     Use - to read the note from standard input.")
+	fmt.Println()
+	fmt.Println("  --exec CMD [ARGS]                  Runs the command with sh, stores what it")
+	fmt.Println("                                     prints in the history tagged 'exec' with the")
+	fmt.Println("                                     command line as its source, and copies it, in")
+	fmt.Println("                                     place of CMD | xclip. Errors still show in the")
+	fmt.Println("                                     terminal; nclip exits with the command's status.")
+	fmt.Println("                                     Everything after --exec goes to the command.")
+	fmt.Println()
+	fmt.Println("  --toggle                           For compositor keybindings: focuses the")
+	fmt.Println("                                     terminal of the running TUI through Hyprland")
+	fmt.Println("                                     or Sway IPC, or opens the TUI in the terminal")
+	fmt.Println("                                     set by toggle_terminal in [behavior] of")
+	fmt.Println("                                     nclip.toml ($TERMINAL -e by default).")
+	fmt.Println()
+	fmt.Println("  --install-hotkey                   Binds a key to --toggle in the detected")
+	fmt.Println("                                     desktop: Super+Shift+V in the Hyprland, Sway")
+	fmt.Println("                                     or i3 config, or as a global shortcut in KDE")
+	fmt.Println("                                     Plasma and GNOME. Does nothing when an nclip")
+	fmt.Println("                                     keybinding is already installed, and stops")
+	fmt.Println("                                     when the key is bound to something else.")
+	fmt.Println()
+	fmt.Println("  --provider copy|paste              Lets modal editors use nclip as their system")
+	fmt.Println("                                     clipboard. copy reads the yanked text from")
+	fmt.Println("                                     standard input, adds it to the history and")
+	fmt.Println("                                     copies it; paste prints the clipboard, or the")
+	fmt.Println("                                     newest text entry when there is no display.")
+	fmt.Println()
+	fmt.Println("  --list --format nuon|fish          Prints every entry with its id, type,")
+	fmt.Println("                                     timestamp, pinned state, threat level, size")
+	fmt.Println("                                     and content. nuon is a list of records for")
+	fmt.Println("                                     'from nuon'; fish is one tab-separated line")
+	fmt.Println("                                     per entry with the content escaped for")
+	fmt.Println("                                     'string unescape'. Image entries have no")
+	fmt.Println("                                     content. See templates/shell for helpers.")
 	fmt.Println()
 	fmt.Println("  --prune, -p                        Removes entries with no data or single")
 	fmt.Println("                                     character data from the clipboard history")
@@ -165,18 +460,104 @@ func showHelp() {
 	fmt.Println("                                     current security detection algorithms. This")
 	fmt.Println("                                     updates threat levels and can reduce false")
 	fmt.Println("                                     positives after security improvements.")
+	fmt.Println("                                     Progress is shown as entries are scanned;")
+	fmt.Println("                                     Ctrl-C stops early and keeps finished batches.")
+	fmt.Println()
+	fmt.Println("  --panic                            Immediately clears the system clipboard,")
+	fmt.Println("                                     deletes every unpinned entry and the whole")
+	fmt.Println("                                     archive, and removes the saved TUI state.")
+	fmt.Println("                                     Pinned entries are kept. Bind it to a")
+	fmt.Println("                                     desktop hotkey to react to an accidental")
+	fmt.Println("                                     copy of a secret. In the TUI press ! twice.")
+	fmt.Println()
+	fmt.Println("  --import-from SOURCE [FILE]        Copies the history of another clipboard")
+	fmt.Println("                                     manager into nclip, keeping timestamps where")
+	fmt.Println("                                     the source records them. Reads the default")
+	fmt.Println("                                     history file unless FILE is given:")
+	fmt.Println("                                       klipper  ~/.local/share/klipper/history2.lst")
+	fmt.Println("                                       gpaste   ~/.local/share/gpaste/history.xml")
+	fmt.Println("                                       clipman  ~/.local/share/clipman.json")
+	fmt.Println("                                       copyq    read through the running copyq")
+	fmt.Println("                                     Entries already in the history are skipped,")
+	fmt.Println("                                     and text goes through the [capture] filters")
+	fmt.Println("                                     of nclipd.toml like copied text.")
+	fmt.Println()
+	fmt.Println("  --cliphist COMMAND                 Reads and edits the nclip history with the")
+	fmt.Println("                                     commands of cliphist: list, decode, delete,")
+	fmt.Println("                                     delete-query and store. Must be the first")
+	fmt.Println("                                     argument. A symlink named cliphist pointing")
+	fmt.Println("                                     to nclip behaves the same, so rofi or wofi")
+	fmt.Println("                                     scripts written for cliphist work unchanged.")
 	fmt.Println()
 	fmt.Println("  --basic-terminal, -b               Disables advanced terminal features like")
 	fmt.Println("                                     Unicode symbols and colors. Use this flag")
 	fmt.Println("                                     when working with old terminals or if you")
 	fmt.Println("                                     experience display issues.")
 	fmt.Println()
+	fmt.Println("  --archive                          Opens the TUI on the archive of entries that")
+	fmt.Println("                                     were evicted by max_entries or ttl_days while")
+	fmt.Println("                                     archive_evicted is enabled in nclipd.toml.")
+	fmt.Println("                                     Press r to restore an entry to the history.")
+	fmt.Println()
+	fmt.Println("  --stay-open                        Keeps the TUI open after copying an item so")
+	fmt.Println("                                     several entries can be copied in a row. The")
+	fmt.Println("                                     footer confirms each copy instead of exiting.")
+	fmt.Println("                                     Can also be enabled with stay_open = true")
+	fmt.Println("                                     in the [behavior] section of nclip.toml.")
+	fmt.Println()
+	fmt.Println("  --zle                              Opens a compact picker on the terminal and")
+	fmt.Println("                                     prints the chosen entry instead of copying it,")
+	fmt.Println("                                     so a shell widget can insert it at the cursor.")
+	fmt.Println("                                     Exits with status 1 when nothing is picked.")
+	fmt.Println("                                     See templates/shell for zsh and bash widgets.")
+	fmt.Println()
+	fmt.Println("  --accessible                       Goes further than --basic-terminal for screen")
+	fmt.Println("                                     readers and high contrast: pin and threat")
+	fmt.Println("                                     state use text labels, the selected item is")
+	fmt.Println("                                     marked with '>', colors and box drawing are")
+	fmt.Println("                                     dropped and status changes ring the bell. Same")
+	fmt.Println("                                     as accessible = true in [display].")
+	fmt.Println()
 	fmt.Println("  --theme FILE, -t FILE              Use a custom theme file instead of the default")
 	fmt.Println("                                     ~/.config/nclip/theme.toml. The file must be")
 	fmt.Println("                                     a valid TOML file with theme configuration.")
 	fmt.Println("                                     Can be an absolute path or relative to current")
 	fmt.Println("                                     directory. See THEMING.md for documentation.")
 	fmt.Println()
+	fmt.Println("  --profile-startup                  Runs the TUI startup sequence without opening")
+	fmt.Println("                                     the interface and prints timings for config")
+	fmt.Println("                                     load, database open, initial meta query and")
+	fmt.Println("                                     first render. A CPU profile is written to")
+	fmt.Println("                                     nclip-startup.pprof in the current directory.")
+	fmt.Println()
+	fmt.Println("  --debug                            Raises the TUI log level to debug and enables")
+	fmt.Println("                                     the debug overlay. Press F12 in the TUI to show")
+	fmt.Println("                                     the current state and the most recent log lines.")
+	fmt.Println("                                     The log file and default level are set in the")
+	fmt.Println("                                     [logging] section of nclip.toml.")
+	fmt.Println()
+	fmt.Println("  --list-chroma-themes               Lists the Chroma themes that can be used for")
+	fmt.Println("                                     syntax highlighting in the [chroma] section of")
+	fmt.Println("                                     theme.toml. Use theme = \"custom\" to color code")
+	fmt.Println("                                     with the [code_highlight] section instead.")
+	fmt.Println()
+	fmt.Println("  --capabilities                     Queries the terminal for Kitty graphics, truecolor")
+	fmt.Println("                                     and sixel support, prints the answers and updates")
+	fmt.Println("                                     the per-TERM cache in ~/.config/nclip. The TUI")
+	fmt.Println("                                     probes only when no cached entry exists.")
+	fmt.Println()
+	fmt.Println("  --check-update                     Asks GitHub for the latest release and reports")
+	fmt.Println("                                     whether it is newer than this build. Nothing")
+	fmt.Println("                                     is downloaded or changed.")
+	fmt.Println()
+	fmt.Println("  --self-update                      Downloads the latest release for this platform,")
+	fmt.Println("                                     verifies its signed checksums and replaces")
+	fmt.Println("                                     nclip, nclipd and nclip-tray where they are")
+	fmt.Println("                                     installed next to nclip. Refuses to touch")
+	fmt.Println("                                     binaries installed by a package manager")
+	fmt.Println("                                     (AUR, Homebrew, Nix, ...) and development")
+	fmt.Println("                                     builds.")
+	fmt.Println()
 	fmt.Println("  --version, -v                      Shows the version information including")
 	fmt.Println("                                     git tag, build time, and commit hash.")
 	fmt.Println()
@@ -185,6 +566,14 @@ func showHelp() {
 	fmt.Println("For more information, see the README.md file.")
 }
 
+// listChromaThemes prints the available syntax highlighting themes
+func listChromaThemes() {
+	for _, name := range config.ChromaThemeNames() {
+		fmt.Println(name)
+	}
+	fmt.Printf("%s (uses the [code_highlight] colors from theme.toml)\n", config.CustomChromaTheme)
+}
+
 func clearSecurityInformation() error {
 	// Initialize hash store and clear it
 	hashStore, err := security.NewHashStore()
@@ -343,9 +732,18 @@ func rescanSecurityThreats() error {
 	fmt.Println("[INFO] Re-scanning all clipboard entries with updated security detection...")
 	fmt.Println("[INFO] This may take a moment for large databases...")
 
+	// Stop at the next batch boundary on Ctrl-C, keeping the batches already committed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Run the security rescan
-	stats, err := store.RescanSecurityThreats()
-	if err != nil {
+	stats, err := store.RescanSecurityThreatsContext(ctx, func(scanned, total int) {
+		fmt.Printf("\r[INFO] Scanned %d/%d text entries", scanned, total)
+	})
+	fmt.Println()
+	if errors.Is(err, context.Canceled) {
+		fmt.Printf("[INFO] Rescan cancelled after %d entries, completed batches were saved\n", stats["items_scanned"])
+	} else if err != nil {
 		return fmt.Errorf("failed to rescan security threats: %w", err)
 	}
 
@@ -393,6 +791,10 @@ func rescanSecurityThreats() error {
 		fmt.Printf("⚠️  %d items had their threat level increased\n", stats["upgraded"])
 	}
 
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
 	fmt.Println()
 	fmt.Println("[OK] Security rescan completed successfully!")
 	fmt.Println("💡 The improved detection should show fewer false positives for:")
@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// iconSize is the width and height of the tray icon in pixels
+const iconSize = 32
+
+// Board color of the icon for each daemon state
+var stateColors = map[daemonState]color.NRGBA{
+	stateRunning: {0x4c, 0xaf, 0x50, 0xff},
+	statePaused:  {0xff, 0xa7, 0x26, 0xff},
+	stateStopped: {0x9e, 0x9e, 0x9e, 0xff},
+}
+
+// trayIcon draws a clipboard in the color of state, as PNG
+func trayIcon(state daemonState) []byte {
+	board := stateColors[state]
+	clip := color.NRGBA{0x42, 0x42, 0x42, 0xff}
+	paper := color.NRGBA{0xfa, 0xfa, 0xfa, 0xff}
+
+	img := image.NewNRGBA(image.Rect(0, 0, iconSize, iconSize))
+	fill := func(x0, y0, x1, y1 int, c color.NRGBA) {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				img.SetNRGBA(x, y, c)
+			}
+		}
+	}
+	fill(5, 4, 27, 31, board)
+	fill(9, 9, 23, 27, paper)
+	fill(11, 1, 21, 7, clip)
+	// Lines of text on the paper
+	for y := 12; y < 25; y += 4 {
+		fill(11, y, 21, y+2, board)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,284 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// nclip-tray shows the state of nclipd in the system tray (StatusNotifierItem), with menu
+// entries to pause capture, open the TUI, clear the clipboard and copy recent items
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/systray"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+	"github.com/adaryorg/nclip/internal/version"
+)
+
+const (
+	recentItems     = 10              // Entries listed under Recent
+	refreshInterval = 2 * time.Second // How often the daemon state and history are checked
+	labelLength     = 40              // Characters of an entry shown in the menu
+)
+
+// daemonState is what the tray icon shows
+type daemonState int
+
+const (
+	stateStopped daemonState = iota
+	stateRunning
+	statePaused
+)
+
+func (s daemonState) String() string {
+	switch s {
+	case stateRunning:
+		return "nclipd is running"
+	case statePaused:
+		return "nclipd is paused"
+	}
+	return "nclipd is not running"
+}
+
+// currentState reads the daemon state from the records nclipd keeps for the session
+func currentState() daemonState {
+	if _, running := clipboard.DaemonPID(); !running {
+		return stateStopped
+	}
+	if clipboard.Paused() {
+		return statePaused
+	}
+	return stateRunning
+}
+
+// tray holds the menu entries that change while the tray runs
+type tray struct {
+	store   *storage.Storage
+	status  *systray.MenuItem
+	pause   *systray.MenuItem
+	recent  []*systray.MenuItem
+	state   daemonState
+	version int64 // Database change counter when recent entries were last filled
+
+	// Item shown by each recent entry, written by refresh and read by the click handlers
+	mu  sync.Mutex
+	ids []string
+}
+
+func main() {
+	versionFlag := flag.Bool("version", false, "Display version and build information")
+	flag.Parse()
+	if *versionFlag {
+		fmt.Printf("nclip-tray version %s | %s (%s)\n", version.Version, version.BuildTime, version.CommitHash)
+		os.Exit(0)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+	defer store.Close()
+
+	t := &tray{store: store, state: -1, version: -1}
+	systray.Run(t.ready, func() {})
+}
+
+// ready builds the menu once the tray is registered and starts handling it
+func (t *tray) ready() {
+	systray.SetTitle("nclip")
+
+	t.status = systray.AddMenuItem("", "")
+	t.status.Disable()
+	t.pause = systray.AddMenuItemCheckbox("Pause capture", "Stop storing copied content until resumed", false)
+	open := systray.AddMenuItem("Open nclip", "Focus the TUI, or open it in a terminal")
+	clear := systray.AddMenuItem("Clear clipboard", "Empty the clipboard and the primary selection")
+	systray.AddSeparator()
+	recentMenu := systray.AddMenuItem("Recent", "Copy a recent entry")
+	t.ids = make([]string, recentItems)
+	for i := 0; i < recentItems; i++ {
+		item := recentMenu.AddSubMenuItem("", "")
+		item.Hide()
+		t.recent = append(t.recent, item)
+		go t.copyOnClick(i)
+	}
+	systray.AddSeparator()
+	quit := systray.AddMenuItem("Quit", "Close the tray icon")
+
+	t.refresh()
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.refresh()
+			case <-t.pause.ClickedCh:
+				if err := clipboard.SetPaused(!clipboard.Paused()); err != nil {
+					logging.Error("Failed to change the capture pause: %v", err)
+				}
+				t.refresh()
+			case <-open.ClickedCh:
+				if err := openTUI(); err != nil {
+					logging.Error("Failed to open nclip: %v", err)
+				}
+			case <-clear.ClickedCh:
+				if err := clipboard.Clear(); err != nil {
+					logging.Error("Failed to clear the clipboard: %v", err)
+				}
+			case <-quit.ClickedCh:
+				systray.Quit()
+				return
+			}
+		}
+	}()
+}
+
+// refresh updates the icon when the daemon state changed and the recent entries when the
+// history did
+func (t *tray) refresh() {
+	if state := currentState(); state != t.state {
+		t.state = state
+		systray.SetIcon(trayIcon(state))
+		systray.SetTooltip(state.String())
+		t.status.SetTitle(state.String())
+		if state == statePaused {
+			t.pause.Check()
+		} else {
+			t.pause.Uncheck()
+		}
+	}
+
+	current, err := t.store.DataVersion()
+	if err == nil && current == t.version {
+		return
+	}
+	t.version = current
+
+	items := t.store.GetPage(0, recentItems)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, entry := range t.recent {
+		if i >= len(items) {
+			t.ids[i] = ""
+			entry.Hide()
+			continue
+		}
+		t.ids[i] = items[i].ID
+		entry.SetTitle(menuLabel(items[i]))
+		entry.Show()
+	}
+}
+
+// copyOnClick copies the item shown by the i-th recent entry whenever it is clicked
+func (t *tray) copyOnClick(i int) {
+	for range t.recent[i].ClickedCh {
+		t.mu.Lock()
+		id := t.ids[i]
+		t.mu.Unlock()
+		if id == "" {
+			continue
+		}
+		if err := copyItem(t.store, id); err != nil {
+			logging.Error("Failed to copy entry: %v", err)
+		}
+	}
+}
+
+// copyItem puts an item from the history on the clipboard, as the TUI does
+func copyItem(store *storage.Storage, id string) error {
+	item := store.GetFullItem(id)
+	if item == nil {
+		return fmt.Errorf("item %s not found", id)
+	}
+	// Let the daemon recognize this copy, so the item isn't stored again and bumped
+	if err := store.RecordCopy(item.Content, item.ContentType, item.ImageData); err != nil {
+		logging.Warn("Failed to record copy: %v", err)
+	}
+	switch {
+	case item.ContentType == "image":
+		return clipboard.CopyImage(item.ImageData)
+	case item.ThreatLevel == "high":
+		return clipboard.CopySensitive(item.Content)
+	}
+	return clipboard.Copy(item.Content)
+}
+
+// menuLabel describes an entry on one line, without showing text that looks like a secret
+func menuLabel(item storage.ClipboardItemMeta) string {
+	text := item.Content // Images are listed by their description
+	if item.ContentType != "image" {
+		switch {
+		case item.ThreatLevel == "high" || item.ThreatLevel == "medium":
+			text = "(possibly sensitive text)"
+		case item.Title != "":
+			text = item.Title
+		}
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) > labelLength {
+		text = string([]rune(text)[:labelLength-1]) + "…"
+	}
+	if item.IsPinned {
+		text = "📌 " + text
+	}
+	return text
+}
+
+// openTUI runs nclip --toggle, found next to nclip-tray or on the PATH
+func openTUI() error {
+	nclip := "nclip"
+	if self, err := os.Executable(); err == nil {
+		if sibling := filepath.Join(filepath.Dir(self), "nclip"); isExecutable(sibling) {
+			nclip = sibling
+		}
+	}
+	cmd := exec.Command(nclip, "--toggle")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// isExecutable reports whether path is a regular file anyone may execute
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode()&0111 != 0
+}
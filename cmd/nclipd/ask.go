@@ -0,0 +1,119 @@
+//go:build !nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// askTimeout bounds how long a notification waits for an answer; the item then stays
+// pending for the TUI
+const askTimeout = 2 * time.Minute
+
+// askToKeep holds captured content back as pending and asks in a desktop notification
+// whether to keep it; kept runs once it is stored. Unanswered items wait in the TUI.
+func askToKeep(ctx context.Context, store *storage.Storage, content, contentType string, imageData []byte, source storage.Source, kept func()) {
+	id, err := store.AddPending(content, contentType, imageData, source)
+	if err != nil {
+		logging.Error("Failed to hold clipboard content for review: %v", err)
+		return
+	}
+	logging.Debug("Holding %s %s until it is kept", contentType, id)
+
+	if !writes.begin() {
+		return
+	}
+	go func() {
+		defer writes.end()
+		answer, err := askDesktop(ctx, "Keep copied "+contentType+"?", pendingPreview(content, contentType, source))
+		if err != nil {
+			logging.Debug("No answer for pending %s %s, leaving it for the TUI: %v", contentType, id, err)
+			return
+		}
+		switch answer {
+		case "keep":
+			if err := store.ApprovePending(id); err != nil {
+				logging.Error("Failed to keep pending clipboard content: %v", err)
+				return
+			}
+			if kept != nil {
+				kept()
+			}
+		case "discard":
+			if err := store.RejectPending(id); err != nil {
+				logging.Error("Failed to discard pending clipboard content: %v", err)
+			}
+		}
+	}()
+}
+
+// askDesktop shows a notification with Keep and Discard actions through notify-send and
+// returns the chosen action, "" when the notification was dismissed
+func askDesktop(ctx context.Context, summary, body string) (string, error) {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, askTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path, "--app-name=nclip", "--wait",
+		"--action=keep=Keep", "--action=discard=Discard", summary, body).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// pendingPreview describes pending content in a notification without showing secrets,
+// which other notification listeners and the notification history could record
+func pendingPreview(content, contentType string, source storage.Source) string {
+	preview := content
+	if contentType == "text" {
+		if level := storage.ThreatLevel(content, contentType); level == "high" || level == "medium" {
+			preview = "Possibly sensitive text (hidden)"
+		} else {
+			preview = strings.TrimSpace(preview)
+			if line, _, found := strings.Cut(preview, "\n"); found {
+				preview = line + " …"
+			}
+			if runes := []rune(preview); len(runes) > 100 {
+				preview = string(runes[:100]) + "…"
+			}
+		}
+	}
+	if !source.IsZero() {
+		preview += "\nfrom " + source.String()
+	}
+	return preview
+}
@@ -0,0 +1,117 @@
+//go:build !nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/mqtt"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// clipboardEvent is the JSON payload published for every stored entry
+type clipboardEvent struct {
+	Event       string    `json:"event"`
+	Type        string    `json:"type"`
+	Host        string    `json:"host"`
+	Timestamp   time.Time `json:"timestamp"`
+	Length      int       `json:"length"` // Characters for text, bytes for images
+	Lines       int       `json:"lines,omitempty"`
+	ThreatLevel string    `json:"threat_level"`
+	Description string    `json:"description,omitempty"`
+	Content     string    `json:"content,omitempty"`
+}
+
+// eventPublisher publishes clipboard events to MQTT
+type eventPublisher struct {
+	publisher      *mqtt.Publisher
+	host           string
+	includeContent bool
+}
+
+// newEventPublisher creates a publisher from the [mqtt] configuration
+func newEventPublisher(cfg config.MQTTConfig) *eventPublisher {
+	host, _ := os.Hostname()
+	opts := mqtt.Options{
+		Broker:    cfg.Broker,
+		ClientID:  cfg.ClientID,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		KeepAlive: time.Duration(cfg.KeepAliveSeconds) * time.Second,
+	}
+	return &eventPublisher{
+		publisher:      mqtt.NewPublisher(opts, cfg.Topic, cfg.Retain),
+		host:           host,
+		includeContent: cfg.IncludeContent,
+	}
+}
+
+// textEvent builds the event for stored text, leaving out content with security threats
+func (p *eventPublisher) textEvent(content string) clipboardEvent {
+	event := clipboardEvent{
+		Event:       "stored",
+		Type:        "text",
+		Host:        p.host,
+		Timestamp:   time.Now(),
+		Length:      utf8.RuneCountInString(content),
+		Lines:       strings.Count(content, "\n") + 1,
+		ThreatLevel: storage.ThreatLevel(content, "text"),
+	}
+	if p.includeContent && event.ThreatLevel == "none" {
+		event.Content = content
+	}
+	return event
+}
+
+// imageEvent builds the event for a stored image; image data is never published
+func (p *eventPublisher) imageEvent(data []byte, description string) clipboardEvent {
+	return clipboardEvent{
+		Event:       "stored",
+		Type:        "image",
+		Host:        p.host,
+		Timestamp:   time.Now(),
+		Length:      len(data),
+		ThreatLevel: "none",
+		Description: description,
+	}
+}
+
+// publish queues an event for delivery
+func (p *eventPublisher) publish(event clipboardEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logging.Error("Failed to encode MQTT event: %v", err)
+		return
+	}
+	p.publisher.Publish(payload)
+}
@@ -0,0 +1,67 @@
+//go:build !nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+// reportHijack logs a swapped payment destination and raises a critical desktop notification.
+// The log only keeps masked destinations; the notification shows enough to compare them.
+func reportHijack(alert clipboard.HijackAlert) {
+	logging.Warn("SECURITY: Possible clipboard hijack: %s %q replaced by %q after %s",
+		alert.Kind, security.MaskMatch(alert.Previous), security.MaskMatch(alert.Current), alert.Elapsed.Round(time.Millisecond))
+
+	body := fmt.Sprintf("The %s you copied was replaced %s later by a different one:\n%s\n→ %s\nCheck the destination before pasting it.",
+		alert.Kind, alert.Elapsed.Round(100*time.Millisecond), shortDestination(alert.Previous), shortDestination(alert.Current))
+	if err := notifyDesktop("Clipboard hijack suspected", body); err != nil {
+		logging.Warn("Failed to show clipboard hijack notification: %v", err)
+	}
+}
+
+// shortDestination keeps the start and end of a long address, the parts people compare
+func shortDestination(destination string) string {
+	if len(destination) <= 24 {
+		return destination
+	}
+	return destination[:10] + "…" + destination[len(destination)-10:]
+}
+
+// notifyDesktop shows a critical notification through notify-send
+func notifyDesktop(summary, body string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return err
+	}
+	return exec.Command(path, "--urgency=critical", "--app-name=nclip", summary, body).Run()
+}
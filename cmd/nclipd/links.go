@@ -0,0 +1,131 @@
+//go:build !nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/enrich"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// maxTitleFetches limits concurrent title requests; links copied while all are busy
+// keep their bare URL
+const maxTitleFetches = 2
+
+// shortenerTimeout bounds the lookup of a shortened link before it is stored
+const shortenerTimeout = 3 * time.Second
+
+// resolveShortener looks up where content leads in the background when it is a shortened
+// link, so the capture callback isn't held up by the shortener. The destination goes to
+// resolved, which must filter it like copied content since it was never checked; content
+// goes to unresolved when the shortener doesn't answer in time. It reports false, doing
+// nothing, when content isn't a shortened link.
+func resolveShortener(ctx context.Context, content string, resolved, unresolved func(string)) bool {
+	link, ok := enrich.LinkURL(content)
+	if !ok || !enrich.IsShortened(link) {
+		return false
+	}
+	if !writes.begin() {
+		return true
+	}
+	go func() {
+		defer writes.end()
+		ctx, cancel := context.WithTimeout(ctx, shortenerTimeout)
+		defer cancel()
+		target, err := enrich.ResolveShortener(ctx, http.DefaultClient, link)
+		if err != nil {
+			logging.Debug("Failed to resolve shortened link: %v", err)
+			unresolved(content)
+			return
+		}
+		resolved(target)
+	}()
+	return true
+}
+
+// titleFetcher looks up the page titles of copied links in the background
+type titleFetcher struct {
+	ctx       context.Context
+	store     *storage.Storage
+	client    *http.Client
+	withQuery bool // Also request links with a query string or fragment
+	running   chan struct{}
+}
+
+// newTitleFetcher creates a fetcher whose requests give up after timeout
+func newTitleFetcher(ctx context.Context, store *storage.Storage, timeout time.Duration, withQuery bool) *titleFetcher {
+	return &titleFetcher{
+		ctx:       ctx,
+		store:     store,
+		client:    enrich.PublicClient(timeout),
+		withQuery: withQuery,
+		running:   make(chan struct{}, maxTitleFetches),
+	}
+}
+
+// fetch stores the title of content if it is a link without detected secrets, and without
+// a query string or fragment that may hold a one-time token unless those are allowed
+func (f *titleFetcher) fetch(content string) {
+	link, ok := enrich.LinkURL(content)
+	if !ok || storage.ThreatLevel(content, "text") != "none" {
+		return
+	}
+	if enrich.HasQuery(link) && !f.withQuery {
+		logging.Debug("Skipping link title, the link has a query string or fragment")
+		return
+	}
+	select {
+	case f.running <- struct{}{}:
+	default:
+		logging.Debug("Skipping link title, too many requests in flight")
+		return
+	}
+
+	if !writes.begin() {
+		<-f.running
+		return
+	}
+	go func() {
+		defer writes.end()
+		defer func() { <-f.running }()
+		title, err := enrich.FetchTitle(f.ctx, f.client, link)
+		if err != nil {
+			logging.Debug("Failed to fetch link title: %v", err)
+			return
+		}
+		if title == "" {
+			return
+		}
+		if err := f.store.SetTextTitle(content, title); err != nil {
+			logging.Warn("Failed to store link title: %v", err)
+		}
+	}()
+}
@@ -1,3 +1,5 @@
+//go:build !nodaemon
+
 /*
 MIT License
 
@@ -84,38 +86,212 @@ func main() {
 		log.Fatalf("Failed to initialize storage: %v", err)
 	}
 	defer store.Close()
+	for _, migration := range store.AppliedMigrations() {
+		logging.Info("Applied database migration %s", migration)
+	}
+	if writer := store.PreviousWriter(); writer != "" {
+		logging.Info("Database was last opened by nclip %s", writer)
+	}
+	if cfg.Database.SecurePermissions {
+		if err := store.SecurePermissions(); err != nil {
+			logging.Error("Failed to secure database permissions: %v", err)
+		}
+	}
+	for _, warning := range store.PermissionWarnings() {
+		logging.Warn("Database permissions: %s", warning)
+	}
+	if cfg.Database.ShredDeleted {
+		if err := store.SetShred(true); err != nil {
+			logging.Error("Failed to enable shredding of deleted entries: %v", err)
+		}
+	}
+	store.SetRetention(cfg.Database.TTL(), cfg.Database.ArchiveEvicted)
+	store.SetTypeLimits(cfg.Database.MaxTextEntries, cfg.Database.MaxImageEntries)
+	var appRetention []storage.AppRetentionRule
+	for _, rule := range cfg.Database.AppRetention {
+		appRetention = append(appRetention, storage.AppRetentionRule{
+			Apps:       rule.Apps,
+			TTL:        time.Duration(rule.TTLDays) * 24 * time.Hour,
+			MaxEntries: rule.MaxEntries,
+		})
+	}
+	store.SetAppRetention(appRetention)
+	otpTTL := time.Duration(cfg.Capture.OTPTTLSeconds) * time.Second
+	store.SetOTPExpiry(otpTTL)
+	store.SetNormalization(storage.NormalizeOptions{
+		TrimTrailingWhitespace: cfg.Capture.TrimTrailingWhitespace,
+		NormalizeLineEndings:   cfg.Capture.NormalizeLineEndings,
+		StripQuotes:            cfg.Capture.StripQuotes,
+		StripTrackingParams:    cfg.Capture.StripTrackingParams,
+	})
 
-	monitor := clipboard.NewMonitorWithSecurity(
-		func(content string) {
-			if err := store.Add(content); err != nil {
-				logging.Error("Failed to store clipboard content: %v", err)
+	ignoreRules, err := clipboard.NewIgnoreRules(cfg.Capture.Ignore.Patterns, cfg.Capture.Ignore.Globs)
+	if err != nil {
+		logging.Error("Failed to load capture ignore rules: %v", err)
+		log.Fatalf("Failed to load capture ignore rules: %v", err)
+	}
+	if ignoreRules.Len() > 0 {
+		logging.Info("Loaded %d capture ignore rules", ignoreRules.Len())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Lets nclip-tray show whether the daemon runs
+	unregister, err := clipboard.RegisterDaemon()
+	if err != nil {
+		logging.Warn("Failed to record daemon process: %v", err)
+	}
+	defer unregister()
+	if clipboard.Paused() {
+		logging.Info("Capture is paused; resume it from nclip-tray")
+	}
+	if previous, err := store.MarkDaemonStarted(); err != nil {
+		logging.Warn("Failed to record daemon start: %v", err)
+	} else if previous.Running {
+		logging.Warn("The daemon started %s did not shut down cleanly; clipboard changes may have been lost",
+			previous.Since.Format(time.RFC3339))
+	}
+
+	var events *eventPublisher
+	if cfg.MQTT.Enabled {
+		events = newEventPublisher(cfg.MQTT)
+		go events.publisher.Run(ctx)
+		logging.Info("Publishing clipboard events to %s on %s", cfg.MQTT.Topic, cfg.MQTT.Broker)
+	}
+
+	var titles *titleFetcher
+	if cfg.Enrich.FetchURLTitles {
+		titles = newTitleFetcher(ctx, store, time.Duration(cfg.Enrich.URLTitleTimeoutSeconds)*time.Second, cfg.Enrich.FetchTitlesWithQuery)
+		logging.Warn("fetch_url_titles is enabled: copied links are requested from their servers to read page titles")
+	}
+
+	// Shared by every monitor, so a watcher restart doesn't reset it
+	var hijack *clipboard.HijackDetector
+	if cfg.Capture.HijackWindowSeconds > 0 {
+		hijack = clipboard.NewHijackDetector(time.Duration(cfg.Capture.HijackWindowSeconds) * time.Second)
+	}
+
+	// In ask mode captured content waits to be kept before it enters the history
+	askMode := cfg.Capture.Mode == config.CaptureModeAsk
+	if askMode {
+		logging.Info("Capture mode ask: copied content is held back until it is kept")
+	}
+	if cfg.Capture.Quarantine {
+		logging.Info("Quarantining blocked content for %s", storage.QuarantineTTL)
+	}
+
+	// A new monitor is created whenever the clipboard watcher has to be restarted
+	newMonitor := func() *clipboard.Monitor {
+		storeText := func(content string, source clipboard.Source) {
+			stored := func() {
+				if titles != nil {
+					titles.fetch(content)
+				}
+				if events != nil {
+					events.publish(events.textEvent(content))
+				}
 			}
-		},
-		func(imageData []byte, description string) {
-			if err := store.AddImage(imageData, description); err != nil {
-				logging.Error("Failed to store clipboard image: %v", err)
+			if askMode {
+				askToKeep(ctx, store, content, "text", nil, storage.Source(source), stored)
+				return
 			}
-		},
-		func(content string, threats []security.SecurityThreat) {
-			// Security content detected - just log for awareness
-			if len(threats) > 0 {
-				threat := security.GetHighestThreat(threats)
-				if threat != nil {
-					if security.IsHighRiskThreat(threats) {
-						logging.Warn("SECURITY: High-risk %s content detected (%.0f%% confidence): %s - stored with warning indicator",
-							threat.Type, threat.Confidence*100, threat.Reason)
-					} else {
-						logging.Info("SECURITY: Medium-risk %s content detected (%.0f%% confidence): %s - stored with caution indicator",
-							threat.Type, threat.Confidence*100, threat.Reason)
-					}
-				}
+			if err := store.AddWithSource(content, "text", nil, storage.Source(source)); err != nil {
+				logging.Error("Failed to store clipboard content: %v", err)
+				return
 			}
-		},
-	)
-	defer monitor.Close()
+			stored()
+		}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+		var monitor *clipboard.Monitor
+		monitor = clipboard.NewSourceMonitor(
+			func(content string, source clipboard.Source) {
+				if !writes.begin() {
+					return
+				}
+				defer writes.end()
+				if isOwnCopy(store, cfg.Capture.SuppressOwnCopies, content, "text", nil) {
+					return
+				}
+				// The destination of a shortened link goes through the monitor's filters
+				// again, as the ignore rules, blocked hashes and secret checks only saw the
+				// short link
+				if cfg.Enrich.ResolveShorteners && resolveShortener(ctx, content,
+					func(target string) { monitor.StoreText(target, source) },
+					func(content string) { storeText(content, source) }) {
+					return
+				}
+				storeText(content, source)
+			},
+			func(imageData []byte, description string, source clipboard.Source) {
+				if !writes.begin() {
+					return
+				}
+				defer writes.end()
+				if isOwnCopy(store, cfg.Capture.SuppressOwnCopies, description, "image", imageData) {
+					return
+				}
+				stored := func() {
+					if events != nil {
+						events.publish(events.imageEvent(imageData, description))
+					}
+				}
+				if askMode {
+					askToKeep(ctx, store, description, "image", imageData, storage.Source(source), stored)
+					return
+				}
+				if err := store.AddWithSource(description, "image", imageData, storage.Source(source)); err != nil {
+					logging.Error("Failed to store clipboard image: %v", err)
+					return
+				}
+				stored()
+			},
+			func(content string, threats []security.SecurityThreat) {
+				// Security content detected - just log for awareness
+				if len(threats) > 0 {
+					threat := security.GetHighestThreat(threats)
+					if threat != nil {
+						if security.IsHighRiskThreat(threats) {
+							logging.Warn("SECURITY: High-risk %s content detected (%.0f%% confidence): %s - stored with warning indicator",
+								threat.Type, threat.Confidence*100, threat.Reason)
+						} else {
+							logging.Info("SECURITY: Medium-risk %s content detected (%.0f%% confidence): %s - stored with caution indicator",
+								threat.Type, threat.Confidence*100, threat.Reason)
+						}
+					}
+				}
+			},
+		)
+		monitor.SetIgnoreRules(ignoreRules)
+		monitor.SetIgnoredApps(cfg.Capture.Ignore.Apps)
+		monitor.SetImageOptions(clipboard.ImageOptions{
+			ConvertToPNG: cfg.Capture.Images.ConvertToPNG,
+			MaxDimension: cfg.Capture.Images.MaxDimension,
+		})
+		monitor.SetLengthLimits(cfg.Capture.MinLength, cfg.Capture.MaxLength)
+		monitor.SetPauseCheck(clipboard.Paused)
+		if cfg.Capture.Quarantine {
+			monitor.SetQuarantine(func(content string, imageData []byte, source clipboard.Source, reason string) {
+				contentType := "text"
+				if imageData != nil {
+					contentType = "image"
+				}
+				if _, err := store.Quarantine(content, contentType, imageData, storage.Source(source), reason); err != nil {
+					logging.Error("Failed to quarantine blocked content: %v", err)
+				}
+			})
+		}
+		if hijack != nil {
+			monitor.SetHijackDetector(hijack, reportHijack)
+		}
+		if limit := cfg.Capture.RateLimit; limit.Burst > 0 {
+			monitor.SetRateLimiter(clipboard.NewRateLimiter(limit.Burst, limit.PerMinute, time.Duration(limit.CoalesceMS)*time.Millisecond))
+		}
+		return monitor
+	}
+	if limit := cfg.Capture.RateLimit; limit.Burst > 0 {
+		logging.Info("Rate limiting clipboard changes to bursts of %d, %d per minute", limit.Burst, limit.PerMinute)
+	}
 
 	// Start maintenance tasks
 	if cfg.Maintenance.AutoDedupe {
@@ -140,17 +316,107 @@ func main() {
 		})
 	}
 
+	if cfg.Database.TTLDays > 0 {
+		go startMaintenanceTask(ctx, store, "retention", time.Duration(cfg.Maintenance.RetentionInterval)*time.Minute, func() {
+			logging.Info("Running automatic retention check...")
+			if evictedCount, err := store.EnforceRetention(); err != nil {
+				logging.Error("Automatic retention failed: %v", err)
+			} else if evictedCount > 0 {
+				logging.Info("Automatic retention evicted %d expired entries", evictedCount)
+			}
+		})
+	}
+
+	if cfg.Database.MaxDBSizeMB > 0 {
+		budget := int64(cfg.Database.MaxDBSizeMB) << 20
+		enforceSizeBudget(store, budget, cfg.Database.SizeStrategy)
+		go startMaintenanceTask(ctx, store, "size budget", time.Duration(cfg.Maintenance.RetentionInterval)*time.Minute, func() {
+			enforceSizeBudget(store, budget, cfg.Database.SizeStrategy)
+		})
+	}
+
+	if len(appRetention) > 0 {
+		go startMaintenanceTask(ctx, store, "application retention", time.Duration(cfg.Maintenance.RetentionInterval)*time.Minute, func() {
+			logging.Info("Running per-application retention check...")
+			if evictedCount, err := store.EnforceAppRetention(); err != nil {
+				logging.Error("Per-application retention failed: %v", err)
+			} else if evictedCount > 0 {
+				logging.Info("Per-application retention evicted %d entries", evictedCount)
+			}
+		})
+	}
+
+	if otpTTL > 0 {
+		// Checked often so codes disappear close to their expiry
+		go startMaintenanceTask(ctx, store, "one-time code expiry", otpExpiryInterval(otpTTL), func() {
+			if deletedCount, err := store.DeleteExpired(); err != nil {
+				logging.Error("Deleting expired one-time codes failed: %v", err)
+			} else if deletedCount > 0 {
+				logging.Info("Deleted %d expired one-time codes", deletedCount)
+			}
+		})
+	}
+
+	// Runs in every capture mode: content held back before switching to "store", or
+	// before turning quarantine off, must still expire
+	pendingTTL := time.Duration(cfg.Capture.PendingTTLMinutes) * time.Minute
+	go startMaintenanceTask(ctx, store, "pending expiry", min(pendingTTL, time.Minute), func() {
+		if discarded, err := store.DeleteExpiredPending(pendingTTL); err != nil {
+			logging.Error("Discarding expired pending content failed: %v", err)
+		} else if discarded > 0 {
+			logging.Info("Discarded %d pending or quarantined entries nobody kept", discarded)
+		}
+	})
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
-		<-sigChan
+		sig := <-sigChan
+		logging.Info("Received %v, shutting down", sig)
 		cancel()
 	}()
 
-	if err := monitor.Start(ctx); err != nil && err != context.Canceled {
-		logging.Error("Monitor failed: %v", err)
-		log.Fatalf("Monitor failed: %v", err)
+	// Runs until a signal cancels ctx, restarting the monitor if the clipboard watcher dies
+	clipboard.Supervise(ctx, newMonitor)
+	shutdown(store)
+}
+
+// isOwnCopy reports whether clipboard content was just copied from the history by nclip.
+// Unless suppress is set, only items moved out of the history count, so they stay removed.
+func isOwnCopy(store *storage.Storage, suppress bool, content, contentType string, imageData []byte) bool {
+	consume := store.ConsumeMove
+	if suppress {
+		consume = store.ConsumeCopy
+	}
+	own, err := consume(content, contentType, imageData)
+	if err != nil {
+		logging.Warn("Failed to check for own copy: %v", err)
+		return false
+	}
+	if own {
+		logging.Debug("Skipping %s copied from the history by nclip", contentType)
+	}
+	return own
+}
+
+// otpExpiryInterval returns how often expired one-time codes are deleted: a quarter of
+// their lifetime, between 5 and 30 seconds
+func otpExpiryInterval(ttl time.Duration) time.Duration {
+	return min(max(ttl/4, 5*time.Second), 30*time.Second)
+}
+
+// enforceSizeBudget evicts items until the history is within budget bytes, logging each one
+func enforceSizeBudget(store *storage.Storage, budget int64, strategy string) {
+	evicted, err := store.EnforceSizeBudget(budget, strategy)
+	for _, item := range evicted {
+		logging.Info("Size budget evicted %s entry %s copied %s (%d bytes)",
+			item.ContentType, item.ID, item.Timestamp.Format(time.RFC3339), item.Size)
+	}
+	if err != nil {
+		logging.Error("Size budget enforcement failed: %v", err)
+	} else if len(evicted) > 0 {
+		logging.Info("Size budget (%d MB, %s first) evicted %d entries", budget>>20, strategy, len(evicted))
 	}
 }
 
@@ -167,7 +433,11 @@ func startMaintenanceTask(ctx context.Context, store *storage.Storage, taskName
 			logging.Info("Stopping %s maintenance task", taskName)
 			return
 		case <-ticker.C:
+			if !writes.begin() {
+				return
+			}
 			task()
+			writes.end()
 		}
 	}
 }
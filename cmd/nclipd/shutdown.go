@@ -0,0 +1,102 @@
+//go:build !nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// shutdownTimeout bounds how long the daemon waits for background writes when it stops
+const shutdownTimeout = 5 * time.Second
+
+// writeTracker counts background work that writes to the database, such as answered
+// notifications and fetched link titles, and turns new work away once shutdown begins
+type writeTracker struct {
+	mu       sync.Mutex
+	stopping bool
+	wg       sync.WaitGroup
+}
+
+// writes tracks the daemon's background writes
+var writes writeTracker
+
+// begin registers a write, returning false once the daemon is shutting down; every
+// successful begin must be followed by end
+func (t *writeTracker) begin() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopping {
+		return false
+	}
+	t.wg.Add(1)
+	return true
+}
+
+// end marks a write begun with begin as finished
+func (t *writeTracker) end() {
+	t.wg.Done()
+}
+
+// wait refuses new writes and waits up to timeout for the running ones, reporting
+// whether they all finished
+func (t *writeTracker) wait(timeout time.Duration) bool {
+	t.mu.Lock()
+	t.stopping = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// shutdown runs after the monitor has stopped and flushed its hash store: it lets
+// background writes finish and records a clean shutdown, which nclip checks to warn
+// about a daemon that was killed or crashed. The marker is left unset if writes were
+// still running at the deadline, so that exit counts as unclean.
+func shutdown(store *storage.Storage) {
+	if !writes.wait(shutdownTimeout) {
+		logging.Warn("Background writes did not finish within %v; shutdown is not clean", shutdownTimeout)
+		return
+	}
+	if err := store.MarkCleanShutdown(); err != nil {
+		logging.Error("Failed to record clean shutdown: %v", err)
+		return
+	}
+	logging.Info("NClip daemon shut down cleanly")
+}
@@ -0,0 +1,114 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+	"github.com/adaryorg/nclip/internal/ui"
+)
+
+// startupProfileFile is where --profile-startup writes its CPU profile
+const startupProfileFile = "nclip-startup.pprof"
+
+// startupPhase records how long a single startup step took
+type startupPhase struct {
+	name     string
+	duration time.Duration
+}
+
+// profileStartup runs the TUI startup sequence without entering the event loop,
+// recording timings for each phase and a CPU profile of the whole sequence
+func profileStartup(customThemeFile string, basicTerminal bool) error {
+	profileFile, err := os.Create(startupProfileFile)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file: %w", err)
+	}
+	defer profileFile.Close()
+
+	if err := pprof.StartCPUProfile(profileFile); err != nil {
+		return fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+
+	var phases []startupPhase
+	track := func(name string, start time.Time) {
+		phases = append(phases, startupPhase{name: name, duration: time.Since(start)})
+	}
+	total := time.Now()
+
+	// Config load
+	start := time.Now()
+	cfg, err := config.LoadWithCustomTheme(customThemeFile)
+	if err != nil {
+		pprof.StopCPUProfile()
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	track("Config load", start)
+
+	// DB open
+	start = time.Now()
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		pprof.StopCPUProfile()
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+	track("Database open", start)
+
+	// Initial meta query, as performed by the model on startup
+	start = time.Now()
+	itemCount := len(store.GetAllMeta())
+	track("Initial meta query", start)
+
+	// Model construction (cache fill, hash store, terminal detection)
+	start = time.Now()
+	var model tea.Model = ui.NewModel(store, cfg, basicTerminal)
+	track("Model init", start)
+
+	// First render at a typical terminal size
+	start = time.Now()
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model.View()
+	track("First render", start)
+
+	totalDuration := time.Since(total)
+	pprof.StopCPUProfile()
+
+	fmt.Printf("[INFO] Startup profile for %d entries\n", itemCount)
+	for _, phase := range phases {
+		fmt.Printf("  %-20s %10s\n", phase.name, phase.duration.Round(time.Microsecond))
+	}
+	fmt.Printf("  %-20s %10s\n", "Total", totalDuration.Round(time.Microsecond))
+	fmt.Printf("[OK] CPU profile written to %s (inspect with: go tool pprof %s)\n", startupProfileFile, startupProfileFile)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/security"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// Actions of the editor clipboard provider (nclip --provider)
+const (
+	providerCopy  = "copy"
+	providerPaste = "paste"
+)
+
+// runProvider acts as the clipboard command of a modal editor such as Helix or Kakoune:
+// "copy" stores standard input in the history and on the clipboard, "paste" prints the
+// clipboard, or the newest text entry when there is no display
+func runProvider(action string) error {
+	if action != providerCopy && action != providerPaste {
+		return fmt.Errorf("unknown provider action %q, expected %s or %s", action, providerCopy, providerPaste)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	store, err := storage.New(cfg.Database.MaxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	if action == providerCopy {
+		daemonConfig, err := config.LoadDaemonConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		return providerYank(store, daemonConfig, os.Stdin)
+	}
+	return providerPut(store, os.Stdout)
+}
+
+// providerYank adds the yanked text to the history, filtered like copies the daemon
+// captures, and copies it to the clipboard
+func providerYank(store *storage.Storage, daemonConfig *config.DaemonConfig, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	content := string(data)
+	security.Zero(data)
+	if content == "" {
+		return nil
+	}
+
+	// Content the capture policy blocks still reaches the clipboard, but stays out of the history
+	var storeErr error
+	filter, err := newCaptureFilter(daemonConfig, store, func(content string) {
+		storeErr = store.Add(content)
+	})
+	if err != nil {
+		return err
+	}
+	filter.StoreText(content, clipboard.Source{})
+	filter.Close()
+	if storeErr != nil {
+		return fmt.Errorf("failed to store yanked text: %w", storeErr)
+	}
+	// The daemon would otherwise see the clipboard change and store the text again
+	if err := store.RecordCopy(content, "text", nil); err != nil {
+		return fmt.Errorf("failed to record copy: %w", err)
+	}
+
+	// Without a display the history is the clipboard, and paste reads it from there
+	if clipboard.Headless() {
+		return nil
+	}
+	if storage.ThreatLevel(content, "text") == "high" {
+		return clipboard.CopySensitive(content)
+	}
+	return clipboard.Copy(content)
+}
+
+// providerPut writes the clipboard text, falling back to the newest text entry in the
+// history when there is no display or the clipboard can't be read
+func providerPut(store *storage.Storage, w io.Writer) error {
+	if content, err := clipboard.Paste(); err == nil {
+		_, err = io.WriteString(w, content)
+		return err
+	}
+	item := store.LatestText()
+	if item == nil {
+		return nil
+	}
+	_, err := io.WriteString(w, item.Content)
+	return err
+}
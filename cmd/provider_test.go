@@ -0,0 +1,69 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestProviderYank_CaptureFilters(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	store, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	daemonConfig := &config.DaemonConfig{Capture: config.CaptureConfig{
+		MinLength:              3,
+		TrimTrailingWhitespace: true,
+		OTPTTLSeconds:          120,
+		Ignore:                 config.IgnoreConfig{Patterns: []string{"^secret"}},
+	}}
+	for _, content := range []string{"secret token", "ab", "kept line  \n\n", "493021"} {
+		if err := providerYank(store, daemonConfig, strings.NewReader(content)); err != nil {
+			t.Fatalf("providerYank(%q) failed: %v", content, err)
+		}
+	}
+
+	var stored []string
+	for _, item := range store.GetAllMeta() {
+		stored = append(stored, item.Content)
+	}
+	if !slices.Equal(stored, []string{"493021", "kept line"}) {
+		t.Errorf("Expected only the normalized line and the code stored, got %q", stored)
+	}
+	if code := store.LatestText(); code == nil || !slices.Contains(store.GetTags(code.ID), "otp") {
+		t.Error("Expected the one-time code to be tagged to expire")
+	}
+}
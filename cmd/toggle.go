@@ -0,0 +1,166 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// errNoCompositorIPC is returned by --toggle outside Hyprland and Sway
+var errNoCompositorIPC = errors.New("focusing the running TUI needs Hyprland or Sway")
+
+// instancePath is where a running TUI records its process id for nclip --toggle
+func instancePath() (string, error) {
+	return clipboard.RuntimePath("nclip-tui.pid")
+}
+
+// registerInstance records this TUI for nclip --toggle and returns a function that
+// removes the record again, unless a newer instance replaced it in the meantime
+func registerInstance() func() {
+	path, err := instancePath()
+	if err != nil {
+		logging.Warn("Failed to record TUI instance: %v", err)
+		return func() {}
+	}
+	pid := strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(path, []byte(pid+"\n"), 0600); err != nil {
+		logging.Warn("Failed to record TUI instance: %v", err)
+		return func() {}
+	}
+	return func() {
+		if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) == pid {
+			os.Remove(path)
+		}
+	}
+}
+
+// runningInstance returns the process id of a running TUI, if there is one
+func runningInstance() (int, bool) {
+	path, err := instancePath()
+	if err != nil {
+		return 0, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 || syscall.Kill(pid, 0) != nil {
+		return 0, false
+	}
+	// A stale record may point at a reused process id
+	exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil || !strings.HasPrefix(filepath.Base(exe), "nclip") {
+		return 0, false
+	}
+	return pid, true
+}
+
+// processAncestors returns pid followed by its parent, grandparent and so on up to init
+func processAncestors(pid int) []int {
+	var chain []int
+	for pid > 1 && len(chain) < 32 {
+		chain = append(chain, pid)
+		stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			break
+		}
+		// The command name is in parentheses and may itself contain spaces
+		fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+		if len(fields) < 2 {
+			break
+		}
+		pid, _ = strconv.Atoi(fields[1])
+	}
+	return chain
+}
+
+// focusInstance asks the compositor to focus the terminal window that runs the TUI.
+// The window belongs to the terminal, so the TUI's ancestors are tried in turn.
+func focusInstance(pid int) error {
+	var focus func(int) bool
+	switch {
+	case os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "":
+		focus = func(pid int) bool {
+			out, err := exec.Command("hyprctl", "dispatch", "focuswindow", fmt.Sprintf("pid:%d", pid)).Output()
+			return err == nil && strings.TrimSpace(string(out)) == "ok"
+		}
+	case os.Getenv("SWAYSOCK") != "":
+		focus = func(pid int) bool {
+			return exec.Command("swaymsg", fmt.Sprintf("[pid=%d]", pid), "focus").Run() == nil
+		}
+	default:
+		return errNoCompositorIPC
+	}
+
+	for _, ancestor := range processAncestors(pid) {
+		if focus(ancestor) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no window found for the TUI (process %d)", pid)
+}
+
+// toggleTUI focuses the running TUI, or opens one in a new terminal when none is running
+func toggleTUI() error {
+	if pid, ok := runningInstance(); ok {
+		return focusInstance(pid)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	terminal := strings.Fields(cfg.Behavior.ToggleTerminal)
+	if len(terminal) == 0 {
+		if os.Getenv("TERMINAL") == "" {
+			return errors.New("no terminal to open: set toggle_terminal in [behavior] of nclip.toml, or $TERMINAL")
+		}
+		terminal = []string{os.Getenv("TERMINAL"), "-e"}
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate nclip: %w", err)
+	}
+
+	// Detach, so the terminal outlives the keybinding that started it
+	cmd := exec.Command(terminal[0], append(terminal[1:], self)...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", terminal[0], err)
+	}
+	return cmd.Process.Release()
+}
@@ -25,17 +25,65 @@ SOFTWARE.
 package main
 
 import (
+	"fmt"
 	"log"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
 	"github.com/adaryorg/nclip/internal/storage"
 	"github.com/adaryorg/nclip/internal/ui"
 )
 
-func startTUI(store *storage.Storage, cfg *config.Config, basicTerminal bool) {
+func startTUI(store *storage.Storage, cfg *config.Config, basicTerminal bool, archive bool, debug bool) {
+	for _, program := range cfg.Editor.MissingPrograms() {
+		logging.Warn("Editor program not found on PATH: %s", program)
+	}
+
+	// Probe the terminal once per TERM; the answers are cached for later runs
+	if !basicTerminal {
+		if _, err := ui.LoadTerminalCapabilities(false); err != nil {
+			logging.Warn("Failed to probe terminal capabilities: %v", err)
+		}
+	}
+
 	model := ui.NewModel(store, cfg, basicTerminal)
+	if archive {
+		model = ui.NewArchiveModel(store, cfg, basicTerminal)
+	}
+	model.SetDebugMode(debug)
+
+	// Resume where the last session left off (the archive view starts fresh)
+	statePath := ""
+	if cfg.Behavior.RememberState && !archive {
+		path, err := ui.StatePath()
+		if err != nil {
+			logging.Warn("Failed to locate TUI state file: %v", err)
+		} else if state, err := ui.LoadState(path); err != nil {
+			logging.Warn("Failed to load TUI state: %v", err)
+			statePath = path
+		} else {
+			model.RestoreState(state)
+			statePath = path
+		}
+	}
+
+	// Keep counting feature use where the last session stopped
+	usagePath := ""
+	if cfg.Behavior.UsageInsights {
+		path, err := ui.UsagePath()
+		if err != nil {
+			logging.Warn("Failed to locate usage insights file: %v", err)
+		} else {
+			usage, err := ui.LoadUsage(path)
+			if err != nil {
+				logging.Warn("Failed to load usage insights, counting from scratch: %v", err)
+			}
+			model.EnableUsage(usage)
+			usagePath = path
+		}
+	}
 
 	// Configure program options based on configuration
 	options := []tea.ProgramOption{tea.WithAltScreen()}
@@ -47,7 +95,40 @@ func startTUI(store *storage.Storage, cfg *config.Config, basicTerminal bool) {
 
 	p := tea.NewProgram(model, options...)
 
-	if _, err := p.Run(); err != nil {
+	// Let nclip --toggle find this instance (the archive view is a separate tool)
+	if !archive {
+		defer registerInstance()()
+	}
+
+	finalModel, err := p.Run()
+	// Editors may still hold temp files with clipboard content; remove them even on failure
+	ui.RemoveTempFiles(cfg.Editor.TempDir)
+	if err != nil {
 		log.Fatalf("Error running program: %v", err)
 	}
+
+	if m, ok := finalModel.(ui.Model); ok {
+		// Headless "print" copy: the terminal is ours again, so the text can be written out
+		if text, ok := m.PrintOnExit(); ok {
+			fmt.Println(text)
+		}
+	}
+
+	if statePath != "" {
+		if m, ok := finalModel.(ui.Model); ok {
+			if err := ui.SaveState(statePath, m.State()); err != nil {
+				logging.Warn("Failed to save TUI state: %v", err)
+			}
+		}
+	}
+
+	if usagePath != "" {
+		if m, ok := finalModel.(ui.Model); ok {
+			if usage, ok := m.Usage(); ok {
+				if err := ui.SaveUsage(usagePath, usage); err != nil {
+					logging.Warn("Failed to save usage insights: %v", err)
+				}
+			}
+		}
+	}
 }
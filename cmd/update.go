@@ -0,0 +1,143 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/update"
+	"github.com/adaryorg/nclip/internal/version"
+)
+
+// updateTimeout bounds the release lookup and the downloads of an update
+const updateTimeout = 2 * time.Minute
+
+// latestRelease looks up the newest release, returning it and whether it is newer than
+// this build
+func latestRelease(ctx context.Context, client *http.Client) (*update.Release, bool, error) {
+	release, err := update.Latest(ctx, client, update.LatestReleaseURL)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	return release, update.Newer(release.Tag, version.Version), nil
+}
+
+// checkUpdate reports whether a newer release is available, without changing anything
+func checkUpdate() error {
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+
+	release, newer, err := latestRelease(ctx, &http.Client{})
+	if err != nil {
+		return err
+	}
+	switch {
+	case !update.IsRelease(version.Version):
+		fmt.Printf("This is a development build; the latest release is %s\n", release.Tag)
+	case newer:
+		fmt.Printf("nclip %s is available (this is %s): %s\n", release.Tag, version.Version, release.URL)
+	default:
+		fmt.Printf("[OK] nclip %s is the latest release\n", version.Version)
+	}
+	return nil
+}
+
+// selfUpdate replaces the installed nclip binaries with the latest release, after
+// verifying the release's signed checksums
+func selfUpdate() error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate nclip: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(self); err == nil {
+		self = resolved
+	}
+	if update.ManagedByPackageManager(self) {
+		return fmt.Errorf("%s was installed by a package manager; update nclip with it instead", self)
+	}
+	if !update.IsRelease(version.Version) {
+		return errors.New("this is a development build; rebuild it from source instead")
+	}
+	if version.ReleaseKey == "" {
+		return errors.New("this build has no release key to verify updates with")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+	client := &http.Client{}
+
+	release, newer, err := latestRelease(ctx, client)
+	if err != nil {
+		return err
+	}
+	if !newer {
+		fmt.Printf("[OK] nclip %s is the latest release\n", version.Version)
+		return nil
+	}
+
+	archiveName := update.ArchiveName(release.Tag, runtime.GOOS, runtime.GOARCH)
+	files := make(map[string][]byte)
+	for _, name := range []string{update.ChecksumsName, update.SignatureName, archiveName} {
+		asset, ok := release.Asset(name)
+		if !ok {
+			return fmt.Errorf("release %s has no %s", release.Tag, name)
+		}
+		fmt.Printf("Downloading %s...\n", name)
+		if files[name], err = update.Download(ctx, client, asset.URL); err != nil {
+			return fmt.Errorf("failed to download %s: %w", name, err)
+		}
+	}
+
+	if err := update.VerifySignature(files[update.ChecksumsName], files[update.SignatureName], version.ReleaseKey); err != nil {
+		return err
+	}
+	if err := update.VerifyChecksum(files[update.ChecksumsName], archiveName, files[archiveName]); err != nil {
+		return err
+	}
+	binaries, err := update.ExtractBinaries(files[archiveName])
+	if err != nil {
+		return err
+	}
+
+	replaced, err := update.Install(filepath.Dir(self), binaries)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[OK] Updated %s from %s to %s\n", strings.Join(replaced, ", "), version.Version, release.Tag)
+	for _, name := range replaced {
+		if name == "nclipd" {
+			fmt.Println("Restart the daemon to use the new version, e.g. systemctl --user restart nclip")
+		}
+	}
+	return nil
+}
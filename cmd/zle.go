@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+	"github.com/adaryorg/nclip/internal/ui"
+)
+
+// runInsertPicker runs the compact picker for the shell widgets in templates/shell and
+// prints the chosen text, without a trailing newline, for the widget to insert at the
+// cursor. Stdout is captured by the widget, so the picker draws on the terminal directly.
+// It returns false when nothing was picked.
+func runInsertPicker(store *storage.Storage, cfg *config.Config, basicTerminal bool) (bool, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to open the terminal: %w", err)
+	}
+	defer tty.Close()
+
+	// Detect colors from the terminal rather than the captured stdout
+	lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(tty))
+
+	model := ui.NewModel(store, cfg, basicTerminal)
+	model.SetInsertMode(true)
+
+	options := []tea.ProgramOption{tea.WithAltScreen(), tea.WithInput(tty), tea.WithOutput(tty)}
+	if cfg.Mouse.Enable {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+
+	finalModel, err := tea.NewProgram(model, options...).Run()
+	ui.RemoveTempFiles(cfg.Editor.TempDir)
+	if err != nil {
+		return false, fmt.Errorf("error running picker: %w", err)
+	}
+
+	m, ok := finalModel.(ui.Model)
+	if !ok {
+		return false, nil
+	}
+	text, ok := m.PrintOnExit()
+	if !ok {
+		return false, nil
+	}
+	fmt.Print(text)
+	return true, nil
+}
@@ -0,0 +1,94 @@
+//go:build !noimages
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif" // Register the GIF decoder so GIF sizes can be read
+	"image/jpeg"
+	"image/png"
+
+	_ "golang.org/x/image/bmp" // Register the BMP decoder
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff" // Register the TIFF decoder
+)
+
+// ImageOptions controls how captured images are normalized before they are stored
+type ImageOptions struct {
+	ConvertToPNG bool // Re-encode BMP and TIFF images as PNG
+	MaxDimension int  // Downscale images wider or taller than this many pixels, 0 disables
+}
+
+// jpegQuality is used when a downscaled JPEG is encoded again
+const jpegQuality = 90
+
+// normalizeImage converts and downscales image data according to opts, reporting
+// whether it changed anything. GIFs are never downscaled, which would drop their
+// animation.
+func normalizeImage(data []byte, opts ImageOptions) ([]byte, bool, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return data, false, fmt.Errorf("failed to read image header: %w", err)
+	}
+
+	convert := opts.ConvertToPNG && (format == "bmp" || format == "tiff")
+	scale := opts.MaxDimension > 0 && format != "gif" &&
+		(config.Width > opts.MaxDimension || config.Height > opts.MaxDimension)
+	if !convert && !scale {
+		return data, false, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return data, false, fmt.Errorf("failed to decode %s image: %w", format, err)
+	}
+
+	if scale {
+		bounds := img.Bounds()
+		factor := float64(opts.MaxDimension) / float64(max(bounds.Dx(), bounds.Dy()))
+		width := max(1, int(float64(bounds.Dx())*factor))
+		height := max(1, int(float64(bounds.Dy())*factor))
+
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+		img = dst
+	}
+
+	// Photos stay JPEG; everything else is stored as PNG
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return data, false, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
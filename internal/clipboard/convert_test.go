@@ -0,0 +1,106 @@
+//go:build !noimages
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"golang.org/x/image/bmp"
+)
+
+// testImage returns a w x h image with a gradient, so encoders can't collapse it
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	return img
+}
+
+func TestNormalizeImage_ConvertsBMP(t *testing.T) {
+	var buf bytes.Buffer
+	if err := bmp.Encode(&buf, testImage(40, 30)); err != nil {
+		t.Fatalf("Failed to encode BMP: %v", err)
+	}
+
+	data, changed, err := normalizeImage(buf.Bytes(), ImageOptions{ConvertToPNG: true})
+	if err != nil || !changed {
+		t.Fatalf("Expected the BMP to be converted, got changed=%v err=%v", changed, err)
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || format != "png" || config.Width != 40 || config.Height != 30 {
+		t.Errorf("Expected a 40x30 PNG, got %s %dx%d (%v)", format, config.Width, config.Height, err)
+	}
+
+	if _, changed, _ := normalizeImage(buf.Bytes(), ImageOptions{}); changed {
+		t.Error("Expected the BMP to be kept with conversion disabled")
+	}
+}
+
+func TestNormalizeImage_Downscales(t *testing.T) {
+	var buf bytes.Buffer
+	png.Encode(&buf, testImage(200, 100))
+
+	data, changed, err := normalizeImage(buf.Bytes(), ImageOptions{MaxDimension: 50})
+	if err != nil || !changed {
+		t.Fatalf("Expected the image to be downscaled, got changed=%v err=%v", changed, err)
+	}
+	config, format, _ := image.DecodeConfig(bytes.NewReader(data))
+	if format != "png" || config.Width != 50 || config.Height != 25 {
+		t.Errorf("Expected a 50x25 PNG, got %s %dx%d", format, config.Width, config.Height)
+	}
+
+	// Images within the limit are left alone
+	if _, changed, _ := normalizeImage(buf.Bytes(), ImageOptions{MaxDimension: 200}); changed {
+		t.Error("Expected an image within max_dimension to be kept")
+	}
+}
+
+func TestNormalizeImage_KeepsGIF(t *testing.T) {
+	var buf bytes.Buffer
+	paletted := image.NewPaletted(image.Rect(0, 0, 100, 100), color.Palette{color.Black, color.White})
+	gif.Encode(&buf, paletted, nil)
+
+	if _, changed, err := normalizeImage(buf.Bytes(), ImageOptions{ConvertToPNG: true, MaxDimension: 10}); changed || err != nil {
+		t.Errorf("Expected GIFs to be kept, got changed=%v err=%v", changed, err)
+	}
+}
+
+func TestNormalizeImage_InvalidData(t *testing.T) {
+	data := []byte("not an image at all")
+	got, changed, err := normalizeImage(data, ImageOptions{ConvertToPNG: true})
+	if err == nil || changed || !bytes.Equal(got, data) {
+		t.Errorf("Expected invalid data to be returned unchanged with an error, got changed=%v err=%v", changed, err)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// RuntimePath returns where per-session state shared by nclip, nclipd and their
+// companions is kept: $XDG_RUNTIME_DIR, or else a directory in the system temp directory
+// that is checked to be private to the user, so no one else can plant files or symlinks
+// at the guessable path
+func RuntimePath(name string) (string, error) {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, name), nil
+	}
+
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("nclip-runtime-%d", os.Getuid()))
+	if err := os.Mkdir(dir, 0700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("failed to create runtime directory: %w", err)
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !info.IsDir() || !ok || int(stat.Uid) != os.Getuid() || info.Mode().Perm() != 0700 {
+		return "", fmt.Errorf("runtime directory %s is not a private directory of this user", dir)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Paused reports whether capture was paused with SetPaused. The pause lasts until it is
+// lifted or the session ends.
+func Paused() bool {
+	path, err := RuntimePath("nclipd.paused")
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// SetPaused pauses or resumes capture by every watcher in the session
+func SetPaused(paused bool) error {
+	path, err := RuntimePath("nclipd.paused")
+	if err != nil {
+		return err
+	}
+	if !paused {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, nil, 0600)
+}
+
+// RegisterDaemon records this process as the session's clipboard daemon and returns a
+// function that removes the record again, unless a newer daemon replaced it
+func RegisterDaemon() (func(), error) {
+	path, err := RuntimePath("nclipd.pid")
+	if err != nil {
+		return func() {}, err
+	}
+	pid := strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(path, []byte(pid+"\n"), 0600); err != nil {
+		return func() {}, err
+	}
+	return func() {
+		if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) == pid {
+			os.Remove(path)
+		}
+	}, nil
+}
+
+// DaemonPID returns the process id of the running clipboard daemon, if there is one
+func DaemonPID() (int, bool) {
+	path, err := RuntimePath("nclipd.pid")
+	if err != nil {
+		return 0, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 || syscall.Kill(pid, 0) != nil {
+		return 0, false
+	}
+	// A stale record may point at a reused process id
+	if exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid)); err == nil && !strings.HasPrefix(filepath.Base(exe), "nclip") {
+		return 0, false
+	}
+	return pid, true
+}
@@ -0,0 +1,122 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetPaused(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	if Paused() {
+		t.Fatal("Expected capture to run by default")
+	}
+	if err := SetPaused(true); err != nil {
+		t.Fatalf("SetPaused failed: %v", err)
+	}
+	if !Paused() {
+		t.Error("Expected capture to be paused")
+	}
+	if err := SetPaused(false); err != nil {
+		t.Fatalf("SetPaused failed: %v", err)
+	}
+	if err := SetPaused(false); err != nil {
+		t.Errorf("Expected resuming twice to succeed, got %v", err)
+	}
+	if Paused() {
+		t.Error("Expected capture to be resumed")
+	}
+}
+
+func TestRegisterDaemon(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	if _, running := DaemonPID(); running {
+		t.Fatal("Expected no daemon before one registers")
+	}
+	unregister, err := RegisterDaemon()
+	if err != nil {
+		t.Fatalf("RegisterDaemon failed: %v", err)
+	}
+	// The test binary isn't named nclip*, so its record looks like a reused process id
+	if pid, running := DaemonPID(); running {
+		t.Errorf("Expected a process not named nclip to be treated as stale, got %d", pid)
+	}
+	if data, err := os.ReadFile(runtimePathForTest(t, "nclipd.pid")); err != nil || len(data) == 0 {
+		t.Errorf("Expected the process id to be recorded, got %q (%v)", data, err)
+	}
+
+	unregister()
+	if _, err := os.Stat(runtimePathForTest(t, "nclipd.pid")); !os.IsNotExist(err) {
+		t.Errorf("Expected the record to be removed, got %v", err)
+	}
+}
+
+// runtimePathForTest returns RuntimePath(name), failing the test on error
+func runtimePathForTest(t *testing.T, name string) string {
+	t.Helper()
+	path, err := RuntimePath(name)
+	if err != nil {
+		t.Fatalf("RuntimePath failed: %v", err)
+	}
+	return path
+}
+
+func TestRuntimePath_PrivateFallback(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	path := runtimePathForTest(t, "nclipd.paused")
+	info, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("Expected the runtime directory to be created: %v", err)
+	}
+	if !info.IsDir() || info.Mode().Perm() != 0700 {
+		t.Errorf("Expected a 0700 directory, got %v", info.Mode())
+	}
+
+	// A directory someone else could write to is refused rather than used
+	if err := os.Chmod(filepath.Dir(path), 0777); err != nil {
+		t.Fatalf("Failed to loosen permissions: %v", err)
+	}
+	if _, err := RuntimePath("nclipd.paused"); err == nil {
+		t.Error("Expected a world-writable runtime directory to be refused")
+	}
+
+	// So is a symlink planted at the directory's path
+	if err := os.Remove(filepath.Dir(path)); err != nil {
+		t.Fatalf("Failed to remove directory: %v", err)
+	}
+	if err := os.Symlink(t.TempDir(), filepath.Dir(path)); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if _, err := RuntimePath("nclipd.paused"); err == nil {
+		t.Error("Expected a symlinked runtime directory to be refused")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNoDisplay is returned by the clipboard functions when there is no graphical session
+var ErrNoDisplay = errors.New("no display: DISPLAY and WAYLAND_DISPLAY are not set, the system clipboard is unavailable")
+
+// Headless reports whether nclip runs without a graphical session, for example over SSH
+// or on a server console, where wl-copy, xclip and the X11 clipboard can't work
+func Headless() bool {
+	return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+// OSC52 returns the terminal escape sequence that asks the terminal to put text on the
+// clipboard of the machine it runs on. Inside tmux the sequence is wrapped so tmux passes
+// it through to the outer terminal (requires tmux's allow-passthrough option).
+func OSC52(text string) string {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if os.Getenv("TMUX") != "" {
+		return "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return sequence
+}
+
+// CopyOSC52 writes the OSC 52 sequence for text to w, which should be the terminal
+func CopyOSC52(w io.Writer, text string) error {
+	_, err := io.WriteString(w, OSC52(text))
+	return err
+}
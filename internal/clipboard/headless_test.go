@@ -0,0 +1,73 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestHeadless(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	if !Headless() {
+		t.Error("Expected a session without DISPLAY and WAYLAND_DISPLAY to be headless")
+	}
+	if err := Copy("text"); !errors.Is(err, ErrNoDisplay) {
+		t.Errorf("Expected ErrNoDisplay from Copy, got %v", err)
+	}
+	if err := CopyImage([]byte("png")); !errors.Is(err, ErrNoDisplay) {
+		t.Errorf("Expected ErrNoDisplay from CopyImage, got %v", err)
+	}
+
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	if Headless() {
+		t.Error("Expected a Wayland session not to be headless")
+	}
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", ":0")
+	if Headless() {
+		t.Error("Expected an X11 session not to be headless")
+	}
+}
+
+func TestOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if got := OSC52("hello"); got != "\x1b]52;c;aGVsbG8=\x07" {
+		t.Errorf("Unexpected OSC 52 sequence %q", got)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if got := OSC52("hello"); got != "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\x07\x1b\\" {
+		t.Errorf("Unexpected tmux passthrough sequence %q", got)
+	}
+
+	t.Setenv("TMUX", "")
+	var out bytes.Buffer
+	if err := CopyOSC52(&out, "hi"); err != nil || out.String() != OSC52("hi") {
+		t.Errorf("CopyOSC52 wrote %q, %v", out.String(), err)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrWatcherStopped is returned by Monitor.Start when the clipboard can no longer be
+// watched, e.g. after the X server or the compositor restarted
+var ErrWatcherStopped = errors.New("clipboard watcher stopped")
+
+// healthCheckInterval is how often the polling monitor checks the display server is reachable
+const healthCheckInterval = 5 * time.Second
+
+// checkSession returns an error when the display server the monitor reads from is gone
+func (m *Monitor) checkSession() error {
+	if m.useWayland {
+		socket := os.Getenv("WAYLAND_DISPLAY")
+		if socket == "" {
+			socket = "wayland-0"
+		}
+		if !filepath.IsAbs(socket) {
+			socket = filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), socket)
+		}
+		if _, err := os.Stat(socket); err != nil {
+			return fmt.Errorf("%w: Wayland socket %s is gone", ErrWatcherStopped, socket)
+		}
+		return nil
+	}
+
+	network, address, ok := x11Address(os.Getenv("DISPLAY"))
+	if !ok {
+		return nil // Unknown display syntax, nothing to check
+	}
+	conn, err := net.DialTimeout(network, address, time.Second)
+	if err != nil {
+		return fmt.Errorf("%w: X display %s is unreachable: %v", ErrWatcherStopped, os.Getenv("DISPLAY"), err)
+	}
+	conn.Close()
+	return nil
+}
+
+// x11Address returns where the X server of a DISPLAY value ("[host]:display[.screen]") listens
+func x11Address(display string) (network, address string, ok bool) {
+	colon := strings.LastIndex(display, ":")
+	if colon < 0 {
+		return "", "", false
+	}
+	host, number := display[:colon], display[colon+1:]
+	if dot := strings.Index(number, "."); dot >= 0 {
+		number = number[:dot]
+	}
+	var n int
+	if _, err := fmt.Sscanf(number, "%d", &n); err != nil {
+		return "", "", false
+	}
+
+	switch host {
+	case "", "unix":
+		return "unix", fmt.Sprintf("/tmp/.X11-unix/X%d", n), true
+	default:
+		if strings.HasPrefix(host, "/") {
+			return "", "", false // launchd style socket paths on macOS
+		}
+		return "tcp", net.JoinHostPort(host, fmt.Sprintf("%d", 6000+n)), true
+	}
+}
@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+// HijackAlert describes a payment destination replaced by a different one of the same kind
+// shortly after it was copied, which is how clipboard-hijacking malware swaps in its own
+// wallet address or bank account
+type HijackAlert struct {
+	Kind     string // e.g. "Bitcoin address" or "IBAN"
+	Previous string
+	Current  string
+	Elapsed  time.Duration
+}
+
+// HijackDetector remembers the last payment destination seen on the clipboard. It outlives
+// the monitor, so a watcher restart doesn't hide a swap.
+type HijackDetector struct {
+	mu       sync.Mutex
+	window   time.Duration
+	lastKind string
+	last     string
+	lastAt   time.Time
+	now      func() time.Time
+}
+
+// NewHijackDetector creates a detector that flags replacements made within window
+func NewHijackDetector(window time.Duration) *HijackDetector {
+	return &HijackDetector{window: window, now: time.Now}
+}
+
+// Check records content and reports whether it replaced a different payment destination
+// of the same kind within the window
+func (h *HijackDetector) Check(content string) (HijackAlert, bool) {
+	kind, ok := security.PaymentDestination(content)
+	if !ok {
+		return HijackAlert{}, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	previous, previousKind, elapsed := h.last, h.lastKind, now.Sub(h.lastAt)
+	h.last, h.lastKind, h.lastAt = content, kind, now
+
+	if previous == "" || previousKind != kind || previous == content || elapsed > h.window {
+		return HijackAlert{}, false
+	}
+	return HijackAlert{Kind: kind, Previous: previous, Current: content, Elapsed: elapsed}, true
+}
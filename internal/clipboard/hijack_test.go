@@ -0,0 +1,83 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHijackDetector(t *testing.T) {
+	const (
+		btcA = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+		btcB = "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"
+		iban = "GB82WEST12345698765432"
+	)
+	now := time.Unix(1700000000, 0)
+	detector := NewHijackDetector(5 * time.Second)
+	detector.now = func() time.Time { return now }
+	check := func(content string, after time.Duration) bool {
+		now = now.Add(after)
+		_, swapped := detector.Check(content)
+		return swapped
+	}
+
+	if check(btcA, 0) {
+		t.Error("first address should not be a swap")
+	}
+	if check(btcA, time.Second) {
+		t.Error("copying the same address again should not be a swap")
+	}
+	if check(iban, time.Second) {
+		t.Error("a different kind of destination should not be a swap")
+	}
+	if check(btcB, 10*time.Second) {
+		t.Error("a replacement after the window should not be a swap")
+	}
+	if check("some text", time.Second) {
+		t.Error("ordinary text should not be a swap")
+	}
+
+	now = now.Add(time.Second)
+	alert, swapped := detector.Check(btcA)
+	if !swapped {
+		t.Fatal("expected replacing an address within the window to be a swap")
+	}
+	if alert.Kind != "Bitcoin address" || alert.Previous != btcB || alert.Current != btcA || alert.Elapsed != 2*time.Second {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+}
+
+func TestStoreContent_HijackAlert(t *testing.T) {
+	var alerts []HijackAlert
+	monitor := &Monitor{textCallback: func(string) {}}
+	monitor.SetHijackDetector(NewHijackDetector(5*time.Second), func(alert HijackAlert) { alerts = append(alerts, alert) })
+
+	monitor.storeContent("DE89370400440532013000", Source{})
+	monitor.storeContent("GB82WEST12345698765432", Source{})
+	if len(alerts) != 1 || alerts[0].Kind != "IBAN" {
+		t.Errorf("expected one IBAN alert, got %+v", alerts)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IgnoreRules decides which text content is never stored ([capture.ignore] in nclipd.toml).
+// Rules are matched against the content with surrounding whitespace trimmed.
+type IgnoreRules struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	source string // The rule as written in the config, for logging
+	re     *regexp.Regexp
+}
+
+// NewIgnoreRules compiles regular expressions and glob patterns into ignore rules
+func NewIgnoreRules(patterns, globs []string) (*IgnoreRules, error) {
+	r := &IgnoreRules{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+		r.rules = append(r.rules, ignoreRule{source: "pattern " + pattern, re: re})
+	}
+	for _, glob := range globs {
+		r.rules = append(r.rules, ignoreRule{source: "glob " + glob, re: globToRegexp(glob)})
+	}
+	return r, nil
+}
+
+// globToRegexp converts a glob where * matches any run of characters (including '/')
+// and ? a single character into an anchored regular expression
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString(`$`)
+	return regexp.MustCompile(b.String())
+}
+
+// Len returns the number of rules
+func (r *IgnoreRules) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.rules)
+}
+
+// Match reports whether content should be ignored, and the rule that matched
+func (r *IgnoreRules) Match(content string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	trimmed := strings.TrimSpace(content)
+	for _, rule := range r.rules {
+		if rule.re.MatchString(trimmed) {
+			return rule.source, true
+		}
+	}
+	return "", false
+}
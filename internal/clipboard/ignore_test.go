@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import "testing"
+
+func TestIgnoreRules(t *testing.T) {
+	rules, err := NewIgnoreRules([]string{`^\d{6}$`}, []string{"https://bank.example/*", "*secret*"})
+	if err != nil {
+		t.Fatalf("Failed to compile rules: %v", err)
+	}
+
+	tests := []struct {
+		content string
+		ignored bool
+	}{
+		{"123456", true},
+		{"  654321\n", true}, // Surrounding whitespace is trimmed
+		{"1234567", false},
+		{"order 123456", false},
+		{"https://bank.example/account/42", true},
+		{"https://bank.example.evil.com/", false},
+		{"my secret\nvalue", true},
+		{"plain text", false},
+	}
+	for _, test := range tests {
+		if _, ignored := rules.Match(test.content); ignored != test.ignored {
+			t.Errorf("Match(%q) = %v, expected %v", test.content, ignored, test.ignored)
+		}
+	}
+
+	if rule, _ := rules.Match("123456"); rule != `pattern ^\d{6}$` {
+		t.Errorf("Expected the matching rule to be reported, got %q", rule)
+	}
+}
+
+func TestIgnoreRules_InvalidPattern(t *testing.T) {
+	if _, err := NewIgnoreRules([]string{"("}, nil); err == nil {
+		t.Error("Expected an error for an invalid regular expression")
+	}
+}
+
+func TestProcessClipboardContent_Ignored(t *testing.T) {
+	var stored []string
+	rules, _ := NewIgnoreRules([]string{`^\d{6}$`}, nil)
+	monitor := &Monitor{textCallback: func(content string) { stored = append(stored, content) }}
+	monitor.SetIgnoreRules(rules)
+
+	monitor.processClipboardContent("123456")
+	monitor.processClipboardContent("hello")
+
+	if len(stored) != 1 || stored[0] != "hello" {
+		t.Errorf("Expected only non-ignored content to be stored, got %q", stored)
+	}
+}
@@ -25,31 +25,27 @@ SOFTWARE.
 package clipboard
 
 import (
-	"context"
-	"crypto/sha256"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
-	"sync"
 	"time"
+	"unicode/utf8"
 
 	atotto "github.com/atotto/clipboard"
-	"golang.design/x/clipboard"
 
 	"github.com/adaryorg/nclip/internal/logging"
 	"github.com/adaryorg/nclip/internal/security"
 )
 
-var (
-	initOnce sync.Once
-	initErr  error
-)
-
 type ContentCallback func(string)
 type ImageCallback func([]byte, string)
 type SecurityCallback func(string, []security.SecurityThreat)
 
+// QuarantineCallback receives content the capture policy blocked and why; imageData is
+// nil for text, and content holds the image description otherwise
+type QuarantineCallback func(content string, imageData []byte, source Source, reason string)
+
 type Monitor struct {
 	lastContent      string
 	lastImageHash    string
@@ -57,8 +53,21 @@ type Monitor struct {
 	textCallback     ContentCallback
 	imageCallback    ImageCallback
 	securityCallback SecurityCallback
+	// Used instead of textCallback and imageCallback when set, see NewSourceMonitor
+	textSourceCallback  func(content string, source Source)
+	imageSourceCallback func(imageData []byte, description string, source Source)
+	ignoredApps         map[string]bool // Lower case application ids whose content isn't stored
 	detector         *security.SecurityDetector
 	hashStore        *security.HashStore
+	ignoreRules      *IgnoreRules
+	minLength        int // Text shorter than this many characters isn't stored, 0 disables
+	maxLength        int // Text longer than this many characters isn't stored, 0 disables
+	rateLimiter      *RateLimiter // Throttles rapid changes, nil disables
+	imageOptions     ImageOptions // Conversion applied to captured images
+	hijack           *HijackDetector // Watches for swapped payment destinations, nil disables
+	hijackCallback   func(HijackAlert)
+	quarantine       QuarantineCallback // Receives content the capture policy blocks, nil discards it
+	paused           func() bool        // Reports whether capture is paused, nil never pauses
 	useWayland       bool
 	
 	// Anti-bump fields
@@ -68,7 +77,7 @@ type Monitor struct {
 }
 
 func NewMonitor(callback func(string)) *Monitor {
-	detector := security.NewSecurityDetector()
+	detector := security.DefaultDetector()
 	hashStore, _ := security.NewHashStore() // Ignore error, will be nil if failed
 
 	return &Monitor{
@@ -82,7 +91,7 @@ func NewMonitor(callback func(string)) *Monitor {
 }
 
 func NewMonitorWithImage(textCallback ContentCallback, imageCallback ImageCallback) *Monitor {
-	detector := security.NewSecurityDetector()
+	detector := security.DefaultDetector()
 	hashStore, _ := security.NewHashStore() // Ignore error, will be nil if failed
 
 	return &Monitor{
@@ -97,7 +106,7 @@ func NewMonitorWithImage(textCallback ContentCallback, imageCallback ImageCallba
 }
 
 func NewMonitorWithSecurity(textCallback ContentCallback, imageCallback ImageCallback, securityCallback SecurityCallback) *Monitor {
-	detector := security.NewSecurityDetector()
+	detector := security.DefaultDetector()
 	hashStore, _ := security.NewHashStore() // Ignore error, will be nil if failed
 
 	return &Monitor{
@@ -112,131 +121,117 @@ func NewMonitorWithSecurity(textCallback ContentCallback, imageCallback ImageCal
 	}
 }
 
-func (m *Monitor) Start(ctx context.Context) error {
-	if m.useWayland {
-		return m.startWaylandMonitor(ctx)
-	}
-	return m.startX11Monitor(ctx)
+// NewSourceMonitor creates a monitor whose callbacks also receive the source of each
+// change, looked up from the window focused when the change was seen
+func NewSourceMonitor(textCallback func(string, Source), imageCallback func([]byte, string, Source), securityCallback SecurityCallback) *Monitor {
+	m := NewMonitorWithSecurity(nil, nil, securityCallback)
+	m.textSourceCallback = textCallback
+	m.imageSourceCallback = imageCallback
+	return m
 }
 
-func (m *Monitor) startWaylandMonitor(ctx context.Context) error {
-	// Use wl-paste --watch for real-time clipboard monitoring
-	cmd := exec.CommandContext(ctx, "wl-paste", "--watch", "echo", "CLIPBOARD_CHANGED")
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-	
-	// Start the wl-paste watcher
-	err := cmd.Start()
-	if err != nil {
-		// Fallback to polling if wl-paste watch fails
-		logging.Warn("wl-paste watch failed, falling back to polling: %v", err)
-		return m.startPollingMonitor(ctx)
-	}
-
-	go func() {
-		cmd.Wait()
-	}()
-
-	// Monitor clipboard changes
-	ticker := time.NewTicker(100 * time.Millisecond) // Faster polling for Wayland
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			cmd.Process.Kill()
-			return ctx.Err()
-		case <-ticker.C:
-			content, err := m.getWaylandClipboardContent()
-			if err != nil {
-				continue
-			}
-			
-			if content != "" && content != m.lastContent {
-				m.handleClipboardChange(content)
-				m.lastContent = content
-			}
+// SetImageOptions sets the conversion applied to captured images before they are stored
+func (m *Monitor) SetImageOptions(opts ImageOptions) {
+	m.imageOptions = opts
+}
 
-			// Monitor image content if callback is set
-			if m.imageCallback != nil {
-				imageData, err := m.getWaylandClipboardImage()
-				if err == nil && len(imageData) > 16 { // Ignore very small images (likely empty/invalid)
-					imageHash := fmt.Sprintf("%x", sha256.Sum256(imageData))
-					if imageHash != m.lastImageHash {
-						m.lastImageHash = imageHash
-						description := fmt.Sprintf("Image (%d bytes)", len(imageData))
-						logging.Debug("Captured image data: %d bytes", len(imageData))
-						m.imageCallback(imageData, description)
-					}
-				}
-			}
+// SetIgnoredApps sets the application ids or window classes whose clipboard content is never stored
+func (m *Monitor) SetIgnoredApps(apps []string) {
+	m.ignoredApps = make(map[string]bool, len(apps))
+	for _, app := range apps {
+		if app != "" {
+			m.ignoredApps[strings.ToLower(app)] = true
 		}
 	}
 }
 
-func (m *Monitor) startX11Monitor(ctx context.Context) error {
-	// Initialize clipboard
-	if err := ensureInit(); err != nil {
-		return err
+// watchesImages reports whether image content is captured
+func (m *Monitor) watchesImages() bool {
+	return m.imageCallback != nil || m.imageSourceCallback != nil
+}
+
+// detectSource looks up the focused window, only when something uses the source
+func (m *Monitor) detectSource() Source {
+	source := Source{Selection: SelectionClipboard}
+	if m.textSourceCallback != nil || m.imageSourceCallback != nil || len(m.ignoredApps) > 0 {
+		source.App, source.Title = focusedWindow(m.useWayland)
+	}
+	return source
+}
+
+// rateKey returns the rate limiter source for a change: the application if known,
+// otherwise the kind of content
+func rateKey(kind string, source Source) string {
+	if source.App != "" {
+		return source.App
 	}
+	return kind
+}
 
-	return m.startPollingMonitor(ctx)
+// SetIgnoreRules sets the rules for text content that is never stored
+func (m *Monitor) SetIgnoreRules(rules *IgnoreRules) {
+	m.ignoreRules = rules
 }
 
-func (m *Monitor) startPollingMonitor(ctx context.Context) error {
-	ticker := time.NewTicker(m.interval)
-	defer ticker.Stop()
+// SetHijackDetector reports payment destinations swapped on the clipboard to alert
+func (m *Monitor) SetHijackDetector(detector *HijackDetector, alert func(HijackAlert)) {
+	m.hijack = detector
+	m.hijackCallback = alert
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			var content string
-			var err error
-			
-			if m.useWayland {
-				content, err = m.getWaylandClipboardContent()
-				if err != nil {
-					continue
-				}
-			} else {
-				content = string(clipboard.Read(clipboard.FmtText))
-			}
-			
-			if content != "" && content != m.lastContent {
-				m.handleClipboardChange(content)
-				m.lastContent = content
-			}
+// SetQuarantine passes content blocked by ignored apps or ignore rules to quarantine
+// instead of discarding it; nil discards it. Blocked hashes are always discarded.
+func (m *Monitor) SetQuarantine(quarantine QuarantineCallback) {
+	m.quarantine = quarantine
+}
 
-			// Monitor image content if callback is set
-			if m.imageCallback != nil {
-				var imageData []byte
-				var err error
-				
-				if m.useWayland {
-					imageData, err = m.getWaylandClipboardImage()
-					if err != nil {
-						continue
-					}
-				} else {
-					imageData = clipboard.Read(clipboard.FmtImage)
-				}
-				
-				if len(imageData) > 16 { // Ignore very small images (likely empty/invalid)
-					imageHash := fmt.Sprintf("%x", sha256.Sum256(imageData))
-					if imageHash != m.lastImageHash {
-						m.lastImageHash = imageHash
-						description := fmt.Sprintf("Image (%d bytes)", len(imageData))
-						logging.Debug("Captured image data: %d bytes", len(imageData))
-						m.imageCallback(imageData, description)
-					}
-				}
-			}
-		}
+// quarantineBlocked hands blocked content to the quarantine callback, if any
+func (m *Monitor) quarantineBlocked(content string, imageData []byte, source Source, reason string) {
+	if m.quarantine != nil {
+		m.quarantine(content, imageData, source, reason)
 	}
 }
 
+// SetPauseCheck makes the monitor skip clipboard changes while paused reports true
+func (m *Monitor) SetPauseCheck(paused func() bool) {
+	m.paused = paused
+}
+
+// isPaused reports whether clipboard changes are currently skipped
+func (m *Monitor) isPaused() bool {
+	if m.paused != nil && m.paused() {
+		logging.Debug("Capture is paused, skipping clipboard change")
+		return true
+	}
+	return false
+}
+
+// SetLengthLimits sets the length range of text that is stored, in characters; 0 disables a limit
+func (m *Monitor) SetLengthLimits(minLength, maxLength int) {
+	m.minLength = minLength
+	m.maxLength = maxLength
+}
+
+// SetRateLimiter sets the limiter that coalesces rapid clipboard changes; nil disables it
+func (m *Monitor) SetRateLimiter(limiter *RateLimiter) {
+	m.rateLimiter = limiter
+}
+
+// lengthOutOfRange reports why content falls outside the configured length limits
+func (m *Monitor) lengthOutOfRange(content string) (string, bool) {
+	if m.minLength <= 0 && m.maxLength <= 0 {
+		return "", false
+	}
+	length := utf8.RuneCountInString(strings.TrimSpace(content))
+	if m.minLength > 0 && length < m.minLength {
+		return fmt.Sprintf("%d characters, below min_length %d", length, m.minLength), true
+	}
+	if m.maxLength > 0 && length > m.maxLength {
+		return fmt.Sprintf("%d characters, above max_length %d", length, m.maxLength), true
+	}
+	return "", false
+}
+
 func (m *Monitor) handleClipboardChange(content string) {
 	// Check if this content is a substring expansion of the last content
 	if m.isSubstringExpansion(content) {
@@ -310,25 +305,95 @@ func (m *Monitor) truncateForLog(content string) string {
 	return content[:maxLen-3] + "..."
 }
 
+// processClipboardContent stores changed text, subject to the rate limit
 func (m *Monitor) processClipboardContent(content string) {
+	if m.isPaused() {
+		return
+	}
+	source := m.detectSource()
+	if m.ignoredApps[strings.ToLower(source.App)] {
+		logging.Info("Ignoring clipboard content copied from %s", source.App)
+		m.quarantineBlocked(content, nil, source, "ignored app "+source.App)
+		return
+	}
+	if m.rateLimiter != nil {
+		m.rateLimiter.Offer(rateKey("text", source), func() { m.storeContent(content, source) })
+		return
+	}
+	m.storeContent(content, source)
+}
+
+// processImage stores changed image data, subject to the rate limit
+func (m *Monitor) processImage(imageData []byte) {
+	if m.isPaused() {
+		return
+	}
+	if converted, changed, err := normalizeImage(imageData, m.imageOptions); err != nil {
+		logging.Warn("Storing image as captured: %v", err)
+	} else if changed {
+		logging.Debug("Converted captured image: %d bytes to %d bytes", len(imageData), len(converted))
+		imageData = converted
+	}
+
+	source := m.detectSource()
+	if m.ignoredApps[strings.ToLower(source.App)] {
+		logging.Info("Ignoring clipboard image copied from %s", source.App)
+		m.quarantineBlocked(fmt.Sprintf("Image (%d bytes)", len(imageData)), imageData, source, "ignored app "+source.App)
+		return
+	}
+	store := func() {
+		description := fmt.Sprintf("Image (%d bytes)", len(imageData))
+		logging.Debug("Captured image data: %d bytes", len(imageData))
+		if m.imageSourceCallback != nil {
+			m.imageSourceCallback(imageData, description, source)
+		} else {
+			m.imageCallback(imageData, description)
+		}
+	}
+	if m.rateLimiter != nil {
+		m.rateLimiter.Offer(rateKey("image", source), store)
+		return
+	}
+	store()
+}
+
+// StoreText runs text the daemon derived from a copy, such as the destination of a
+// shortened link, through the same filters as copied text and passes it to the callbacks
+func (m *Monitor) StoreText(content string, source Source) {
+	m.storeContent(content, source)
+}
+
+// storeContent filters text and passes it to the callbacks
+func (m *Monitor) storeContent(content string, source Source) {
+	// Swaps are checked before any filtering, so ignored or blocked content still counts
+	if m.hijack != nil {
+		if alert, swapped := m.hijack.Check(content); swapped && m.hijackCallback != nil {
+			m.hijackCallback(alert)
+		}
+	}
+
+	// Content matching [capture.ignore] is dropped before any other processing
+	if rule, ignored := m.ignoreRules.Match(content); ignored {
+		logging.Info("Ignoring clipboard content matching %s", rule)
+		m.quarantineBlocked(content, nil, source, "ignore rule "+rule)
+		return
+	}
+	if reason, skip := m.lengthOutOfRange(content); skip {
+		logging.Info("Ignoring clipboard content: %s", reason)
+		return
+	}
+
+	// Content the user blocked in the TUI is rejected whether or not the detector flags it now
+	// Content the user deleted and blocked is never quarantined, so it stays off disk
+	if m.isBlocked(content) {
+		return
+	}
+
 	// Check for security threats
 	if m.detector != nil {
 		threats := m.detector.DetectSecurity(content)
 
 		if len(threats) > 0 {
-			// Security content detected
-			contentHash := security.CreateHash(content)
-
-			// Check if this content is already known to be blocked by user
-			if m.hashStore != nil {
-				isKnown, _, err := m.hashStore.IsKnownSecurityContent(content)
-				if err == nil && isKnown {
-					// Skip only content user has explicitly chosen to block
-					logging.Info("Skipping user-blocked security content (hash: %s)", contentHash[:8])
-					return
-				}
-			}
-
 			// Log security detection but don't block - let TUI show indicators
 			highestThreat := security.GetHighestThreat(threats)
 			if highestThreat != nil {
@@ -351,17 +416,30 @@ func (m *Monitor) processClipboardContent(content string) {
 	}
 
 	// Store content normally
-	if m.textCallback != nil {
+	if m.textSourceCallback != nil {
+		logging.Debug("Storing clipboard content from %q (len=%d): %s...", source.App, len(content), m.truncateForLog(content))
+		m.textSourceCallback(content, source)
+	} else if m.textCallback != nil {
 		logging.Debug("Storing clipboard content (len=%d): %s...", len(content), m.truncateForLog(content))
 		m.textCallback(content)
 	}
 }
 
-func ensureInit() error {
-	initOnce.Do(func() {
-		initErr = clipboard.Init()
-	})
-	return initErr
+// isBlocked reports whether the user blocked content, counting the hit in the hash store
+func (m *Monitor) isBlocked(content string) bool {
+	if m.hashStore == nil {
+		return false
+	}
+	contentHash := security.CreateHash(content)
+	blocked, err := m.hashStore.RecordHit(contentHash)
+	if err != nil {
+		logging.Warn("Failed to check blocked content hashes: %v", err)
+		return false
+	}
+	if blocked {
+		logging.Info("Skipping user-blocked content (hash: %s)", contentHash[:8])
+	}
+	return blocked
 }
 
 func isWaylandSession() bool {
@@ -374,7 +452,9 @@ func (m *Monitor) getWaylandClipboardContent() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return string(output), nil
+	content := string(output)
+	security.Zero(output) // The string is a copy; don't leave the raw read behind
+	return content, nil
 }
 
 func (m *Monitor) getWaylandClipboardImage() ([]byte, error) {
@@ -392,7 +472,7 @@ func (m *Monitor) getWaylandClipboardImage() ([]byte, error) {
 	}
 	
 	// Try different image formats
-	formats := []string{"image/png", "image/jpeg", "image/gif", "image/bmp"}
+	formats := []string{"image/png", "image/jpeg", "image/gif", "image/bmp", "image/tiff"}
 	for _, format := range formats {
 		if strings.Contains(typesStr, format) {
 			cmd := exec.Command("wl-paste", "--type", format)
@@ -407,12 +487,30 @@ func (m *Monitor) getWaylandClipboardImage() ([]byte, error) {
 }
 
 func Copy(content string) error {
+	if Headless() {
+		return ErrNoDisplay
+	}
 	if isWaylandSession() {
 		return copyWayland(content)
 	}
 	return copyX11(content)
 }
 
+// Paste returns the text on the system clipboard
+func Paste() (string, error) {
+	if Headless() {
+		return "", ErrNoDisplay
+	}
+	if isWaylandSession() {
+		output, err := exec.Command("wl-paste", "--no-newline").Output()
+		if err != nil {
+			return "", fmt.Errorf("wl-paste failed: %v", err)
+		}
+		return string(output), nil
+	}
+	return atotto.ReadAll()
+}
+
 func copyWayland(content string) error {
 	// Use wl-copy for Wayland clipboard
 	cmd := exec.Command("wl-copy")
@@ -443,7 +541,78 @@ func copyX11(content string) error {
 	return nil
 }
 
+// CopySensitive copies text flagged as a security threat. Unlike Copy, the content is
+// written to the clipboard tools from a buffer that is zeroed afterwards, so no
+// additional plaintext copies are left in memory.
+func CopySensitive(content string) error {
+	if Headless() {
+		return ErrNoDisplay
+	}
+	data := []byte(content)
+	defer security.Zero(data)
+
+	if isWaylandSession() {
+		if err := runWithInput(exec.Command("wl-copy"), data); err != nil {
+			return fmt.Errorf("wl-copy failed: %v", err)
+		}
+		return nil
+	}
+
+	if err := runWithInput(exec.Command("xclip", "-selection", "clipboard"), data); err != nil {
+		// Without xclip, fall back to the regular copy rather than failing
+		return copyX11(content)
+	}
+	runWithInput(exec.Command("xclip", "-selection", "primary"), data) // PRIMARY is optional
+	return nil
+}
+
+// runWithInput runs cmd with data written directly to its standard input, avoiding
+// the intermediate copy buffer exec uses for an io.Reader
+func runWithInput(cmd *exec.Cmd, data []byte) error {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	_, writeErr := stdin.Write(data)
+	closeErr := stdin.Close()
+	if err := cmd.Wait(); err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return closeErr
+}
+
+// Clear empties the clipboard and the primary selection
+func Clear() error {
+	if Headless() {
+		return ErrNoDisplay
+	}
+	if isWaylandSession() {
+		if err := exec.Command("wl-copy", "--clear").Run(); err != nil {
+			return fmt.Errorf("wl-copy failed: %v", err)
+		}
+		exec.Command("wl-copy", "--primary", "--clear").Run() // Primary selection is optional
+		return nil
+	}
+
+	if err := runWithInput(exec.Command("xclip", "-selection", "clipboard"), nil); err != nil {
+		if err := atotto.WriteAll(""); err != nil {
+			return err
+		}
+	}
+	runWithInput(exec.Command("xclip", "-selection", "primary"), nil) // PRIMARY is optional
+	return nil
+}
+
 func CopyImage(imageData []byte) error {
+	if Headless() {
+		return ErrNoDisplay
+	}
 	if isWaylandSession() {
 		return copyImageWayland(imageData)
 	}
@@ -461,18 +630,6 @@ func copyImageWayland(imageData []byte) error {
 	return nil
 }
 
-func copyImageX11(imageData []byte) error {
-	// Initialize clipboard if needed
-	if err := ensureInit(); err != nil {
-		return err
-	}
-
-	// Copy image data to clipboard
-	clipboard.Write(clipboard.FmtImage, imageData)
-
-	return nil
-}
-
 // Close cleans up resources used by the monitor
 func (m *Monitor) Close() error {
 	// Cancel any pending stabilization timer
@@ -480,9 +637,16 @@ func (m *Monitor) Close() error {
 		m.stabilizeTimer.Stop()
 		m.stabilizeTimer = nil
 	}
+	// Store the last change of a burst that is still being coalesced
+	if m.rateLimiter != nil {
+		m.rateLimiter.Close()
+	}
 	
 	if m.hashStore != nil {
-		return m.hashStore.Close()
+		if err := m.hashStore.Close(); err != nil {
+			logging.Warn("Failed to flush the security hash store: %v", err)
+			return err
+		}
 	}
 	return nil
 }
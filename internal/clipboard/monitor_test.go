@@ -25,7 +25,13 @@ SOFTWARE.
 package clipboard
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/adaryorg/nclip/internal/security"
 )
 
 func TestCopy(t *testing.T) {
@@ -50,3 +56,133 @@ func TestCopyImage(t *testing.T) {
 		t.Logf("CopyImage failed (this is expected in headless environments): %v", err)
 	}
 }
+
+func TestProcessClipboardContent_LengthLimits(t *testing.T) {
+	var stored []string
+	monitor := &Monitor{textCallback: func(content string) { stored = append(stored, content) }}
+	monitor.SetLengthLimits(3, 10)
+
+	for _, content := range []string{"a", " ab \n", "abc", "日本語", "0123456789", "01234567890"} {
+		monitor.processClipboardContent(content)
+	}
+
+	expected := []string{"abc", "日本語", "0123456789"}
+	if len(stored) != len(expected) {
+		t.Fatalf("Expected %q to be stored, got %q", expected, stored)
+	}
+	for i := range expected {
+		if stored[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], stored[i])
+		}
+	}
+}
+
+func TestProcessClipboardContent_BlockedHashes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hashStore, err := security.NewHashStore()
+	if err != nil {
+		t.Fatalf("Failed to create hash store: %v", err)
+	}
+	defer hashStore.Close()
+
+	// Blocked content is rejected even when the detector does not flag it
+	blockedHash := security.CreateHash("blocked note")
+	if err := hashStore.AddHash(blockedHash, security.SecurityThreat{Type: "secret", Confidence: 0.9, Reason: "test"}); err != nil {
+		t.Fatalf("Failed to add hash: %v", err)
+	}
+
+	var stored, quarantined []string
+	monitor := &Monitor{
+		textCallback: func(content string) { stored = append(stored, content) },
+		detector:     security.DefaultDetector(),
+		hashStore:    hashStore,
+	}
+	monitor.SetQuarantine(func(content string, imageData []byte, source Source, reason string) {
+		quarantined = append(quarantined, content)
+	})
+	monitor.processClipboardContent("blocked note")
+	monitor.processClipboardContent("allowed note")
+
+	if len(stored) != 1 || stored[0] != "allowed note" {
+		t.Errorf("Expected only the allowed note to be stored, got %q", stored)
+	}
+	if len(quarantined) != 0 {
+		t.Errorf("Expected blocked content to skip quarantine, got %q", quarantined)
+	}
+	if entry, _ := hashStore.GetHash(blockedHash); entry == nil || entry.Count != 2 {
+		t.Errorf("Expected the rejection to be counted as a hit, got %+v", entry)
+	}
+}
+
+func TestRunWithInput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out")
+	data := []byte("secret token")
+
+	if err := runWithInput(exec.Command("sh", "-c", "cat > "+output), data); err != nil {
+		t.Fatalf("runWithInput failed: %v", err)
+	}
+	if written, _ := os.ReadFile(output); string(written) != "secret token" {
+		t.Errorf("Expected the data on stdin, got %q", written)
+	}
+	if err := runWithInput(exec.Command("sh", "-c", "exit 3"), data); err == nil {
+		t.Error("Expected the command's exit status as an error")
+	}
+}
+
+func TestProcessClipboardContent_Quarantine(t *testing.T) {
+	rules, err := NewIgnoreRules([]string{`^\d{6}$`}, nil)
+	if err != nil {
+		t.Fatalf("Failed to compile rules: %v", err)
+	}
+
+	var stored, quarantined []string
+	monitor := &Monitor{textCallback: func(content string) { stored = append(stored, content) }}
+	monitor.SetIgnoreRules(rules)
+	monitor.SetQuarantine(func(content string, imageData []byte, source Source, reason string) {
+		quarantined = append(quarantined, content+": "+reason)
+	})
+
+	monitor.processClipboardContent("123456")
+	monitor.processClipboardContent("hello")
+
+	if len(stored) != 1 || stored[0] != "hello" {
+		t.Errorf("Expected only the allowed text to be stored, got %q", stored)
+	}
+	if len(quarantined) != 1 || !strings.HasPrefix(quarantined[0], "123456: ignore rule ") {
+		t.Errorf("Expected the blocked code to be quarantined with its rule, got %q", quarantined)
+	}
+}
+
+func TestProcessClipboardContent_Paused(t *testing.T) {
+	var stored []string
+	paused := true
+	monitor := &Monitor{textCallback: func(content string) { stored = append(stored, content) }}
+	monitor.SetPauseCheck(func() bool { return paused })
+
+	monitor.processClipboardContent("while paused")
+	paused = false
+	monitor.processClipboardContent("after resuming")
+
+	if len(stored) != 1 || stored[0] != "after resuming" {
+		t.Errorf("Expected only content copied after resuming to be stored, got %q", stored)
+	}
+}
+
+func TestStoreText_Filtered(t *testing.T) {
+	rules, err := NewIgnoreRules(nil, []string{"https://bank.example/*"})
+	if err != nil {
+		t.Fatalf("Failed to compile rules: %v", err)
+	}
+
+	var stored []string
+	monitor := &Monitor{textCallback: func(content string) { stored = append(stored, content) }}
+	monitor.SetIgnoreRules(rules)
+
+	// A resolved link is checked against the rules the short link passed
+	monitor.StoreText("https://bank.example/reset?token=1", Source{})
+	monitor.StoreText("https://example.com/page", Source{})
+
+	if len(stored) != 1 || stored[0] != "https://example.com/page" {
+		t.Errorf("Expected only the allowed link to be stored, got %q", stored)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// RateLimiter protects the history from applications that update the clipboard many
+// times per second. Each source may store a burst of changes, refilled at a steady
+// rate; changes beyond that are coalesced and only the latest is stored once the
+// source has been quiet for the coalescing window.
+type RateLimiter struct {
+	mu       sync.Mutex
+	burst    float64
+	refill   float64 // Allowance regained per second
+	coalesce time.Duration
+	sources  map[string]*rateSource
+	now      func() time.Time
+}
+
+// rateSource tracks the allowance and the throttled change of one source
+type rateSource struct {
+	tokens    float64
+	updated   time.Time
+	pending   func() // Latest throttled change
+	coalesced int    // Throttled changes replaced by pending
+	timer     *time.Timer
+	seq       int // Identifies the current timer so a stale one doesn't deliver
+}
+
+// NewRateLimiter creates a limiter allowing burst changes per source, refilled at
+// perMinute changes per minute, coalescing the rest over the given quiet period
+func NewRateLimiter(burst, perMinute int, coalesce time.Duration) *RateLimiter {
+	return &RateLimiter{
+		burst:    float64(burst),
+		refill:   float64(perMinute) / 60,
+		coalesce: coalesce,
+		sources:  make(map[string]*rateSource),
+		now:      time.Now,
+	}
+}
+
+// Offer runs deliver right away while source is within its rate. Otherwise deliver
+// replaces the pending change of that source, which runs once no further change
+// has arrived for the coalescing window.
+func (r *RateLimiter) Offer(source string, deliver func()) {
+	r.mu.Lock()
+	now := r.now()
+	s, ok := r.sources[source]
+	if !ok {
+		s = &rateSource{tokens: r.burst, updated: now}
+		r.sources[source] = s
+	}
+	s.tokens = min(r.burst, s.tokens+now.Sub(s.updated).Seconds()*r.refill)
+	s.updated = now
+
+	// While a change is pending, later ones join it so they are never stored out of order
+	if s.timer == nil && s.tokens >= 1 {
+		s.tokens--
+		r.mu.Unlock()
+		deliver()
+		return
+	}
+
+	if s.pending == nil {
+		logging.Debug("Clipboard changes from %s exceed the rate limit, coalescing", source)
+	} else {
+		s.coalesced++
+	}
+	s.pending = deliver
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.seq++
+	seq := s.seq
+	s.timer = time.AfterFunc(r.coalesce, func() {
+		r.flush(source, s, seq)
+	})
+	r.mu.Unlock()
+}
+
+// flush delivers the pending change of a source if its timer is still the current one
+func (r *RateLimiter) flush(source string, s *rateSource, seq int) {
+	r.mu.Lock()
+	if s.seq != seq || s.pending == nil {
+		r.mu.Unlock()
+		return
+	}
+	deliver, coalesced := s.pending, s.coalesced
+	s.pending, s.coalesced, s.timer = nil, 0, nil
+	r.mu.Unlock()
+
+	if coalesced > 0 {
+		logging.Info("Storing the last of %d rapid clipboard changes from %s", coalesced+1, source)
+	}
+	deliver()
+}
+
+// Close stops all timers and delivers the pending changes, so nothing is lost on shutdown
+func (r *RateLimiter) Close() {
+	r.mu.Lock()
+	var pending []func()
+	for _, s := range r.sources {
+		if s.timer != nil {
+			s.timer.Stop()
+			s.timer = nil
+		}
+		if s.pending != nil {
+			pending = append(pending, s.pending)
+			s.pending, s.coalesced = nil, 0
+		}
+		s.seq++
+	}
+	r.mu.Unlock()
+
+	for _, deliver := range pending {
+		deliver()
+	}
+}
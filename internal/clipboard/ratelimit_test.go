@@ -0,0 +1,111 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects delivered changes from limiter callbacks
+type recorder struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (r *recorder) deliver(value string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.values = append(r.values, value)
+	}
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.values...)
+}
+
+func TestRateLimiter_CoalescesBursts(t *testing.T) {
+	limiter := NewRateLimiter(2, 60, 30*time.Millisecond)
+	var got recorder
+
+	for _, value := range []string{"a", "b", "c", "d", "e"} {
+		limiter.Offer("text", got.deliver(value))
+	}
+	if values := got.get(); len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Fatalf("Expected the burst to be delivered at once, got %v", values)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if values := got.get(); len(values) != 3 || values[2] != "e" {
+		t.Errorf("Expected only the last throttled change after the quiet period, got %v", values)
+	}
+}
+
+func TestRateLimiter_SourcesAreIndependent(t *testing.T) {
+	limiter := NewRateLimiter(1, 60, time.Hour)
+	defer limiter.Close()
+	var got recorder
+
+	limiter.Offer("text", got.deliver("text 1"))
+	limiter.Offer("text", got.deliver("text 2"))
+	limiter.Offer("image", got.deliver("image 1"))
+
+	if values := got.get(); len(values) != 2 || values[1] != "image 1" {
+		t.Errorf("Expected a throttled source not to hold back another, got %v", values)
+	}
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewRateLimiter(1, 60, time.Hour)
+	limiter.now = func() time.Time { return now }
+	var got recorder
+
+	limiter.Offer("text", got.deliver("a"))
+	now = now.Add(2 * time.Second)
+	limiter.Offer("text", got.deliver("b"))
+
+	if values := got.get(); len(values) != 2 {
+		t.Errorf("Expected the allowance to refill after a second, got %v", values)
+	}
+}
+
+func TestRateLimiter_CloseDeliversPending(t *testing.T) {
+	limiter := NewRateLimiter(1, 60, time.Hour)
+	var got recorder
+
+	limiter.Offer("text", got.deliver("a"))
+	limiter.Offer("text", got.deliver("b"))
+	limiter.Offer("text", got.deliver("c"))
+	limiter.Close()
+
+	if values := got.get(); len(values) != 2 || values[1] != "c" {
+		t.Errorf("Expected Close to deliver the pending change, got %v", values)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Source describes where clipboard content was copied: the focused window at the
+// time of the change and the selection it was read from
+type Source struct {
+	App       string // Application id (Wayland) or window class (X11), "" if unknown
+	Title     string // Window title
+	Selection string // "clipboard" or "primary"
+}
+
+// SelectionClipboard is the selection the monitor reads
+const SelectionClipboard = "clipboard"
+
+// sourceCommandTimeout bounds each window manager query, so a hung compositor can't stall capture
+const sourceCommandTimeout = 500 * time.Millisecond
+
+// runSourceCommand runs a window manager query; replaced in tests
+var runSourceCommand = func(name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sourceCommandTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// focusedWindow returns the application and title of the focused window. Hyprland and
+// sway are asked over their IPC tools, X11 through xprop; other Wayland compositors
+// don't expose the focused window and return empty values.
+func focusedWindow(useWayland bool) (app, title string) {
+	switch {
+	case os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "":
+		if out, err := runSourceCommand("hyprctl", "activewindow", "-j"); err == nil {
+			return parseHyprlandWindow(out)
+		}
+	case os.Getenv("SWAYSOCK") != "":
+		if out, err := runSourceCommand("swaymsg", "-t", "get_tree", "-r"); err == nil {
+			return parseSwayTree(out)
+		}
+	case !useWayland && os.Getenv("DISPLAY") != "":
+		return x11FocusedWindow()
+	}
+	return "", ""
+}
+
+// parseHyprlandWindow reads the output of hyprctl activewindow -j
+func parseHyprlandWindow(out []byte) (app, title string) {
+	var window struct {
+		Class string `json:"class"`
+		Title string `json:"title"`
+	}
+	if json.Unmarshal(out, &window) != nil {
+		return "", ""
+	}
+	return window.Class, window.Title
+}
+
+// swayNode is the part of a sway tree node needed to find the focused window
+type swayNode struct {
+	Name             string     `json:"name"`
+	AppID            string     `json:"app_id"`
+	Focused          bool       `json:"focused"`
+	Nodes            []swayNode `json:"nodes"`
+	FloatingNodes    []swayNode `json:"floating_nodes"`
+	WindowProperties struct {
+		Class string `json:"class"`
+	} `json:"window_properties"`
+}
+
+// parseSwayTree finds the focused window in the output of swaymsg -t get_tree
+func parseSwayTree(out []byte) (app, title string) {
+	var root swayNode
+	if json.Unmarshal(out, &root) != nil {
+		return "", ""
+	}
+	if node := findFocusedSwayNode(&root); node != nil {
+		app = node.AppID
+		if app == "" {
+			app = node.WindowProperties.Class // XWayland windows
+		}
+		return app, node.Name
+	}
+	return "", ""
+}
+
+// findFocusedSwayNode searches the tree for the focused node
+func findFocusedSwayNode(node *swayNode) *swayNode {
+	if node.Focused {
+		return node
+	}
+	for _, children := range [][]swayNode{node.Nodes, node.FloatingNodes} {
+		for i := range children {
+			if found := findFocusedSwayNode(&children[i]); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	xpropWindowID = regexp.MustCompile(`window id # (0x[0-9a-fA-F]+)`)
+	xpropClass    = regexp.MustCompile(`WM_CLASS\([^)]*\) = "[^"]*", "([^"]*)"`)
+	xpropName     = regexp.MustCompile(`_NET_WM_NAME\([^)]*\) = "(.*)"`)
+)
+
+// x11FocusedWindow asks xprop for the class and title of the active window
+func x11FocusedWindow() (app, title string) {
+	out, err := runSourceCommand("xprop", "-root", "_NET_ACTIVE_WINDOW")
+	if err != nil {
+		return "", ""
+	}
+	match := xpropWindowID.FindSubmatch(out)
+	if match == nil || string(match[1]) == "0x0" {
+		return "", ""
+	}
+	out, err = runSourceCommand("xprop", "-id", string(match[1]), "WM_CLASS", "_NET_WM_NAME")
+	if err != nil {
+		return "", ""
+	}
+	return parseXpropWindow(out)
+}
+
+// parseXpropWindow reads the WM_CLASS class name and the _NET_WM_NAME title
+func parseXpropWindow(out []byte) (app, title string) {
+	if match := xpropClass.FindSubmatch(out); match != nil {
+		app = string(match[1])
+	}
+	if match := xpropName.FindSubmatch(out); match != nil {
+		title = strings.ReplaceAll(string(match[1]), `\"`, `"`)
+	}
+	return app, title
+}
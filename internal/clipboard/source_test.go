@@ -0,0 +1,105 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"testing"
+)
+
+func TestParseHyprlandWindow(t *testing.T) {
+	app, title := parseHyprlandWindow([]byte(`{"address": "0x1", "class": "firefox", "title": "Example Domain"}`))
+	if app != "firefox" || title != "Example Domain" {
+		t.Errorf("Got %q, %q", app, title)
+	}
+	if app, title := parseHyprlandWindow([]byte("Invalid")); app != "" || title != "" {
+		t.Errorf("Expected nothing for invalid output, got %q, %q", app, title)
+	}
+}
+
+func TestParseSwayTree(t *testing.T) {
+	tree := `{"name": "root", "nodes": [{"name": "1", "nodes": [
+		{"name": "~", "app_id": "foot", "focused": false},
+		{"name": "Editor", "app_id": null, "focused": false, "floating_nodes": [
+			{"name": "Secrets", "app_id": null, "window_properties": {"class": "KeePassXC"}, "focused": true}
+		]}
+	]}]}`
+	app, title := parseSwayTree([]byte(tree))
+	if app != "KeePassXC" || title != "Secrets" {
+		t.Errorf("Got %q, %q", app, title)
+	}
+}
+
+func TestParseXpropWindow(t *testing.T) {
+	out := "WM_CLASS(STRING) = \"navigator\", \"firefox\"\n_NET_WM_NAME(UTF8_STRING) = \"Say \\\"hi\\\" - Mozilla Firefox\"\n"
+	app, title := parseXpropWindow([]byte(out))
+	if app != "firefox" || title != `Say "hi" - Mozilla Firefox` {
+		t.Errorf("Got %q, %q", app, title)
+	}
+}
+
+func TestFocusedWindow_Hyprland(t *testing.T) {
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "test")
+	original := runSourceCommand
+	defer func() { runSourceCommand = original }()
+	runSourceCommand = func(name string, args ...string) ([]byte, error) {
+		if name != "hyprctl" {
+			t.Errorf("Unexpected command %s", name)
+		}
+		return []byte(`{"class": "kitty", "title": "~"}`), nil
+	}
+
+	if app, title := focusedWindow(true); app != "kitty" || title != "~" {
+		t.Errorf("Got %q, %q", app, title)
+	}
+}
+
+func TestMonitor_IgnoredApps(t *testing.T) {
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "test")
+	original := runSourceCommand
+	defer func() { runSourceCommand = original }()
+	app := "org.keepassxc.KeePassXC"
+	runSourceCommand = func(name string, args ...string) ([]byte, error) {
+		return []byte(`{"class": "` + app + `", "title": "Database"}`), nil
+	}
+
+	var stored []string
+	var sources []Source
+	monitor := &Monitor{textSourceCallback: func(content string, source Source) {
+		stored = append(stored, content)
+		sources = append(sources, source)
+	}}
+	monitor.SetIgnoredApps([]string{"org.keepassxc.keepassxc"})
+
+	monitor.processClipboardContent("hunter2")
+	app = "kitty"
+	monitor.processClipboardContent("ls -la")
+
+	if len(stored) != 1 || stored[0] != "ls -la" {
+		t.Fatalf("Expected only content from kitty to be stored, got %v", stored)
+	}
+	if sources[0].App != "kitty" || sources[0].Selection != SelectionClipboard {
+		t.Errorf("Unexpected source %+v", sources[0])
+	}
+}
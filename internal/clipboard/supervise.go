@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// Restart delays used by Supervise. A monitor that ran for healthyRunTime before
+// failing is restarted after the minimum delay again.
+var (
+	minRestartDelay = time.Second
+	maxRestartDelay = time.Minute
+	healthyRunTime  = time.Minute
+)
+
+// watcher is the part of Monitor that Supervise drives
+type watcher interface {
+	Start(ctx context.Context) error
+	Close() error
+}
+
+// Supervise runs monitors created by newMonitor until ctx is cancelled. When the
+// clipboard watcher fails or panics, the failure is logged and a new monitor is
+// started after an exponentially growing delay.
+func Supervise(ctx context.Context, newMonitor func() *Monitor) {
+	supervise(ctx, func() watcher { return newMonitor() })
+}
+
+// supervise is Supervise for any watcher
+func supervise(ctx context.Context, newWatcher func() watcher) {
+	delay := minRestartDelay
+	for {
+		started := time.Now()
+		err := runWatcher(ctx, newWatcher())
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) >= healthyRunTime {
+			delay = minRestartDelay
+		}
+		logging.Error("Clipboard monitor stopped: %v; restarting in %v", err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRestartDelay)
+		logging.Info("Restarting clipboard monitor")
+	}
+}
+
+// runWatcher runs one watcher until it stops, turning a panic into an error
+func runWatcher(ctx context.Context, w watcher) (err error) {
+	defer w.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("monitor panicked: %v", r)
+		}
+	}()
+
+	if err := w.Start(ctx); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: monitor returned", ErrWatcherStopped)
+}
@@ -0,0 +1,117 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeWatcher fails, panics or blocks until cancelled, and records Close
+type fakeWatcher struct {
+	start  func(ctx context.Context) error
+	closed bool
+}
+
+func (w *fakeWatcher) Start(ctx context.Context) error { return w.start(ctx) }
+func (w *fakeWatcher) Close() error                    { w.closed = true; return nil }
+
+func TestSupervise_RestartsFailedWatchers(t *testing.T) {
+	oldMin, oldMax := minRestartDelay, maxRestartDelay
+	minRestartDelay, maxRestartDelay = time.Millisecond, 4*time.Millisecond
+	defer func() { minRestartDelay, maxRestartDelay = oldMin, oldMax }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var watchers []*fakeWatcher
+	newWatcher := func() watcher {
+		w := &fakeWatcher{}
+		switch len(watchers) {
+		case 0:
+			w.start = func(context.Context) error { return ErrWatcherStopped }
+		case 1:
+			w.start = func(context.Context) error { panic("display went away") }
+		default:
+			w.start = func(ctx context.Context) error {
+				cancel()
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		}
+		watchers = append(watchers, w)
+		return w
+	}
+
+	done := make(chan struct{})
+	go func() {
+		supervise(ctx, newWatcher)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("supervise did not return after cancellation")
+	}
+
+	if len(watchers) != 3 {
+		t.Fatalf("Expected 3 watchers after a failure and a panic, got %d", len(watchers))
+	}
+	for i, w := range watchers {
+		if !w.closed {
+			t.Errorf("Watcher %d was not closed", i)
+		}
+	}
+}
+
+func TestRunWatcher_ReturningIsAFailure(t *testing.T) {
+	w := &fakeWatcher{start: func(context.Context) error { return nil }}
+	if err := runWatcher(context.Background(), w); !errors.Is(err, ErrWatcherStopped) {
+		t.Errorf("Expected ErrWatcherStopped, got %v", err)
+	}
+}
+
+func TestX11Address(t *testing.T) {
+	tests := []struct {
+		display, network, address string
+		ok                        bool
+	}{
+		{":0", "unix", "/tmp/.X11-unix/X0", true},
+		{":1.0", "unix", "/tmp/.X11-unix/X1", true},
+		{"unix:2", "unix", "/tmp/.X11-unix/X2", true},
+		{"localhost:10.0", "tcp", "localhost:6010", true},
+		{"", "", "", false},
+		{"/private/tmp/com.apple.launchd.abc/org.xquartz:0", "", "", false},
+	}
+	for _, tt := range tests {
+		network, address, ok := x11Address(tt.display)
+		if network != tt.network || address != tt.address || ok != tt.ok {
+			t.Errorf("x11Address(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.display, network, address, ok, tt.network, tt.address, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+//go:build !nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"golang.design/x/clipboard"
+
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+var (
+	initMu   sync.Mutex
+	initDone bool
+)
+
+func (m *Monitor) Start(ctx context.Context) error {
+	if m.useWayland {
+		return m.startWaylandMonitor(ctx)
+	}
+	return m.startX11Monitor(ctx)
+}
+
+func (m *Monitor) startWaylandMonitor(ctx context.Context) error {
+	// Use wl-paste --watch for real-time clipboard monitoring
+	cmd := exec.CommandContext(ctx, "wl-paste", "--watch", "echo", "CLIPBOARD_CHANGED")
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+
+	// Start the wl-paste watcher
+	err := cmd.Start()
+	if err != nil {
+		// Fallback to polling if wl-paste watch fails
+		logging.Warn("wl-paste watch failed, falling back to polling: %v", err)
+		return m.startPollingMonitor(ctx)
+	}
+
+	// wl-paste --watch only exits when the compositor connection is gone
+	watchDone := make(chan error, 1)
+	go func() {
+		watchDone <- cmd.Wait()
+	}()
+
+	// Monitor clipboard changes
+	ticker := time.NewTicker(100 * time.Millisecond) // Faster polling for Wayland
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cmd.Process.Kill()
+			return ctx.Err()
+		case err := <-watchDone:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%w: wl-paste --watch exited: %v", ErrWatcherStopped, err)
+		case <-ticker.C:
+			content, err := m.getWaylandClipboardContent()
+			if err != nil {
+				continue
+			}
+
+			if content != "" && content != m.lastContent {
+				m.handleClipboardChange(content)
+				m.lastContent = content
+			}
+
+			// Monitor image content if callback is set
+			if m.watchesImages() {
+				imageData, err := m.getWaylandClipboardImage()
+				if err == nil && len(imageData) > 16 { // Ignore very small images (likely empty/invalid)
+					imageHash := fmt.Sprintf("%x", sha256.Sum256(imageData))
+					if imageHash != m.lastImageHash {
+						m.lastImageHash = imageHash
+						m.processImage(imageData)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (m *Monitor) startX11Monitor(ctx context.Context) error {
+	// Initialize clipboard
+	if err := ensureInit(); err != nil {
+		return err
+	}
+
+	return m.startPollingMonitor(ctx)
+}
+
+func (m *Monitor) startPollingMonitor(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	healthTicker := time.NewTicker(healthCheckInterval)
+	defer healthTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-healthTicker.C:
+			// Reads fail silently when the display server is gone, so check it directly
+			if err := m.checkSession(); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			var content string
+			var err error
+
+			if m.useWayland {
+				content, err = m.getWaylandClipboardContent()
+				if err != nil {
+					continue
+				}
+			} else {
+				content = string(clipboard.Read(clipboard.FmtText))
+			}
+
+			if content != "" && content != m.lastContent {
+				m.handleClipboardChange(content)
+				m.lastContent = content
+			}
+
+			// Monitor image content if callback is set
+			if m.watchesImages() {
+				var imageData []byte
+				var err error
+
+				if m.useWayland {
+					imageData, err = m.getWaylandClipboardImage()
+					if err != nil {
+						continue
+					}
+				} else {
+					imageData = clipboard.Read(clipboard.FmtImage)
+				}
+
+				if len(imageData) > 16 { // Ignore very small images (likely empty/invalid)
+					imageHash := fmt.Sprintf("%x", sha256.Sum256(imageData))
+					if imageHash != m.lastImageHash {
+						m.lastImageHash = imageHash
+						m.processImage(imageData)
+					}
+				}
+			}
+		}
+	}
+}
+
+// ensureInit initializes X11 clipboard access, retrying on later calls until it succeeds
+// so a monitor restarted after the X server came up can use it
+func ensureInit() error {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if initDone {
+		return nil
+	}
+	if err := clipboard.Init(); err != nil {
+		return err
+	}
+	initDone = true
+	return nil
+}
+
+func copyImageX11(imageData []byte) error {
+	// Initialize clipboard if needed
+	if err := ensureInit(); err != nil {
+		return err
+	}
+
+	// Copy image data to clipboard
+	clipboard.Write(clipboard.FmtImage, imageData)
+
+	return nil
+}
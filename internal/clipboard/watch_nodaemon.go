@@ -0,0 +1,52 @@
+//go:build nodaemon
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// ErrNoMonitor is returned by Start in builds without the clipboard monitor
+var ErrNoMonitor = errors.New("clipboard monitor not compiled in (built with -tags nodaemon)")
+
+// Start is not available without the clipboard monitor
+func (m *Monitor) Start(ctx context.Context) error {
+	return ErrNoMonitor
+}
+
+// copyImageX11 copies an image with xclip, since the X11 clipboard library is part of
+// the monitor
+func copyImageX11(imageData []byte) error {
+	cmd := exec.Command("xclip", "-selection", "clipboard", "-t", "image/png")
+	if err := runWithInput(cmd, imageData); err != nil {
+		return fmt.Errorf("xclip image failed: %v", err)
+	}
+	return nil
+}
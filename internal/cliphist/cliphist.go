@@ -0,0 +1,176 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package cliphist implements the list, decode, delete and store commands of cliphist,
+// so launcher scripts written for cliphist work with the nclip history
+package cliphist
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// DefaultPreviewWidth is the preview length used by cliphist list
+const DefaultPreviewWidth = 100
+
+// fieldSep separates the ID from the preview, as in cliphist
+const fieldSep = "\t"
+
+// List writes one "ID<TAB>preview" line per entry, newest first
+func List(w io.Writer, store *storage.Storage, width int) error {
+	bw := bufio.NewWriter(w)
+	for _, meta := range store.GetAllMeta() {
+		var line string
+		if meta.ContentType == "image" {
+			var data []byte
+			if item := store.GetFullItem(meta.ID); item != nil {
+				data = item.ImageData
+			}
+			line = imagePreview(data)
+		} else {
+			line = textPreview(meta.Content, width)
+		}
+		fmt.Fprintf(bw, "%s%s%s\n", meta.ID, fieldSep, line)
+	}
+	return bw.Flush()
+}
+
+// Decode writes the content of the entry selected by a line of List output
+func Decode(w io.Writer, store *storage.Storage, line string) error {
+	id, err := ParseID(line)
+	if err != nil {
+		return err
+	}
+	item := store.GetFullItem(id)
+	if item == nil {
+		return fmt.Errorf("entry %s not found", id)
+	}
+	if item.ContentType == "image" {
+		_, err = w.Write(item.ImageData)
+	} else {
+		_, err = io.WriteString(w, item.Content)
+	}
+	return err
+}
+
+// Delete removes the entries selected by lines of List output read from r
+func Delete(store *storage.Storage, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		id, err := ParseID(scanner.Text())
+		if err != nil {
+			return err
+		}
+		if err := store.Delete(id); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// DeleteQuery removes every text entry containing query, returning how many were removed
+func DeleteQuery(store *storage.Storage, query string) (int, error) {
+	removed := 0
+	for _, meta := range store.GetAllMeta() {
+		if meta.ContentType == "text" && strings.Contains(meta.Content, query) {
+			if err := store.Delete(meta.ID); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Store adds content read from r, as `wl-paste --watch cliphist store` does.
+// clipboardState is the CLIPBOARD_STATE set by wl-paste; sensitive content is not stored.
+func Store(store *storage.Storage, r io.Reader, clipboardState string) error {
+	if clipboardState == "sensitive" || clipboardState == "clear" {
+		return nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return store.AddImage(data, fmt.Sprintf("Image (%d bytes)", len(data)))
+	}
+	return store.Add(string(data))
+}
+
+// ParseID returns the ID at the start of a List line
+func ParseID(line string) (string, error) {
+	id, _, _ := strings.Cut(strings.TrimSpace(line), fieldSep)
+	if id == "" || strings.Trim(id, "0123456789") != "" {
+		return "", errors.New("input not prefixed with id")
+	}
+	return id, nil
+}
+
+// textPreview collapses whitespace and truncates to width characters
+func textPreview(content string, width int) string {
+	preview := strings.Join(strings.Fields(content), " ")
+	if width > 0 && utf8.RuneCountInString(preview) > width {
+		preview = string([]rune(preview)[:width-1]) + "…"
+	}
+	return preview
+}
+
+// imagePreview describes image data like cliphist's "[[ binary data ... ]]"
+func imagePreview(data []byte) string {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Sprintf("[[ binary data %s ]]", sizeString(len(data)))
+	}
+	return fmt.Sprintf("[[ binary data %s %s %dx%d ]]", sizeString(len(data)), format, config.Width, config.Height)
+}
+
+// sizeString formats a byte count with binary units
+func sizeString(size int) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%d %s", size, units[unit])
+}
@@ -0,0 +1,153 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package cliphist
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func openTestStorage(t *testing.T) *storage.Storage {
+	t.Helper()
+	store, err := storage.Open(filepath.Join(t.TempDir(), "history.db"), 100)
+	if err != nil {
+		t.Fatalf("Failed to open storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func testImage(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3, 2))); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestListAndDecode(t *testing.T) {
+	store := openTestStorage(t)
+	imageData := testImage(t)
+	store.AddImage(imageData, "Image")
+	store.Add("  first line\n\tsecond   line  ")
+
+	var out bytes.Buffer
+	if err := List(&out, store, DefaultPreviewWidth); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", out.String())
+	}
+	if !strings.HasSuffix(lines[0], "\tfirst line second line") {
+		t.Errorf("Unexpected text line %q", lines[0])
+	}
+	if want := "\t[[ binary data " + sizeString(len(imageData)) + " png 3x2 ]]"; !strings.HasSuffix(lines[1], want) {
+		t.Errorf("Unexpected image line %q", lines[1])
+	}
+
+	var decoded bytes.Buffer
+	if err := Decode(&decoded, store, lines[0]+"\n"); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded.String() != "  first line\n\tsecond   line  " {
+		t.Errorf("Expected original content, got %q", decoded.String())
+	}
+
+	decoded.Reset()
+	if err := Decode(&decoded, store, lines[1]); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !bytes.Equal(decoded.Bytes(), imageData) {
+		t.Error("Expected the original image data")
+	}
+
+	if err := Decode(&decoded, store, "not an id"); err == nil {
+		t.Error("Expected an error for input without an ID")
+	}
+}
+
+func TestDeleteAndDeleteQuery(t *testing.T) {
+	store := openTestStorage(t)
+	store.Add("keep")
+	store.Add("remove me")
+	store.Add("token abc")
+	store.Add("token def")
+
+	var out bytes.Buffer
+	List(&out, store, DefaultPreviewWidth)
+	first := strings.SplitN(out.String(), "\n", 2)[0]
+	if err := Delete(store, strings.NewReader(first+"\n\n")); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	removed, err := DeleteQuery(store, "token")
+	if err != nil {
+		t.Fatalf("DeleteQuery failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 removed by query, got %d", removed)
+	}
+
+	items := store.GetAllMeta()
+	if len(items) != 2 || items[0].Content != "remove me" || items[1].Content != "keep" {
+		t.Errorf("Unexpected remaining items %+v", items)
+	}
+}
+
+func TestStore(t *testing.T) {
+	store := openTestStorage(t)
+
+	if err := Store(store, strings.NewReader("copied"), ""); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := Store(store, strings.NewReader("secret"), "sensitive"); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := Store(store, bytes.NewReader(testImage(t)), ""); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	items := store.GetAllMeta()
+	if len(items) != 2 || items[0].ContentType != "image" || items[1].Content != "copied" {
+		t.Errorf("Unexpected items %+v", items)
+	}
+}
+
+func TestTextPreview(t *testing.T) {
+	if got := textPreview(strings.Repeat("é", 10), 5); got != "éééé…" {
+		t.Errorf("Expected truncated preview, got %q", got)
+	}
+	if got := sizeString(3 * 1024 * 1024); got != "3 MiB" {
+		t.Errorf("Unexpected size %q", got)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// backupTimeFormat is the timestamp appended to backups of replaced config files
+const backupTimeFormat = "20060102-150405"
+
+// writeConfigFile writes a config or theme file so that a crash never leaves a
+// truncated file behind: the content goes to a temporary file in the same directory
+// that is then renamed over configPath. An existing file is first copied to
+// configPath.bak-<timestamp> so hand edits are never lost.
+func writeConfigFile(configPath, content string) error {
+	configDir := filepath.Dir(configPath)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(configPath); err == nil {
+		perm = info.Mode().Perm()
+		if _, err := backupConfigFile(configPath, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(configDir, "."+filepath.Base(configPath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, configPath); err != nil {
+		return err
+	}
+
+	// Persist the rename itself
+	if dir, err := os.Open(configDir); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
+
+// backupConfigFile copies configPath to a timestamped backup next to it and returns its path
+func backupConfigFile(configPath string, now time.Time) (string, error) {
+	src, err := os.Open(configPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return "", err
+	}
+	backupPath := configPath + ".bak-" + now.Format(backupTimeFormat)
+	dst, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", configPath, err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return "", fmt.Errorf("failed to back up %s: %w", configPath, err)
+	}
+	if err := dst.Close(); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", configPath, err)
+	}
+	return backupPath, nil
+}
@@ -0,0 +1,100 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteConfigFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nclip")
+	configPath := filepath.Join(dir, "nclip.toml")
+
+	if err := writeConfigFile(configPath, "[editor]\n"); err != nil {
+		t.Fatalf("writeConfigFile failed: %v", err)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "[editor]\n" {
+		t.Errorf("Unexpected content %q", data)
+	}
+
+	// Replacing a hand-edited file keeps a backup and its permissions
+	if err := os.WriteFile(configPath, []byte("# my edits\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.Chmod(configPath, 0600); err != nil {
+		t.Fatalf("Failed to chmod config: %v", err)
+	}
+	if err := writeConfigFile(configPath, "[mouse]\n"); err != nil {
+		t.Fatalf("writeConfigFile failed: %v", err)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "[mouse]\n" {
+		t.Errorf("Unexpected content %q", data)
+	}
+	if info, _ := os.Stat(configPath); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected permissions 0600 to be kept, got %v", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	var backups []string
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("Temporary file left behind: %s", entry.Name())
+		}
+		if strings.HasPrefix(entry.Name(), "nclip.toml.bak-") {
+			backups = append(backups, entry.Name())
+		}
+	}
+	if len(backups) != 1 {
+		t.Fatalf("Expected one backup, got %v", backups)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, backups[0])); string(data) != "# my edits\n" {
+		t.Errorf("Expected backup of the edited file, got %q", data)
+	}
+}
+
+func TestBackupConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "theme.toml")
+	if err := os.WriteFile(configPath, []byte("[main]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	backupPath, err := backupConfigFile(configPath, time.Date(2025, 6, 15, 12, 30, 45, 0, time.Local))
+	if err != nil {
+		t.Fatalf("backupConfigFile failed: %v", err)
+	}
+	if backupPath != configPath+".bak-20250615-123045" {
+		t.Errorf("Unexpected backup path %s", backupPath)
+	}
+	if _, err := backupConfigFile(filepath.Join(t.TempDir(), "missing.toml"), time.Now()); err == nil {
+		t.Error("Expected an error backing up a missing file")
+	}
+}
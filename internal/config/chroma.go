@@ -0,0 +1,53 @@
+//go:build !nochroma
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// CustomChromaTheme is the [chroma] theme name that builds a style from [code_highlight]
+const CustomChromaTheme = "custom"
+
+// ChromaThemeNames returns the names of all built-in Chroma styles, sorted
+func ChromaThemeNames() []string {
+	return styles.Names()
+}
+
+// ValidateChromaTheme checks that the configured Chroma theme exists
+func (t *ThemeConfig) ValidateChromaTheme() error {
+	name := t.Chroma.Theme
+	if name == "" || name == CustomChromaTheme {
+		return nil
+	}
+	if _, ok := styles.Registry[name]; !ok {
+		return fmt.Errorf("unknown chroma theme %q (run nclip --list-chroma-themes to see available themes)", name)
+	}
+	return nil
+}
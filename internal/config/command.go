@@ -0,0 +1,162 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// FilePlaceholder is replaced with the file to open in editor and viewer command templates
+const FilePlaceholder = "{file}"
+
+// SplitCommand splits a command line into arguments the way a POSIX shell would, honouring
+// single quotes, double quotes and backslash escapes. Variables and globs are not expanded.
+func SplitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// ExpandCommand splits a command template and replaces {file} with file. When the
+// template has no placeholder the file is appended, so a plain program name still works.
+func ExpandCommand(template, file string) ([]string, error) {
+	args, err := SplitCommand(template)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("empty command")
+	}
+
+	substituted := false
+	for i, arg := range args {
+		if strings.Contains(arg, FilePlaceholder) {
+			args[i] = strings.ReplaceAll(arg, FilePlaceholder, file)
+			substituted = true
+		}
+	}
+	if !substituted {
+		args = append(args, file)
+	}
+	return args, nil
+}
+
+// commands returns the configured command templates keyed by their config name
+func (e EditorConfig) commands() []struct{ key, value string } {
+	return []struct{ key, value string }{
+		{"editor.text_editor", e.TextEditor},
+		{"editor.image_editor", e.ImageEditor},
+		{"editor.image_viewer", e.ImageViewer},
+		{"editor.browser", e.Browser},
+		{"editor.annotate_command", e.AnnotateCommand},
+		{"editor.spell_command", e.SpellCommand},
+	}
+}
+
+// Validate checks that every editor and viewer command template can be parsed
+func (e EditorConfig) Validate() error {
+	for _, command := range e.commands() {
+		if command.value == "" {
+			continue
+		}
+		args, err := SplitCommand(command.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", command.key, command.value, err)
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("invalid %s: command is blank", command.key)
+		}
+	}
+	if e.AnnotateCommand != "" && !strings.Contains(e.AnnotateCommand, "{input}") {
+		return fmt.Errorf("invalid editor.annotate_command %q: must contain {input}", e.AnnotateCommand)
+	}
+	return nil
+}
+
+// MissingPrograms lists the configured editor and viewer programs that are not on PATH
+func (e EditorConfig) MissingPrograms() []string {
+	var missing []string
+	for _, command := range e.commands() {
+		args, err := SplitCommand(command.value)
+		if err != nil || len(args) == 0 {
+			continue
+		}
+		if _, err := exec.LookPath(args[0]); err != nil {
+			missing = append(missing, fmt.Sprintf("%s (%s)", args[0], command.key))
+		}
+	}
+	return missing
+}
@@ -0,0 +1,132 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"nano", []string{"nano"}},
+		{"code --wait {file}", []string{"code", "--wait", "{file}"}},
+		{"  feh   --fullscreen\t{file} ", []string{"feh", "--fullscreen", "{file}"}},
+		{`"/opt/My Editor/bin/edit" -n`, []string{"/opt/My Editor/bin/edit", "-n"}},
+		{`vim -c 'set ft=markdown' {file}`, []string{"vim", "-c", "set ft=markdown", "{file}"}},
+		{`edit My\ File ""`, []string{"edit", "My File", ""}},
+		{`echo 'it"s' "a'b"`, []string{"echo", `it"s`, "a'b"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := SplitCommand(tt.command)
+		if err != nil {
+			t.Errorf("SplitCommand(%q) failed: %v", tt.command, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+
+	for _, command := range []string{`code "unterminated`, `vim 'x`, `edit \`} {
+		if _, err := SplitCommand(command); err == nil {
+			t.Errorf("Expected SplitCommand(%q) to fail", command)
+		}
+	}
+}
+
+func TestExpandCommand(t *testing.T) {
+	got, err := ExpandCommand("code --wait {file}", "/tmp/a b.txt")
+	if err != nil || !reflect.DeepEqual(got, []string{"code", "--wait", "/tmp/a b.txt"}) {
+		t.Errorf("Unexpected expansion %q, %v", got, err)
+	}
+
+	// Plain program names get the file appended
+	got, err = ExpandCommand("gimp", "/tmp/x.png")
+	if err != nil || !reflect.DeepEqual(got, []string{"gimp", "/tmp/x.png"}) {
+		t.Errorf("Unexpected expansion %q, %v", got, err)
+	}
+
+	got, err = ExpandCommand("edit --file={file}", "/tmp/x.txt")
+	if err != nil || !reflect.DeepEqual(got, []string{"edit", "--file=/tmp/x.txt"}) {
+		t.Errorf("Unexpected expansion %q, %v", got, err)
+	}
+
+	if _, err := ExpandCommand("  ", "/tmp/x.txt"); err == nil {
+		t.Error("Expected an error for a blank command")
+	}
+}
+
+func TestEditorConfig_Validate(t *testing.T) {
+	valid := EditorConfig{TextEditor: "code --wait {file}", ImageEditor: "gimp", ImageViewer: "feh --fullscreen {file}"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+
+	invalid := valid
+	invalid.ImageViewer = `feh "{file}`
+	if err := invalid.Validate(); err == nil || !strings.Contains(err.Error(), "editor.image_viewer") {
+		t.Errorf("Expected an image_viewer error, got %v", err)
+	}
+
+	invalid = valid
+	invalid.AnnotateCommand = "satty --output-filename {output}"
+	if err := invalid.Validate(); err == nil || !strings.Contains(err.Error(), "{input}") {
+		t.Errorf("Expected an annotate_command error, got %v", err)
+	}
+}
+
+func TestEditorConfig_MissingPrograms(t *testing.T) {
+	editor := EditorConfig{TextEditor: "sh -c true", ImageEditor: "nclip-no-such-editor --flag"}
+	missing := editor.MissingPrograms()
+	if len(missing) != 1 || !strings.HasPrefix(missing[0], "nclip-no-such-editor (editor.image_editor)") {
+		t.Errorf("Expected only the image editor to be missing, got %v", missing)
+	}
+}
+
+func TestLoadTUIConfig_RejectsInvalidEditorCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "[editor]\ntext_editor = \"code --wait '{file}\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "nclip.toml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadTUIConfig(); err == nil || !strings.Contains(err.Error(), "editor.text_editor") {
+		t.Errorf("Expected an editor.text_editor error, got %v", err)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -39,18 +40,98 @@ type Config struct {
 	Editor   EditorConfig   `toml:"editor"`
 	Logging  LoggingConfig  `toml:"logging"`
 	Mouse    MouseConfig    `toml:"mouse"`
+	Behavior BehaviorConfig `toml:"behavior"`
+	Cache    CacheConfig    `toml:"cache"`
+	Display  DisplayConfig  `toml:"display"`
+	Keys     KeysConfig     `toml:"keys"`
+	Confirm  ConfirmConfig  `toml:"confirm"`
+
+	Templates []TemplateConfig `toml:"templates"`
 }
 
 // TUI-specific configuration (nclip.toml)
 type TUIConfig struct {
-	Editor EditorConfig `toml:"editor"`
-	Mouse  MouseConfig  `toml:"mouse"`
+	Editor   EditorConfig   `toml:"editor"`
+	Mouse    MouseConfig    `toml:"mouse"`
+	Behavior BehaviorConfig `toml:"behavior"`
+	Logging  LoggingConfig  `toml:"logging"`
+	Cache    CacheConfig    `toml:"cache"`
+	Display  DisplayConfig  `toml:"display"`
+	Keys     KeysConfig     `toml:"keys"`
+	Confirm  ConfirmConfig  `toml:"confirm"`
+
+	// Entries of the "format as" menu (F), DefaultTemplates when none are set
+	Templates []TemplateConfig `toml:"templates"`
 }
 
 type MouseConfig struct {
 	Enable bool `toml:"enable"`
 }
 
+// BehaviorConfig controls how the TUI reacts to user actions
+type BehaviorConfig struct {
+	StayOpen      bool   `toml:"stay_open"`      // Keep the TUI open after copying an item
+	RememberState bool   `toml:"remember_state"` // Restore filters and cursor position on the next start
+	HeadlessCopy  string `toml:"headless_copy"`  // How to copy without a display: HeadlessCopyOSC52 or HeadlessCopyPrint
+	UsageInsights bool   `toml:"usage_insights"` // Count which TUI features are used, for the stats screen (T)
+
+	// Terminal command nclip --toggle starts the TUI in, with the nclip command appended;
+	// empty uses "$TERMINAL -e"
+	ToggleTerminal string `toml:"toggle_terminal"`
+}
+
+// Copy fallbacks for BehaviorConfig.HeadlessCopy, used when there is no display
+const (
+	HeadlessCopyOSC52 = "osc52" // ask the terminal to set its clipboard with an OSC 52 sequence
+	HeadlessCopyPrint = "print" // quit and print the copied text to stdout
+)
+
+// ConfirmConfig sets which destructive TUI actions ask for confirmation first
+type ConfirmConfig struct {
+	Delete    bool   `toml:"delete"`     // x deletes only when pressed twice, default true
+	Bulk      bool   `toml:"bulk"`       // S, U and R act only when pressed twice, default true
+	BlockHash bool   `toml:"block_hash"` // Blocking and unblocking content hashes need a second x, default true
+	Wipe      string `toml:"wipe"`       // How the panic wipe (!) is confirmed: WipeConfirmPress or WipeConfirmTyped
+}
+
+// Confirmations for ConfirmConfig.Wipe
+const (
+	WipeConfirmPress = "press" // press ! again
+	WipeConfirmTyped = "typed" // type WipeConfirmWord and press Enter
+	WipeConfirmWord  = "wipe"
+)
+
+// CacheConfig limits the memory the TUI uses for cached clipboard data
+type CacheConfig struct {
+	ImageBudgetMB int `toml:"image_budget_mb"` // Total size of image data kept in memory
+}
+
+// Bidirectional text modes for DisplayConfig.Bidi
+const (
+	BidiApp      = "app"      // nclip reorders right-to-left text for display
+	BidiTerminal = "terminal" // the terminal reorders text itself
+)
+
+// DisplayConfig controls how clipboard text is laid out in the TUI
+type DisplayConfig struct {
+	Bidi              string `toml:"bidi"`               // Who reorders right-to-left scripts: "app" or "terminal"
+	PreviewLines      int    `toml:"preview_lines"`      // Rows shown per item in the list
+	CollapseMultiline bool   `toml:"collapse_multiline"` // Join the lines of multiline items into one preview
+	Compact           bool   `toml:"compact"`            // Single-line list: one row per item, no separators
+	Accessible        bool   `toml:"accessible"`         // Text labels, no colors or box drawing, bell cues
+	Layout            string `toml:"layout"`             // LayoutCentered, LayoutFullScreen or LayoutSplit
+	MarginX           int    `toml:"margin_x"`           // Blank columns left and right of the dialog
+	MarginY           int    `toml:"margin_y"`           // Blank rows above and below the dialog
+	SplitPercent      int    `toml:"split_percent"`      // Share of the width given to the list in the split layout
+}
+
+// Dialog layouts for DisplayConfig.Layout, cycled with L in the TUI
+const (
+	LayoutCentered   = "centered"   // dialog centered inside the configured margins
+	LayoutFullScreen = "fullscreen" // dialog uses the whole terminal, ignoring margins
+	LayoutSplit      = "split"      // list on the left, preview of the selection on the right
+)
+
 // Theme configuration (theme.toml)
 type ThemeConfig struct {
 	// Main view elements (serve as defaults for other views)
@@ -128,8 +209,8 @@ type WarningTheme struct {
 	Prompt    *ColorConfig `toml:"prompt"`
 }
 
+// CodeHighlightTheme colors syntax tokens when [chroma] theme = "custom"
 type CodeHighlightTheme struct {
-	// Will be expanded later for comprehensive syntax highlighting
 	Keyword   ColorConfig `toml:"keyword"`
 	String    ColorConfig `toml:"string"`
 	Comment   ColorConfig `toml:"comment"`
@@ -141,7 +222,7 @@ type CodeHighlightTheme struct {
 }
 
 type ChromaConfig struct {
-	Theme string `toml:"theme"`
+	Theme string `toml:"theme"` // Chroma style name, or "custom" to use [code_highlight]
 }
 
 // GetViewTheme returns the effective theme for a specific view with inheritance
@@ -291,10 +372,135 @@ type DaemonConfig struct {
 	Database    DatabaseConfig    `toml:"database"`
 	Logging     LoggingConfig     `toml:"logging"`
 	Maintenance MaintenanceConfig `toml:"maintenance"`
+	Capture     CaptureConfig     `toml:"capture"`
+	Enrich      EnrichConfig      `toml:"enrich"`
+	MQTT        MQTTConfig        `toml:"mqtt"`
+}
+
+// EnrichConfig looks up extra information about stored entries over the network
+type EnrichConfig struct {
+	// Fetch the page title of copied links and show it in the list. Off by default:
+	// each copied link is requested from its server.
+	FetchURLTitles         bool `toml:"fetch_url_titles"`
+	URLTitleTimeoutSeconds int  `toml:"url_title_timeout_seconds"` // Default 5
+
+	// Also fetch titles of links with a query string or fragment, which often carry
+	// one-time tokens (password resets, sign-in links) that a request can use up
+	FetchTitlesWithQuery bool `toml:"fetch_titles_with_query"`
+
+	// Store where a copied bit.ly, t.co, ... link leads instead of the shortened link
+	ResolveShorteners bool `toml:"resolve_shorteners"`
+}
+
+// MQTTConfig publishes clipboard events to an MQTT broker
+type MQTTConfig struct {
+	Enabled          bool   `toml:"enabled"`
+	Broker           string `toml:"broker"`    // tcp://host:1883 or tls://host:8883
+	Topic            string `toml:"topic"`     // Default "nclip/events"
+	ClientID         string `toml:"client_id"` // Default "nclipd-<hostname>"
+	Username         string `toml:"username"`
+	Password         string `toml:"password"`
+	Retain           bool   `toml:"retain"`            // Broker keeps the last event for new subscribers
+	IncludeContent   bool   `toml:"include_content"`   // Add the text of entries without security threats
+	KeepAliveSeconds int    `toml:"keepalive_seconds"` // Default 60
+}
+
+// CaptureConfig controls which clipboard content the daemon stores
+type CaptureConfig struct {
+	MinLength int             `toml:"min_length"` // Skip text shorter than this many characters, 0 disables
+	MaxLength int             `toml:"max_length"` // Skip text longer than this many characters, 0 disables
+	Ignore    IgnoreConfig    `toml:"ignore"`
+	RateLimit RateLimitConfig `toml:"rate_limit"`
+	Images    ImagesConfig    `toml:"images"`
+
+	// Skip items nclip copied from the history, so copying doesn't move them to the top
+	SuppressOwnCopies bool `toml:"suppress_own_copies"`
+
+	// Tag 6-8 digit one-time codes "otp" and delete them this many seconds after they
+	// were copied, default 120, 0 keeps them
+	OTPTTLSeconds int `toml:"otp_ttl_seconds"`
+
+	// Warn when a copied cryptocurrency address or IBAN is replaced by a different one
+	// within this many seconds, default 5, 0 disables
+	HijackWindowSeconds int `toml:"hijack_window_seconds"`
+
+	// Cleanup applied to text before it is stored and deduplicated
+	TrimTrailingWhitespace bool `toml:"trim_trailing_whitespace"`
+	NormalizeLineEndings   bool `toml:"normalize_line_endings"`
+	StripQuotes            bool `toml:"strip_quotes"`
+	StripTrackingParams    bool `toml:"strip_tracking_params"` // Remove utm_*, fbclid, gclid, ... from copied links
+
+	// CaptureModeStore or CaptureModeAsk; in ask mode content waits to be kept or
+	// discarded in a desktop notification or the TUI before it enters the history
+	Mode              string `toml:"mode"`
+	PendingTTLMinutes int    `toml:"pending_ttl_minutes"` // Discard pending content after this long, default 60
+
+	// Keep content blocked by ignored apps or ignore rules in quarantine for an hour, so
+	// false positives can be rescued in the TUI; blocked hashes are always discarded
+	Quarantine bool `toml:"quarantine"`
+}
+
+// Capture modes
+const (
+	CaptureModeStore = "store" // store content that passes the capture rules right away
+	CaptureModeAsk   = "ask"   // hold content as pending until the user keeps it
+)
+
+// ImagesConfig normalizes captured images before they are stored
+type ImagesConfig struct {
+	ConvertToPNG bool `toml:"convert_to_png"` // Store BMP and TIFF images as PNG, default true
+	MaxDimension int  `toml:"max_dimension"`  // Downscale larger images to this many pixels, 0 disables
+}
+
+// RateLimitConfig throttles applications that update the clipboard many times per second
+type RateLimitConfig struct {
+	Burst      int `toml:"burst"`       // Changes stored at once before throttling starts, 0 disables
+	PerMinute  int `toml:"per_minute"`  // Rate at which the burst allowance refills, default 30
+	CoalesceMS int `toml:"coalesce_ms"` // Quiet period before the last throttled change is stored, default 1000
+}
+
+// IgnoreConfig lists content that is never stored in the history; with quarantine on it
+// is held in quarantine for an hour instead
+type IgnoreConfig struct {
+	Patterns []string `toml:"patterns"` // Regular expressions, e.g. '^\d{6}$' for OTP codes
+	Globs    []string `toml:"globs"`    // Whole-content globs where * matches anything, e.g. "https://bank.example/*"
+	Apps     []string `toml:"apps"`     // Application ids or window classes, e.g. "org.keepassxc.KeePassXC"
 }
 
 type DatabaseConfig struct {
-	MaxEntries int `toml:"max_entries"`
+	MaxEntries     int  `toml:"max_entries"`
+	TTLDays        int  `toml:"ttl_days"`        // Expire unpinned items older than this, 0 disables expiry
+	ArchiveEvicted bool `toml:"archive_evicted"` // Move evicted items to the archive instead of deleting them
+
+	// Separate quotas for text and image items, which then no longer count toward
+	// max_entries; 0 leaves that type under max_entries
+	MaxTextEntries  int `toml:"max_text_entries"`
+	MaxImageEntries int `toml:"max_image_entries"`
+
+	// Evict unpinned items once the history's content and image data exceed this many MB,
+	// 0 disables
+	MaxDBSizeMB  int    `toml:"max_db_size_mb"`
+	SizeStrategy string `toml:"size_strategy"` // Which items go first: "oldest" or "largest"
+
+	// Restrict ~/.config/nclip to 0700 and history.db to 0600 on startup
+	SecurePermissions bool `toml:"secure_permissions"`
+	// Overwrite deleted entries with zeros and vacuum so they cannot be recovered
+	ShredDeleted bool `toml:"shred_deleted"`
+
+	// Limits for items copied from specific applications, applied by nclipd
+	AppRetention []AppRetentionConfig `toml:"app_retention"`
+}
+
+// AppRetentionConfig is a retention rule for items copied from matching applications
+type AppRetentionConfig struct {
+	Apps       []string `toml:"apps"`        // Substrings of the application id or window class, e.g. "firefox"
+	TTLDays    int      `toml:"ttl_days"`    // Expire unpinned matching items older than this, 0 disables
+	MaxEntries int      `toml:"max_entries"` // Keep only this many unpinned matching items, 0 disables
+}
+
+// TTL returns the configured item expiry as a duration
+func (d DatabaseConfig) TTL() time.Duration {
+	return time.Duration(d.TTLDays) * 24 * time.Hour
 }
 
 type FrameConfig struct {
@@ -312,6 +518,13 @@ type EditorConfig struct {
 	TextEditor  string `toml:"text_editor"`
 	ImageEditor string `toml:"image_editor"`
 	ImageViewer string `toml:"image_viewer"`
+	Browser     string `toml:"browser"` // Opens links for the "open" Enter action
+	// AnnotateCommand opens an image for annotation; {input} and {output} are replaced with file paths
+	AnnotateCommand string `toml:"annotate_command"`
+	// SpellCommand is a spell checker speaking the ispell pipe protocol, e.g. "hunspell -a -d de_DE"
+	SpellCommand string `toml:"spell_command"`
+	// TempDir holds the temp files given to editors and viewers; empty means $XDG_RUNTIME_DIR/nclip
+	TempDir string `toml:"temp_dir"`
 }
 
 type LoggingConfig struct {
@@ -323,12 +536,13 @@ type LoggingConfig struct {
 }
 
 type MaintenanceConfig struct {
-	AutoDedupe      bool `toml:"auto_dedupe"`
-	DedupeInterval  int  `toml:"dedupe_interval_minutes"`
-	AutoPrune       bool `toml:"auto_prune"`
-	PruneInterval   int  `toml:"prune_interval_minutes"`
-	PruneEmptyData  bool `toml:"prune_empty_data"`
-	PruneSingleChar bool `toml:"prune_single_char"`
+	AutoDedupe        bool `toml:"auto_dedupe"`
+	DedupeInterval    int  `toml:"dedupe_interval_minutes"`
+	AutoPrune         bool `toml:"auto_prune"`
+	PruneInterval     int  `toml:"prune_interval_minutes"`
+	PruneEmptyData    bool `toml:"prune_empty_data"`
+	PruneSingleChar   bool `toml:"prune_single_char"`
+	RetentionInterval int  `toml:"retention_interval_minutes"`
 }
 
 // Load unified config (backwards compatibility)
@@ -357,8 +571,15 @@ func LoadWithCustomTheme(customThemeFile string) (*Config, error) {
 		Database: daemonConfig.Database,
 		Theme:    *themeConfig,
 		Editor:   tuiConfig.Editor,
-		Logging:  daemonConfig.Logging,
+		Logging:  tuiConfig.Logging, // The TUI logs separately from the daemon
 		Mouse:    tuiConfig.Mouse,
+		Behavior: tuiConfig.Behavior,
+		Cache:    tuiConfig.Cache,
+		Display:  tuiConfig.Display,
+		Keys:     tuiConfig.Keys,
+		Confirm:  tuiConfig.Confirm,
+
+		Templates: tuiConfig.Templates,
 	}, nil
 }
 
@@ -380,7 +601,8 @@ func LoadTUIConfig() (*TUIConfig, error) {
 	}
 
 	var config TUIConfig
-	if _, err := toml.DecodeFile(configPath, &config); err != nil {
+	meta, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode TUI config file: %w", err)
 	}
 
@@ -394,10 +616,85 @@ func LoadTUIConfig() (*TUIConfig, error) {
 	if config.Editor.ImageViewer == "" {
 		config.Editor.ImageViewer = "loupe"
 	}
+	if config.Editor.Browser == "" {
+		config.Editor.Browser = "xdg-open"
+	}
+	if err := config.Editor.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Set default mouse configuration (disabled by default to allow text selection)
 	// Note: Mouse support can interfere with terminal text selection
 
+	if config.Cache.ImageBudgetMB <= 0 {
+		config.Cache.ImageBudgetMB = 64 // Default 64 MB of cached images
+	}
+
+	if config.Display.PreviewLines <= 0 {
+		config.Display.PreviewLines = 5 // Default 5 rows per item
+	}
+
+	switch config.Behavior.HeadlessCopy {
+	case "":
+		config.Behavior.HeadlessCopy = HeadlessCopyOSC52
+	case HeadlessCopyOSC52, HeadlessCopyPrint:
+	default:
+		return nil, fmt.Errorf("invalid behavior.headless_copy %q: must be %q or %q", config.Behavior.HeadlessCopy, HeadlessCopyOSC52, HeadlessCopyPrint)
+	}
+
+	switch config.Display.Bidi {
+	case "":
+		config.Display.Bidi = BidiApp
+	case BidiApp, BidiTerminal:
+	default:
+		return nil, fmt.Errorf("invalid display.bidi %q: must be %q or %q", config.Display.Bidi, BidiApp, BidiTerminal)
+	}
+
+	switch config.Display.Layout {
+	case "":
+		config.Display.Layout = LayoutCentered
+	case LayoutCentered, LayoutFullScreen, LayoutSplit:
+	default:
+		return nil, fmt.Errorf("invalid display.layout %q: must be %q, %q or %q", config.Display.Layout, LayoutCentered, LayoutFullScreen, LayoutSplit)
+	}
+	if config.Display.MarginX < 0 || config.Display.MarginY < 0 {
+		return nil, fmt.Errorf("display.margin_x and display.margin_y must not be negative")
+	}
+	if config.Display.SplitPercent == 0 {
+		config.Display.SplitPercent = 50 // Default: list and preview share the width
+	}
+	if config.Display.SplitPercent < 20 || config.Display.SplitPercent > 80 {
+		return nil, fmt.Errorf("invalid display.split_percent %d: must be between 20 and 80", config.Display.SplitPercent)
+	}
+
+	if err := config.Keys.setDefaults(); err != nil {
+		return nil, err
+	}
+	if err := setTemplateDefaults(&config.Templates); err != nil {
+		return nil, err
+	}
+
+	// Destructive actions ask for confirmation unless turned off
+	if !meta.IsDefined("confirm", "delete") {
+		config.Confirm.Delete = true
+	}
+	if !meta.IsDefined("confirm", "bulk") {
+		config.Confirm.Bulk = true
+	}
+	if !meta.IsDefined("confirm", "block_hash") {
+		config.Confirm.BlockHash = true
+	}
+	switch config.Confirm.Wipe {
+	case "":
+		config.Confirm.Wipe = WipeConfirmPress
+	case WipeConfirmPress, WipeConfirmTyped:
+	default:
+		return nil, fmt.Errorf("invalid confirm.wipe %q: must be %q or %q", config.Confirm.Wipe, WipeConfirmPress, WipeConfirmTyped)
+	}
+
+	// The TUI only logs warnings by default, to its own file
+	setLoggingDefaults(&config.Logging, "warn", "nclip.log")
+
 	return &config, nil
 }
 
@@ -497,7 +794,8 @@ func LoadDaemonConfig() (*DaemonConfig, error) {
 	}
 
 	var config DaemonConfig
-	if _, err := toml.DecodeFile(configPath, &config); err != nil {
+	meta, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode daemon config file: %w", err)
 	}
 
@@ -507,22 +805,7 @@ func LoadDaemonConfig() (*DaemonConfig, error) {
 	}
 
 	// Set default logging values if not specified
-	if config.Logging.Level == "" {
-		config.Logging.Level = "info"
-	}
-	if config.Logging.LogFile == "" {
-		homeDir, _ := os.UserHomeDir()
-		config.Logging.LogFile = filepath.Join(homeDir, ".local", "log", "nclipd.log")
-	}
-	if config.Logging.MaxAge <= 0 {
-		config.Logging.MaxAge = 10 // 10 days
-	}
-	if config.Logging.MaxSize <= 0 {
-		config.Logging.MaxSize = 10 // 10 MB
-	}
-	if config.Logging.MaxBackups <= 0 {
-		config.Logging.MaxBackups = 10 // 10 backups
-	}
+	setLoggingDefaults(&config.Logging, "info", "nclipd.log")
 
 	// Set default maintenance values if not specified
 	if config.Maintenance.DedupeInterval <= 0 {
@@ -531,52 +814,255 @@ func LoadDaemonConfig() (*DaemonConfig, error) {
 	if config.Maintenance.PruneInterval <= 0 {
 		config.Maintenance.PruneInterval = 60 // Default 60 minutes
 	}
+	if config.Maintenance.RetentionInterval <= 0 {
+		config.Maintenance.RetentionInterval = 60 // Default 60 minutes
+	}
+	if config.Database.TTLDays < 0 {
+		config.Database.TTLDays = 0
+	}
+	if config.Database.MaxTextEntries < 0 {
+		config.Database.MaxTextEntries = 0
+	}
+	if config.Database.MaxImageEntries < 0 {
+		config.Database.MaxImageEntries = 0
+	}
+	if config.Database.MaxDBSizeMB < 0 {
+		config.Database.MaxDBSizeMB = 0
+	}
+	switch config.Database.SizeStrategy {
+	case "":
+		config.Database.SizeStrategy = "oldest"
+	case "oldest", "largest":
+	default:
+		return nil, fmt.Errorf("database.size_strategy must be \"oldest\" or \"largest\", got %q", config.Database.SizeStrategy)
+	}
+	for i, rule := range config.Database.AppRetention {
+		if len(rule.Apps) == 0 {
+			return nil, fmt.Errorf("database.app_retention rule %d has no apps", i+1)
+		}
+		if rule.TTLDays <= 0 && rule.MaxEntries <= 0 {
+			return nil, fmt.Errorf("database.app_retention rule %d needs ttl_days or max_entries", i+1)
+		}
+	}
+	if config.Capture.MinLength < 0 {
+		config.Capture.MinLength = 0
+	}
+	if config.Capture.MaxLength < 0 {
+		config.Capture.MaxLength = 0
+	}
+	if config.Capture.MaxLength > 0 && config.Capture.MinLength > config.Capture.MaxLength {
+		return nil, fmt.Errorf("capture.min_length (%d) is greater than capture.max_length (%d)", config.Capture.MinLength, config.Capture.MaxLength)
+	}
+	if !meta.IsDefined("capture", "suppress_own_copies") {
+		config.Capture.SuppressOwnCopies = true
+	}
+	if !meta.IsDefined("capture", "otp_ttl_seconds") {
+		config.Capture.OTPTTLSeconds = 120
+	}
+	if config.Capture.OTPTTLSeconds < 0 {
+		config.Capture.OTPTTLSeconds = 0
+	}
+	if !meta.IsDefined("capture", "hijack_window_seconds") {
+		config.Capture.HijackWindowSeconds = 5
+	}
+	if config.Capture.HijackWindowSeconds < 0 {
+		config.Capture.HijackWindowSeconds = 0
+	}
+	if !meta.IsDefined("capture", "images", "convert_to_png") {
+		config.Capture.Images.ConvertToPNG = true
+	}
+	if config.Capture.Images.MaxDimension < 0 {
+		config.Capture.Images.MaxDimension = 0
+	}
+	switch config.Capture.Mode {
+	case "":
+		config.Capture.Mode = CaptureModeStore
+	case CaptureModeStore, CaptureModeAsk:
+	default:
+		return nil, fmt.Errorf("capture.mode must be %q or %q, got %q", CaptureModeStore, CaptureModeAsk, config.Capture.Mode)
+	}
+	if config.Capture.PendingTTLMinutes <= 0 {
+		config.Capture.PendingTTLMinutes = 60
+	}
+	if config.Capture.RateLimit.Burst < 0 {
+		config.Capture.RateLimit.Burst = 0
+	}
+	if config.Capture.RateLimit.PerMinute <= 0 {
+		config.Capture.RateLimit.PerMinute = 30
+	}
+	if config.Capture.RateLimit.CoalesceMS <= 0 {
+		config.Capture.RateLimit.CoalesceMS = 1000
+	}
+	if config.Enrich.URLTitleTimeoutSeconds <= 0 {
+		config.Enrich.URLTitleTimeoutSeconds = 5
+	}
+	if config.MQTT.Enabled && config.MQTT.Broker == "" {
+		return nil, fmt.Errorf("mqtt.broker must be set when mqtt is enabled")
+	}
+	if config.MQTT.Topic == "" {
+		config.MQTT.Topic = "nclip/events"
+	}
+	if config.MQTT.ClientID == "" {
+		hostname, _ := os.Hostname()
+		config.MQTT.ClientID = "nclipd-" + hostname
+	}
+	if config.MQTT.KeepAliveSeconds <= 0 {
+		config.MQTT.KeepAliveSeconds = 60
+	}
 
 	return &config, nil
 }
 
-func createDefaultTUIConfig(configPath string) error {
-	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
+// setLoggingDefaults fills in unset logging values, placing the log file in ~/.local/log
+func setLoggingDefaults(logging *LoggingConfig, level, fileName string) {
+	if logging.Level == "" {
+		logging.Level = level
 	}
-
-	file, err := os.Create(configPath)
-	if err != nil {
-		return err
+	if logging.LogFile == "" {
+		homeDir, _ := os.UserHomeDir()
+		logging.LogFile = filepath.Join(homeDir, ".local", "log", fileName)
+	}
+	if logging.MaxAge <= 0 {
+		logging.MaxAge = 10 // 10 days
+	}
+	if logging.MaxSize <= 0 {
+		logging.MaxSize = 10 // 10 MB
 	}
-	defer file.Close()
+	if logging.MaxBackups <= 0 {
+		logging.MaxBackups = 10 // 10 backups
+	}
+}
 
-	_, err = file.WriteString(`[editor]
+func createDefaultTUIConfig(configPath string) error {
+	return writeConfigFile(configPath, `[editor]
+# Editor and viewer settings are commands: a program name, or a command line where
+# {file} is replaced with the file to open, e.g. "code --wait {file}" or
+# "feh --fullscreen {file}". Quote arguments containing spaces. Without {file} the
+# file is passed as the last argument. $EDITOR, when set, overrides text_editor.
 text_editor = "nano"
 image_editor = "gimp"
 image_viewer = "loupe"
+# Opens links when Enter or alt+enter is set to "open" for links (see [keys])
+browser = "xdg-open"
+# Command used by the 'a' (annotate) action on images. {input} is the image to
+# annotate and {output} the file to save to; without {output} the tool is expected
+# to save over {input}. When empty, satty, swappy or ksnip is used if installed.
+# annotate_command = "satty --filename {input} --output-filename {output} --early-exit"
+# Spell checker for the 'C' action, run with the text on stdin. It must speak the ispell
+# pipe protocol, as "hunspell -a" and "aspell -a" do; -d picks the dictionary. When empty,
+# hunspell or aspell is used if installed.
+# spell_command = "hunspell -a -d en_US"
+# Private directory (0700) for the temp files handed to editors and viewers. They are
+# removed when nclip exits. Defaults to $XDG_RUNTIME_DIR/nclip, which is tmpfs on most
+# systems; point it at another tmpfs if XDG_RUNTIME_DIR is not set.
+# temp_dir = "/dev/shm/nclip"
 
 [mouse]
 # Enable mouse support in the TUI (default: false)
 # Note: Enabling mouse support may interfere with terminal text selection
 # Disable this (false) to allow normal text selection with mouse
 enable = false
-`)
 
-	return err
-}
+[behavior]
+# Keep the TUI open after copying an item (same as nclip --stay-open)
+stay_open = false
+# Resume with the last filter, search and selected item (default: false)
+remember_state = false
+# Count which features you use, shown on the stats screen (T) next to the ones you haven't
+# tried yet. The counts stay in ~/.config/nclip/usage.json and are never sent anywhere
+# (default: false)
+usage_insights = false
+# How to copy when there is no display (no DISPLAY or WAYLAND_DISPLAY, e.g. over SSH):
+# "osc52" asks the terminal to set its clipboard, "print" quits and prints the text
+headless_copy = "osc52"
+# Terminal that nclip --toggle opens the TUI in when none is running; the nclip command is
+# appended. Empty uses "$TERMINAL -e". A fixed app id makes window rules easy to write.
+# toggle_terminal = "foot --app-id nclip -e"
+
+[keys]
+# What Enter does in the list for each kind of entry, and alt+enter for the other action.
+# "copy" copies the entry, "open" opens a link in the browser or an image in image_viewer,
+# and "view" shows the entry in the full-screen viewer. Text can't be opened. An unset
+# alt_enter is the opposite of Enter: open for links and images, view for text, and copy
+# when Enter opens or views.
+enter_text = "copy"
+enter_link = "copy"
+enter_image = "copy"
+# alt_enter_text = "view"
+# alt_enter_link = "open"
+# alt_enter_image = "open"
+
+[confirm]
+# Destructive actions that only run when their key is pressed twice (default: true)
+delete = true        # x on an item, in the list and the viewers
+bulk = true          # S, U and R on all filtered items
+block_hash = true    # x in the security view (delete and block) and the blocked hashes view
+# How the panic wipe (!) is confirmed: "press" ! again, or "typed" to type "wipe" and
+# press Enter (default: press)
+wipe = "press"
+
+[cache]
+# Memory budget for image data cached by the TUI, in MB (default: 64)
+image_budget_mb = 64
+
+[display]
+# Right-to-left text (Hebrew, Arabic) is reordered by nclip ("app", default).
+# Use "terminal" if your terminal already applies the bidi algorithm (e.g. mlterm, Konsole)
+bidi = "app"
+# Rows of preview shown per item in the list (default: 5)
+preview_lines = 5
+# Join the lines of multiline items into a single preview (default: false)
+collapse_multiline = false
+# Single-line list showing one row per item without separators (default: false)
+compact = false
+# Accessibility mode for screen readers and high contrast (same as nclip --accessible):
+# text labels instead of colored icons, a ">" selection marker, no colors or box
+# drawing, and a terminal bell on status changes (default: false)
+accessible = false
+# Dialog layout: "centered" inside the margins below, "fullscreen" ignoring them, or
+# "split" with the list on the left and a preview on the right. Press L to cycle
+layout = "centered"
+# Blank columns left/right and rows above/below the dialog (default: 0)
+margin_x = 0
+margin_y = 0
+# Percentage of the width given to the list in the split layout, 20-80 (default: 50)
+split_percent = 50
 
-func createDefaultThemeConfig(configPath string) error {
-	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
-	}
+[logging]
+# TUI log, separate from the daemon's nclipd.log (nclip --debug forces level = "debug")
+level = "warn"                             # Options: debug, info, warn, error
+log_file = "~/.local/log/nclip.log"        # Log file location
+max_age_days = 10                          # Maximum age of log files in days
+max_size_mb = 10                           # Maximum size of each log file in MB
+max_backups = 10                           # Number of backup log files to keep
 
-	file, err := os.Create(configPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+# Entries of the "format as" menu (F), which copies the selected text wrapped in a
+# template. In format, {content} is the entry, {title} the page title of a link (fetched
+# when needed, the link itself if it has none), {language} the highlighting language,
+# {date} the day it was copied and {app} the application it came from. match limits a
+# template to "link" or "code" entries; any text matches by default. Defining templates
+# replaces the built-in ones, which are:
+# [[templates]]
+# name = "Markdown link"
+# match = "link"
+# format = "[{title}]({content})"
+#
+# [[templates]]
+# name = "Org link"
+# match = "link"
+# format = "[[{content}][{title}]]"
+#
+# [[templates]]
+# name = "Markdown code block"
+# format = """
+# `+"```"+`{language}
+# {content}
+# `+"```"+`"""
+`)
+}
 
-	_, err = file.WriteString(`# NClip Theme Configuration
+func createDefaultThemeConfig(configPath string) error {
+	return writeConfigFile(configPath, `# NClip Theme Configuration
 # This file supports comprehensive theming with inheritance
 # Views inherit from [main] theme unless overridden
 
@@ -680,7 +1166,7 @@ bold = false
 # background = ""
 # bold = false
 
-# Code syntax highlighting theme
+# Code syntax highlighting colors, used when [chroma] theme = "custom"
 [code_highlight]
 
 [code_highlight.keyword]
@@ -785,25 +1271,31 @@ foreground = ""
 background = ""
 bold = false
 `)
-
-	return err
 }
 
 func createDefaultDaemonConfig(configPath string) error {
-	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
-	}
-
-	file, err := os.Create(configPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	_, err = file.WriteString(`[database]
+	return writeConfigFile(configPath, `[database]
 max_entries = 1000
+ttl_days = 0                 # Expire unpinned entries older than this many days (0 = never)
+archive_evicted = false      # Move evicted entries to the archive (browse with nclip --archive)
+# Give text and images quotas of their own so screenshots don't push text out of the
+# history; a type with its own quota no longer counts toward max_entries
+max_text_entries = 0         # 0 = text shares max_entries
+max_image_entries = 0        # 0 = images share max_entries, e.g. 50 for a screenshot-heavy workflow
+max_db_size_mb = 0           # Evict unpinned entries when their data exceeds this many MB (0 = no limit)
+size_strategy = "oldest"     # Which entries go first over the size limit: "oldest" or "largest"
+secure_permissions = false   # chmod ~/.config/nclip to 0700 and history.db to 0600 on startup
+shred_deleted = false        # Overwrite deleted entries so they cannot be recovered from history.db
+
+# Per-application limits for unpinned entries, matched against the application id or
+# window class recorded on Hyprland, sway and X11. Checked every retention_interval_minutes.
+# [[database.app_retention]]
+# apps = ["kitty", "foot", "alacritty"]
+# ttl_days = 1                 # Expire entries copied from terminals after a day
+#
+# [[database.app_retention]]
+# apps = ["firefox", "chromium"]
+# max_entries = 200            # Keep the 200 most recent entries copied from browsers
 
 [logging]
 level = "info"                             # Options: debug, info, warn, error
@@ -820,27 +1312,90 @@ auto_prune = true                # Enable automatic pruning
 prune_interval_minutes = 60      # Run pruning every 60 minutes
 prune_empty_data = true          # Remove entries with no data
 prune_single_char = true         # Remove entries with single character data
+retention_interval_minutes = 60  # Check for expired entries every 60 minutes (with ttl_days or app_retention)
+
+[capture]
+# Text outside these lengths (in characters, ignoring surrounding whitespace) is not stored
+min_length = 0                   # Skip shorter text, e.g. 3 to drop single characters (0 = no limit)
+max_length = 0                   # Skip longer text, e.g. 100000 for accidental whole-file copies (0 = no limit)
+# Cleanup applied before text is stored, so copies from different editors deduplicate
+trim_trailing_whitespace = false # Remove trailing spaces, tabs and blank lines
+normalize_line_endings = false   # Convert Windows (\r\n) and old Mac (\r) line endings to \n
+strip_quotes = false             # Remove quotes enclosing the whole text, e.g. "value" -> value
+strip_tracking_params = false    # Remove utm_*, fbclid, gclid and similar parameters from copied links
+# Copying an entry from nclip doesn't store it again and move it to the top of the list
+suppress_own_copies = true
+# One-time codes (6-8 digits, e.g. "123456" or "123 456") are tagged otp and deleted
+# this many seconds after they were copied, unless pinned
+otp_ttl_seconds = 120            # 0 keeps them like any other text
+# A cryptocurrency address or IBAN replaced by a different one this soon after it was
+# copied is how clipboard-hijacking malware works; nclipd logs it and shows a desktop warning
+hijack_window_seconds = 5        # 0 disables the check
+# "ask" holds each copied item back and asks in a desktop notification whether to keep it;
+# unanswered items wait in the TUI (P) and are discarded after pending_ttl_minutes
+mode = "store"                   # "store" or "ask"
+pending_ttl_minutes = 60
+# Keep content blocked by ignored apps or ignore rules for an hour, so false positives
+# can be rescued in the TUI (Q) before it is discarded. Quarantined content is written
+# to the database; content whose hash you blocked is always discarded.
+quarantine = false
+
+[capture.ignore]
+# Text matching any of these rules is never stored (with quarantine = true it is kept in
+# quarantine for an hour instead). Rules are matched against the content with
+# surrounding whitespace trimmed.
+patterns = []                    # Regular expressions, e.g. ['^\d{6}$'] for OTP codes
+globs = []                       # Globs where * matches anything, e.g. ["https://bank.example/*"]
+# Content copied from these applications is dropped like matching text (Hyprland, sway
+# and X11 only).
+# Use the application id or window class nclip shows in the text viewer header.
+apps = []                        # e.g. ["org.keepassxc.KeePassXC"]
+
+[capture.images]
+convert_to_png = true            # Store BMP and TIFF images as PNG so viewers and editors can open them
+max_dimension = 0                # Downscale images wider or taller than this many pixels (0 = keep size)
+
+[capture.rate_limit]
+# Some applications (e.g. Electron apps) update the clipboard many times per second.
+# Each source may store burst changes at once; beyond that, changes are coalesced and
+# only the last one is stored after coalesce_ms without further changes.
+burst = 5                        # Changes stored before throttling starts (0 = no rate limit)
+per_minute = 30                  # Rate at which the burst allowance refills
+coalesce_ms = 1000               # Quiet period before the last throttled change is stored
+
+[enrich]
+# Fetch the <title> of copied links so the list shows it instead of the bare URL.
+# Privacy: every copied link is requested from its server, which learns your IP address
+# and that you copied the link. Links with credentials or detected secrets are skipped,
+# and so are addresses on this machine or the local network.
+fetch_url_titles = false
+url_title_timeout_seconds = 5
+# Links with a query string or fragment often carry one-time tokens (password resets,
+# sign-in links) that requesting them can use up, so they are skipped unless this is set
+fetch_titles_with_query = false
+# Store where copied bit.ly, t.co and other shortened links lead. Only the shortener is
+# asked for the redirect; the destination itself is not requested.
+resolve_shorteners = false
+
+[mqtt]
+# Publish an event for every stored entry, e.g. for home automation or other devices.
+# Events carry metadata only (type, length, threat level) unless include_content is set.
+enabled = false
+broker = ""                      # tcp://host:1883 or tls://host:8883
+topic = "nclip/events"
+client_id = ""                   # Default: nclipd-<hostname>
+username = ""
+password = ""
+retain = false                   # Let the broker keep the last event for new subscribers
+include_content = false          # Add the text of entries without detected security threats
+keepalive_seconds = 60
 `)
-
-	return err
 }
 
 // Legacy function for backwards compatibility
 func createDefaultConfig(configPath string) error {
 	// This function creates the old unified config.toml
-	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
-	}
-
-	file, err := os.Create(configPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	_, err = file.WriteString(`[database]
+	return writeConfigFile(configPath, `[database]
 max_entries = 1000
 
 [theme.header]
@@ -889,9 +1444,21 @@ background = ""  # Background removed for better syntax highlighting
 bold = false
 
 [editor]
+# Editor and viewer settings are commands: a program name, or a command line where
+# {file} is replaced with the file to open, e.g. "code --wait {file}" or
+# "feh --fullscreen {file}". Quote arguments containing spaces. Without {file} the
+# file is passed as the last argument. $EDITOR, when set, overrides text_editor.
 text_editor = "nano"
 image_editor = "gimp"
 image_viewer = "loupe"
+# Command used by the 'a' (annotate) action on images. {input} is the image to
+# annotate and {output} the file to save to; without {output} the tool is expected
+# to save over {input}. When empty, satty, swappy or ksnip is used if installed.
+# annotate_command = "satty --filename {input} --output-filename {output} --early-exit"
+# Private directory (0700) for the temp files handed to editors and viewers. They are
+# removed when nclip exits. Defaults to $XDG_RUNTIME_DIR/nclip, which is tmpfs on most
+# systems; point it at another tmpfs if XDG_RUNTIME_DIR is not set.
+# temp_dir = "/dev/shm/nclip"
 
 [mouse]
 # Enable mouse support in the TUI (default: false)
@@ -902,6 +1469,4 @@ enable = false
 [logging]
 level = "error"  # Options: debug, info, warn, error
 `)
-
-	return err
 }
\ No newline at end of file
@@ -373,3 +373,775 @@ func TestLoad_HomeDirectoryError(t *testing.T) {
 		t.Errorf("Expected home directory error, got: %v", err)
 	}
 }
+
+func TestLoadTUIConfig_Behavior(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	// Default config keeps the old copy-and-exit behavior
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Behavior.StayOpen {
+		t.Error("Expected StayOpen to default to false")
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	if err := os.WriteFile(configPath, []byte("[behavior]\nstay_open = true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if !config.Behavior.StayOpen {
+		t.Error("Expected StayOpen to be loaded from nclip.toml")
+	}
+}
+
+func TestLoadTUIConfig_Logging(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "nclip.toml"), []byte("[editor]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// The TUI logs to its own file, not the daemon's
+	if config.Logging.Level != "warn" {
+		t.Errorf("Expected default TUI log level 'warn', got '%s'", config.Logging.Level)
+	}
+	expectedLogFile := filepath.Join(tmpDir, ".local", "log", "nclip.log")
+	if config.Logging.LogFile != expectedLogFile {
+		t.Errorf("Expected TUI log file '%s', got '%s'", expectedLogFile, config.Logging.LogFile)
+	}
+
+	custom := "[logging]\nlevel = \"debug\"\nlog_file = \"/tmp/custom-nclip.log\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "nclip.toml"), []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Logging.Level != "debug" || tuiConfig.Logging.LogFile != "/tmp/custom-nclip.log" {
+		t.Errorf("Expected custom logging settings, got %+v", tuiConfig.Logging)
+	}
+	if tuiConfig.Logging.MaxAge != 10 {
+		t.Errorf("Expected default MaxAge 10, got %d", tuiConfig.Logging.MaxAge)
+	}
+}
+
+func TestLoadTUIConfig_CacheBudget(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Cache.ImageBudgetMB != 64 {
+		t.Errorf("Expected default image budget of 64 MB, got %d", tuiConfig.Cache.ImageBudgetMB)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	if err := os.WriteFile(configPath, []byte("[cache]\nimage_budget_mb = 16\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Cache.ImageBudgetMB != 16 {
+		t.Errorf("Expected image budget of 16 MB from nclip.toml, got %d", config.Cache.ImageBudgetMB)
+	}
+}
+
+func TestLoadTUIConfig_Display(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Display.Bidi != BidiApp {
+		t.Errorf("Expected default bidi mode %q, got %q", BidiApp, tuiConfig.Display.Bidi)
+	}
+	if tuiConfig.Display.PreviewLines != 5 || tuiConfig.Display.Compact {
+		t.Errorf("Expected 5 preview lines and no compact list by default, got %+v", tuiConfig.Display)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	if err := os.WriteFile(configPath, []byte("[display]\nbidi = \"terminal\"\npreview_lines = 2\ncompact = true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Display.Bidi != BidiTerminal {
+		t.Errorf("Expected bidi mode %q from nclip.toml, got %q", BidiTerminal, config.Display.Bidi)
+	}
+	if config.Display.PreviewLines != 2 || !config.Display.Compact {
+		t.Errorf("Expected preview_lines and compact from nclip.toml, got %+v", config.Display)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[display]\nbidi = \"sideways\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	if _, err := LoadTUIConfig(); err == nil {
+		t.Error("Expected an error for an invalid bidi mode")
+	}
+}
+
+func TestValidateChromaTheme(t *testing.T) {
+	if len(ChromaThemeNames()) == 0 {
+		t.Skip("chroma not compiled in")
+	}
+	tests := []struct {
+		theme   string
+		wantErr bool
+	}{
+		{"", false},
+		{"monokai", false},
+		{"gruvbox-light", false},
+		{CustomChromaTheme, false},
+		{"not-a-theme", true},
+	}
+	for _, test := range tests {
+		theme := ThemeConfig{Chroma: ChromaConfig{Theme: test.theme}}
+		err := theme.ValidateChromaTheme()
+		if (err != nil) != test.wantErr {
+			t.Errorf("ValidateChromaTheme(%q) error = %v, wantErr %v", test.theme, err, test.wantErr)
+		}
+	}
+}
+
+func TestLoadThemeConfigFromFile_InvalidChromaTheme(t *testing.T) {
+	if len(ChromaThemeNames()) == 0 {
+		t.Skip("chroma not compiled in")
+	}
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	themePath := filepath.Join(tmpDir, "theme.toml")
+	if err := os.WriteFile(themePath, []byte("[chroma]\ntheme = \"monokia\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write theme file: %v", err)
+	}
+
+	// A typo must not keep the TUI from starting; it falls back to the default theme
+	theme, err := LoadThemeConfigFromFile(themePath)
+	if err != nil {
+		t.Fatalf("Expected the theme file to load, got %v", err)
+	}
+	if err := theme.ValidateChromaTheme(); err == nil || !strings.Contains(err.Error(), "monokia") {
+		t.Errorf("Expected validation to name the unknown chroma theme, got %v", err)
+	}
+}
+
+func TestLoadDaemonConfig_CaptureIgnore(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	// The default config documents the section but ignores nothing
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if len(daemonConfig.Capture.Ignore.Patterns) != 0 || len(daemonConfig.Capture.Ignore.Globs) != 0 {
+		t.Errorf("Expected no ignore rules by default, got %+v", daemonConfig.Capture.Ignore)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclipd.toml")
+	content := "[capture.ignore]\npatterns = ['^\\d{6}$']\nglobs = [\"https://bank.example/*\"]\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err = LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if len(daemonConfig.Capture.Ignore.Patterns) != 1 || daemonConfig.Capture.Ignore.Patterns[0] != `^\d{6}$` {
+		t.Errorf("Unexpected patterns: %q", daemonConfig.Capture.Ignore.Patterns)
+	}
+	if len(daemonConfig.Capture.Ignore.Globs) != 1 {
+		t.Errorf("Unexpected globs: %q", daemonConfig.Capture.Ignore.Globs)
+	}
+}
+
+func TestLoadDaemonConfig_CaptureLength(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclipd.toml")
+
+	if err := os.WriteFile(configPath, []byte("[capture]\nmin_length = 3\nmax_length = 100\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Capture.MinLength != 3 || daemonConfig.Capture.MaxLength != 100 {
+		t.Errorf("Expected limits 3 and 100, got %+v", daemonConfig.Capture)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[capture]\nmin_length = 50\nmax_length = 10\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	if _, err := LoadDaemonConfig(); err == nil {
+		t.Error("Expected an error when min_length exceeds max_length")
+	}
+}
+
+func TestLoadDaemonConfig_MQTT(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	// Disabled by default, with defaults filled in
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.MQTT.Enabled {
+		t.Error("Expected MQTT to be disabled by default")
+	}
+	if daemonConfig.MQTT.Topic != "nclip/events" || daemonConfig.MQTT.KeepAliveSeconds != 60 {
+		t.Errorf("Unexpected MQTT defaults: %+v", daemonConfig.MQTT)
+	}
+	if !strings.HasPrefix(daemonConfig.MQTT.ClientID, "nclipd-") {
+		t.Errorf("Expected default client ID nclipd-<hostname>, got %q", daemonConfig.MQTT.ClientID)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclipd.toml")
+	if err := os.WriteFile(configPath, []byte("[mqtt]\nenabled = true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	if _, err := LoadDaemonConfig(); err == nil {
+		t.Error("Expected an error when MQTT is enabled without a broker")
+	}
+
+	content := "[mqtt]\nenabled = true\nbroker = \"tls://broker.example:8883\"\ntopic = \"home/clipboard\"\ninclude_content = true\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err = LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.MQTT.Broker != "tls://broker.example:8883" || daemonConfig.MQTT.Topic != "home/clipboard" || !daemonConfig.MQTT.IncludeContent {
+		t.Errorf("Unexpected MQTT config: %+v", daemonConfig.MQTT)
+	}
+}
+
+func TestLoadDaemonConfig_RateLimit(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	// The default config enables rate limiting
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if limit := daemonConfig.Capture.RateLimit; limit.Burst != 5 || limit.PerMinute != 30 || limit.CoalesceMS != 1000 {
+		t.Errorf("Unexpected default rate limit: %+v", limit)
+	}
+
+	// Configs without the section keep it disabled, with defaults filled in
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclipd.toml")
+	if err := os.WriteFile(configPath, []byte("[capture]\nmin_length = 0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err = LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if limit := daemonConfig.Capture.RateLimit; limit.Burst != 0 || limit.PerMinute != 30 || limit.CoalesceMS != 1000 {
+		t.Errorf("Unexpected rate limit without section: %+v", limit)
+	}
+}
+
+func TestLoadDaemonConfig_SuppressOwnCopies(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclipd.toml")
+
+	// Enabled when not set, so existing configs get it too
+	if err := os.WriteFile(configPath, []byte("[capture]\nmin_length = 0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if !daemonConfig.Capture.SuppressOwnCopies {
+		t.Error("Expected suppress_own_copies to default to true")
+	}
+	if !daemonConfig.Capture.Images.ConvertToPNG || daemonConfig.Capture.Images.MaxDimension != 0 {
+		t.Errorf("Unexpected image defaults: %+v", daemonConfig.Capture.Images)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[capture]\nsuppress_own_copies = false\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err = LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Capture.SuppressOwnCopies {
+		t.Error("Expected suppress_own_copies = false to be kept")
+	}
+}
+
+func TestLoadDaemonConfig_OTPTTL(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclipd.toml")
+
+	for content, expected := range map[string]int{
+		"[capture]\nmin_length = 0\n":      120,
+		"[capture]\notp_ttl_seconds = 0\n":  0,
+		"[capture]\notp_ttl_seconds = 30\n": 30,
+		"[capture]\notp_ttl_seconds = -5\n": 0,
+	} {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write daemon config file: %v", err)
+		}
+		daemonConfig, err := LoadDaemonConfig()
+		if err != nil {
+			t.Fatalf("Failed to load daemon config: %v", err)
+		}
+		if daemonConfig.Capture.OTPTTLSeconds != expected {
+			t.Errorf("%q: expected otp_ttl_seconds %d, got %d", content, expected, daemonConfig.Capture.OTPTTLSeconds)
+		}
+	}
+}
+
+func TestLoadDaemonConfig_AppRetention(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclipd.toml")
+
+	content := "[[database.app_retention]]\napps = [\"kitty\"]\nttl_days = 1\n\n[[database.app_retention]]\napps = [\"firefox\"]\nmax_entries = 200\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	rules := daemonConfig.Database.AppRetention
+	if len(rules) != 2 || rules[0].Apps[0] != "kitty" || rules[0].TTLDays != 1 || rules[1].MaxEntries != 200 {
+		t.Errorf("Unexpected rules %+v", rules)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[[database.app_retention]]\napps = [\"kitty\"]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	if _, err := LoadDaemonConfig(); err == nil {
+		t.Error("Expected an error for a rule without ttl_days or max_entries")
+	}
+}
+
+func TestLoadTUIConfig_HeadlessCopy(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Behavior.HeadlessCopy != HeadlessCopyOSC52 {
+		t.Errorf("Expected headless_copy to default to %q, got %q", HeadlessCopyOSC52, tuiConfig.Behavior.HeadlessCopy)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	if err := os.WriteFile(configPath, []byte("[behavior]\nheadless_copy = \"clipboard\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	if _, err := LoadTUIConfig(); err == nil {
+		t.Error("Expected an error for an unknown headless_copy mode")
+	}
+}
+
+func TestLoadTUIConfig_Layout(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Display.Layout != LayoutCentered || tuiConfig.Display.SplitPercent != 50 {
+		t.Errorf("Expected centered layout with a 50%% split, got %q and %d", tuiConfig.Display.Layout, tuiConfig.Display.SplitPercent)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	for _, invalid := range []string{
+		"[display]\nlayout = \"tiled\"\n",
+		"[display]\nmargin_x = -1\n",
+		"[display]\nsplit_percent = 95\n",
+	} {
+		if err := os.WriteFile(configPath, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to write TUI config file: %v", err)
+		}
+		if _, err := LoadTUIConfig(); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadTUIConfig_Keys(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	keys := tuiConfig.Keys
+	if keys.EnterAction(EntryLink, false) != ActionCopy || keys.EnterAction(EntryLink, true) != ActionOpen {
+		t.Errorf("Expected links to copy on Enter and open on alt+enter, got %+v", keys)
+	}
+	if keys.EnterAction(EntryText, true) != ActionView || tuiConfig.Editor.Browser != "xdg-open" {
+		t.Errorf("Expected text to be viewed on alt+enter and xdg-open as the browser, got %+v", tuiConfig)
+	}
+
+	// Making Enter open a link turns alt+enter into copy
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	if err := os.WriteFile(configPath, []byte("[keys]\nenter_link = \"open\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	tuiConfig, err = LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if tuiConfig.Keys.EnterAction(EntryLink, false) != ActionOpen || tuiConfig.Keys.EnterAction(EntryLink, true) != ActionCopy {
+		t.Errorf("Expected links to open on Enter and copy on alt+enter, got %+v", tuiConfig.Keys)
+	}
+
+	for _, invalid := range []string{
+		"[keys]\nenter_text = \"open\"\n",
+		"[keys]\nalt_enter_image = \"paste\"\n",
+	} {
+		if err := os.WriteFile(configPath, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to write TUI config file: %v", err)
+		}
+		if _, err := LoadTUIConfig(); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadTUIConfig_Templates(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	// The commented templates in the default config leave the built-in ones in place
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if len(tuiConfig.Templates) != len(DefaultTemplates) || tuiConfig.Templates[0].Name != "Markdown link" {
+		t.Errorf("Expected the built-in templates, got %+v", tuiConfig.Templates)
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclip.toml")
+	custom := "[[templates]]\nname = \"Quote\"\nformat = \"> {content}\"\n"
+	if err := os.WriteFile(configPath, []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	tuiConfig, err = LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	if len(tuiConfig.Templates) != 1 || tuiConfig.Templates[0].Match != TemplateMatchText {
+		t.Errorf("Expected only the configured template, matching any text, got %+v", tuiConfig.Templates)
+	}
+
+	for _, invalid := range []string{
+		"[[templates]]\nformat = \"{content}\"\n",
+		"[[templates]]\nname = \"Empty\"\n",
+		"[[templates]]\nname = \"Images\"\nformat = \"{content}\"\nmatch = \"image\"\n",
+	} {
+		if err := os.WriteFile(configPath, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to write TUI config file: %v", err)
+		}
+		if _, err := LoadTUIConfig(); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadTUIConfig_Confirm(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclip.toml")
+
+	// Older config files without [confirm] keep every confirmation
+	if err := os.WriteFile(configPath, []byte("[behavior]\nstay_open = false\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	tuiConfig, err := LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	confirm := tuiConfig.Confirm
+	if !confirm.Delete || !confirm.Bulk || !confirm.BlockHash || confirm.Wipe != WipeConfirmPress {
+		t.Errorf("Expected all confirmations on by default, got %+v", confirm)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[confirm]\ndelete = false\nwipe = \"typed\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	tuiConfig, err = LoadTUIConfig()
+	if err != nil {
+		t.Fatalf("Failed to load TUI config: %v", err)
+	}
+	confirm = tuiConfig.Confirm
+	if confirm.Delete || !confirm.Bulk || confirm.Wipe != WipeConfirmTyped {
+		t.Errorf("Expected delete off, bulk on and a typed wipe, got %+v", confirm)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[confirm]\nwipe = \"never\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write TUI config file: %v", err)
+	}
+	if _, err := LoadTUIConfig(); err == nil {
+		t.Error("Expected an error for an unknown wipe confirmation")
+	}
+}
+
+func TestLoadDaemonConfig_HijackWindow(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclipd.toml")
+
+	for content, expected := range map[string]int{
+		"[capture]\nmin_length = 0\n":             5,
+		"[capture]\nhijack_window_seconds = 0\n":  0,
+		"[capture]\nhijack_window_seconds = 10\n": 10,
+		"[capture]\nhijack_window_seconds = -1\n": 0,
+	} {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write daemon config file: %v", err)
+		}
+		daemonConfig, err := LoadDaemonConfig()
+		if err != nil {
+			t.Fatalf("Failed to load daemon config: %v", err)
+		}
+		if daemonConfig.Capture.HijackWindowSeconds != expected {
+			t.Errorf("%q: expected hijack_window_seconds %d, got %d", content, expected, daemonConfig.Capture.HijackWindowSeconds)
+		}
+	}
+}
+
+func TestLoadDaemonConfig_Enrich(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Enrich.FetchURLTitles {
+		t.Error("Expected link titles to be off by default")
+	}
+	if daemonConfig.Enrich.URLTitleTimeoutSeconds != 5 {
+		t.Errorf("Expected url_title_timeout_seconds to default to 5, got %d", daemonConfig.Enrich.URLTitleTimeoutSeconds)
+	}
+}
+
+func TestLoadDaemonConfig_SizeBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, ".config", "nclip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "nclipd.toml")
+
+	write := func(content string) {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write daemon config file: %v", err)
+		}
+	}
+
+	write("[database]\nmax_db_size_mb = -5\n")
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Database.MaxDBSizeMB != 0 || daemonConfig.Database.SizeStrategy != "oldest" {
+		t.Errorf("Expected no limit and the oldest strategy, got %d MB, %q", daemonConfig.Database.MaxDBSizeMB, daemonConfig.Database.SizeStrategy)
+	}
+
+	write("[database]\nmax_db_size_mb = 200\nsize_strategy = \"largest\"\n")
+	if daemonConfig, err = LoadDaemonConfig(); err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Database.MaxDBSizeMB != 200 || daemonConfig.Database.SizeStrategy != "largest" {
+		t.Errorf("Expected 200 MB, largest first, got %d MB, %q", daemonConfig.Database.MaxDBSizeMB, daemonConfig.Database.SizeStrategy)
+	}
+
+	write("[database]\nsize_strategy = \"random\"\n")
+	if _, err := LoadDaemonConfig(); err == nil {
+		t.Error("Expected an error for an unknown size strategy")
+	}
+}
+
+func TestLoadDaemonConfig_CaptureMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	daemonConfig, err := LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Capture.Mode != CaptureModeStore || daemonConfig.Capture.PendingTTLMinutes != 60 {
+		t.Errorf("Expected store mode with a 60 minute pending TTL by default, got %q and %d",
+			daemonConfig.Capture.Mode, daemonConfig.Capture.PendingTTLMinutes)
+	}
+	if daemonConfig.Capture.Quarantine {
+		t.Error("Expected quarantine to be off by default")
+	}
+
+	configPath := filepath.Join(tmpDir, ".config", "nclip", "nclipd.toml")
+	if err := os.WriteFile(configPath, []byte("[capture]\nmode = \"ask\"\npending_ttl_minutes = 10\nquarantine = true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	daemonConfig, err = LoadDaemonConfig()
+	if err != nil {
+		t.Fatalf("Failed to load daemon config: %v", err)
+	}
+	if daemonConfig.Capture.Mode != CaptureModeAsk || daemonConfig.Capture.PendingTTLMinutes != 10 {
+		t.Errorf("Expected ask mode with a 10 minute pending TTL, got %q and %d",
+			daemonConfig.Capture.Mode, daemonConfig.Capture.PendingTTLMinutes)
+	}
+	if !daemonConfig.Capture.Quarantine {
+		t.Error("Expected quarantine to be enabled")
+	}
+
+	if err := os.WriteFile(configPath, []byte("[capture]\nmode = \"maybe\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write daemon config file: %v", err)
+	}
+	if _, err := LoadDaemonConfig(); err == nil {
+		t.Error("Expected an error for an unknown capture mode")
+	}
+}
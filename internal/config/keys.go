@@ -0,0 +1,123 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import "fmt"
+
+// Actions that Enter and alt+enter can take on the selected entry ([keys])
+const (
+	ActionCopy = "copy" // copy the entry to the clipboard
+	ActionOpen = "open" // open a link in the browser or an image in the image viewer
+	ActionView = "view" // show the entry in the full-screen viewer
+)
+
+// Kinds of entry that Enter is configured for
+const (
+	EntryText  = "text"
+	EntryLink  = "link"
+	EntryImage = "image"
+)
+
+// KeysConfig sets what Enter and alt+enter do for each kind of entry in the list
+type KeysConfig struct {
+	EnterText     string `toml:"enter_text"`
+	EnterLink     string `toml:"enter_link"`
+	EnterImage    string `toml:"enter_image"`
+	AltEnterText  string `toml:"alt_enter_text"`
+	AltEnterLink  string `toml:"alt_enter_link"`
+	AltEnterImage string `toml:"alt_enter_image"`
+}
+
+// EnterAction returns the action of Enter, or of alt+enter when alt is set, for a kind
+// of entry
+func (k KeysConfig) EnterAction(kind string, alt bool) string {
+	var action string
+	switch kind {
+	case EntryLink:
+		action = pick(alt, k.AltEnterLink, k.EnterLink)
+	case EntryImage:
+		action = pick(alt, k.AltEnterImage, k.EnterImage)
+	default:
+		action = pick(alt, k.AltEnterText, k.EnterText)
+	}
+	if action == "" {
+		return ActionCopy
+	}
+	return action
+}
+
+// pick returns a when alt is set and b otherwise
+func pick(alt bool, a, b string) string {
+	if alt {
+		return a
+	}
+	return b
+}
+
+// otherAction is the default alt+enter action for an Enter action
+func otherAction(kind, enter string) string {
+	if enter != ActionCopy {
+		return ActionCopy
+	}
+	if kind == EntryText {
+		return ActionView
+	}
+	return ActionOpen
+}
+
+// setDefaults fills in the unset actions and checks that each one applies to its kind
+func (k *KeysConfig) setDefaults() error {
+	settings := []struct {
+		kind       string
+		enter, alt *string
+	}{
+		{EntryText, &k.EnterText, &k.AltEnterText},
+		{EntryLink, &k.EnterLink, &k.AltEnterLink},
+		{EntryImage, &k.EnterImage, &k.AltEnterImage},
+	}
+	for _, setting := range settings {
+		if *setting.enter == "" {
+			*setting.enter = ActionCopy
+		}
+		if *setting.alt == "" {
+			*setting.alt = otherAction(setting.kind, *setting.enter)
+		}
+		for _, key := range []struct{ name, action string }{
+			{"enter_" + setting.kind, *setting.enter},
+			{"alt_enter_" + setting.kind, *setting.alt},
+		} {
+			switch key.action {
+			case ActionCopy, ActionView:
+			case ActionOpen:
+				if setting.kind == EntryText {
+					return fmt.Errorf("invalid keys.%s %q: text can only be copied or viewed", key.name, key.action)
+				}
+			default:
+				return fmt.Errorf("invalid keys.%s %q: must be %q, %q or %q", key.name, key.action, ActionCopy, ActionOpen, ActionView)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of entry a format template applies to ([[templates]] match); text matches any
+// text entry, the default
+const (
+	TemplateMatchText = "text"
+	TemplateMatchLink = "link" // a single http or https link
+	TemplateMatchCode = "code" // text highlighted as a programming language
+)
+
+// TemplateConfig is an entry of the "format as" menu. Format is the text copied, with
+// {content}, {title}, {language}, {date} and {app} replaced from the selected entry.
+type TemplateConfig struct {
+	Name   string `toml:"name"`
+	Format string `toml:"format"`
+	Match  string `toml:"match"`
+}
+
+// DefaultTemplates are offered when nclip.toml defines no templates
+var DefaultTemplates = []TemplateConfig{
+	{Name: "Markdown link", Format: "[{title}]({content})", Match: TemplateMatchLink},
+	{Name: "Org link", Format: "[[{content}][{title}]]", Match: TemplateMatchLink},
+	{Name: "Markdown code block", Format: "```{language}\n{content}\n```", Match: TemplateMatchText},
+}
+
+// setTemplateDefaults falls back to the built-in templates and checks the configured ones
+func setTemplateDefaults(templates *[]TemplateConfig) error {
+	if len(*templates) == 0 {
+		*templates = append([]TemplateConfig(nil), DefaultTemplates...)
+		return nil
+	}
+	for i := range *templates {
+		template := &(*templates)[i]
+		if strings.TrimSpace(template.Name) == "" {
+			return fmt.Errorf("templates entry %d has no name", i+1)
+		}
+		if template.Format == "" {
+			return fmt.Errorf("template %q has no format", template.Name)
+		}
+		switch template.Match {
+		case "":
+			template.Match = TemplateMatchText
+		case TemplateMatchText, TemplateMatchLink, TemplateMatchCode:
+		default:
+			return fmt.Errorf("invalid match %q in template %q: must be %q, %q or %q",
+				template.Match, template.Name, TemplateMatchText, TemplateMatchLink, TemplateMatchCode)
+		}
+	}
+	return nil
+}
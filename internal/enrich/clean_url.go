@@ -0,0 +1,155 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxRedirects bounds how many shortener hops ResolveShortener follows
+const maxRedirects = 5
+
+// trackingParams are query parameters that only identify the click or campaign.
+// Parameters starting with "utm_" are removed as well.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"gbraid":  true,
+	"wbraid":  true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+	"mkt_tok": true,
+}
+
+// shortenerHosts are link shorteners whose redirect target can be looked up
+var shortenerHosts = map[string]bool{
+	"bit.ly":      true,
+	"buff.ly":     true,
+	"cutt.ly":     true,
+	"goo.gl":      true,
+	"is.gd":       true,
+	"lnkd.in":     true,
+	"ow.ly":       true,
+	"rb.gy":       true,
+	"rebrand.ly":  true,
+	"shorturl.at": true,
+	"t.co":        true,
+	"t.ly":        true,
+	"tiny.cc":     true,
+	"tinyurl.com": true,
+}
+
+// StripTracking removes tracking parameters from a link, keeping the other parameters in
+// their original order and encoding. It returns the link and how many were removed.
+func StripTracking(link string) (string, int) {
+	u, err := url.Parse(link)
+	if err != nil || u.RawQuery == "" {
+		return link, 0
+	}
+
+	var kept []string
+	removed := 0
+	for _, param := range strings.Split(u.RawQuery, "&") {
+		name, _, _ := strings.Cut(param, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		name = strings.ToLower(name)
+		if trackingParams[name] || strings.HasPrefix(name, "utm_") {
+			removed++
+			continue
+		}
+		kept = append(kept, param)
+	}
+	if removed == 0 {
+		return link, 0
+	}
+	u.RawQuery = strings.Join(kept, "&")
+	u.ForceQuery = false
+	return u.String(), removed
+}
+
+// IsShortened reports whether a link points to a known link shortener
+func IsShortened(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return shortenerHosts[strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")]
+}
+
+// ResolveShortener follows the redirects of a shortened link, without requesting the
+// destination itself, and returns where it leads. Other links are returned unchanged.
+func ResolveShortener(ctx context.Context, client *http.Client, link string) (string, error) {
+	noFollow := *client
+	noFollow.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	for hop := 0; IsShortened(link); hop++ {
+		if hop == maxRedirects {
+			return "", errors.New("too many redirects")
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, link, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := noFollow.Do(req)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+		location, err := resp.Location()
+		if err != nil {
+			return "", fmt.Errorf("%s did not redirect (%s)", link, resp.Status)
+		}
+		link = location.String()
+	}
+	return link, nil
+}
+
+// CleanLink strips tracking parameters from a link, first resolving it when it is
+// shortened and resolve is set. It reports whether anything changed.
+func CleanLink(ctx context.Context, client *http.Client, link string, resolve bool) (string, bool, error) {
+	cleaned := link
+	if resolve && IsShortened(link) {
+		target, err := ResolveShortener(ctx, client, link)
+		if err != nil {
+			return link, false, err
+		}
+		cleaned = target
+	}
+	cleaned, _ = StripTracking(cleaned)
+	return cleaned, cleaned != link, nil
+}
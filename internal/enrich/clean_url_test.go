@@ -0,0 +1,106 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestStripTracking(t *testing.T) {
+	tests := []struct {
+		link    string
+		want    string
+		removed int
+	}{
+		{"https://example.com/a?utm_source=x&id=5&UTM_Medium=y&fbclid=abc#top", "https://example.com/a?id=5#top", 3},
+		{"https://example.com/?gclid=1", "https://example.com/", 1},
+		{"https://example.com/?q=a%20b&z=1&a=2", "https://example.com/?q=a%20b&z=1&a=2", 0},
+		{"https://example.com/path", "https://example.com/path", 0},
+	}
+	for _, test := range tests {
+		got, removed := StripTracking(test.link)
+		if got != test.want || removed != test.removed {
+			t.Errorf("StripTracking(%q) = %q, %d; want %q, %d", test.link, got, removed, test.want, test.removed)
+		}
+	}
+}
+
+func TestIsShortened(t *testing.T) {
+	if !IsShortened("https://bit.ly/abc") || !IsShortened("http://www.tinyurl.com/x") {
+		t.Error("Expected known shorteners to be recognized")
+	}
+	if IsShortened("https://example.com/abc") || IsShortened("not a link") {
+		t.Error("Expected other links not to be treated as shortened")
+	}
+}
+
+func TestCleanLink_ResolvesShortener(t *testing.T) {
+	destinationRequested := false
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/short":
+			http.Redirect(w, r, "https://example.com/article?utm_campaign=spring&page=2", http.StatusMovedPermanently)
+		case "/loop":
+			http.Redirect(w, r, server.URL+"/loop", http.StatusFound)
+		default:
+			destinationRequested = true
+		}
+	}))
+	defer server.Close()
+
+	host := mustHost(t, server.URL)
+	shortenerHosts[host] = true
+	defer delete(shortenerHosts, host)
+
+	ctx := context.Background()
+	cleaned, changed, err := CleanLink(ctx, server.Client(), server.URL+"/short", true)
+	if err != nil || !changed || cleaned != "https://example.com/article?page=2" {
+		t.Errorf("Expected the resolved, de-tracked link, got %q, %v, %v", cleaned, changed, err)
+	}
+	if destinationRequested {
+		t.Error("Expected the destination itself not to be requested")
+	}
+
+	if cleaned, changed, _ := CleanLink(ctx, server.Client(), server.URL+"/short", false); changed || cleaned != server.URL+"/short" {
+		t.Errorf("Expected the link to be left alone without resolving, got %q", cleaned)
+	}
+	if _, _, err := CleanLink(ctx, server.Client(), server.URL+"/loop", true); err == nil {
+		t.Error("Expected an error for a redirect loop")
+	}
+}
+
+func mustHost(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", link, err)
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,122 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Host info cache limits: entries are looked up again after hostCacheMaxAge, and only
+// the newest hostCacheMaxEntries are kept
+const (
+	hostCacheMaxAge     = 7 * 24 * time.Hour
+	hostCacheMaxEntries = 500
+)
+
+// HostInfoCachePath returns the location of the host info cache
+func HostInfoCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "nclip", "host_info.json"), nil
+}
+
+// HostLookup looks up host info through a cache file, so addresses seen before can be
+// shown without a network
+type HostLookup struct {
+	CachePath string
+	Resolver  Resolver
+	Whois     WhoisFunc
+}
+
+// Info returns the cached info for the target while it is fresh, looking it up otherwise.
+// When a new lookup finds nothing, such as when offline, older cached info is returned
+// marked stale. Cache write failures are returned along with the info
+func (l HostLookup) Info(ctx context.Context, target string, refresh bool) (HostInfo, error) {
+	cache, _ := loadHostCache(l.CachePath)
+	cached, ok := cache[target]
+	if ok && !refresh && time.Since(cached.LookedUpAt) < hostCacheMaxAge {
+		cached.Cached = true
+		return cached, nil
+	}
+
+	info := LookupHostInfo(ctx, target, l.Resolver, l.Whois)
+	if !info.found() && ok {
+		cached.Cached = true
+		cached.Stale = true
+		return cached, nil
+	}
+	cache[target] = info
+	return info, saveHostCache(l.CachePath, cache)
+}
+
+// loadHostCache reads the cache, returning an empty one if it doesn't exist or can't be
+// read
+func loadHostCache(path string) (map[string]HostInfo, error) {
+	cache := map[string]HostInfo{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("failed to read host info cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return map[string]HostInfo{}, fmt.Errorf("failed to parse host info cache: %w", err)
+	}
+	return cache, nil
+}
+
+// saveHostCache writes the cache, dropping the oldest entries beyond the limit
+func saveHostCache(path string, cache map[string]HostInfo) error {
+	if len(cache) > hostCacheMaxEntries {
+		targets := make([]string, 0, len(cache))
+		for target := range cache {
+			targets = append(targets, target)
+		}
+		sort.Slice(targets, func(i, j int) bool {
+			return cache[targets[i]].LookedUpAt.After(cache[targets[j]].LookedUpAt)
+		})
+		for _, target := range targets[hostCacheMaxEntries:] {
+			delete(cache, target)
+		}
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode host info cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create host info cache directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
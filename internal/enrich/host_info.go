@@ -0,0 +1,288 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxWhoisBytes bounds how much of a whois reply is read
+const maxWhoisBytes = 64 * 1024
+
+// ErrNoWhois is returned when the whois command isn't installed
+var ErrNoWhois = errors.New("whois is not installed")
+
+// hostnamePattern matches a dotted hostname with an alphabetic top-level domain
+var hostnamePattern = regexp.MustCompile(`(?i)^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}\.?$`)
+
+// secondLevelLabels are the labels under which country domains register names, as in
+// example.co.uk
+var secondLevelLabels = map[string]bool{"co": true, "com": true, "net": true, "org": true, "gov": true, "edu": true, "ac": true}
+
+// Resolver is the part of net.Resolver the host lookups use
+type Resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// WhoisFunc returns the raw whois reply for a query
+type WhoisFunc func(ctx context.Context, query string) (string, error)
+
+// WhoisField is one line of a whois summary
+type WhoisField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// HostInfo is what is known about an IP address or hostname
+type HostInfo struct {
+	Target     string       `json:"target"`
+	Addresses  []string     `json:"addresses,omitempty"`   // Forward lookup of a hostname
+	ReverseDNS []string     `json:"reverse_dns,omitempty"` // Reverse lookup of an IP address
+	Whois      []WhoisField `json:"whois,omitempty"`
+	Hints      []string     `json:"hints,omitempty"`    // Offline notes on the address ranges and where they are
+	Problems   []string     `json:"problems,omitempty"` // Lookups that failed
+	LookedUpAt time.Time    `json:"looked_up_at"`
+	Cached     bool         `json:"-"` // Loaded from the cache rather than looked up now
+	Stale      bool         `json:"-"` // Cached results shown because a new lookup found nothing
+}
+
+// found reports whether any of the network lookups returned something
+func (info HostInfo) found() bool {
+	return len(info.Addresses) > 0 || len(info.ReverseDNS) > 0 || len(info.Whois) > 0
+}
+
+// HostTarget returns the IP address or hostname when the whole content is one, dropping
+// a port if present
+func HostTarget(content string) (string, bool) {
+	target := strings.TrimSpace(content)
+	if target == "" || strings.ContainsAny(target, " \t\r\n/") {
+		return "", false
+	}
+	if host, _, err := net.SplitHostPort(target); err == nil {
+		target = host
+	}
+	if ip := net.ParseIP(target); ip != nil {
+		return ip.String(), true
+	}
+	if len(target) > 253 || !hostnamePattern.MatchString(target) {
+		return "", false
+	}
+	return strings.ToLower(strings.TrimSuffix(target, ".")), true
+}
+
+// AddressHints describes the special ranges an address belongs to; these need no
+// network access
+func AddressHints(ip net.IP) []string {
+	ranges := []struct {
+		cidr string
+		hint string
+	}{
+		{"10.0.0.0/8", "Private network (RFC 1918), not reachable from the internet"},
+		{"172.16.0.0/12", "Private network (RFC 1918), not reachable from the internet"},
+		{"192.168.0.0/16", "Private network (RFC 1918), not reachable from the internet"},
+		{"100.64.0.0/10", "Carrier-grade NAT (RFC 6598), shared by an ISP's customers"},
+		{"192.0.2.0/24", "Documentation range (RFC 5737), used in examples only"},
+		{"198.51.100.0/24", "Documentation range (RFC 5737), used in examples only"},
+		{"203.0.113.0/24", "Documentation range (RFC 5737), used in examples only"},
+		{"198.18.0.0/15", "Benchmarking range (RFC 2544)"},
+		{"2001:db8::/32", "Documentation range (RFC 3849), used in examples only"},
+		{"fc00::/7", "Unique local address (RFC 4193), not reachable from the internet"},
+		{"64:ff9b::/96", "NAT64 address (RFC 6052) wrapping an IPv4 address"},
+	}
+	for _, r := range ranges {
+		if _, network, err := net.ParseCIDR(r.cidr); err == nil && network.Contains(ip) {
+			return []string{r.hint}
+		}
+	}
+
+	switch {
+	case ip.IsUnspecified():
+		return []string{"Unspecified address, meaning any local address"}
+	case ip.IsLoopback():
+		return []string{"Loopback, this machine"}
+	case ip.IsLinkLocalUnicast():
+		return []string{"Link-local, only valid on the local network segment"}
+	case ip.IsMulticast():
+		return []string{"Multicast group address"}
+	}
+	return []string{"Public address"}
+}
+
+// isPublic reports whether an address is in none of the special ranges
+func isPublic(ip net.IP) bool {
+	hints := AddressHints(ip)
+	return len(hints) == 1 && hints[0] == "Public address"
+}
+
+// whoisLabels maps the keys used by the registries to summary labels, in display order
+var whoisLabels = []struct {
+	label string
+	keys  []string
+}{
+	{"Network", []string{"netname"}},
+	{"Organization", []string{"orgname", "org-name", "organization", "registrant organization", "owner", "descr"}},
+	{"Country", []string{"country", "registrant country"}},
+	{"Range", []string{"cidr", "inetnum", "inet6num", "netrange", "route"}},
+	{"AS", []string{"originas", "origin"}},
+	{"Registrar", []string{"registrar"}},
+	{"Created", []string{"creation date", "created", "regdate"}},
+	{"Expires", []string{"registry expiry date", "registrar registration expiration date", "expiry date", "paid-till"}},
+	{"Abuse", []string{"orgabuseemail", "abuse-mailbox"}},
+}
+
+// SummarizeWhois picks the useful fields out of a whois reply. The first value of each
+// field wins, as registries put the most specific record first
+func SummarizeWhois(reply string) []WhoisField {
+	values := map[string]string{}
+	for _, line := range strings.Split(reply, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '%' || line[0] == '#' || line[0] == '>' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			continue
+		}
+		if _, seen := values[key]; !seen {
+			values[key] = value
+		}
+	}
+
+	var fields []WhoisField
+	for _, entry := range whoisLabels {
+		for _, key := range entry.keys {
+			if value, ok := values[key]; ok {
+				fields = append(fields, WhoisField{Label: entry.label, Value: value})
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// RunWhois runs the system whois command. Many whois servers exit non-zero even when they
+// answer, so any output counts as a reply
+func RunWhois(ctx context.Context, query string) (string, error) {
+	path, err := exec.LookPath("whois")
+	if err != nil {
+		return "", ErrNoWhois
+	}
+	cmd := exec.CommandContext(ctx, path, query)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", fmt.Errorf("failed to run whois: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to run whois: %w", err)
+	}
+	var out bytes.Buffer
+	_, readErr := io.Copy(&out, io.LimitReader(stdout, maxWhoisBytes))
+	_, _ = io.Copy(io.Discard, stdout)
+	waitErr := cmd.Wait()
+	if out.Len() > 0 {
+		return out.String(), nil
+	}
+	if readErr != nil {
+		return "", fmt.Errorf("failed to read whois reply: %w", readErr)
+	}
+	if waitErr != nil {
+		return "", fmt.Errorf("whois failed: %w", waitErr)
+	}
+	return "", errors.New("whois returned nothing")
+}
+
+// registeredDomain approximates the domain a hostname is registered under, which is what
+// whois knows about
+func registeredDomain(host string) string {
+	labels := strings.Split(host, ".")
+	keep := 2
+	if len(labels) > 2 && len(labels[len(labels)-1]) == 2 && secondLevelLabels[labels[len(labels)-2]] {
+		keep = 3
+	}
+	if len(labels) <= keep {
+		return host
+	}
+	return strings.Join(labels[len(labels)-keep:], ".")
+}
+
+// LookupHostInfo resolves an IP address or hostname and asks whois about it. Failed
+// lookups are listed as problems, so the offline hints are shown even without a network
+func LookupHostInfo(ctx context.Context, target string, resolver Resolver, whois WhoisFunc) HostInfo {
+	info := HostInfo{Target: target, LookedUpAt: time.Now()}
+	whoisQuery := ""
+
+	if ip := net.ParseIP(target); ip != nil {
+		info.Hints = AddressHints(ip)
+		names, err := resolver.LookupAddr(ctx, target)
+		if err != nil {
+			info.Problems = append(info.Problems, "reverse DNS: "+err.Error())
+		}
+		for _, name := range names {
+			info.ReverseDNS = append(info.ReverseDNS, strings.TrimSuffix(name, "."))
+		}
+		// The registries know nothing about the special ranges
+		if isPublic(ip) {
+			whoisQuery = target
+		}
+	} else {
+		addrs, err := resolver.LookupHost(ctx, target)
+		if err != nil {
+			info.Problems = append(info.Problems, "DNS: "+err.Error())
+		}
+		info.Addresses = addrs
+		for _, addr := range addrs {
+			if ip := net.ParseIP(addr); ip != nil && !isPublic(ip) {
+				info.Hints = append(info.Hints, addr+": "+AddressHints(ip)[0])
+			}
+		}
+		whoisQuery = registeredDomain(target)
+	}
+
+	if whoisQuery != "" && whois != nil {
+		reply, err := whois(ctx, whoisQuery)
+		if err != nil {
+			info.Problems = append(info.Problems, "whois: "+err.Error())
+		}
+		info.Whois = SummarizeWhois(reply)
+		for _, field := range info.Whois {
+			if field.Label == "Country" {
+				info.Hints = append(info.Hints, "Registered in "+strings.ToUpper(field.Value)+" (the registrant's country, not necessarily where the host is)")
+			}
+		}
+	}
+	return info
+}
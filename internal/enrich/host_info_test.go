@@ -0,0 +1,222 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package enrich
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers lookups from fixed tables, failing for anything else
+type fakeResolver struct {
+	names map[string][]string
+	addrs map[string][]string
+}
+
+func (r fakeResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	if names, ok := r.names[addr]; ok {
+		return names, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (r fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.addrs[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+const sampleWhois = `% This is the RIPE Database query service.
+inetnum:        193.0.0.0 - 193.0.7.255
+netname:        RIPE-NCC
+descr:          RIPE Network Coordination Centre
+country:        NL
+country:        DE
+origin:         AS3333
+abuse-mailbox:  abuse@ripe.net
+`
+
+func TestHostTarget(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+		ok      bool
+	}{
+		{" 192.168.1.10\n", "192.168.1.10", true},
+		{"10.0.0.1:22", "10.0.0.1", true},
+		{"[2001:db8::1]:443", "2001:db8::1", true},
+		{"Mail.Example.COM.", "mail.example.com", true},
+		{"db-01.internal.example.org:5432", "db-01.internal.example.org", true},
+		{"localhost", "", false},
+		{"https://example.com/", "", false},
+		{"two words.com", "", false},
+		{"1.2.3", "", false},
+	}
+	for _, test := range tests {
+		got, ok := HostTarget(test.content)
+		if got != test.want || ok != test.ok {
+			t.Errorf("HostTarget(%q) = %q, %v; want %q, %v", test.content, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestAddressHints(t *testing.T) {
+	tests := map[string]string{
+		"10.1.2.3":    "Private network",
+		"100.64.0.1":  "Carrier-grade NAT",
+		"127.0.0.1":   "Loopback",
+		"fe80::1":     "Link-local",
+		"fd00::1":     "Unique local",
+		"203.0.113.9": "Documentation",
+		"8.8.8.8":     "Public address",
+	}
+	for addr, want := range tests {
+		hints := AddressHints(net.ParseIP(addr))
+		if len(hints) != 1 || !strings.HasPrefix(hints[0], want) {
+			t.Errorf("AddressHints(%s) = %v, want a hint starting %q", addr, hints, want)
+		}
+	}
+}
+
+func TestSummarizeWhois(t *testing.T) {
+	got := SummarizeWhois(sampleWhois)
+	want := []WhoisField{
+		{"Network", "RIPE-NCC"},
+		{"Organization", "RIPE Network Coordination Centre"},
+		{"Country", "NL"},
+		{"Range", "193.0.0.0 - 193.0.7.255"},
+		{"AS", "AS3333"},
+		{"Abuse", "abuse@ripe.net"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SummarizeWhois() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("field %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRegisteredDomain(t *testing.T) {
+	tests := map[string]string{
+		"www.example.com":    "example.com",
+		"example.com":        "example.com",
+		"shop.example.co.uk": "example.co.uk",
+		"a.b.example.de":     "example.de",
+	}
+	for host, want := range tests {
+		if got := registeredDomain(host); got != want {
+			t.Errorf("registeredDomain(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestLookupHostInfo(t *testing.T) {
+	resolver := fakeResolver{
+		names: map[string][]string{"193.0.6.139": {"www.ripe.net."}},
+		addrs: map[string][]string{"app.example.com": {"10.0.0.5", "93.184.216.34"}},
+	}
+	var queries []string
+	whois := func(ctx context.Context, query string) (string, error) {
+		queries = append(queries, query)
+		return sampleWhois, nil
+	}
+
+	info := LookupHostInfo(context.Background(), "193.0.6.139", resolver, whois)
+	if len(info.ReverseDNS) != 1 || info.ReverseDNS[0] != "www.ripe.net" {
+		t.Errorf("Expected reverse DNS www.ripe.net, got %v", info.ReverseDNS)
+	}
+	if len(info.Hints) != 2 || !strings.HasPrefix(info.Hints[1], "Registered in NL") {
+		t.Errorf("Expected a public address hint and the whois country, got %v", info.Hints)
+	}
+
+	info = LookupHostInfo(context.Background(), "app.example.com", resolver, whois)
+	if len(info.Addresses) != 2 || len(info.Hints) < 1 || !strings.HasPrefix(info.Hints[0], "10.0.0.5: Private") {
+		t.Errorf("Expected both addresses with a hint for the private one, got %+v", info)
+	}
+	if queries[len(queries)-1] != "example.com" {
+		t.Errorf("Expected whois to be asked about the registered domain, got %q", queries[len(queries)-1])
+	}
+
+	// Private addresses are not sent to whois, and failures are reported as problems
+	queries = nil
+	info = LookupHostInfo(context.Background(), "192.168.1.1", resolver, whois)
+	if len(queries) != 0 {
+		t.Errorf("Expected no whois query for a private address, got %v", queries)
+	}
+	if len(info.Problems) != 1 || !strings.HasPrefix(info.Problems[0], "reverse DNS") {
+		t.Errorf("Expected the failed reverse lookup as a problem, got %v", info.Problems)
+	}
+}
+
+func TestHostLookup_Cache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host_info.json")
+	lookups := 0
+	online := true
+	whois := func(ctx context.Context, query string) (string, error) {
+		lookups++
+		if !online {
+			return "", errors.New("network is unreachable")
+		}
+		return sampleWhois, nil
+	}
+	lookup := HostLookup{CachePath: path, Resolver: fakeResolver{}, Whois: whois}
+
+	info, err := lookup.Info(context.Background(), "193.0.6.139", false)
+	if err != nil || info.Cached || len(info.Whois) == 0 {
+		t.Fatalf("Expected a fresh lookup, got %+v, %v", info, err)
+	}
+	info, _ = lookup.Info(context.Background(), "193.0.6.139", false)
+	if !info.Cached || lookups != 1 {
+		t.Errorf("Expected the second lookup to come from the cache, got cached=%v after %d lookups", info.Cached, lookups)
+	}
+
+	// Offline, a refresh keeps showing what was found before
+	online = false
+	info, _ = lookup.Info(context.Background(), "193.0.6.139", true)
+	if !info.Stale || len(info.Whois) == 0 || lookups != 2 {
+		t.Errorf("Expected stale cached info after a failed refresh, got %+v", info)
+	}
+
+	// Old entries are looked up again
+	cache, _ := loadHostCache(path)
+	entry := cache["193.0.6.139"]
+	entry.LookedUpAt = time.Now().Add(-hostCacheMaxAge - time.Hour)
+	cache["193.0.6.139"] = entry
+	if err := saveHostCache(path, cache); err != nil {
+		t.Fatal(err)
+	}
+	lookup.Info(context.Background(), "193.0.6.139", false)
+	if lookups != 3 {
+		t.Errorf("Expected an expired entry to be looked up again, got %d lookups", lookups)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package enrich looks up information about copied content that isn't in the content
+// itself, such as the page title of a copied link
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits for title fetching: pages are only read up to maxPageBytes, since the title is
+// near the top, and titles are cut to maxTitleLength characters
+const (
+	maxPageBytes   = 512 * 1024
+	maxTitleLength = 200
+)
+
+// titlePattern matches the first <title> element of an HTML page
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// LinkURL returns the URL when the whole content is a single http or https link
+func LinkURL(content string) (string, bool) {
+	link := strings.TrimSpace(content)
+	if link == "" || strings.ContainsAny(link, " \t\r\n") {
+		return "", false
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	// Links with credentials are never requested
+	if u.User != nil {
+		return "", false
+	}
+	return link, true
+}
+
+// HasQuery reports whether a link has a query string or fragment. These often carry
+// one-time tokens, as in password reset and sign-in links, which a request can use up.
+func HasQuery(link string) bool {
+	u, err := url.Parse(link)
+	return err != nil || u.RawQuery != "" || u.ForceQuery || u.Fragment != ""
+}
+
+// PublicClient returns an HTTP client for requests made on the user's behalf without
+// asking, such as title lookups. It only connects to public addresses: the address is
+// checked after DNS resolution on every connection, redirects included, so a link can't
+// reach a service on this machine or the local network. Proxies are not used, as they
+// would hide the address.
+func PublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: refusePrivateAddress}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        4,
+		IdleConnTimeout:     time.Minute,
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("refusing to follow a redirect to %s", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// refusePrivateAddress is a net.Dialer Control function that rejects connections to
+// loopback, private, link-local, multicast and unspecified addresses
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !publicAddress(addr.Unmap()) {
+		return fmt.Errorf("refusing to connect to non-public address %s", addr)
+	}
+	return nil
+}
+
+// sharedAddressSpace is carrier-grade NAT (RFC 6598), which netip doesn't count as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddress reports whether addr may be reachable from the internet
+func publicAddress(addr netip.Addr) bool {
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// FetchTitle requests an HTML page and returns its title, or "" when it has none
+func FetchTitle(ctx context.Context, client *http.Client, link string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "nclip (link titles)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s", link, resp.Status)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "", nil
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return "", err
+	}
+	return ExtractTitle(page), nil
+}
+
+// ExtractTitle returns the decoded, single-line title of an HTML page
+func ExtractTitle(page []byte) string {
+	match := titlePattern.FindSubmatch(page)
+	if match == nil || !utf8.Valid(match[1]) {
+		return ""
+	}
+	title := strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " ")
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = string(runes[:maxTitleLength-1]) + "…"
+	}
+	return title
+}
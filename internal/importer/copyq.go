@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package importer
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runCopyQ runs the copyq command line client; replaced in tests
+var runCopyQ = func(args ...string) ([]byte, error) {
+	return exec.Command("copyq", args...).Output()
+}
+
+// readCopyQ reads the current CopyQ tab through the copyq client, as the tab files
+// use a private format that changes between CopyQ versions. CopyQ must be running.
+func readCopyQ() ([]Entry, error) {
+	output, err := runCopyQ("count")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run copyq (is CopyQ running?): %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return nil, fmt.Errorf("unexpected copyq count output %q", output)
+	}
+
+	var entries []Entry
+	for row := 0; row < count; row++ {
+		index := strconv.Itoa(row)
+		formats, err := runCopyQ("read", "?", index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CopyQ item %d: %w", row, err)
+		}
+
+		available := strings.Fields(string(formats))
+		switch {
+		case slices.Contains(available, "text/plain"):
+			text, err := runCopyQ("read", "text/plain", index)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CopyQ item %d: %w", row, err)
+			}
+			entries = append(entries, Entry{Type: "text", Content: string(text)})
+		case slices.Contains(available, "image/png"):
+			image, err := runCopyQ("read", "image/png", index)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CopyQ item %d: %w", row, err)
+			}
+			entries = append(entries, imageEntry(image, time.Time{}))
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,86 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// gpasteHistory is GPaste's history.xml, newest item first
+type gpasteHistory struct {
+	Items []struct {
+		Kind  string `xml:"kind,attr"`
+		Date  string `xml:"date,attr"`
+		Value string `xml:"value"`
+	} `xml:"item"`
+}
+
+// readGPaste reads GPaste's history.xml. Password items are not imported and images
+// are read from the files GPaste keeps next to the history.
+func readGPaste(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GPaste history: %w", err)
+	}
+	var history gpasteHistory
+	if err := xml.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("invalid GPaste history: %w", err)
+	}
+
+	var entries []Entry
+	for _, item := range history.Items {
+		timestamp := parseUnixTime(item.Date)
+		switch item.Kind {
+		case "Text", "Uris":
+			entries = append(entries, Entry{Type: "text", Content: item.Value, Timestamp: timestamp})
+		case "Image":
+			image, err := os.ReadFile(item.Value)
+			if err != nil {
+				continue // GPaste removes image files along with their items
+			}
+			entries = append(entries, imageEntry(image, timestamp))
+		}
+	}
+	return entries, nil
+}
+
+// parseUnixTime parses a Unix time in seconds, milliseconds or microseconds
+func parseUnixTime(value string) time.Time {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	switch {
+	case n > 1e14:
+		return time.UnixMicro(n)
+	case n > 1e11:
+		return time.UnixMilli(n)
+	}
+	return time.Unix(n, 0)
+}
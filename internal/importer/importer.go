@@ -0,0 +1,127 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package importer reads the history of other clipboard managers
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a clipboard entry read from another clipboard manager
+type Entry struct {
+	Type      string // "text" or "image"
+	Content   string // The text, or a description for images
+	ImageData []byte
+	Timestamp time.Time
+}
+
+// Sources lists the clipboard managers that can be imported
+var Sources = []string{"klipper", "gpaste", "clipman", "copyq"}
+
+// DefaultPath returns where source keeps its history, or "" when it is read through its CLI
+func DefaultPath(source string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		dataDir = filepath.Join(homeDir, ".local", "share")
+	}
+
+	switch source {
+	case "klipper":
+		return filepath.Join(dataDir, "klipper", "history2.lst"), nil
+	case "gpaste":
+		return filepath.Join(dataDir, "gpaste", "history.xml"), nil
+	case "clipman":
+		return filepath.Join(dataDir, "clipman.json"), nil
+	case "copyq":
+		return "", nil
+	}
+	return "", unknownSource(source)
+}
+
+// Read returns the history of source, newest first. An empty path reads the
+// default location.
+func Read(source, path string) ([]Entry, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(source); err != nil {
+			return nil, err
+		}
+	}
+
+	var entries []Entry
+	var err error
+	switch source {
+	case "klipper":
+		entries, err = readKlipper(path)
+	case "gpaste":
+		entries, err = readGPaste(path)
+	case "clipman":
+		entries, err = readClipman(path)
+	case "copyq":
+		entries, err = readCopyQ()
+	default:
+		return nil, unknownSource(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	assignTimestamps(entries, time.Now())
+	return entries, nil
+}
+
+// assignTimestamps gives entries without a timestamp one second steps back from the
+// previous entry, so the imported order is kept
+func assignTimestamps(entries []Entry, now time.Time) {
+	previous := now
+	for i := range entries {
+		if entries[i].Timestamp.IsZero() || entries[i].Timestamp.After(previous) {
+			entries[i].Timestamp = previous.Add(-time.Second)
+		}
+		previous = entries[i].Timestamp
+	}
+}
+
+// imageEntry creates an image entry described like images captured by the daemon
+func imageEntry(data []byte, timestamp time.Time) Entry {
+	return Entry{
+		Type:      "image",
+		Content:   fmt.Sprintf("Image (%d bytes)", len(data)),
+		ImageData: data,
+		Timestamp: timestamp,
+	}
+}
+
+func unknownSource(source string) error {
+	return fmt.Errorf("unknown import source %q, expected one of: %s", source, strings.Join(Sources, ", "))
+}
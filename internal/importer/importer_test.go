@@ -0,0 +1,211 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+// qtWriter encodes the QDataStream types read by qtStream
+type qtWriter struct{ bytes.Buffer }
+
+func (w *qtWriter) uint32(n uint32) { binary.Write(&w.Buffer, binary.BigEndian, n) }
+
+func (w *qtWriter) bytes(b []byte) {
+	w.uint32(uint32(len(b)))
+	w.Write(b)
+}
+
+func (w *qtWriter) string(s string) {
+	units := utf16.Encode([]rune(s))
+	w.uint32(uint32(2 * len(units)))
+	for _, u := range units {
+		binary.Write(&w.Buffer, binary.BigEndian, u)
+	}
+}
+
+// testPNG is a PNG signature followed by an empty IHDR-like chunk and IEND
+var testPNG = append(append([]byte{}, pngSignature...),
+	0, 0, 0, 0, 'I', 'H', 'D', 'R', 1, 2, 3, 4,
+	0, 0, 0, 0, 'I', 'E', 'N', 'D', 0xAE, 0x42, 0x60, 0x82)
+
+func klipperFile(payload []byte) []byte {
+	var file qtWriter
+	file.uint32(crc32.ChecksumIEEE(payload))
+	file.bytes(payload)
+	return file.Bytes()
+}
+
+func TestParseKlipper(t *testing.T) {
+	var payload qtWriter
+	payload.bytes([]byte("6.0.0\x00"))
+	payload.string("string")
+	payload.string("newest שלום")
+	payload.string("url")
+	payload.uint32(2)
+	payload.bytes([]byte("file:///tmp/a.txt"))
+	payload.bytes([]byte("file:///tmp/b.txt"))
+	payload.uint32(1)
+	payload.string("key")
+	payload.string("value")
+	payload.uint32(0)
+	payload.string("image")
+	payload.uint32(1)
+	payload.Write(testPNG)
+	payload.string("string")
+	payload.string("oldest")
+
+	entries, err := parseKlipper(klipperFile(payload.Bytes()))
+	if err != nil {
+		t.Fatalf("parseKlipper failed: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Content != "newest שלום" {
+		t.Errorf("Unexpected first entry %q", entries[0].Content)
+	}
+	if entries[1].Content != "file:///tmp/a.txt\nfile:///tmp/b.txt" {
+		t.Errorf("Unexpected url entry %q", entries[1].Content)
+	}
+	if entries[2].Type != "image" || !bytes.Equal(entries[2].ImageData, testPNG) {
+		t.Errorf("Unexpected image entry %+v", entries[2])
+	}
+	if entries[3].Content != "oldest" {
+		t.Errorf("Unexpected last entry %q", entries[3].Content)
+	}
+
+	corrupt := klipperFile(payload.Bytes())
+	corrupt[len(corrupt)-1] ^= 0xFF
+	if _, err := parseKlipper(corrupt); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("Expected checksum error, got %v", err)
+	}
+}
+
+func TestReadGPaste(t *testing.T) {
+	dir := t.TempDir()
+	imagePath := filepath.Join(dir, "image.png")
+	if err := os.WriteFile(imagePath, testPNG, 0644); err != nil {
+		t.Fatalf("Failed to write image: %v", err)
+	}
+	history := `<?xml version="1.0" encoding="UTF-8"?>
+<history version="2.0">
+  <item kind="Text" uuid="1"><value><![CDATA[hello <world>]]></value></item>
+  <item kind="Password" uuid="2" name="bank"><value><![CDATA[hunter2]]></value></item>
+  <item kind="Image" uuid="3" date="1700000000" checksum="x"><value><![CDATA[` + imagePath + `]]></value></item>
+  <item kind="Image" uuid="4" date="1600000000" checksum="y"><value><![CDATA[/missing.png]]></value></item>
+  <item kind="Uris" uuid="5"><value><![CDATA[file:///tmp/a.txt]]></value></item>
+</history>`
+	path := filepath.Join(dir, "history.xml")
+	if err := os.WriteFile(path, []byte(history), 0644); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+
+	entries, err := readGPaste(path)
+	if err != nil {
+		t.Fatalf("readGPaste failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries without password and missing image, got %+v", entries)
+	}
+	if entries[0].Content != "hello <world>" || entries[2].Content != "file:///tmp/a.txt" {
+		t.Errorf("Unexpected text entries %q, %q", entries[0].Content, entries[2].Content)
+	}
+	if entries[1].Type != "image" || !entries[1].Timestamp.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Unexpected image entry %+v", entries[1])
+	}
+}
+
+func TestReadClipman(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clipman.json")
+	if err := os.WriteFile(path, []byte(`["oldest","middle","newest"]`), 0644); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+	entries, err := Read("clipman", path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Content != "newest" || entries[2].Content != "oldest" {
+		t.Fatalf("Expected newest first, got %+v", entries)
+	}
+	if !entries[0].Timestamp.After(entries[1].Timestamp) || !entries[1].Timestamp.After(entries[2].Timestamp) {
+		t.Error("Expected timestamps to keep the history order")
+	}
+}
+
+func TestReadCopyQ(t *testing.T) {
+	original := runCopyQ
+	defer func() { runCopyQ = original }()
+	runCopyQ = func(args ...string) ([]byte, error) {
+		switch strings.Join(args, " ") {
+		case "count":
+			return []byte("2\n"), nil
+		case "read ? 0":
+			return []byte("text/plain\ntext/html\n"), nil
+		case "read text/plain 0":
+			return []byte("copied text"), nil
+		case "read ? 1":
+			return []byte("image/png\n"), nil
+		case "read image/png 1":
+			return testPNG, nil
+		}
+		t.Fatalf("Unexpected copyq call %q", args)
+		return nil, nil
+	}
+
+	entries, err := Read("copyq", "")
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Content != "copied text" || entries[1].Type != "image" {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
+
+func TestRead_UnknownSource(t *testing.T) {
+	if _, err := Read("xclip", ""); err == nil {
+		t.Error("Expected an error for an unknown source")
+	}
+}
+
+func TestParseUnixTime(t *testing.T) {
+	want := time.Unix(1700000000, 0)
+	for _, value := range []string{"1700000000", "1700000000000", "1700000000000000"} {
+		if got := parseUnixTime(value); !got.Equal(want) {
+			t.Errorf("parseUnixTime(%s) = %v, want %v", value, got, want)
+		}
+	}
+	if !parseUnixTime("").IsZero() {
+		t.Error("Expected zero time for an empty value")
+	}
+}
@@ -0,0 +1,177 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// pngSignature starts every PNG file
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// readKlipper reads KDE Klipper's history2.lst. The file is a Qt data stream holding
+// a CRC32 checksum and a byte array; the byte array contains the Klipper version
+// followed by typed items, youngest first.
+func readKlipper(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Klipper history: %w", err)
+	}
+	return parseKlipper(data)
+}
+
+// parseKlipper decodes the contents of history2.lst
+func parseKlipper(data []byte) ([]Entry, error) {
+	file := &qtStream{r: bytes.NewReader(data)}
+	crc := file.uint32()
+	payload := file.bytes()
+	if file.err != nil {
+		return nil, fmt.Errorf("invalid Klipper history: %w", file.err)
+	}
+	if crc32.ChecksumIEEE(payload) != crc {
+		return nil, errors.New("invalid Klipper history: checksum mismatch")
+	}
+
+	stream := &qtStream{r: bytes.NewReader(payload)}
+	stream.bytes() // Klipper version
+	var entries []Entry
+	for stream.err == nil && stream.r.Len() > 0 {
+		switch kind := stream.string(); kind {
+		case "string":
+			if text := stream.string(); stream.err == nil {
+				entries = append(entries, Entry{Type: "text", Content: text})
+			}
+		case "url":
+			var urls []string
+			for n := stream.uint32(); n > 0 && stream.err == nil; n-- {
+				urls = append(urls, string(stream.bytes()))
+			}
+			for n := stream.uint32(); n > 0 && stream.err == nil; n-- {
+				stream.string() // Metadata key
+				stream.string() // Metadata value
+			}
+			stream.uint32() // Cut flag
+			if stream.err == nil && len(urls) > 0 {
+				entries = append(entries, Entry{Type: "text", Content: strings.Join(urls, "\n")})
+			}
+		case "image":
+			if stream.uint32() == 0 {
+				continue // Null image
+			}
+			if image := stream.png(); stream.err == nil {
+				entries = append(entries, imageEntry(image, time.Time{}))
+			}
+		default:
+			if stream.err == nil {
+				return nil, fmt.Errorf("invalid Klipper history: unknown item type %q", kind)
+			}
+		}
+	}
+	if stream.err != nil {
+		return nil, fmt.Errorf("invalid Klipper history: %w", stream.err)
+	}
+	return entries, nil
+}
+
+// qtStream decodes the QDataStream types used by Klipper, remembering the first error
+type qtStream struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (s *qtStream) read(n int) []byte {
+	if s.err != nil {
+		return nil
+	}
+	if n > s.r.Len() {
+		s.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	buf := make([]byte, n)
+	io.ReadFull(s.r, buf)
+	return buf
+}
+
+func (s *qtStream) uint32() uint32 {
+	buf := s.read(4)
+	if buf == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(buf)
+}
+
+// bytes reads a QByteArray (or a char* string)
+func (s *qtStream) bytes() []byte {
+	n := s.uint32()
+	if n == 0xFFFFFFFF {
+		return nil
+	}
+	return bytes.TrimSuffix(s.read(int(n)), []byte{0})
+}
+
+// string reads a QString, which is stored as UTF-16BE
+func (s *qtStream) string() string {
+	n := s.uint32()
+	if n == 0xFFFFFFFF || s.err != nil {
+		return ""
+	}
+	buf := s.read(int(n))
+	units := make([]uint16, len(buf)/2)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(buf[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// png reads a PNG image written without a length prefix, ending at its IEND chunk
+func (s *qtStream) png() []byte {
+	var out bytes.Buffer
+	out.Write(s.read(len(pngSignature)))
+	if s.err == nil && !bytes.Equal(out.Bytes(), pngSignature) {
+		s.err = errors.New("image is not a PNG")
+	}
+	for s.err == nil {
+		header := s.read(8)
+		if header == nil {
+			break
+		}
+		length := binary.BigEndian.Uint32(header)
+		out.Write(header)
+		out.Write(s.read(int(length) + 4)) // Chunk data and CRC
+		if string(header[4:]) == "IEND" {
+			break
+		}
+	}
+	return out.Bytes()
+}
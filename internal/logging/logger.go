@@ -39,11 +39,42 @@ var globalLogger zerolog.Logger
 
 // InitLogger sets up logging with file rotation and dual output (file + stdout/stderr)
 func InitLogger(logFile string, level string, maxAge, maxSize, maxBackups int) error {
+	fileWriter, err := newFileWriter(logFile, maxAge, maxSize, maxBackups)
+	if err != nil {
+		return err
+	}
+
+	// Create console writer for stdout/stderr
+	consoleWriter := zerolog.ConsoleWriter{
+		Out:        os.Stdout,
+		TimeFormat: "2006-01-02 15:04:05",
+		NoColor:    false,
+	}
+
+	// Set up multi-writer to write to both file and console
+	configureLogger(io.MultiWriter(fileWriter, consoleWriter, newRecentWriter()), level)
+	return nil
+}
+
+// InitFileLogger sets up logging to a rotated file only, for programs that own the
+// terminal (the TUI) and must not have log lines written over their output
+func InitFileLogger(logFile string, level string, maxAge, maxSize, maxBackups int) error {
+	fileWriter, err := newFileWriter(logFile, maxAge, maxSize, maxBackups)
+	if err != nil {
+		return err
+	}
+
+	configureLogger(io.MultiWriter(fileWriter, newRecentWriter()), level)
+	return nil
+}
+
+// newFileWriter creates a rotating log file writer, expanding ~ and creating the directory
+func newFileWriter(logFile string, maxAge, maxSize, maxBackups int) (io.Writer, error) {
 	// Expand ~ to home directory if present
 	if strings.HasPrefix(logFile, "~/") {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		logFile = filepath.Join(homeDir, logFile[2:])
 	}
@@ -51,37 +82,30 @@ func InitLogger(logFile string, level string, maxAge, maxSize, maxBackups int) e
 	// Ensure log directory exists
 	logDir := filepath.Dir(logFile)
 	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return err
-	}
-
-	// Parse log level
-	logLevel, err := zerolog.ParseLevel(level)
-	if err != nil {
-		logLevel = zerolog.InfoLevel // Default to info if invalid level
+		return nil, err
 	}
 
 	// Set up lumberjack for log rotation
-	fileWriter := &lumberjack.Logger{
+	return &lumberjack.Logger{
 		Filename:   logFile,
 		MaxSize:    maxSize,    // MB
 		MaxAge:     maxAge,     // days
 		MaxBackups: maxBackups, // number of backups
 		LocalTime:  true,
 		Compress:   true, // compress old log files
-	}
+	}, nil
+}
 
-	// Create console writer for stdout/stderr
-	consoleWriter := zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: "2006-01-02 15:04:05",
-		NoColor:    false,
+// configureLogger installs a logger writing to w at the given level
+func configureLogger(w io.Writer, level string) {
+	// Parse log level
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		logLevel = zerolog.InfoLevel // Default to info if invalid level
 	}
 
-	// Set up multi-writer to write to both file and console
-	multiWriter := io.MultiWriter(fileWriter, consoleWriter)
-
 	// Configure global logger
-	globalLogger = zerolog.New(multiWriter).
+	globalLogger = zerolog.New(w).
 		Level(logLevel).
 		With().
 		Timestamp().
@@ -90,8 +114,6 @@ func InitLogger(logFile string, level string, maxAge, maxSize, maxBackups int) e
 
 	// Also set the global zerolog logger
 	log.Logger = globalLogger
-
-	return nil
 }
 
 // Debug logs a debug message
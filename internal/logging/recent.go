@@ -0,0 +1,99 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package logging
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// recentCapacity is the number of log lines kept in memory for in-app display
+const recentCapacity = 200
+
+// recentLog holds the most recent formatted log lines in a ring buffer
+var recentLog = &recentBuffer{}
+
+type recentBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+// Write stores each complete line written by the console formatter
+func (b *recentBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if len(b.lines) < recentCapacity {
+			b.lines = append(b.lines, line)
+			continue
+		}
+		b.lines[b.next] = line
+		b.next = (b.next + 1) % recentCapacity
+		b.full = true
+	}
+	return len(p), nil
+}
+
+// snapshot returns up to n of the most recent lines, oldest first
+func (b *recentBuffer) snapshot(n int) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ordered := make([]string, 0, len(b.lines))
+	if b.full {
+		ordered = append(ordered, b.lines[b.next:]...)
+		ordered = append(ordered, b.lines[:b.next]...)
+	} else {
+		ordered = append(ordered, b.lines...)
+	}
+
+	if n > 0 && len(ordered) > n {
+		ordered = ordered[len(ordered)-n:]
+	}
+	return ordered
+}
+
+// newRecentWriter formats log events as plain console lines into the recent buffer
+func newRecentWriter() zerolog.ConsoleWriter {
+	return zerolog.ConsoleWriter{
+		Out:          recentLog,
+		TimeFormat:   "15:04:05",
+		NoColor:      true,
+		PartsExclude: []string{zerolog.CallerFieldName},
+	}
+}
+
+// Recent returns up to n of the most recent log lines, oldest first (n <= 0 returns all)
+func Recent(n int) []string {
+	return recentLog.snapshot(n)
+}
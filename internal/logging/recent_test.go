@@ -0,0 +1,64 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package logging
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRecentBuffer_KeepsOrder(t *testing.T) {
+	b := &recentBuffer{}
+	b.Write([]byte("one\ntwo\n"))
+	b.Write([]byte("three\n"))
+
+	got := b.snapshot(0)
+	if len(got) != 3 || got[0] != "one" || got[2] != "three" {
+		t.Errorf("Expected [one two three], got %v", got)
+	}
+
+	got = b.snapshot(2)
+	if len(got) != 2 || got[0] != "two" || got[1] != "three" {
+		t.Errorf("Expected the two newest lines, got %v", got)
+	}
+}
+
+func TestRecentBuffer_WrapsAtCapacity(t *testing.T) {
+	b := &recentBuffer{}
+	for i := 0; i < recentCapacity+5; i++ {
+		b.Write([]byte(fmt.Sprintf("line %d\n", i)))
+	}
+
+	got := b.snapshot(0)
+	if len(got) != recentCapacity {
+		t.Fatalf("Expected %d lines, got %d", recentCapacity, len(got))
+	}
+	if got[0] != "line 5" {
+		t.Errorf("Expected oldest line to be 'line 5', got %q", got[0])
+	}
+	if got[len(got)-1] != fmt.Sprintf("line %d", recentCapacity+4) {
+		t.Errorf("Expected newest line last, got %q", got[len(got)-1])
+	}
+}
@@ -0,0 +1,290 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package mqtt is a minimal MQTT 3.1.1 client that publishes QoS 0 messages
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Packet types (high nibble of the fixed header)
+const (
+	packetConnect    = 0x10
+	packetConnack    = 0x20
+	packetPublish    = 0x30
+	packetPingreq    = 0xC0
+	packetDisconnect = 0xE0
+)
+
+// connackReasons are the CONNACK return codes of MQTT 3.1.1
+var connackReasons = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Options configures a connection to a broker
+type Options struct {
+	Broker    string // tcp://host:1883 or tls://host:8883
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration // Interval at which the broker expects traffic, 0 disables
+	Timeout   time.Duration // Dial and CONNACK timeout, default 10s
+}
+
+// Conn is a connection to an MQTT broker
+type Conn struct {
+	conn   net.Conn
+	mu     sync.Mutex
+	closed chan struct{}
+	err    error
+}
+
+// Dial connects to the broker and completes the MQTT handshake
+func Dial(opts Options) (*Conn, error) {
+	network, address, useTLS, err := parseBroker(opts.Broker)
+	if err != nil {
+		return nil, err
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if useTLS {
+		host, _, _ := net.SplitHostPort(address)
+		conn, err = tls.DialWithDialer(dialer, network, address, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial(network, address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s: %w", opts.Broker, err)
+	}
+
+	conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write(connectPacket(opts)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send MQTT connect: %w", err)
+	}
+	reader := bufio.NewReader(conn)
+	packetType, body, err := readPacket(reader)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read MQTT connack: %w", err)
+	}
+	if packetType&0xF0 != packetConnack || len(body) != 2 {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected MQTT packet 0x%02x instead of connack", packetType)
+	}
+	if code := body[1]; code != 0 {
+		conn.Close()
+		reason, ok := connackReasons[code]
+		if !ok {
+			reason = fmt.Sprintf("return code %d", code)
+		}
+		return nil, fmt.Errorf("MQTT broker refused connection: %s", reason)
+	}
+	conn.SetDeadline(time.Time{})
+
+	c := &Conn{conn: conn, closed: make(chan struct{})}
+	go c.drain(reader)
+	return c, nil
+}
+
+// drain discards packets from the broker (only PINGRESP is expected for QoS 0)
+// and records when the connection goes away
+func (c *Conn) drain(reader *bufio.Reader) {
+	defer close(c.closed)
+	for {
+		if _, _, err := readPacket(reader); err != nil {
+			c.mu.Lock()
+			c.err = err
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+// Done is closed when the connection to the broker is lost
+func (c *Conn) Done() <-chan struct{} {
+	return c.closed
+}
+
+// Err returns why the connection was lost, once Done is closed
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Publish sends a QoS 0 message
+func (c *Conn) Publish(topic string, payload []byte, retain bool) error {
+	header := byte(packetPublish)
+	if retain {
+		header |= 0x01
+	}
+	body := appendString(nil, topic)
+	body = append(body, payload...)
+	return c.write(packet(header, body))
+}
+
+// Ping sends a keepalive request
+func (c *Conn) Ping() error {
+	return c.write([]byte{packetPingreq, 0})
+}
+
+// Close disconnects cleanly from the broker
+func (c *Conn) Close() error {
+	c.write([]byte{packetDisconnect, 0})
+	return c.conn.Close()
+}
+
+func (c *Conn) write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return fmt.Errorf("MQTT connection lost: %w", c.err)
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := c.conn.Write(data)
+	return err
+}
+
+// parseBroker turns a broker URL into a dial address
+func parseBroker(broker string) (network, address string, useTLS bool, err error) {
+	u, err := url.Parse(broker)
+	if err != nil || u.Host == "" {
+		return "", "", false, fmt.Errorf("invalid MQTT broker %q, expected tcp://host:port or tls://host:port", broker)
+	}
+
+	port := "1883"
+	switch u.Scheme {
+	case "tcp", "mqtt":
+	case "tls", "ssl", "mqtts":
+		useTLS = true
+		port = "8883"
+	default:
+		return "", "", false, fmt.Errorf("unsupported MQTT broker scheme %q", u.Scheme)
+	}
+	if u.Port() != "" {
+		port = u.Port()
+	}
+	return "tcp", net.JoinHostPort(u.Hostname(), port), useTLS, nil
+}
+
+// connectPacket builds a CONNECT packet with a clean session
+func connectPacket(opts Options) []byte {
+	flags := byte(0x02) // Clean session
+	if opts.Username != "" {
+		flags |= 0x80
+		if opts.Password != "" {
+			flags |= 0x40
+		}
+	}
+	keepAlive := int(opts.KeepAlive / time.Second)
+	if keepAlive > 0xFFFF {
+		keepAlive = 0xFFFF
+	}
+
+	body := appendString(nil, "MQTT")
+	body = append(body, 4, flags, byte(keepAlive>>8), byte(keepAlive))
+	body = appendString(body, opts.ClientID)
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+		if opts.Password != "" {
+			body = appendString(body, opts.Password)
+		}
+	}
+	return packet(packetConnect, body)
+}
+
+// packet prefixes body with the fixed header
+func packet(header byte, body []byte) []byte {
+	out := append([]byte{header}, encodeLength(len(body))...)
+	return append(out, body...)
+}
+
+// appendString appends a length-prefixed UTF-8 string
+func appendString(b []byte, s string) []byte {
+	b = append(b, byte(len(s)>>8), byte(len(s)))
+	return append(b, s...)
+}
+
+// encodeLength encodes the remaining length as a variable byte integer
+func encodeLength(n int) []byte {
+	var out []byte
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		out = append(out, digit)
+		if n == 0 {
+			return out
+		}
+	}
+}
+
+// readPacket reads one packet, returning its first header byte and body
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed MQTT remaining length")
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7F) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
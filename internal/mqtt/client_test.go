@@ -0,0 +1,217 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBroker accepts one connection, answers CONNECT with returnCode and
+// forwards every later packet to the returned channel
+func fakeBroker(t *testing.T, returnCode byte) (string, <-chan []byte, <-chan []byte) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	connects := make(chan []byte, 1)
+	packets := make(chan []byte, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		header, body, err := readPacket(reader)
+		if err != nil || header != packetConnect {
+			return
+		}
+		connects <- body
+		conn.Write([]byte{packetConnack, 2, 0, returnCode})
+
+		for {
+			header, body, err := readPacket(reader)
+			if err != nil {
+				return
+			}
+			packets <- append([]byte{header}, body...)
+		}
+	}()
+	return "tcp://" + listener.Addr().String(), connects, packets
+}
+
+func TestEncodeLength(t *testing.T) {
+	tests := map[int][]byte{
+		0:       {0x00},
+		127:     {0x7F},
+		128:     {0x80, 0x01},
+		16383:   {0xFF, 0x7F},
+		2097152: {0x80, 0x80, 0x80, 0x01},
+	}
+	for n, want := range tests {
+		got := encodeLength(n)
+		if string(got) != string(want) {
+			t.Errorf("encodeLength(%d) = %x, want %x", n, got, want)
+		}
+		_, body, err := readPacket(bufio.NewReader(strings.NewReader(string(append(append([]byte{0x30}, got...), make([]byte, n)...)))))
+		if err != nil || len(body) != n {
+			t.Errorf("readPacket round trip for %d: len %d, err %v", n, len(body), err)
+		}
+	}
+}
+
+func TestParseBroker(t *testing.T) {
+	tests := []struct {
+		broker  string
+		address string
+		useTLS  bool
+		wantErr bool
+	}{
+		{"tcp://localhost", "localhost:1883", false, false},
+		{"mqtt://broker:1884", "broker:1884", false, false},
+		{"tls://broker.example", "broker.example:8883", true, false},
+		{"ssl://[::1]:9000", "[::1]:9000", true, false},
+		{"http://broker", "", false, true},
+		{"localhost:1883", "", false, true},
+	}
+	for _, tt := range tests {
+		_, address, useTLS, err := parseBroker(tt.broker)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBroker(%q) error = %v, wantErr %v", tt.broker, err, tt.wantErr)
+			continue
+		}
+		if address != tt.address || useTLS != tt.useTLS {
+			t.Errorf("parseBroker(%q) = %q tls=%v, want %q tls=%v", tt.broker, address, useTLS, tt.address, tt.useTLS)
+		}
+	}
+}
+
+func TestDialAndPublish(t *testing.T) {
+	broker, connects, packets := fakeBroker(t, 0)
+
+	conn, err := Dial(Options{Broker: broker, ClientID: "nclipd-test", Username: "user", Password: "pass", KeepAlive: 30 * time.Second})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	connect := <-connects
+	if !strings.HasPrefix(string(connect), "\x00\x04MQTT\x04") {
+		t.Errorf("Unexpected protocol header %q", connect[:7])
+	}
+	if flags := connect[7]; flags != 0xC2 {
+		t.Errorf("Expected username, password and clean session flags, got 0x%02x", flags)
+	}
+	if keepAlive := int(connect[8])<<8 | int(connect[9]); keepAlive != 30 {
+		t.Errorf("Expected keepalive 30, got %d", keepAlive)
+	}
+	if !strings.Contains(string(connect), "nclipd-test") || !strings.HasSuffix(string(connect), "\x00\x04user\x00\x04pass") {
+		t.Errorf("Unexpected connect payload %q", connect[10:])
+	}
+
+	if err := conn.Publish("nclip/events", []byte(`{"type":"text"}`), true); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	publish := <-packets
+	if publish[0] != packetPublish|0x01 {
+		t.Errorf("Expected retained publish header, got 0x%02x", publish[0])
+	}
+	if string(publish[1:]) != "\x00\x0cnclip/events{\"type\":\"text\"}" {
+		t.Errorf("Unexpected publish body %q", publish[1:])
+	}
+}
+
+func TestDialRefused(t *testing.T) {
+	broker, _, _ := fakeBroker(t, 4)
+
+	_, err := Dial(Options{Broker: broker, ClientID: "nclipd-test"})
+	if err == nil || !strings.Contains(err.Error(), "bad user name or password") {
+		t.Errorf("Expected bad credentials error, got %v", err)
+	}
+}
+
+func TestPublisher_DeliversQueuedMessages(t *testing.T) {
+	broker, _, packets := fakeBroker(t, 0)
+
+	publisher := NewPublisher(Options{Broker: broker, ClientID: "nclipd-test"}, "nclip/events", false)
+	publisher.Publish([]byte("queued before connect"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go publisher.Run(ctx)
+
+	select {
+	case publish := <-packets:
+		if !strings.HasSuffix(string(publish), "queued before connect") {
+			t.Errorf("Unexpected publish %q", publish)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the queued message")
+	}
+}
+
+func TestPublisher_BacksOffWhenDroppedRightAway(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	// Accept every session and close it straight away, like a broker handing the
+	// client id to another connection
+	connects := make(chan struct{}, 100)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if header, _, err := readPacket(bufio.NewReader(conn)); err == nil && header == packetConnect {
+				conn.Write([]byte{packetConnack, 2, 0, 0})
+				connects <- struct{}{}
+			}
+			conn.Close()
+		}
+	}()
+
+	publisher := NewPublisher(Options{Broker: "tcp://" + listener.Addr().String(), ClientID: "nclipd-test"}, "nclip/events", false)
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	publisher.Run(ctx)
+
+	// One connection right away and one after the first one second delay
+	if count := len(connects); count > 2 {
+		t.Errorf("Expected the publisher to back off, got %d connections in 1.5s", count)
+	}
+}
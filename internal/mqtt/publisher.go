@@ -0,0 +1,128 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mqtt
+
+import (
+	"context"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// queueSize is how many messages are buffered while the broker is unreachable
+const queueSize = 100
+
+// stableAfter is how long a connection has to stay up before the reconnect delay starts
+// over, so a broker that drops us right away isn't redialled in a tight loop
+const stableAfter = time.Minute
+
+// Publisher sends messages to one topic in the background, reconnecting as needed.
+// Messages are dropped rather than blocking the caller when the queue is full.
+type Publisher struct {
+	opts   Options
+	topic  string
+	retain bool
+	queue  chan []byte
+}
+
+// NewPublisher creates a publisher; call Run to start delivering messages
+func NewPublisher(opts Options, topic string, retain bool) *Publisher {
+	return &Publisher{
+		opts:   opts,
+		topic:  topic,
+		retain: retain,
+		queue:  make(chan []byte, queueSize),
+	}
+}
+
+// Publish queues a message for delivery
+func (p *Publisher) Publish(payload []byte) {
+	select {
+	case p.queue <- payload:
+	default:
+		logging.Warn("MQTT queue full, dropping event for %s", p.topic)
+	}
+}
+
+// Run connects to the broker and delivers queued messages until ctx is cancelled
+func (p *Publisher) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		conn, err := Dial(p.opts)
+		if err != nil {
+			logging.Error("MQTT: %v (retrying in %v)", err, backoff)
+		} else {
+			logging.Info("Connected to MQTT broker %s", p.opts.Broker)
+			connected := time.Now()
+			err = p.serve(ctx, conn)
+			conn.Close()
+			if ctx.Err() != nil {
+				return
+			}
+
+			// E.g. another daemon with the same client id keeps taking over the session
+			if time.Since(connected) >= stableAfter {
+				backoff = time.Second
+			}
+			logging.Warn("MQTT connection lost: %v (reconnecting in %v)", err, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, time.Minute)
+	}
+}
+
+// serve publishes queued messages and keepalive pings on one connection
+func (p *Publisher) serve(ctx context.Context, conn *Conn) error {
+	var ping <-chan time.Time
+	if p.opts.KeepAlive > 0 {
+		ticker := time.NewTicker(p.opts.KeepAlive / 2)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-conn.Done():
+			return conn.Err()
+		case payload := <-p.queue:
+			if err := conn.Publish(p.topic, payload, p.retain); err != nil {
+				// Put the message back so it is sent after reconnecting
+				p.Publish(payload)
+				return err
+			}
+		case <-ping:
+			if err := conn.Ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
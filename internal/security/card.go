@@ -0,0 +1,108 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cardIssuer is a range of issuer identification numbers (the leading digits of a
+// card number) and the card lengths the issuer uses
+type cardIssuer struct {
+	name      string
+	low, high int // Inclusive IIN range, compared against the same number of leading digits
+	lengths   []int
+}
+
+// cardIssuers lists the IIN ranges of the major card networks
+var cardIssuers = []cardIssuer{
+	{"Visa", 4, 4, []int{13, 16, 19}},
+	{"Mastercard", 51, 55, []int{16}},
+	{"Mastercard", 2221, 2720, []int{16}},
+	{"American Express", 34, 34, []int{15}},
+	{"American Express", 37, 37, []int{15}},
+	{"Discover", 6011, 6011, []int{16, 19}},
+	{"Discover", 644, 649, []int{16, 19}},
+	{"Discover", 65, 65, []int{16, 19}},
+	{"UnionPay", 62, 62, []int{16, 17, 18, 19}},
+	{"JCB", 3528, 3589, []int{16, 17, 18, 19}},
+	{"Diners Club", 300, 305, []int{14, 16, 19}},
+	{"Diners Club", 36, 36, []int{14, 16, 19}},
+	{"Diners Club", 38, 39, []int{16, 19}},
+	{"Maestro", 50, 50, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 56, 58, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 67, 67, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
+
+// cardNetwork returns the card network issuing a number, or "" if no known IIN range matches
+func cardNetwork(digits string) string {
+	for _, issuer := range cardIssuers {
+		prefixLen := len(strconv.Itoa(issuer.low))
+		if len(digits) < prefixLen {
+			continue
+		}
+		prefix, err := strconv.Atoi(digits[:prefixLen])
+		if err != nil || prefix < issuer.low || prefix > issuer.high {
+			continue
+		}
+		for _, length := range issuer.lengths {
+			if len(digits) == length {
+				return issuer.name
+			}
+		}
+	}
+	return ""
+}
+
+// luhnValid reports whether digits pass the Luhn checksum used by card numbers
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return len(digits) > 0 && sum%10 == 0
+}
+
+// isCardNumber reports whether content, ignoring spaces and dashes, is a number from a
+// known card network that passes the Luhn check
+func isCardNumber(content string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(content)
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return cardNetwork(digits) != "" && luhnValid(digits)
+}
@@ -0,0 +1,176 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"math/big"
+	"strings"
+)
+
+// bip39English is the BIP-39 English mnemonic wordlist, one word per line in index order
+//
+//go:embed bip39_english.txt
+var bip39English string
+
+// bip39Index maps each BIP-39 word to its 11-bit index
+var bip39Index = func() map[string]int {
+	words := strings.Fields(bip39English)
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[word] = i
+	}
+	return index
+}()
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// isBitcoinAddress reports whether content is a legacy (Base58Check) or SegWit (bech32)
+// Bitcoin mainnet address with a valid checksum
+func isBitcoinAddress(content string) bool {
+	if len(content) > 3 && strings.EqualFold(content[:3], "bc1") {
+		return isBech32Address(content)
+	}
+	return isBase58Address(content)
+}
+
+// isBase58Address validates a P2PKH ("1...") or P2SH ("3...") address: 25 bytes whose last
+// four are the double SHA-256 of the version byte and payload
+func isBase58Address(content string) bool {
+	if len(content) < 26 || len(content) > 35 {
+		return false
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range content {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return false
+		}
+		n.Mul(n, radix).Add(n, big.NewInt(int64(digit)))
+	}
+	if n.BitLen() > 25*8 {
+		return false
+	}
+	decoded := n.FillBytes(make([]byte, 25))
+	if decoded[0] != 0x00 && decoded[0] != 0x05 {
+		return false
+	}
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	return string(second[:4]) == string(decoded[21:])
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants for witness version 0 (bech32, BIP-173) and later versions (bech32m, BIP-350)
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// isBech32Address validates a "bc1" SegWit address, checking the checksum variant that
+// matches its witness version
+func isBech32Address(content string) bool {
+	if len(content) < 14 || len(content) > 90 {
+		return false
+	}
+	if content != strings.ToLower(content) && content != strings.ToUpper(content) {
+		return false // Mixed case is never valid
+	}
+	content = strings.ToLower(content)
+
+	values := []int{int('b') >> 5, int('c') >> 5, 0, int('b') & 31, int('c') & 31}
+	data := content[3:]
+	for _, c := range data {
+		value := strings.IndexRune(bech32Charset, c)
+		if value < 0 {
+			return false
+		}
+		values = append(values, value)
+	}
+	// The first data value is the witness version, and the last six are the checksum
+	if len(data) < 7 || values[5] > 16 {
+		return false
+	}
+	want := bech32Const
+	if values[5] > 0 {
+		want = bech32mConst
+	}
+	return bech32Polymod(values) == want
+}
+
+// bech32Polymod computes the bech32 BCH checksum over the expanded prefix and data values
+func bech32Polymod(values []int) int {
+	generator := [5]int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	check := 1
+	for _, value := range values {
+		top := check >> 25
+		check = (check&0x1ffffff)<<5 ^ value
+		for i, g := range generator {
+			if (top>>i)&1 == 1 {
+				check ^= g
+			}
+		}
+	}
+	return check
+}
+
+// seedPhraseWords splits content into a BIP-39 mnemonic of 12, 15, 18, 21 or 24 words,
+// returning the word indexes, or nil if any word is not in the wordlist
+func seedPhraseWords(content string) []int {
+	words := strings.Fields(strings.ToLower(content))
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil
+	}
+	indexes := make([]int, len(words))
+	for i, word := range words {
+		index, ok := bip39Index[word]
+		if !ok {
+			return nil
+		}
+		indexes[i] = index
+	}
+	return indexes
+}
+
+// validSeedChecksum reports whether the mnemonic's trailing checksum bits equal the leading
+// bits of the SHA-256 of its entropy, as every generated BIP-39 phrase does
+func validSeedChecksum(indexes []int) bool {
+	totalBits := len(indexes) * 11
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+
+	bits := new(big.Int)
+	for _, index := range indexes {
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(int64(index)))
+	}
+	checksum := new(big.Int).And(bits, big.NewInt(int64(1<<checksumBits-1)))
+	entropy := new(big.Int).Rsh(bits, uint(checksumBits)).FillBytes(make([]byte, entropyBits/8))
+
+	hash := sha256.Sum256(entropy)
+	return int64(hash[0]>>(8-checksumBits)) == checksum.Int64()
+}
@@ -0,0 +1,122 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBip39Wordlist(t *testing.T) {
+	if len(bip39Index) != 2048 {
+		t.Fatalf("wordlist has %d words, want 2048", len(bip39Index))
+	}
+	for word, want := range map[string]int{"abandon": 0, "about": 3, "satoshi": 1531, "zoo": 2047} {
+		if got := bip39Index[word]; got != want {
+			t.Errorf("index of %q = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestIsBitcoinAddress(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected bool
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},                              // P2PKH
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", true},                              // P2SH
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", true},                      // SegWit v0, bech32
+		{"BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", true},                      // Upper case is valid
+		{"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", true},  // Taproot, bech32m
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", false},                             // Bad checksum
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdr", false},                     // Bad checksum
+		{"bc1qAR0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", false},                     // Mixed case
+		{"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3298", false}, // Bad checksum
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7Divf0a", false},                             // 0 is not base58
+		{"", false},
+	}
+	for _, test := range tests {
+		if got := isBitcoinAddress(test.content); got != test.expected {
+			t.Errorf("isBitcoinAddress(%q) = %v, want %v", test.content, got, test.expected)
+		}
+	}
+}
+
+func TestValidSeedChecksum(t *testing.T) {
+	valid12 := strings.Repeat("abandon ", 11) + "about"
+	valid24 := strings.Repeat("abandon ", 23) + "art"
+	invalid := strings.Repeat("abandon ", 11) + "abandon"
+
+	for _, phrase := range []string{valid12, valid24} {
+		indexes := seedPhraseWords(phrase)
+		if indexes == nil || !validSeedChecksum(indexes) {
+			t.Errorf("expected %q to be a valid mnemonic", phrase)
+		}
+	}
+	if indexes := seedPhraseWords(invalid); indexes == nil || validSeedChecksum(indexes) {
+		t.Errorf("expected %q to fail the checksum", invalid)
+	}
+	if seedPhraseWords(strings.Repeat("abandon ", 13)) != nil {
+		t.Error("13 words is not a mnemonic length")
+	}
+	if seedPhraseWords(strings.Repeat("abandon ", 11)+"notaword") != nil {
+		t.Error("expected a non-BIP-39 word to be rejected")
+	}
+}
+
+func TestDetectSecurity_CryptoWallets(t *testing.T) {
+	if !Enabled {
+		t.Skip("threat detection not compiled in")
+	}
+	detector := NewSecurityDetector()
+	highRisk := func(content, threatType string) bool {
+		threats := detector.DetectSecurity(content)
+		for _, threat := range threats {
+			if threat.Type == threatType {
+				return IsHighRiskThreat(threats)
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		content    string
+		threatType string
+		expected   bool
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "crypto_address", true},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "crypto_address", true},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "crypto_address", true},
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", "crypto_address", false},
+		{strings.Repeat("abandon ", 11) + "about", "seed_phrase", true},
+		{strings.Repeat("abandon ", 11) + "abandon", "seed_phrase", true}, // Checksum mismatch still counts
+		{"the quick brown fox jumps over the lazy dog and then runs far away", "seed_phrase", false},
+	}
+	for _, test := range tests {
+		if got := highRisk(test.content, test.threatType); got != test.expected {
+			t.Errorf("high-risk %s for %q = %v, want %v", test.threatType, test.content, got, test.expected)
+		}
+	}
+}
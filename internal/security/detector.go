@@ -29,6 +29,7 @@ import (
 	"encoding/hex"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 // SecurityThreat represents the type and confidence level of detected security data
@@ -38,19 +39,36 @@ type SecurityThreat struct {
 	Reason     string  // Human readable explanation
 }
 
-// SecurityDetector contains patterns and logic for detecting sensitive information
+// SecurityDetector contains patterns and logic for detecting sensitive information.
+// Patterns are compiled once at construction and never modified afterwards, so a
+// detector is safe for concurrent use by multiple goroutines.
 type SecurityDetector struct {
 	patterns map[string]*regexp.Regexp
 }
 
+var (
+	defaultDetector     *SecurityDetector
+	defaultDetectorOnce sync.Once
+)
+
+// DefaultDetector returns a process-wide detector, compiling its patterns on first use
+func DefaultDetector() *SecurityDetector {
+	defaultDetectorOnce.Do(func() {
+		defaultDetector = NewSecurityDetector()
+	})
+	return defaultDetector
+}
+
 // NewSecurityDetector creates a new security detector with predefined patterns
 func NewSecurityDetector() *SecurityDetector {
 	detector := &SecurityDetector{
 		patterns: make(map[string]*regexp.Regexp),
 	}
 
-	// Compile all security patterns
-	detector.compilePatterns()
+	// Compile all security patterns, unless detection was left out of the build
+	if Enabled {
+		detector.compilePatterns()
+	}
 
 	return detector
 }
@@ -110,6 +128,11 @@ func (d *SecurityDetector) compilePatterns() {
 		// Credit card numbers (basic pattern)
 		"credit_card": `^[0-9]{4}[- ]?[0-9]{4}[- ]?[0-9]{4}[- ]?[0-9]{4}$`,
 
+		// Cryptocurrency wallets
+		"btc_address": `^((?i:bc1)[02-9ac-hj-np-zAC-HJ-NP-Z]{11,87}|[13][1-9A-HJ-NP-Za-km-z]{25,34})$`,
+		"eth_address": `^0x[0-9a-fA-F]{40}$`,
+		"seed_phrase": `^(?i:[a-z]{3,8}(\s+[a-z]{3,8}){11,23})$`,
+
 		// UUIDs (often used as secrets)
 		"uuid": `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`,
 
@@ -130,26 +153,56 @@ func (d *SecurityDetector) compilePatterns() {
 // DetectSecurity analyzes text content for potential security threats
 func (d *SecurityDetector) DetectSecurity(content string) []SecurityThreat {
 	var threats []SecurityThreat
+	for _, match := range d.matchRules(content) {
+		if match.Counted {
+			threats = append(threats, match.SecurityThreat)
+		}
+	}
+	return threats
+}
+
+// matchRules runs every pattern and heuristic against content, including patterns
+// whose confidence is too low to count as a threat. Base64 or hex encoded text is
+// also decoded and scanned, so secrets wrapped that way are still caught.
+func (d *SecurityDetector) matchRules(content string) []RuleMatch {
+	if !Enabled {
+		return nil
+	}
+	matches := d.matchContent(content)
+	if decoded, encoding, ok := DecodeEncoded(content); ok {
+		for _, match := range d.matchContent(decoded) {
+			match.Rule = encoding + ":" + match.Rule
+			match.Reason += " (" + encoding + "-encoded)"
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// matchContent runs every pattern and heuristic against content as it is
+func (d *SecurityDetector) matchContent(content string) []RuleMatch {
+	var matches []RuleMatch
 
 	content = strings.TrimSpace(content)
 	if len(content) == 0 {
-		return threats
+		return matches
 	}
 
 	// Check each pattern
 	for patternName, regex := range d.patterns {
-		if regex.MatchString(content) {
+		if loc := regex.FindStringIndex(content); loc != nil {
 			threat := d.classifyThreat(patternName, content)
-			if threat.Confidence > 0.5 { // Only include high-confidence matches
-				threats = append(threats, threat)
-			}
+			matches = append(matches, RuleMatch{
+				SecurityThreat: threat,
+				Rule:           patternName,
+				Match:          content[loc[0]:loc[1]],
+				Counted:        threat.Confidence > 0.5, // Only include high-confidence matches
+			})
 		}
 	}
 
 	// Additional heuristic checks
-	threats = append(threats, d.heuristicChecks(content)...)
-
-	return threats
+	return append(matches, d.heuristicMatches(content)...)
 }
 
 // classifyThreat converts pattern matches into structured threat information
@@ -192,11 +245,62 @@ func (d *SecurityDetector) classifyThreat(patternName, content string) SecurityT
 			Reason:     "Password field detected",
 		}
 	case strings.Contains(patternName, "credit_card"):
+		// Phone numbers and order IDs also have 16 digits; real card numbers pass the Luhn check
+		if !isCardNumber(content) {
+			return SecurityThreat{
+				Type:       "credit_card",
+				Confidence: 0.2,
+				Reason:     "16-digit number that fails the Luhn check or matches no card issuer",
+			}
+		}
 		return SecurityThreat{
 			Type:       "credit_card",
 			Confidence: 0.7,
 			Reason:     "Credit card number pattern detected",
 		}
+	case patternName == "btc_address":
+		// Base58 and bech32 alphabets match plenty of ordinary identifiers; real addresses carry a checksum
+		if !isBitcoinAddress(content) {
+			return SecurityThreat{
+				Type:       "crypto_address",
+				Confidence: 0.2,
+				Reason:     "Bitcoin-like address with an invalid checksum",
+			}
+		}
+		return SecurityThreat{
+			Type:       "crypto_address",
+			Confidence: 0.9,
+			Reason:     "Bitcoin wallet address detected",
+		}
+	case patternName == "eth_address":
+		return SecurityThreat{
+			Type:       "crypto_address",
+			Confidence: 0.85,
+			Reason:     "Ethereum wallet address detected",
+		}
+	case patternName == "seed_phrase":
+		indexes := seedPhraseWords(content)
+		switch {
+		case indexes == nil:
+			return SecurityThreat{
+				Type:       "seed_phrase",
+				Confidence: 0.1,
+				Reason:     "Word list that is not a BIP-39 mnemonic",
+			}
+		case validSeedChecksum(indexes):
+			return SecurityThreat{
+				Type:       "seed_phrase",
+				Confidence: 0.99,
+				Reason:     "BIP-39 wallet seed phrase detected (valid checksum)",
+			}
+		default:
+			// Hand-edited or non-BIP-39 wallets (e.g. Electrum) still use the same words
+			return SecurityThreat{
+				Type:       "seed_phrase",
+				Confidence: 0.85,
+				Reason:     "Possible wallet seed phrase (all BIP-39 words, checksum does not match)",
+			}
+		}
 	case strings.Contains(patternName, "db_"):
 		return SecurityThreat{
 			Type:       "connection_string",
@@ -227,12 +331,24 @@ func (d *SecurityDetector) classifyThreat(patternName, content string) SecurityT
 // heuristicChecks performs additional security checks based on content analysis
 func (d *SecurityDetector) heuristicChecks(content string) []SecurityThreat {
 	var threats []SecurityThreat
+	for _, match := range d.heuristicMatches(content) {
+		threats = append(threats, match.SecurityThreat)
+	}
+	return threats
+}
+
+// heuristicMatches runs the heuristic checks, naming each one that matched
+func (d *SecurityDetector) heuristicMatches(content string) []RuleMatch {
+	var matches []RuleMatch
+	add := func(rule, match string, threat SecurityThreat) {
+		matches = append(matches, RuleMatch{SecurityThreat: threat, Rule: rule, Match: match, Heuristic: true, Counted: true})
+	}
 
 	// Determine content type for context-aware detection
 	if d.isSourceCode(content) {
 		// For source code, only scan for tokens and secrets, skip password detection
 		if d.isRandomToken(content) {
-			threats = append(threats, SecurityThreat{
+			add("source_code_token", content, SecurityThreat{
 				Type:       "token",
 				Confidence: 0.75,
 				Reason:     "Long alphanumeric string detected in source code (potential token)",
@@ -241,7 +357,7 @@ func (d *SecurityDetector) heuristicChecks(content string) []SecurityThreat {
 	} else {
 		// For non-source code content, apply revised password detection logic
 		if d.looksLikePasswordRevised(content) {
-			threats = append(threats, SecurityThreat{
+			add("password_complexity", d.passwordCandidate(content), SecurityThreat{
 				Type:       "password",
 				Confidence: 0.85,
 				Reason:     "Potential password detected based on character complexity",
@@ -251,7 +367,7 @@ func (d *SecurityDetector) heuristicChecks(content string) []SecurityThreat {
 
 	// Check for environment variables with sensitive names
 	if d.isSensitiveEnvVar(content) {
-		threats = append(threats, SecurityThreat{
+		add("sensitive_env_var", content, SecurityThreat{
 			Type:       "env_secret",
 			Confidence: 0.8,
 			Reason:     "Environment variable with sensitive name",
@@ -260,7 +376,7 @@ func (d *SecurityDetector) heuristicChecks(content string) []SecurityThreat {
 
 	// Check for long random strings (potential tokens) - only if not source code
 	if !d.isSourceCode(content) && d.isRandomToken(content) {
-		threats = append(threats, SecurityThreat{
+		add("random_token", content, SecurityThreat{
 			Type:       "token",
 			Confidence: 0.75,
 			Reason:     "Long alphanumeric string detected (potential token)",
@@ -269,14 +385,14 @@ func (d *SecurityDetector) heuristicChecks(content string) []SecurityThreat {
 
 	// Check for URLs with query parameters (potential tokens in URLs)
 	if d.isUnsafeURL(content) {
-		threats = append(threats, SecurityThreat{
+		add("url_with_params", content, SecurityThreat{
 			Type:       "url_with_params",
 			Confidence: 0.65,
 			Reason:     "URL with query parameters detected (may contain tokens)",
 		})
 	}
 
-	return threats
+	return matches
 }
 
 // looksLikePasswordRevised implements the revised detection algorithm:
@@ -310,6 +426,20 @@ func (d *SecurityDetector) looksLikePasswordRevised(content string) bool {
 	return false
 }
 
+// passwordCandidate returns the word that made looksLikePasswordRevised flag content
+func (d *SecurityDetector) passwordCandidate(content string) string {
+	words := strings.Fields(content)
+	if len(words) == 1 {
+		return words[0]
+	}
+	for _, word := range words {
+		if d.wordHasCharacterTypes(word, 4) {
+			return word
+		}
+	}
+	return content
+}
+
 // wordHasCharacterTypes checks if a word has at least the specified number of character types
 func (d *SecurityDetector) wordHasCharacterTypes(word string, minTypes int) bool {
 	// Length must be between 8-40 characters (typical password range)
@@ -603,7 +733,8 @@ func IsHighRiskThreat(threats []SecurityThreat) bool {
 	for _, threat := range threats {
 		if threat.Confidence > 0.8 &&
 			(threat.Type == "jwt" || threat.Type == "api_key" ||
-				threat.Type == "ssh_key" || threat.Type == "private_key") {
+				threat.Type == "ssh_key" || threat.Type == "private_key" ||
+				threat.Type == "crypto_address" || threat.Type == "seed_phrase") {
 			return true
 		}
 	}
@@ -1,3 +1,5 @@
+//go:build !nosecurity
+
 /*
 MIT License
 
@@ -252,7 +254,7 @@ func TestLooksLikePassword(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := detector.looksLikePassword(test.input)
+		result := detector.looksLikePasswordRevised(test.input)
 		if result != test.expected {
 			t.Errorf("looksLikePassword('%s') = %v, expected %v", test.input, result, test.expected)
 		}
@@ -0,0 +1,101 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// minEncodedLength keeps short words that happen to be valid base64 from being decoded
+	minEncodedLength = 16
+	// maxEncodedLength bounds the work spent on decoding large clipboard items
+	maxEncodedLength = 1 << 20
+)
+
+// base64Encodings are tried in order; padded forms first so "=" is only accepted at the end
+var base64Encodings = []*base64.Encoding{
+	base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+}
+
+// DecodeEncoded decodes content that is a single base64 or hex payload wrapping text,
+// returning the text and the encoding ("base64" or "hex"). Payloads that decode to
+// binary data are not reported, since there is nothing to read or scan in them.
+func DecodeEncoded(content string) (string, string, bool) {
+	payload := strings.TrimSpace(content)
+	if len(payload) > maxEncodedLength || strings.ContainsAny(payload, " \t") {
+		return "", "", false
+	}
+	// Line-wrapped output of base64(1) is still one payload
+	payload = strings.NewReplacer("\r", "", "\n", "").Replace(payload)
+	if len(payload) < minEncodedLength {
+		return "", "", false
+	}
+
+	if isHexPayload(payload) {
+		if data, err := hex.DecodeString(payload); err == nil && isReadableText(data) {
+			return string(data), "hex", true
+		}
+		return "", "", false
+	}
+	for _, encoding := range base64Encodings {
+		if data, err := encoding.DecodeString(payload); err == nil && isReadableText(data) {
+			return string(data), "base64", true
+		}
+	}
+	return "", "", false
+}
+
+// isHexPayload reports whether s is an even number of hex digits
+func isHexPayload(s string) bool {
+	if len(s)%2 != 0 {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.Is(unicode.ASCII_Hex_Digit, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isReadableText reports whether decoded data is UTF-8 text rather than binary
+func isReadableText(data []byte) bool {
+	if len(data) < 4 || !utf8.Valid(data) {
+		return false
+	}
+	printable, total := 0, 0
+	for _, r := range string(data) {
+		total++
+		if unicode.IsPrint(r) || r == '\n' || r == '\t' || r == '\r' {
+			printable++
+		}
+	}
+	return printable*10 >= total*9
+}
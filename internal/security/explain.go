@@ -0,0 +1,65 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"sort"
+	"strings"
+)
+
+// RuleMatch explains how one detection rule matched content
+type RuleMatch struct {
+	SecurityThreat
+	Rule      string // Pattern or heuristic name, e.g. "github_token" or "random_token"
+	Match     string // The part of the content the rule matched
+	Heuristic bool   // Matched by a heuristic check rather than a pattern
+	Counted   bool   // Confident enough to be reported as a threat
+}
+
+// Explain lists every rule that matched content, most confident first. The
+// confidence of content is that of its most confident counted match.
+func (d *SecurityDetector) Explain(content string) []RuleMatch {
+	matches := d.matchRules(content)
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].Rule < matches[j].Rule
+	})
+	return matches
+}
+
+// MaskMatch hides the middle of a matched secret so it can be shown on screen or logged,
+// keeping two characters at each end to recognize it by. Short secrets, where even those
+// would give away too much, are hidden completely.
+func MaskMatch(match string) string {
+	runes := []rune(strings.Join(strings.Fields(match), " "))
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	const keep = 2
+	hidden := min(len(runes)-2*keep, 8)
+	return string(runes[:keep]) + strings.Repeat("*", hidden) + string(runes[len(runes)-keep:])
+}
@@ -212,6 +212,19 @@ func (s *HashStore) GetAllHashes(threatType string) ([]SecurityHash, error) {
 	return hashes, rows.Err()
 }
 
+// RecordHit reports whether hash is blocked, counting the hit and updating last_seen if it is
+func (s *HashStore) RecordHit(hash string) (bool, error) {
+	result, err := s.db.Exec("UPDATE security_hashes SET last_seen = ?, count = count + 1 WHERE hash = ?", time.Now(), hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to record hash hit: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
 // RemoveHash removes a security hash from the database
 func (s *HashStore) RemoveHash(hash string) error {
 	_, err := s.db.Exec("DELETE FROM security_hashes WHERE hash = ?", hash)
@@ -284,9 +297,11 @@ func (s *HashStore) GetStats() (map[string]interface{}, error) {
 	return stats, nil
 }
 
-// Close closes the database connection
+// Close checkpoints the write-ahead log into the database file, so the hashes are
+// complete on disk without the -wal file, and closes the database connection
 func (s *HashStore) Close() error {
 	if s.db != nil {
+		s.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)") // Best effort, closing checkpoints too
 		return s.db.Close()
 	}
 	return nil
@@ -308,6 +308,38 @@ func TestHashStoreRemoveHash(t *testing.T) {
 	}
 }
 
+func TestHashStoreRecordHit(t *testing.T) {
+	store, _ := createTestHashStore(t)
+
+	blocked, err := store.RecordHit("unknown_hash")
+	if err != nil {
+		t.Fatalf("Failed to record hit: %v", err)
+	}
+	if blocked {
+		t.Error("Unknown hash should not be reported as blocked")
+	}
+
+	hash := "hit_test_hash"
+	if err := store.AddHash(hash, SecurityThreat{Type: "token", Confidence: 0.7, Reason: "Token detected"}); err != nil {
+		t.Fatalf("Failed to add hash: %v", err)
+	}
+	blocked, err = store.RecordHit(hash)
+	if err != nil {
+		t.Fatalf("Failed to record hit: %v", err)
+	}
+	if !blocked {
+		t.Error("Added hash should be reported as blocked")
+	}
+
+	entry, err := store.GetHash(hash)
+	if err != nil {
+		t.Fatalf("Failed to get hash: %v", err)
+	}
+	if entry.Count != 2 {
+		t.Errorf("Expected count 2 after a hit, got %d", entry.Count)
+	}
+}
+
 func TestHashStoreCleanupOldHashes(t *testing.T) {
 	store, _ := createTestHashStore(t)
 
@@ -0,0 +1,59 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"strings"
+	"time"
+)
+
+// IsOTPCode reports whether content is a one-time code as copied from an
+// authenticator: 6 to 8 digits, optionally split in two by a space or dash
+// ("123 456"). Eight digits that form a date (20250101) are not treated as codes.
+func IsOTPCode(content string) bool {
+	code := strings.TrimSpace(content)
+	if i := strings.IndexAny(code, " -"); i > 0 {
+		// A single separator in the middle of the code
+		left, right := code[:i], code[i+1:]
+		if len(left) < 3 || len(right) < 3 || len(left)-len(right) > 1 || len(right)-len(left) > 1 {
+			return false
+		}
+		code = left + right
+	}
+	if len(code) < 6 || len(code) > 8 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	if len(code) == 8 {
+		if _, err := time.Parse("20060102", code); err == nil {
+			return false
+		}
+	}
+	return true
+}
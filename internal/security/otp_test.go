@@ -0,0 +1,55 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import "testing"
+
+func TestIsOTPCode(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected bool
+	}{
+		{"123456", true},
+		{" 123456\n", true},
+		{"1234567", true},
+		{"12345678", true},
+		{"123 456", true},
+		{"1234-5678", true},
+		{"123 4567", true},
+		{"12345", false},     // Too short
+		{"123456789", false}, // Too long
+		{"12a456", false},
+		{"12 3456", false}, // Separator not in the middle
+		{"123 456 789", false},
+		{"20250101", false}, // A date
+		{"2025-01-01", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if got := IsOTPCode(test.content); got != test.expected {
+			t.Errorf("IsOTPCode(%q) = %v, want %v", test.content, got, test.expected)
+		}
+	}
+}
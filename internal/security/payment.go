@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import (
+	"regexp"
+	"strings"
+)
+
+var ethAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// PaymentDestination names the kind of payment identifier content is: a Bitcoin or
+// Ethereum address or an IBAN. ok is false for anything else, including identifiers whose
+// checksum is wrong.
+func PaymentDestination(content string) (kind string, ok bool) {
+	content = strings.TrimSpace(content)
+	switch {
+	case isBitcoinAddress(content):
+		return "Bitcoin address", true
+	case ethAddressPattern.MatchString(content):
+		return "Ethereum address", true
+	case isIBAN(content):
+		return "IBAN", true
+	}
+	return "", false
+}
+
+// isIBAN reports whether content, ignoring spaces, is an international bank account number
+// whose check digits pass the ISO 13616 mod-97 test
+func isIBAN(content string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(content, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	for i, c := range iban {
+		letter := c >= 'A' && c <= 'Z'
+		digit := c >= '0' && c <= '9'
+		switch {
+		case i < 2 && !letter, i >= 2 && i < 4 && !digit, !letter && !digit:
+			return false
+		}
+	}
+
+	// Move the country code and check digits to the end, spell letters as 10-35 and
+	// take the remainder a digit at a time
+	remainder := 0
+	for _, c := range iban[4:] + iban[:4] {
+		if c >= 'A' {
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
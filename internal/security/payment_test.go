@@ -0,0 +1,52 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package security
+
+import "testing"
+
+func TestPaymentDestination(t *testing.T) {
+	tests := []struct {
+		content string
+		kind    string
+		ok      bool
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "Bitcoin address", true},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "Bitcoin address", true},
+		{" 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed\n", "Ethereum address", true},
+		{"GB82WEST12345698765432", "IBAN", true},
+		{"GB82 WEST 1234 5698 7654 32", "IBAN", true},
+		{"DE89370400440532013000", "IBAN", true},
+		{"GB82WEST12345698765433", "", false}, // Bad check digits
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", "", false},
+		{"hello world", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		kind, ok := PaymentDestination(test.content)
+		if kind != test.kind || ok != test.ok {
+			t.Errorf("PaymentDestination(%q) = %q, %v, want %q, %v", test.content, kind, ok, test.kind, test.ok)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package shellfmt writes the clipboard history in formats that structured shells can
+// load into their own pipelines: NUON for Nushell and escaped tab-separated lines for fish
+package shellfmt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// Supported output formats
+const (
+	FormatNUON = "nuon"
+	FormatFish = "fish"
+)
+
+// Formats lists the supported formats, for flag validation and help
+var Formats = []string{FormatNUON, FormatFish}
+
+// timeLayout is RFC 3339 with a numeric offset, which Nushell reads as a datetime literal
+const timeLayout = "2006-01-02T15:04:05-07:00"
+
+// Write writes the entries in the given format. Image entries have no content.
+func Write(w io.Writer, items []storage.ClipboardItemMeta, format string) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case FormatNUON:
+		writeNUON(bw, items)
+	case FormatFish:
+		writeFish(bw, items)
+	default:
+		return fmt.Errorf("unknown format %q, expected one of: %s", format, strings.Join(Formats, ", "))
+	}
+	return bw.Flush()
+}
+
+// writeNUON writes a list of records, one per line, that "from nuon" turns into a table
+func writeNUON(w *bufio.Writer, items []storage.ClipboardItemMeta) {
+	w.WriteString("[\n")
+	for _, item := range items {
+		content := "null"
+		if item.ContentType != "image" {
+			content = nuonString(item.Content)
+		}
+		fmt.Fprintf(w, "  {id: %s, type: %s, timestamp: %s, pinned: %t, threat: %s, size: %db, content: %s}\n",
+			nuonString(item.ID),
+			nuonString(item.ContentType),
+			item.Timestamp.Format(timeLayout),
+			item.IsPinned,
+			nuonString(threatLevel(item)),
+			item.Size,
+			content,
+		)
+	}
+	w.WriteString("]\n")
+}
+
+// nuonString quotes s as a Nushell double-quoted string
+func nuonString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// writeFish writes one tab-separated line per entry: id, type, timestamp, pinned, threat,
+// size in bytes and content. The content is escaped so each entry stays on one line;
+// fish's "string unescape" restores it.
+func writeFish(w *bufio.Writer, items []storage.ClipboardItemMeta) {
+	for _, item := range items {
+		content := ""
+		if item.ContentType != "image" {
+			content = fishEscape(item.Content)
+		}
+		fields := []string{
+			item.ID,
+			item.ContentType,
+			item.Timestamp.Format(timeLayout),
+			strconv.FormatBool(item.IsPinned),
+			threatLevel(item),
+			strconv.FormatInt(item.Size, 10),
+			content,
+		}
+		w.WriteString(strings.Join(fields, "\t"))
+		w.WriteByte('\n')
+	}
+}
+
+// fishEscape backslash-escapes s so that "string unescape" returns it unchanged
+func fishEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '\'', '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\x%02x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// threatLevel returns the stored threat level, "none" when it is unset
+func threatLevel(item storage.ClipboardItemMeta) string {
+	if item.ThreatLevel == "" {
+		return "none"
+	}
+	return item.ThreatLevel
+}
@@ -0,0 +1,90 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package shellfmt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func testItems() []storage.ClipboardItemMeta {
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 0, time.FixedZone("", 2*3600))
+	return []storage.ClipboardItemMeta{
+		{ID: "2", Content: "say \"hi\"\n\tit's $HOME\\x", ContentType: "text", Timestamp: ts, ThreatLevel: "none", IsPinned: true, Size: 22},
+		{ID: "1", ContentType: "image", Timestamp: ts, Size: 2048},
+	}
+}
+
+func TestWrite_NUON(t *testing.T) {
+	var out bytes.Buffer
+	if err := Write(&out, testItems(), FormatNUON); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	want := "[\n" +
+		`  {id: "2", type: "text", timestamp: 2025-03-04T05:06:07+02:00, pinned: true, threat: "none", size: 22b, content: "say \"hi\"\n\tit's $HOME\\x"}` + "\n" +
+		`  {id: "1", type: "image", timestamp: 2025-03-04T05:06:07+02:00, pinned: false, threat: "none", size: 2048b, content: null}` + "\n" +
+		"]\n"
+	if out.String() != want {
+		t.Errorf("Unexpected NUON output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWrite_Fish(t *testing.T) {
+	var out bytes.Buffer
+	if err := Write(&out, testItems(), FormatFish); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per entry, got %q", out.String())
+	}
+	fields := strings.Split(lines[0], "\t")
+	want := []string{"2", "text", "2025-03-04T05:06:07+02:00", "true", "none", "22", `say \"hi\"\n\tit\'s $HOME\\x`}
+	if strings.Join(fields, "|") != strings.Join(want, "|") {
+		t.Errorf("Unexpected fish fields %q, want %q", fields, want)
+	}
+	if !strings.HasSuffix(lines[1], "\t2048\t") {
+		t.Errorf("Expected an image line without content, got %q", lines[1])
+	}
+}
+
+func TestEscape_ControlCharacters(t *testing.T) {
+	if got := nuonString("a\x1bb"); got != `"a\u{1b}b"` {
+		t.Errorf("Unexpected NUON escape %s", got)
+	}
+	if got := fishEscape("a\x1bb\r"); got != `a\x1bb\r` {
+		t.Errorf("Unexpected fish escape %s", got)
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, nil, "csv"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AppRetentionRule limits how long and how many unpinned items copied from matching
+// applications are kept, e.g. one day for terminals or 200 entries for browsers
+type AppRetentionRule struct {
+	Apps       []string      // Matched case-insensitively as substrings of the source application
+	TTL        time.Duration // Expire matching items older than this, 0 disables
+	MaxEntries int           // Keep only the most recent matching items, 0 disables
+}
+
+// SetAppRetention sets the rules applied by EnforceAppRetention
+func (s *Storage) SetAppRetention(rules []AppRetentionRule) {
+	s.appRetention = rules
+}
+
+// EnforceAppRetention evicts items exceeding the per-application rules, archiving
+// them like EnforceRetention does, and returns how many were evicted
+func (s *Storage) EnforceAppRetention() (int, error) {
+	total := 0
+	for _, rule := range s.appRetention {
+		query, args := rule.victims(time.Now())
+		if query == "" {
+			continue
+		}
+		evicted, err := s.evict(query, args)
+		if err != nil {
+			return total, fmt.Errorf("failed to apply retention for %s: %w", strings.Join(rule.Apps, ", "), err)
+		}
+		total += evicted
+	}
+	return total, nil
+}
+
+// victims builds the query selecting the items the rule evicts, "" if the rule is empty
+func (r AppRetentionRule) victims(now time.Time) (string, []interface{}) {
+	var matches []string
+	var matchArgs []interface{}
+	for _, app := range r.Apps {
+		if app == "" {
+			continue
+		}
+		matches = append(matches, "INSTR(LOWER(COALESCE(source_app, '')), ?) > 0")
+		matchArgs = append(matchArgs, strings.ToLower(app))
+	}
+	if len(matches) == 0 || (r.TTL <= 0 && r.MaxEntries <= 0) {
+		return "", nil
+	}
+	matching := "is_pinned = FALSE AND (" + strings.Join(matches, " OR ") + ")"
+
+	var conditions []string
+	var args []interface{}
+	if r.TTL > 0 {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, now.Add(-r.TTL))
+	}
+	if r.MaxEntries > 0 {
+		conditions = append(conditions, "id NOT IN (SELECT id FROM clipboard_items WHERE "+matching+" ORDER BY timestamp DESC LIMIT ?)")
+		args = append(append(args, matchArgs...), r.MaxEntries)
+	}
+
+	query := "SELECT id FROM clipboard_items WHERE " + matching + " AND (" + strings.Join(conditions, " OR ") + ")"
+	return query, append(matchArgs, args...)
+}
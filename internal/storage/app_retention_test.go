@@ -0,0 +1,100 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEnforceAppRetention_TTL(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetAppRetention([]AppRetentionRule{{Apps: []string{"kitty", "foot"}, TTL: 24 * time.Hour}})
+
+	storage.AddWithSource("old terminal", "text", nil, Source{App: "kitty"})
+	storage.AddWithSource("old pinned terminal", "text", nil, Source{App: "foot"})
+	storage.AddWithSource("old browser", "text", nil, Source{App: "firefox"})
+	storage.AddWithSource("new terminal", "text", nil, Source{App: "Kitty"})
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, item := range storage.GetAllMeta() {
+		if item.Content == "new terminal" {
+			continue
+		}
+		if _, err := storage.db.Exec("UPDATE clipboard_items SET timestamp = ? WHERE id = ?", old, item.ID); err != nil {
+			t.Fatalf("Failed to age item: %v", err)
+		}
+		if item.Content == "old pinned terminal" {
+			storage.PinItem(item.ID)
+		}
+	}
+
+	evicted, err := storage.EnforceAppRetention()
+	if err != nil {
+		t.Fatalf("EnforceAppRetention failed: %v", err)
+	}
+	if evicted != 1 {
+		t.Errorf("Expected 1 evicted item, got %d", evicted)
+	}
+	for _, item := range storage.GetAllMeta() {
+		if item.Content == "old terminal" {
+			t.Error("Expected the old terminal item to be evicted")
+		}
+	}
+	if count := storage.GetItemCount(); count != 3 {
+		t.Errorf("Expected 3 remaining items, got %d", count)
+	}
+}
+
+func TestEnforceAppRetention_MaxEntries(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(0, true)
+	storage.SetAppRetention([]AppRetentionRule{{Apps: []string{"firefox"}, MaxEntries: 2}})
+
+	for i := 0; i < 4; i++ {
+		storage.AddWithSource(fmt.Sprintf("browser %d", i), "text", nil, Source{App: "org.mozilla.firefox"})
+	}
+	storage.AddWithSource("terminal", "text", nil, Source{App: "kitty"})
+
+	evicted, err := storage.EnforceAppRetention()
+	if err != nil {
+		t.Fatalf("EnforceAppRetention failed: %v", err)
+	}
+	if evicted != 2 {
+		t.Errorf("Expected 2 evicted items, got %d", evicted)
+	}
+
+	var remaining []string
+	for _, item := range storage.GetAllMeta() {
+		remaining = append(remaining, item.Content)
+	}
+	if len(remaining) != 3 || remaining[0] != "terminal" || remaining[1] != "browser 3" || remaining[2] != "browser 2" {
+		t.Errorf("Unexpected remaining items %v", remaining)
+	}
+	if count := storage.GetArchivedCount(); count != 2 {
+		t.Errorf("Expected evicted items to be archived, got %d", count)
+	}
+}
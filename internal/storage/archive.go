@@ -0,0 +1,219 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// retentionVictimsQuery selects items beyond their quota, plus unpinned items older than the
+// cutoff when expiry is enabled. Text and images with a quota of their own are limited by it
+// alone; everything else shares maxEntries.
+const retentionVictimsQuery = `
+	SELECT id FROM clipboard_items
+	WHERE (content_type <> 'text' OR NOT ?) AND (content_type <> 'image' OR NOT ?) AND id NOT IN (
+		SELECT id FROM clipboard_items
+		WHERE (content_type <> 'text' OR NOT ?) AND (content_type <> 'image' OR NOT ?)
+		ORDER BY timestamp DESC
+		LIMIT ?
+	)
+	OR (content_type = 'text' AND ? AND id NOT IN (
+		SELECT id FROM clipboard_items WHERE content_type = 'text' ORDER BY timestamp DESC LIMIT ?
+	))
+	OR (content_type = 'image' AND ? AND id NOT IN (
+		SELECT id FROM clipboard_items WHERE content_type = 'image' ORDER BY timestamp DESC LIMIT ?
+	))
+	OR (? AND is_pinned = FALSE AND timestamp < ?)
+`
+
+// SetRetention configures age-based expiry and whether evicted items are archived instead of deleted
+func (s *Storage) SetRetention(ttl time.Duration, archive bool) {
+	s.ttl = ttl
+	s.archive = archive
+}
+
+// SetTypeLimits gives text and image items quotas of their own, so a burst of screenshots
+// doesn't evict text history. A limit of 0 leaves that type under maxEntries.
+func (s *Storage) SetTypeLimits(maxText, maxImages int) {
+	s.maxText = maxText
+	s.maxImages = maxImages
+}
+
+// EnforceRetention evicts items beyond their quota or older than the TTL, returning how many were evicted
+func (s *Storage) EnforceRetention() (int, error) {
+	cutoff := time.Now().Add(-s.ttl)
+	ownText, ownImages := s.maxText > 0, s.maxImages > 0
+	return s.evict(retentionVictimsQuery, []interface{}{
+		ownText, ownImages, ownText, ownImages, s.maxEntries,
+		ownText, s.maxText,
+		ownImages, s.maxImages,
+		s.ttl > 0, cutoff,
+	})
+}
+
+// evict archives (when enabled) and deletes the items selected by victimsQuery, returning how
+// many were evicted. With shredding enabled the deleted rows are zeroed like in Delete.
+func (s *Storage) evict(victimsQuery string, args []interface{}) (int, error) {
+	ctx := context.Background()
+	conn, err := s.deleteConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if s.archive {
+		archiveQuery := `
+			INSERT OR REPLACE INTO archived_items (id, content, content_type, image_data, timestamp, threat_level, safe_entry, archived_at)
+			SELECT id, content, content_type, image_data, timestamp, threat_level, safe_entry, ?
+			FROM clipboard_items WHERE id IN (` + victimsQuery + `)`
+		if _, err := tx.ExecContext(ctx, archiveQuery, append([]interface{}{time.Now()}, args...)...); err != nil {
+			return 0, fmt.Errorf("failed to archive items: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM clipboard_items WHERE id IN ("+victimsQuery+")", args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict items: %w", err)
+	}
+
+	evicted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit retention: %w", err)
+	}
+	if evicted == 0 {
+		return 0, nil
+	}
+
+	return int(evicted), s.releaseFreed(ctx, conn)
+}
+
+// GetArchivedMeta returns lightweight metadata for all archived items, most recent first
+func (s *Storage) GetArchivedMeta() []ClipboardItemMeta {
+	query := "SELECT id, content, content_type, timestamp, threat_level, safe_entry, " + sizeColumn + " FROM archived_items ORDER BY timestamp DESC"
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return []ClipboardItemMeta{}
+	}
+	defer rows.Close()
+
+	var items []ClipboardItemMeta
+	for rows.Next() {
+		var item ClipboardItemMeta
+		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.Size)
+		if err != nil {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// GetArchivedItem returns a complete archived item including image data
+func (s *Storage) GetArchivedItem(id string) *ClipboardItem {
+	query := "SELECT id, content, content_type, image_data, timestamp, threat_level, safe_entry FROM archived_items WHERE id = ?"
+	row := s.db.QueryRow(query, id)
+
+	var item ClipboardItem
+	var imageData []byte
+	err := row.Scan(&item.ID, &item.Content, &item.ContentType, &imageData, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry)
+	if err != nil {
+		return nil
+	}
+	item.ImageData = imageData
+
+	return &item
+}
+
+// GetArchivedCount returns the number of archived items
+func (s *Storage) GetArchivedCount() int {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM archived_items").Scan(&count)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// RestoreArchived moves an archived item back into the history as the most recent entry
+func (s *Storage) RestoreArchived(id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Archived items aren't stored with their language, so detect it again
+	var content, contentType string
+	err = tx.QueryRow("SELECT content, content_type FROM archived_items WHERE id = ?", id).Scan(&content, &contentType)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("archived item %s not found", id)
+	} else if err != nil {
+		return fmt.Errorf("failed to read archived item: %w", err)
+	}
+
+	restoreQuery := `
+		INSERT OR REPLACE INTO clipboard_items (id, content, content_type, image_data, timestamp, threat_level, safe_entry, is_pinned, pin_order, text_language)
+		SELECT id, content, content_type, image_data, ?, threat_level, safe_entry, FALSE, 0, ?
+		FROM archived_items WHERE id = ?
+	`
+	if _, err := tx.Exec(restoreQuery, time.Now(), textLanguage(content, contentType), id); err != nil {
+		return fmt.Errorf("failed to restore item: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM archived_items WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to remove item from archive: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit restore: %w", err)
+	}
+
+	// The restored item may push older entries over the limit
+	_, err = s.EnforceRetention()
+	return err
+}
+
+// DeleteArchived permanently removes an item from the archive
+func (s *Storage) DeleteArchived(id string) error {
+	if s.shred {
+		return s.shredDelete("archived_items", id)
+	}
+	_, err := s.db.Exec("DELETE FROM archived_items WHERE id = ?", id)
+	return err
+}
@@ -0,0 +1,204 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEnforceRetention_DeletesWithoutArchive(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	for i := 0; i < 15; i++ {
+		if err := storage.Add(fmt.Sprintf("content %d", i)); err != nil {
+			t.Fatalf("Failed to add content %d: %v", i, err)
+		}
+	}
+
+	if count := storage.GetItemCount(); count != 10 {
+		t.Errorf("Expected 10 items, got %d", count)
+	}
+	if count := storage.GetArchivedCount(); count != 0 {
+		t.Errorf("Expected empty archive when archiving is disabled, got %d", count)
+	}
+}
+
+func TestEnforceRetention_ArchivesEvictedItems(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(0, true)
+
+	for i := 0; i < 15; i++ {
+		if err := storage.Add(fmt.Sprintf("content %d", i)); err != nil {
+			t.Fatalf("Failed to add content %d: %v", i, err)
+		}
+	}
+
+	if count := storage.GetItemCount(); count != 10 {
+		t.Errorf("Expected 10 items, got %d", count)
+	}
+
+	archived := storage.GetArchivedMeta()
+	if len(archived) != 5 {
+		t.Fatalf("Expected 5 archived items, got %d", len(archived))
+	}
+	if archived[0].Content != "content 4" {
+		t.Errorf("Expected most recent archived item 'content 4', got '%s'", archived[0].Content)
+	}
+}
+
+func TestEnforceRetention_TTL(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(24*time.Hour, true)
+
+	old := time.Now().Add(-48 * time.Hour)
+	storage.insertDirectly("old", "old content", "text", nil, old, "none", true)
+	storage.insertDirectly("pinned", "old pinned content", "text", nil, old, "none", true)
+	storage.insertDirectly("fresh", "fresh content", "text", nil, time.Now(), "none", true)
+	if err := storage.PinItem("pinned"); err != nil {
+		t.Fatalf("Failed to pin item: %v", err)
+	}
+
+	evicted, err := storage.EnforceRetention()
+	if err != nil {
+		t.Fatalf("Failed to enforce retention: %v", err)
+	}
+	if evicted != 1 {
+		t.Errorf("Expected 1 evicted item, got %d", evicted)
+	}
+
+	if storage.GetByID("old") != nil {
+		t.Error("Expected expired item to be removed from history")
+	}
+	if storage.GetByID("pinned") == nil {
+		t.Error("Expected pinned item to survive expiry")
+	}
+	if storage.GetArchivedItem("old") == nil {
+		t.Error("Expected expired item to be archived")
+	}
+}
+
+func TestRestoreArchived(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(24*time.Hour, true)
+
+	storage.insertDirectly("old", "old content", "text", nil, time.Now().Add(-48*time.Hour), "none", true)
+	if _, err := storage.EnforceRetention(); err != nil {
+		t.Fatalf("Failed to enforce retention: %v", err)
+	}
+
+	if err := storage.RestoreArchived("old"); err != nil {
+		t.Fatalf("Failed to restore item: %v", err)
+	}
+
+	restored := storage.GetByID("old")
+	if restored == nil {
+		t.Fatal("Expected restored item in history")
+	}
+	if restored.Content != "old content" {
+		t.Errorf("Expected content 'old content', got '%s'", restored.Content)
+	}
+	if time.Since(restored.Timestamp) > time.Minute {
+		t.Errorf("Expected restored item to be moved to the top, got timestamp %v", restored.Timestamp)
+	}
+	if storage.GetArchivedCount() != 0 {
+		t.Error("Expected archive to be empty after restore")
+	}
+
+	german := "Das ist nicht mein Fahrrad, aber ich kann es dir leihen."
+	storage.insertDirectly("german", german, "text", nil, time.Now().Add(-48*time.Hour), "none", true)
+	if _, err := storage.EnforceRetention(); err != nil {
+		t.Fatalf("Failed to enforce retention: %v", err)
+	}
+	if err := storage.RestoreArchived("german"); err != nil {
+		t.Fatalf("Failed to restore item: %v", err)
+	}
+	if meta := storage.GetMeta("german"); meta == nil || meta.TextLanguage != "de" {
+		t.Errorf("Expected the restored item to be detected as German, got %+v", meta)
+	}
+
+	if err := storage.RestoreArchived("missing"); err == nil {
+		t.Error("Expected error when restoring non-existent item")
+	}
+}
+
+func TestDeleteArchived(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(24*time.Hour, true)
+
+	storage.insertDirectly("old", "old content", "text", nil, time.Now().Add(-48*time.Hour), "none", true)
+	storage.EnforceRetention()
+
+	if err := storage.DeleteArchived("old"); err != nil {
+		t.Fatalf("Failed to delete archived item: %v", err)
+	}
+	if storage.GetArchivedCount() != 0 {
+		t.Error("Expected archive to be empty after delete")
+	}
+}
+
+func TestEnforceRetention_TypeLimits(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetTypeLimits(0, 3)
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 8; i++ {
+		storage.insertDirectly(fmt.Sprintf("text%d", i), fmt.Sprintf("text %d", i), "text", nil, base.Add(time.Duration(i)*time.Minute), "none", true)
+	}
+	// Newer screenshots than all the text, more than the image quota
+	for i := 0; i < 6; i++ {
+		storage.insertDirectly(fmt.Sprintf("image%d", i), "Image", "image", []byte{byte(i)}, base.Add(time.Duration(30+i)*time.Minute), "none", true)
+	}
+
+	evicted, err := storage.EnforceRetention()
+	if err != nil {
+		t.Fatalf("EnforceRetention failed: %v", err)
+	}
+	if evicted != 3 {
+		t.Errorf("Expected 3 images evicted, got %d", evicted)
+	}
+	if count := len(storage.GetImages()); count != 3 {
+		t.Errorf("Expected 3 images kept, got %d", count)
+	}
+	// Text is limited by max_entries (10) alone, so none of it is evicted for the images
+	if count := storage.GetItemCount(); count != 11 {
+		t.Errorf("Expected 8 text items and 3 images, got %d items", count)
+	}
+	if storage.GetMeta("image0") != nil || storage.GetMeta("image5") == nil {
+		t.Error("Expected the oldest images to be evicted first")
+	}
+
+	storage.SetTypeLimits(5, 3)
+	if _, err := storage.EnforceRetention(); err != nil {
+		t.Fatalf("EnforceRetention failed: %v", err)
+	}
+	if count := storage.GetItemCount(); count != 8 {
+		t.Errorf("Expected 5 text items and 3 images, got %d items", count)
+	}
+	if storage.GetMeta("text2") != nil || storage.GetMeta("text3") == nil {
+		t.Error("Expected the oldest text to be evicted first")
+	}
+}
@@ -0,0 +1,128 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UpdateSafeEntries sets the safe_entry flag of several items in one transaction,
+// returning how many items changed
+func (s *Storage) UpdateSafeEntries(ids []string, safeEntry bool) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	changed := 0
+	for _, batch := range idBatches(ids, rescanBatchSize) {
+		query := "UPDATE clipboard_items SET safe_entry = ? WHERE safe_entry != ? AND id IN (" + placeholders(len(batch)) + ")"
+		args := append([]interface{}{safeEntry, safeEntry}, batch...)
+		result, err := tx.Exec(query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update items: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			changed += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return changed, nil
+}
+
+// RescanSecurityThreatsForIDs re-scans the given items like RescanSecurityThreatsContext,
+// e.g. the ones matching a filter in the TUI. Images are skipped. Besides the stats it
+// returns the IDs of the items that were upgraded, for review.
+func (s *Storage) RescanSecurityThreatsForIDs(ctx context.Context, ids []string, progress RescanProgressFunc) (map[string]int, []string, error) {
+	stats := newRescanStats()
+	stats["total_items"] = len(ids)
+
+	upgraded := []string{}
+	for _, batch := range idBatches(ids, rescanBatchSize) {
+		if err := ctx.Err(); err != nil {
+			return stats, upgraded, err
+		}
+
+		items, err := s.readRescanItems(batch)
+		if err != nil {
+			return stats, upgraded, err
+		}
+		if err := s.rescanBatch(items, stats, &upgraded); err != nil {
+			return stats, upgraded, err
+		}
+
+		if progress != nil {
+			progress(stats["items_scanned"], len(ids))
+		}
+	}
+
+	return stats, upgraded, nil
+}
+
+// readRescanItems reads the text items among ids
+func (s *Storage) readRescanItems(ids []interface{}) ([]rescanItem, error) {
+	query := "SELECT id, content, threat_level FROM clipboard_items WHERE content_type != 'image' AND id IN (" + placeholders(len(ids)) + ")"
+	rows, err := s.db.Query(query, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []rescanItem
+	for rows.Next() {
+		var item rescanItem
+		if err := rows.Scan(&item.ID, &item.Content, &item.ThreatLevel); err != nil {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// idBatches splits ids into query arguments of at most size items, staying below
+// SQLite's limit on bound parameters
+func idBatches(ids []string, size int) [][]interface{} {
+	var batches [][]interface{}
+	for start := 0; start < len(ids); start += size {
+		end := min(start+size, len(ids))
+		batch := make([]interface{}, 0, end-start)
+		for _, id := range ids[start:end] {
+			batch = append(batch, id)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// placeholders returns n comma separated query placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
@@ -26,6 +26,7 @@ package storage
 
 import (
 	"container/list"
+	"sort"
 	"sync"
 	"time"
 )
@@ -38,12 +39,17 @@ type ItemCache struct {
 	metaItems   []ClipboardItemMeta
 	totalCount  int
 	lastRefresh time.Time
+	generation  uint64 // Incremented whenever metaItems changes
+	dataVersion int64  // Database change counter when metaItems was loaded
+	versionOK   bool   // Whether dataVersion could be read
 	
 	// Image data cache with LRU eviction
 	imageCache     map[string][]byte      // id -> image data
 	imageCacheList *list.List             // LRU list for image cache
 	imageCacheMap  map[string]*list.Element // id -> list element for O(1) access
-	maxImageCache  int                    // Maximum number of images to cache
+	maxImageCache  int                    // Maximum number of images to cache, 0 when only the byte budget applies
+	maxImageBytes  int64                  // Memory budget for cached image data, 0 for no budget
+	imageBytes     int64                  // Total size of cached image data
 	
 	mu sync.RWMutex
 }
@@ -73,14 +79,119 @@ func NewItemCache(storage *Storage, maxImageCache int) *ItemCache {
 	return cache
 }
 
+// NewItemCacheWithBudget creates an ItemCache that limits cached image data by total
+// size instead of image count, so a few large screenshots can't balloon memory use
+func NewItemCacheWithBudget(storage *Storage, budgetMB int) *ItemCache {
+	if budgetMB <= 0 {
+		budgetMB = DefaultImageBudgetMB
+	}
+
+	cache := NewItemCache(storage, 0)
+	cache.maxImageCache = 0
+	cache.maxImageBytes = int64(budgetMB) * 1024 * 1024
+	return cache
+}
+
+// DefaultImageBudgetMB is the image cache budget used when none is configured
+const DefaultImageBudgetMB = 64
+
 // refreshMetadata reloads all item metadata from storage
 func (c *ItemCache) refreshMetadata() {
+	// Read the counter first, so a write landing during the reload is caught next time
+	version, err := c.storage.DataVersion()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
 	c.metaItems = c.storage.GetAllMeta()
 	c.totalCount = len(c.metaItems)
 	c.lastRefresh = time.Now()
+	c.generation++
+	c.dataVersion = version
+	c.versionOK = err == nil
+}
+
+// stale reports whether the database was written since the metadata was loaded, by the
+// daemon or through another connection. When the change counter can't be read, metadata
+// older than 5 seconds counts as stale.
+func (c *ItemCache) stale() bool {
+	version, err := c.storage.DataVersion()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err != nil || !c.versionOK {
+		return time.Since(c.lastRefresh) > 5*time.Second
+	}
+	return version != c.dataVersion
+}
+
+// Generation returns a counter that changes whenever the cached metadata changes,
+// so callers can skip re-filtering when nothing happened
+func (c *ItemCache) Generation() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// RefreshItem re-reads a single item from storage and applies it to the cached metadata.
+// It is much cheaper than ForceRefresh after pinning, editing or deleting one item.
+func (c *ItemCache) RefreshItem(id string) {
+	meta := c.storage.GetMeta(id)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The change counter is left alone: data_version moves once per observation, not once
+	// per commit, so it can't tell the caller's write apart from a daemon write that landed
+	// alongside it. The next GetAllMeta reloads in full and picks up both.
+
+	index := -1
+	for i := range c.metaItems {
+		if c.metaItems[i].ID == id {
+			index = i
+			break
+		}
+	}
+
+	// Unpinning closes the gap in the pin order, as UnpinItem does in the database
+	if index >= 0 && c.metaItems[index].IsPinned && (meta == nil || !meta.IsPinned) {
+		removedOrder := c.metaItems[index].PinOrder
+		for i := range c.metaItems {
+			if c.metaItems[i].IsPinned && c.metaItems[i].PinOrder > removedOrder {
+				c.metaItems[i].PinOrder--
+			}
+		}
+	}
+
+	switch {
+	case meta == nil && index < 0:
+		return // Nothing cached and nothing stored
+	case meta == nil:
+		c.metaItems = append(c.metaItems[:index], c.metaItems[index+1:]...)
+		c.evictImage(id)
+	case index < 0:
+		c.metaItems = append(c.metaItems, *meta)
+	default:
+		c.metaItems[index] = *meta
+	}
+
+	sortMeta(c.metaItems)
+	c.totalCount = len(c.metaItems)
+	c.generation++
+}
+
+// sortMeta orders items like GetAllMeta: pinned first by pin order, then newest first
+func sortMeta(items []ClipboardItemMeta) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].IsPinned != items[j].IsPinned {
+			return items[i].IsPinned
+		}
+		if items[i].PinOrder != items[j].PinOrder {
+			return items[i].PinOrder < items[j].PinOrder
+		}
+		return items[i].Timestamp.After(items[j].Timestamp)
+	})
 }
 
 // ForceRefresh forces an immediate refresh of the cache
@@ -88,17 +199,26 @@ func (c *ItemCache) ForceRefresh() {
 	c.refreshMetadata()
 }
 
+// Clear drops all cached image data and reloads the metadata, e.g. after the history was wiped
+func (c *ItemCache) Clear() {
+	c.mu.Lock()
+	for id := range c.imageCacheMap {
+		c.evictImage(id)
+	}
+	c.mu.Unlock()
+
+	c.refreshMetadata()
+}
+
 // GetAllMeta returns all item metadata (lightweight)
 func (c *ItemCache) GetAllMeta() []ClipboardItemMeta {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	
-	// Refresh if data is older than 5 seconds (to catch new clipboard entries)
-	if time.Since(c.lastRefresh) > 5*time.Second {
-		c.mu.RUnlock()
+	// Reload only when the database actually changed, e.g. the daemon stored a new entry
+	if c.stale() {
 		c.refreshMetadata()
-		c.mu.RLock()
 	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	
 	// Return a copy to prevent external modification
 	result := make([]ClipboardItemMeta, len(c.metaItems))
@@ -138,28 +258,43 @@ func (c *ItemCache) GetImageData(id string) []byte {
 
 // putImageInCache adds image data to cache with LRU eviction
 func (c *ItemCache) putImageInCache(id string, data []byte) {
+	// An image bigger than the whole budget is served uncached
+	if c.maxImageBytes > 0 && int64(len(data)) > c.maxImageBytes {
+		c.evictImage(id)
+		return
+	}
+
 	// Check if already in cache
 	if elem, exists := c.imageCacheMap[id]; exists {
 		// Update existing entry and move to front
 		entry := elem.Value.(*imageCacheEntry)
+		c.imageBytes += int64(len(data)) - int64(len(entry.data))
 		entry.data = data
 		c.imageCacheList.MoveToFront(elem)
 		c.imageCache[id] = data
-		return
+	} else {
+		// Add new entry
+		entry := &imageCacheEntry{id: id, data: data}
+		elem := c.imageCacheList.PushFront(entry)
+		c.imageCacheMap[id] = elem
+		c.imageCache[id] = data
+		c.imageBytes += int64(len(data))
 	}
 	
-	// Add new entry
-	entry := &imageCacheEntry{id: id, data: data}
-	elem := c.imageCacheList.PushFront(entry)
-	c.imageCacheMap[id] = elem
-	c.imageCache[id] = data
-	
 	// Evict if over capacity
-	for c.imageCacheList.Len() > c.maxImageCache {
+	for c.overImageLimit() {
 		c.evictOldestImage()
 	}
 }
 
+// overImageLimit reports whether the image cache exceeds its count or byte limit
+func (c *ItemCache) overImageLimit() bool {
+	if c.maxImageCache > 0 && c.imageCacheList.Len() > c.maxImageCache {
+		return true
+	}
+	return c.maxImageBytes > 0 && c.imageBytes > c.maxImageBytes
+}
+
 // evictOldestImage removes the least recently used image from cache
 func (c *ItemCache) evictOldestImage() {
 	if c.imageCacheList.Len() == 0 {
@@ -172,6 +307,7 @@ func (c *ItemCache) evictOldestImage() {
 		c.imageCacheList.Remove(oldest)
 		delete(c.imageCacheMap, entry.id)
 		delete(c.imageCache, entry.id)
+		c.imageBytes -= int64(len(entry.data))
 	}
 }
 
@@ -204,18 +340,26 @@ func (c *ItemCache) GetFullItem(id string) *ClipboardItem {
 	return &item
 }
 
-// PreloadImageData preloads image data for specific items
+// PreloadImageData preloads image data for specific items, most important first.
+// With a byte budget, preloading stops once the listed images fill it, so later
+// entries can't evict the ones the caller needs most.
 func (c *ItemCache) PreloadImageData(ids []string) {
+	var loaded int64
 	for _, id := range ids {
+		if c.maxImageBytes > 0 && loaded >= c.maxImageBytes {
+			return
+		}
+
 		// Check if already cached
 		c.mu.RLock()
-		_, exists := c.imageCache[id]
+		data, exists := c.imageCache[id]
 		c.mu.RUnlock()
 		
 		if !exists {
 			// Trigger loading (this will cache it)
-			c.GetImageData(id)
+			data = c.GetImageData(id)
 		}
+		loaded += int64(len(data))
 	}
 }
 
@@ -224,7 +368,13 @@ func (c *ItemCache) EvictImageData(id string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
+	c.evictImage(id)
+}
+
+// evictImage removes image data for id from the cache; callers must hold c.mu
+func (c *ItemCache) evictImage(id string) {
 	if elem, exists := c.imageCacheMap[id]; exists {
+		c.imageBytes -= int64(len(elem.Value.(*imageCacheEntry).data))
 		c.imageCacheList.Remove(elem)
 		delete(c.imageCacheMap, id)
 		delete(c.imageCache, id)
@@ -236,11 +386,22 @@ func (c *ItemCache) GetCacheStats() map[string]interface{} {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
+	// Fill ratio of whichever limit applies
+	ratio := 0.0
+	if c.maxImageBytes > 0 {
+		ratio = float64(c.imageBytes) / float64(c.maxImageBytes)
+	} else if c.maxImageCache > 0 {
+		ratio = float64(len(c.imageCache)) / float64(c.maxImageCache)
+	}
+
 	return map[string]interface{}{
 		"total_items":        c.totalCount,
 		"cached_images":      len(c.imageCache),
 		"max_image_cache":    c.maxImageCache,
+		"image_cache_bytes":  c.imageBytes,
+		"max_image_bytes":    c.maxImageBytes,
 		"last_refresh":       c.lastRefresh,
-		"cache_hit_ratio":    float64(len(c.imageCache)) / float64(c.maxImageCache),
+		"generation":         c.generation,
+		"cache_hit_ratio":    ratio,
 	}
 }
\ No newline at end of file
@@ -487,40 +487,47 @@ func TestItemCache_RefreshMetadata(t *testing.T) {
 		t.Fatalf("Failed to add content: %v", err)
 	}
 
-	// Cache should still show old data
-	items = cache.GetAllMeta()
-	if len(items) != 0 {
-		t.Errorf("Expected cache to still show 0 items, got %d", len(items))
-	}
-
-	// Manually refresh
-	cache.refreshMetadata()
-
-	// Now cache should show new data
+	// The write changed the database, so the cache reloads on its own
 	items = cache.GetAllMeta()
 	if len(items) != 1 {
-		t.Errorf("Expected 1 item after refresh, got %d", len(items))
+		t.Fatalf("Expected 1 item after the write, got %d", len(items))
 	}
 
 	if items[0].Content != "new content" {
 		t.Errorf("Expected 'new content', got '%s'", items[0].Content)
 	}
+
+	// Without further writes the cached metadata is reused
+	generation := cache.Generation()
+	cache.GetAllMeta()
+	if cache.Generation() != generation {
+		t.Error("Expected no reload while the database is unchanged")
+	}
 }
 
 func TestItemCache_AutoRefresh(t *testing.T) {
 	storage, _ := createTestStorageForCache(t)
 	cache := NewItemCache(storage, 5)
 
-	// Manually set last refresh to old time to trigger auto-refresh
+	// Old metadata alone doesn't trigger a reload
 	cache.lastRefresh = time.Now().Add(-10 * time.Second)
+	generation := cache.Generation()
+	cache.GetAllMeta()
+	if cache.Generation() != generation {
+		t.Error("Expected no reload while the database is unchanged")
+	}
 
-	// Add data to storage
-	err := storage.Add("auto refresh test")
+	// A second Storage on the same database stands in for the daemon
+	other, err := New(10)
 	if err != nil {
+		t.Fatalf("Failed to open second storage: %v", err)
+	}
+	defer other.Close()
+	if err := other.Add("auto refresh test"); err != nil {
 		t.Fatalf("Failed to add content: %v", err)
 	}
 
-	// GetAllMeta should trigger auto-refresh due to old timestamp
+	// GetAllMeta should notice the external write
 	items := cache.GetAllMeta()
 	if len(items) != 1 {
 		t.Errorf("Expected 1 item after auto-refresh, got %d", len(items))
@@ -616,15 +623,14 @@ func TestItemCache_ForceRefresh(t *testing.T) {
 		t.Fatalf("Failed to add initial item: %v", err)
 	}
 
-	// Cache should not see the new item without refresh
-	items := cache.GetAllMeta()
-	if len(items) != 0 {
-		t.Errorf("Expected 0 items in cache before refresh, got %d", len(items))
+	// The cached count is only updated by a reload
+	if count := cache.GetItemCount(); count != 0 {
+		t.Errorf("Expected 0 items in cache before refresh, got %d", count)
 	}
 
 	// Force refresh should pick up new item
 	cache.ForceRefresh()
-	items = cache.GetAllMeta()
+	items := cache.GetAllMeta()
 	if len(items) != 1 {
 		t.Errorf("Expected 1 item in cache after force refresh, got %d", len(items))
 	}
@@ -643,4 +649,162 @@ func TestItemCache_ForceRefresh(t *testing.T) {
 	if len(items) != 2 {
 		t.Errorf("Expected 2 items in cache after second force refresh, got %d", len(items))
 	}
-}
\ No newline at end of file
+}
+// assertCacheMatchesStorage checks the cached metadata against a fresh query
+func assertCacheMatchesStorage(t *testing.T, cache *ItemCache, storage *Storage) {
+	t.Helper()
+	expected := storage.GetAllMeta()
+	cached := cache.GetAllMeta()
+	if len(cached) != len(expected) {
+		t.Fatalf("Expected %d cached items, got %d", len(expected), len(cached))
+	}
+	for i := range expected {
+		if cached[i].ID != expected[i].ID || cached[i].IsPinned != expected[i].IsPinned || cached[i].PinOrder != expected[i].PinOrder {
+			t.Errorf("Item %d: expected %+v, got %+v", i, expected[i], cached[i])
+		}
+	}
+}
+
+func TestItemCache_RefreshItem(t *testing.T) {
+	storage, _ := createTestStorageForCache(t)
+	for _, content := range []string{"first", "second", "third", "fourth"} {
+		if err := storage.Add(content); err != nil {
+			t.Fatalf("Failed to add content: %v", err)
+		}
+	}
+
+	cache := NewItemCache(storage, 5)
+	items := cache.GetAllMeta()
+	generation := cache.Generation()
+
+	// Pin two items
+	for _, item := range []ClipboardItemMeta{items[2], items[0]} {
+		if err := storage.PinItem(item.ID); err != nil {
+			t.Fatalf("Failed to pin item: %v", err)
+		}
+		cache.RefreshItem(item.ID)
+	}
+	assertCacheMatchesStorage(t, cache, storage)
+
+	if cache.Generation() <= generation {
+		t.Error("Expected generation to advance after RefreshItem")
+	}
+
+	// Unpinning renumbers the remaining pinned items
+	if err := storage.UnpinItem(items[2].ID); err != nil {
+		t.Fatalf("Failed to unpin item: %v", err)
+	}
+	cache.RefreshItem(items[2].ID)
+	assertCacheMatchesStorage(t, cache, storage)
+
+	// Edits replace the row in place
+	if err := storage.Update(items[1].ID, "edited"); err != nil {
+		t.Fatalf("Failed to update item: %v", err)
+	}
+	cache.RefreshItem(items[1].ID)
+	assertCacheMatchesStorage(t, cache, storage)
+	if item := cache.GetFullItem(items[1].ID); item == nil || item.Content != "edited" {
+		t.Errorf("Expected edited content in cache, got %+v", item)
+	}
+
+	// Deletes remove the row
+	if err := storage.Delete(items[3].ID); err != nil {
+		t.Fatalf("Failed to delete item: %v", err)
+	}
+	cache.RefreshItem(items[3].ID)
+	assertCacheMatchesStorage(t, cache, storage)
+	if count := cache.GetItemCount(); count != 3 {
+		t.Errorf("Expected 3 items after delete, got %d", count)
+	}
+}
+
+func TestItemCache_RefreshItemKeepsOutsideChanges(t *testing.T) {
+	storage, _ := createTestStorageForCache(t)
+	if err := storage.Add("first"); err != nil {
+		t.Fatalf("Failed to add content: %v", err)
+	}
+
+	cache := NewItemCache(storage, 5)
+	items := cache.GetAllMeta()
+
+	// A second Storage on the same database stands in for the daemon
+	other, err := New(10)
+	if err != nil {
+		t.Fatalf("Failed to open second storage: %v", err)
+	}
+	defer other.Close()
+
+	// Our write and the daemon's land between two reads of the change counter, which then
+	// moves by one step for both
+	if err := storage.PinItem(items[0].ID); err != nil {
+		t.Fatalf("Failed to pin item: %v", err)
+	}
+	if err := other.Add("from the daemon"); err != nil {
+		t.Fatalf("Failed to add content: %v", err)
+	}
+	cache.RefreshItem(items[0].ID)
+
+	if count := len(cache.GetAllMeta()); count != 2 {
+		t.Errorf("Expected the daemon's item after RefreshItem, got %d items", count)
+	}
+	assertCacheMatchesStorage(t, cache, storage)
+}
+
+func TestItemCache_ImageBudget(t *testing.T) {
+	storage, _ := createTestStorageForCache(t)
+
+	// Three 400 KB images against a 1 MB budget
+	for i := 0; i < 3; i++ {
+		data := make([]byte, 400*1024)
+		data[0] = byte(i) // Keep the images distinct for deduplication
+		if err := storage.AddImage(data, fmt.Sprintf("image %d", i)); err != nil {
+			t.Fatalf("Failed to add image: %v", err)
+		}
+	}
+
+	cache := NewItemCacheWithBudget(storage, 1)
+	items := cache.GetAllMeta()
+	for _, item := range items {
+		cache.GetImageData(item.ID)
+	}
+
+	stats := cache.GetCacheStats()
+	if cached := stats["cached_images"].(int); cached != 2 {
+		t.Errorf("Expected 2 images to fit the budget, got %d", cached)
+	}
+	if bytes := stats["image_cache_bytes"].(int64); bytes > 1024*1024 {
+		t.Errorf("Expected cached bytes within budget, got %d", bytes)
+	}
+
+	// The least recently used image was evicted
+	cache.mu.RLock()
+	_, oldestCached := cache.imageCache[items[0].ID]
+	cache.mu.RUnlock()
+	if oldestCached {
+		t.Error("Expected the least recently used image to be evicted")
+	}
+
+	// Evicting explicitly releases its bytes
+	cache.EvictImageData(items[2].ID)
+	if bytes := cache.GetCacheStats()["image_cache_bytes"].(int64); bytes != 400*1024 {
+		t.Errorf("Expected 400 KB cached after eviction, got %d", bytes)
+	}
+}
+
+func TestItemCache_ImageLargerThanBudget(t *testing.T) {
+	storage, _ := createTestStorageForCache(t)
+	if err := storage.AddImage(make([]byte, 2*1024*1024), "huge screenshot"); err != nil {
+		t.Fatalf("Failed to add image: %v", err)
+	}
+
+	cache := NewItemCacheWithBudget(storage, 1)
+	items := cache.GetAllMeta()
+
+	// Oversized images are still returned but never cached
+	if data := cache.GetImageData(items[0].ID); len(data) != 2*1024*1024 {
+		t.Errorf("Expected image data to be returned, got %d bytes", len(data))
+	}
+	if cached := cache.GetCacheStats()["cached_images"].(int); cached != 0 {
+		t.Errorf("Expected oversized image not to be cached, got %d cached", cached)
+	}
+}
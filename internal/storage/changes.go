@@ -0,0 +1,64 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// DataVersion returns SQLite's data_version counter as seen by a dedicated connection.
+// The value changes whenever another connection, such as the daemon, commits a write,
+// so polling it is a cheap way to notice new clipboard entries.
+func (s *Storage) DataVersion() (int64, error) {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	// data_version is per connection, so it must always be read from the same one
+	if s.watchConn == nil {
+		conn, err := s.db.Conn(context.Background())
+		if err != nil {
+			return 0, fmt.Errorf("failed to open change watch connection: %w", err)
+		}
+		s.watchConn = conn
+	}
+
+	var version int64
+	if err := s.watchConn.QueryRowContext(context.Background(), "PRAGMA data_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read data version: %w", err)
+	}
+	return version, nil
+}
+
+// closeWatchConn releases the connection used by DataVersion
+func (s *Storage) closeWatchConn() {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	if s.watchConn != nil {
+		s.watchConn.Close()
+		s.watchConn = nil
+	}
+}
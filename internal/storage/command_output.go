@@ -0,0 +1,57 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+// Command output captured with nclip --exec is tagged ExecTag and records the command
+// line as its source, under the app ExecSourceApp
+const (
+	ExecTag       = "exec"
+	ExecSourceApp = "exec"
+)
+
+// ErrEmptyOutput is returned when a command printed nothing to store
+var ErrEmptyOutput = errors.New("command printed nothing")
+
+// AddCommandOutput stores the output of a command as a history entry, with the command
+// line as its source, and returns its ID. Output that is already in the history is moved
+// to the top and takes the new command line.
+func (s *Storage) AddCommandOutput(output, command string) (string, error) {
+	if strings.TrimSpace(output) == "" {
+		return "", ErrEmptyOutput
+	}
+	id, err := s.addWithSource(output, "text", nil, Source{App: ExecSourceApp, Title: command})
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", errors.New("command output was not stored")
+	}
+	return id, s.AddTag(id, ExecTag)
+}
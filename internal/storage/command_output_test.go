@@ -0,0 +1,76 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddCommandOutput(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	id, err := storage.AddCommandOutput("total 0\n", "ls -l /tmp/empty")
+	if err != nil {
+		t.Fatalf("AddCommandOutput failed: %v", err)
+	}
+	if tags := storage.GetTags(id); len(tags) != 1 || tags[0] != ExecTag {
+		t.Errorf("Expected the exec tag, got %v", tags)
+	}
+	if source := storage.GetSource(id); source.App != ExecSourceApp || source.Title != "ls -l /tmp/empty" {
+		t.Errorf("Expected the command line as the source, got %+v", source)
+	}
+
+	// The same output from another command is reused and records the newer command
+	again, err := storage.AddCommandOutput("total 0\n", "ls -l /tmp/other")
+	if err != nil {
+		t.Fatalf("AddCommandOutput failed: %v", err)
+	}
+	if again != id || storage.GetItemCount() != 1 {
+		t.Errorf("Expected the existing entry to be reused, got %q and %d entries", again, storage.GetItemCount())
+	}
+	if source := storage.GetSource(id); source.Title != "ls -l /tmp/other" {
+		t.Errorf("Expected the newer command line, got %q", source.Title)
+	}
+
+	if _, err := storage.AddCommandOutput("\n\n", "true"); !errors.Is(err, ErrEmptyOutput) {
+		t.Errorf("Expected ErrEmptyOutput for blank output, got %v", err)
+	}
+}
+
+func TestAddCommandOutput_TrailingNewlines(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	// Output usually ends with a newline, which must not defeat deduplication
+	if _, err := storage.AddCommandOutput("v1.2.3\n", "git describe"); err != nil {
+		t.Fatalf("AddCommandOutput failed: %v", err)
+	}
+	if _, err := storage.AddCommandOutput("v1.2.3\r\n", "git describe"); err != nil {
+		t.Fatalf("AddCommandOutput failed: %v", err)
+	}
+	if storage.GetItemCount() != 1 {
+		t.Errorf("Expected output differing in line endings to be deduplicated, got %d entries", storage.GetItemCount())
+	}
+}
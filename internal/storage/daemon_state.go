@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DaemonState is the last run of nclipd recorded in the database. A daemon that is not
+// running but never recorded its shutdown was killed or crashed, and may have lost
+// clipboard changes.
+type DaemonState struct {
+	Running bool      // Started and not yet shut down cleanly
+	Since   time.Time // When the daemon started, or when it shut down
+}
+
+// MarkDaemonStarted records that the daemon is running, returning the state left by the
+// previous run so an unclean exit can be reported
+func (s *Storage) MarkDaemonStarted() (DaemonState, error) {
+	previous, err := s.DaemonState()
+	if err != nil {
+		return previous, err
+	}
+	return previous, s.setDaemonState(true)
+}
+
+// MarkCleanShutdown records that the daemon finished its writes and stopped
+func (s *Storage) MarkCleanShutdown() error {
+	return s.setDaemonState(false)
+}
+
+// DaemonState returns the last recorded daemon state; the zero state if no daemon
+// has run against this database
+func (s *Storage) DaemonState() (DaemonState, error) {
+	var state DaemonState
+	err := s.db.QueryRow("SELECT running, changed_at FROM daemon_state WHERE id = 1").Scan(&state.Running, &state.Since)
+	if err == sql.ErrNoRows {
+		return DaemonState{}, nil
+	}
+	return state, err
+}
+
+// setDaemonState replaces the recorded daemon state
+func (s *Storage) setDaemonState(running bool) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO daemon_state (id, running, changed_at) VALUES (1, ?, ?)",
+		running, time.Now())
+	return err
+}
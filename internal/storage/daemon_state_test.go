@@ -0,0 +1,56 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import "testing"
+
+func TestDaemonState(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	if state, err := storage.DaemonState(); err != nil || state.Running || !state.Since.IsZero() {
+		t.Fatalf("Expected no recorded daemon run, got %+v, %v", state, err)
+	}
+
+	previous, err := storage.MarkDaemonStarted()
+	if err != nil || previous.Running {
+		t.Fatalf("Expected a first start to follow no run, got %+v, %v", previous, err)
+	}
+	if state, _ := storage.DaemonState(); !state.Running || state.Since.IsZero() {
+		t.Errorf("Expected the daemon to be recorded as running, got %+v", state)
+	}
+
+	// Started again without a clean shutdown, as after a crash
+	previous, err = storage.MarkDaemonStarted()
+	if err != nil || !previous.Running {
+		t.Errorf("Expected the previous run to be reported as unclean, got %+v, %v", previous, err)
+	}
+
+	if err := storage.MarkCleanShutdown(); err != nil {
+		t.Fatalf("MarkCleanShutdown failed: %v", err)
+	}
+	if previous, _ := storage.MarkDaemonStarted(); previous.Running {
+		t.Error("Expected a clean shutdown not to be reported as unclean")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"crypto/sha256"
+	"sort"
+	"strings"
+)
+
+// Kinds of DuplicateCluster, from closest to loosest match
+const (
+	DuplicateExact      = "exact"      // identical content
+	DuplicateWhitespace = "whitespace" // same text apart from whitespace
+	DuplicatePrefix     = "prefix"     // text that starts another entry, e.g. a partial selection
+)
+
+// minPrefixLength keeps short text such as "ok" from being reported as the start of everything
+const minPrefixLength = 8
+
+// DuplicateCluster is a group of entries with near-identical content. Items are most
+// recent first, except prefix clusters, which start with the longest entry.
+type DuplicateCluster struct {
+	Kind  string
+	Items []ClipboardItemMeta
+}
+
+// Size returns the bytes the cluster's entries take up
+func (c DuplicateCluster) Size() int64 {
+	var size int64
+	for _, item := range c.Items {
+		size += item.Size
+	}
+	return size
+}
+
+// Reclaimable returns the bytes freed by keeping only the cluster's first entry
+func (c DuplicateCluster) Reclaimable() int64 {
+	return c.Size() - c.Items[0].Size
+}
+
+// DuplicateReport groups near-duplicate entries without changing anything. Every entry is
+// in at most one cluster of each kind.
+func (s *Storage) DuplicateReport() []DuplicateCluster {
+	items := s.GetAllMeta()
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Timestamp.After(items[j].Timestamp) })
+
+	var text []ClipboardItemMeta
+	for _, item := range items {
+		if item.ContentType == "text" {
+			text = append(text, item)
+		}
+	}
+
+	clusters := groupClusters(DuplicateExact, text, func(item ClipboardItemMeta) string { return item.Content })
+	clusters = append(clusters, s.exactImageClusters(items)...)
+	clusters = append(clusters, whitespaceClusters(text)...)
+	return append(clusters, prefixClusters(text)...)
+}
+
+// groupClusters groups items by key, keeping groups of two or more in first-seen order
+func groupClusters(kind string, items []ClipboardItemMeta, key func(ClipboardItemMeta) string) []DuplicateCluster {
+	groups := map[string][]ClipboardItemMeta{}
+	var order []string
+	for _, item := range items {
+		k := key(item)
+		if _, seen := groups[k]; !seen {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], item)
+	}
+
+	var clusters []DuplicateCluster
+	for _, k := range order {
+		if len(groups[k]) > 1 {
+			clusters = append(clusters, DuplicateCluster{Kind: kind, Items: groups[k]})
+		}
+	}
+	return clusters
+}
+
+// exactImageClusters groups images with identical data
+func (s *Storage) exactImageClusters(items []ClipboardItemMeta) []DuplicateCluster {
+	var images []ClipboardItemMeta
+	hashes := map[string]string{}
+	for _, item := range items {
+		if item.ContentType != "image" {
+			continue
+		}
+		hash := sha256.Sum256(s.GetImageData(item.ID))
+		hashes[item.ID] = string(hash[:])
+		images = append(images, item)
+	}
+	return groupClusters(DuplicateExact, images, func(item ClipboardItemMeta) string { return hashes[item.ID] })
+}
+
+// collapseWhitespace trims text and turns every run of whitespace into a single space
+func collapseWhitespace(content string) string {
+	return strings.Join(strings.Fields(content), " ")
+}
+
+// whitespaceClusters groups text that differs only in whitespace. Exact copies of one
+// variant are already reported, so at least two distinct variants are needed.
+func whitespaceClusters(text []ClipboardItemMeta) []DuplicateCluster {
+	var clusters []DuplicateCluster
+	for _, cluster := range groupClusters(DuplicateWhitespace, text, func(item ClipboardItemMeta) string {
+		return collapseWhitespace(item.Content)
+	}) {
+		for _, item := range cluster.Items[1:] {
+			if item.Content != cluster.Items[0].Content {
+				clusters = append(clusters, cluster)
+				break
+			}
+		}
+	}
+	return clusters
+}
+
+// prefixClusters groups text whose whitespace-collapsed form starts a longer entry. Sorted,
+// every extension of a key directly follows it, so each cluster is the run of keys after
+// its shortest one.
+func prefixClusters(text []ClipboardItemMeta) []DuplicateCluster {
+	byKey := map[string][]ClipboardItemMeta{}
+	for _, item := range text {
+		key := collapseWhitespace(item.Content)
+		if len(key) >= minPrefixLength {
+			byKey[key] = append(byKey[key], item)
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var clusters []DuplicateCluster
+	for i := 0; i < len(keys); {
+		j := i + 1
+		for j < len(keys) && strings.HasPrefix(keys[j], keys[i]) {
+			j++
+		}
+		if j-i > 1 {
+			var cluster DuplicateCluster
+			cluster.Kind = DuplicatePrefix
+			for k := j - 1; k >= i; k-- {
+				cluster.Items = append(cluster.Items, byKey[keys[k]]...)
+			}
+			sort.SliceStable(cluster.Items, func(a, b int) bool {
+				return len(cluster.Items[a].Content) > len(cluster.Items[b].Content)
+			})
+			clusters = append(clusters, cluster)
+		}
+		i = j
+	}
+	return clusters
+}
@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDuplicateReport(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	base := time.Now().Add(-time.Hour)
+	entries := []struct{ id, content, contentType string }{
+		{"exact1", "hello world", "text"},
+		{"exact2", "hello world", "text"},
+		{"space", "hello   world\n", "text"},
+		{"short", "go", "text"},
+		{"short2", "go build", "text"},
+		{"partial", "The quick brown", "text"},
+		{"full", "The quick brown fox jumps", "text"},
+		{"unique", "something else entirely", "text"},
+		{"img1", "Image", "image"},
+		{"img2", "Image", "image"},
+		{"img3", "Image", "image"},
+	}
+	for i, e := range entries {
+		var data []byte
+		if e.contentType == "image" {
+			data = []byte{1, 2, 3}
+			if e.id == "img3" {
+				data = []byte{4, 5, 6}
+			}
+		}
+		if err := storage.insertDirectly(e.id, e.content, e.contentType, data, base.Add(time.Duration(i)*time.Minute), "none", false); err != nil {
+			t.Fatalf("Failed to insert %s: %v", e.id, err)
+		}
+	}
+
+	describe := func(cluster DuplicateCluster) string {
+		s := cluster.Kind + ":"
+		for _, item := range cluster.Items {
+			s += " " + item.ID
+		}
+		return s
+	}
+	var got []string
+	for _, cluster := range storage.DuplicateReport() {
+		got = append(got, describe(cluster))
+	}
+	expected := []string{
+		"exact: exact2 exact1",
+		"exact: img2 img1",
+		"whitespace: space exact2 exact1",
+		"prefix: full partial",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected clusters:\n got %v\nwant %v", got, expected)
+	}
+
+	if count := storage.GetItemCount(); count != len(entries) {
+		t.Errorf("Expected the report to leave all %d entries, got %d", len(entries), count)
+	}
+}
+
+func TestDuplicateClusterSizes(t *testing.T) {
+	cluster := DuplicateCluster{Kind: DuplicateExact, Items: []ClipboardItemMeta{{Size: 10}, {Size: 10}, {Size: 10}}}
+	if cluster.Size() != 30 || cluster.Reclaimable() != 20 {
+		t.Errorf("Expected 30 bytes with 20 reclaimable, got %d and %d", cluster.Size(), cluster.Reclaimable())
+	}
+}
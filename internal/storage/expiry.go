@@ -0,0 +1,81 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+// otpTag marks one-time codes that expire after the OTP TTL
+const otpTag = "otp"
+
+// SetOTPExpiry makes one-time codes (see security.IsOTPCode) stored from now on
+// expire after ttl; DeleteExpired removes them. 0 keeps codes like any other text.
+func (s *Storage) SetOTPExpiry(ttl time.Duration) {
+	s.otpTTL = ttl
+}
+
+// expireIfOTP tags a just stored text item as a one-time code and sets its expiry
+func (s *Storage) expireIfOTP(id, content string) error {
+	if s.otpTTL <= 0 || !security.IsOTPCode(content) {
+		return nil
+	}
+	if _, err := s.db.Exec("UPDATE clipboard_items SET expires_at = ? WHERE id = ?", time.Now().Add(s.otpTTL), id); err != nil {
+		return fmt.Errorf("failed to set expiry: %w", err)
+	}
+	return s.AddTag(id, otpTag)
+}
+
+// DeleteExpired deletes unpinned items whose expiry has passed, returning how many were
+// deleted. Pinning a one-time code keeps it.
+func (s *Storage) DeleteExpired() (int, error) {
+	rows, err := s.db.Query("SELECT id FROM clipboard_items WHERE expires_at IS NOT NULL AND expires_at <= ? AND is_pinned = FALSE", time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired items: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		if err := s.Delete(id); err != nil {
+			return i, fmt.Errorf("failed to delete expired item: %w", err)
+		}
+	}
+	return len(ids), nil
+}
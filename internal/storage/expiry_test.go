@@ -0,0 +1,101 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOTPExpiry(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetOTPExpiry(2 * time.Minute)
+
+	storage.Add("123 456")
+	storage.Add("654321")
+	storage.Add("not a code")
+
+	var codeIDs []string
+	for _, item := range storage.GetAllMeta() {
+		tags := storage.GetTags(item.ID)
+		isCode := item.Content != "not a code"
+		if isCode != (len(tags) == 1 && tags[0] == otpTag) {
+			t.Errorf("%q: unexpected tags %q", item.Content, tags)
+		}
+		if isCode {
+			codeIDs = append(codeIDs, item.ID)
+		}
+	}
+
+	// Nothing has expired yet
+	if deleted, err := storage.DeleteExpired(); err != nil || deleted != 0 {
+		t.Fatalf("Expected nothing to expire yet, got %d (%v)", deleted, err)
+	}
+
+	past := time.Now().Add(-time.Second)
+	for _, id := range codeIDs {
+		if _, err := storage.db.Exec("UPDATE clipboard_items SET expires_at = ? WHERE id = ?", past, id); err != nil {
+			t.Fatalf("Failed to age item: %v", err)
+		}
+	}
+	storage.PinItem(codeIDs[0])
+
+	deleted, err := storage.DeleteExpired()
+	if err != nil {
+		t.Fatalf("DeleteExpired failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected the unpinned code to be deleted, got %d", deleted)
+	}
+	if count := storage.GetItemCount(); count != 2 {
+		t.Errorf("Expected the pinned code and the text to remain, got %d items", count)
+	}
+}
+
+func TestOTPExpiry_RecopyRestartsTimer(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetOTPExpiry(time.Minute)
+
+	storage.Add("246810")
+	id := storage.GetAllMeta()[0].ID
+	if _, err := storage.db.Exec("UPDATE clipboard_items SET expires_at = ? WHERE id = ?", time.Now().Add(-time.Second), id); err != nil {
+		t.Fatalf("Failed to age item: %v", err)
+	}
+
+	storage.Add("246810")
+	if deleted, _ := storage.DeleteExpired(); deleted != 0 {
+		t.Error("Expected copying the code again to restart its expiry")
+	}
+}
+
+func TestOTPExpiry_Disabled(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	storage.Add("123456")
+	id := storage.GetAllMeta()[0].ID
+	if tags := storage.GetTags(id); len(tags) != 0 {
+		t.Errorf("Expected codes not to be tagged without an OTP expiry, got %q", tags)
+	}
+}
@@ -0,0 +1,321 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/version"
+)
+
+// migration is one step of the history database schema, applied in a transaction
+type migration struct {
+	version     int
+	description string
+	apply       func(tx *sql.Tx) error
+}
+
+// migrations lists every schema change in order. Never edit or reorder released
+// steps; append a new one with the next version instead.
+//
+// Databases created before migrations were tracked already contain some of these
+// columns, so column additions are skipped when the column exists.
+var migrations = []migration{
+	{1, "create clipboard_items", execStatements(`
+		CREATE TABLE IF NOT EXISTS clipboard_items (
+			id TEXT PRIMARY KEY,
+			content TEXT NOT NULL,
+			timestamp DATETIME NOT NULL
+		)`)},
+	{2, "add image support", addColumns("clipboard_items",
+		"content_type TEXT DEFAULT 'text'",
+		"image_data BLOB")},
+	{3, "add security threat levels", addColumns("clipboard_items",
+		"threat_level TEXT DEFAULT 'none'",
+		"safe_entry BOOLEAN DEFAULT TRUE")},
+	{4, "add pinning", addColumns("clipboard_items",
+		"is_pinned BOOLEAN DEFAULT FALSE",
+		"pin_order INTEGER DEFAULT 0")},
+	{5, "add language override", addColumns("clipboard_items",
+		"language_override TEXT DEFAULT ''")},
+	{6, "add tags", addColumns("clipboard_items",
+		"tags TEXT DEFAULT ''")},
+	{7, "add list order indexes", execStatements(
+		"CREATE INDEX IF NOT EXISTS idx_clipboard_items_order ON clipboard_items (is_pinned DESC, pin_order ASC, timestamp DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_clipboard_items_threat_level ON clipboard_items (threat_level, is_pinned DESC, pin_order ASC, timestamp DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_clipboard_items_content_type ON clipboard_items (content_type, is_pinned DESC, pin_order ASC, timestamp DESC)")},
+	{8, "create archived_items", execStatements(`
+		CREATE TABLE IF NOT EXISTS archived_items (
+			id TEXT PRIMARY KEY,
+			content TEXT NOT NULL,
+			content_type TEXT DEFAULT 'text',
+			image_data BLOB,
+			timestamp DATETIME NOT NULL,
+			threat_level TEXT DEFAULT 'none',
+			safe_entry BOOLEAN DEFAULT TRUE,
+			archived_at DATETIME NOT NULL
+		)`)},
+	{9, "create self_copy", execStatements(`
+		CREATE TABLE IF NOT EXISTS self_copy (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			hash TEXT NOT NULL,
+			copied_at DATETIME NOT NULL
+		)`)},
+	{10, "add capture source", addColumns("clipboard_items",
+		"source_app TEXT DEFAULT ''",
+		"source_title TEXT DEFAULT ''",
+		"source_selection TEXT DEFAULT ''")},
+	{11, "add item expiry", addColumns("clipboard_items",
+		"expires_at DATETIME")},
+	{12, "add link titles", addColumns("clipboard_items",
+		"title TEXT DEFAULT ''")},
+	{13, "create pending_items", execStatements(`
+		CREATE TABLE IF NOT EXISTS pending_items (
+			id TEXT PRIMARY KEY,
+			content TEXT NOT NULL,
+			content_type TEXT DEFAULT 'text',
+			image_data BLOB,
+			timestamp DATETIME NOT NULL,
+			source_app TEXT DEFAULT '',
+			source_title TEXT DEFAULT '',
+			source_selection TEXT DEFAULT ''
+		)`)},
+	{14, "add quarantine reasons", addColumns("pending_items",
+		"reason TEXT DEFAULT ''")},
+	{15, "create daemon_state", execStatements(`
+		CREATE TABLE IF NOT EXISTS daemon_state (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			running BOOLEAN NOT NULL,
+			changed_at DATETIME NOT NULL
+		)`)},
+	{16, "create writer_version", execStatements(`
+		CREATE TABLE IF NOT EXISTS writer_version (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			version TEXT NOT NULL,
+			schema_version INTEGER NOT NULL,
+			written_at DATETIME NOT NULL
+		)`)},
+	{17, "add text languages", addTextLanguages},
+	{18, "add moved copies", addColumns("self_copy",
+		"moved BOOLEAN DEFAULT FALSE")},
+}
+
+// SchemaVersion is the schema version this build of nclip creates and understands
+var SchemaVersion = migrations[len(migrations)-1].version
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer nclip. Opening
+// it with an older binary could silently lose or corrupt the columns it doesn't know.
+type ErrSchemaTooNew struct {
+	Version   int    // Version found in the database
+	WrittenBy string // nclip version that last wrote the database, "" if not recorded
+}
+
+func (e *ErrSchemaTooNew) Error() string {
+	if e.WrittenBy == "" {
+		return fmt.Sprintf("database schema version %d was written by a newer nclip, but this nclip (%s) only "+
+			"supports version %d; install the newer nclip again, or restore a backup of the database made "+
+			"before the upgrade", e.Version, writerVersion(), SchemaVersion)
+	}
+	return fmt.Sprintf("database schema version %d was written by nclip %s, but this nclip (%s) only supports "+
+		"version %d; install nclip %s or later again, or restore a backup of the database made before the upgrade",
+		e.Version, e.WrittenBy, writerVersion(), SchemaVersion, e.WrittenBy)
+}
+
+// writerVersion names this build in the writer record, "dev" for builds without a version
+func writerVersion() string {
+	if version.Version == "" {
+		return "dev"
+	}
+	return version.Version
+}
+
+// migrate brings the schema up to date, returning the descriptions of the steps applied
+func (s *Storage) migrate() ([]string, error) {
+	if _, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)`); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	current, err := s.schemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if current > SchemaVersion {
+		return nil, &ErrSchemaTooNew{Version: current, WrittenBy: s.lastWriter()}
+	}
+
+	// Steps run on a handle whose transactions begin with BEGIN IMMEDIATE. When the TUI
+	// and the daemon migrate at the same time, the second then waits for the first one's
+	// write lock, instead of failing to upgrade its read lock or adding a column twice.
+	var locking *sql.DB
+	if current < SchemaVersion {
+		locking, err = sql.Open("sqlite3", s.path+"?_txlock=immediate")
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database for migration: %w", err)
+		}
+		defer locking.Close()
+	}
+
+	var applied []string
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		ran, err := applyMigration(locking, m)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", m.version, m.description, err)
+		}
+		if ran {
+			applied = append(applied, fmt.Sprintf("%d: %s", m.version, m.description))
+		}
+	}
+
+	if writer := s.lastWriter(); writer != writerVersion() {
+		s.previousWriter = writer
+	}
+	if _, err := s.db.Exec("INSERT OR REPLACE INTO writer_version (id, version, schema_version, written_at) VALUES (1, ?, ?, ?)",
+		writerVersion(), SchemaVersion, time.Now()); err != nil {
+		return applied, fmt.Errorf("failed to record nclip version: %w", err)
+	}
+	return applied, nil
+}
+
+// lastWriter returns the nclip version recorded by the last binary that opened the
+// database, "" when none was recorded
+func (s *Storage) lastWriter() string {
+	var writer string
+	if err := s.db.QueryRow("SELECT version FROM writer_version WHERE id = 1").Scan(&writer); err != nil {
+		return ""
+	}
+	return writer
+}
+
+// PreviousWriter returns the nclip version that opened the database before this one if
+// it was a different version; "" when it was the same, for a new database, or for one
+// last opened by a release that didn't record it
+func (s *Storage) PreviousWriter() string {
+	return s.previousWriter
+}
+
+// applyMigration runs one step on db and records it in the same transaction. It reports
+// false when another process (the TUI or the daemon) applied the step first.
+func applyMigration(db *sql.DB, m migration) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var done int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE version = ?", m.version).Scan(&done); err != nil {
+		return false, err
+	}
+	if done > 0 {
+		return false, nil
+	}
+
+	if err := m.apply(tx); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)",
+		m.version, m.description, time.Now()); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// schemaVersion returns the highest applied migration, 0 for a new database
+func (s *Storage) schemaVersion() (int, error) {
+	var version sql.NullInt64
+	if err := s.db.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// AppliedMigrations returns the migrations applied when the database was opened
+func (s *Storage) AppliedMigrations() []string {
+	return s.appliedMigrations
+}
+
+// execStatements returns a migration step running the given statements
+func execStatements(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// addColumns returns a migration step adding columns ("name TYPE ...") that are missing from table
+func addColumns(table string, columns ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		existing, err := tableColumns(tx, table)
+		if err != nil {
+			return err
+		}
+		for _, column := range columns {
+			var name string
+			fmt.Sscan(column, &name)
+			if existing[name] {
+				continue
+			}
+			if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, column)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// tableColumns returns the column names of table
+func tableColumns(tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
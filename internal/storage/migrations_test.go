@@ -0,0 +1,215 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrate_NewDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+
+	store, err := Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if len(store.AppliedMigrations()) != len(migrations) {
+		t.Errorf("Expected all %d migrations, got %v", len(migrations), store.AppliedMigrations())
+	}
+	if version, _ := store.schemaVersion(); version != SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", SchemaVersion, version)
+	}
+	store.Close()
+
+	store, err = Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer store.Close()
+	if len(store.AppliedMigrations()) != 0 {
+		t.Errorf("Expected no migrations on reopen, got %v", store.AppliedMigrations())
+	}
+}
+
+func TestMigrate_UntrackedLegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+
+	// A database from before content types, with one entry
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("CREATE TABLE clipboard_items (id TEXT PRIMARY KEY, content TEXT NOT NULL, timestamp DATETIME NOT NULL)"); err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	if _, err := db.Exec("ALTER TABLE clipboard_items ADD COLUMN content_type TEXT DEFAULT 'text'"); err != nil {
+		t.Fatalf("Failed to add legacy column: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO clipboard_items (id, content, timestamp) VALUES ('1', 'legacy entry', ?)", time.Now()); err != nil {
+		t.Fatalf("Failed to insert legacy entry: %v", err)
+	}
+	db.Close()
+
+	store, err := Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed on legacy database: %v", err)
+	}
+	defer store.Close()
+
+	items := store.GetAll()
+	if len(items) != 1 || items[0].Content != "legacy entry" || items[0].ThreatLevel != "none" {
+		t.Errorf("Expected the legacy entry with default columns, got %+v", items)
+	}
+	if err := store.SetTags("1", []string{"old"}); err != nil {
+		t.Errorf("Expected tags column to be added: %v", err)
+	}
+}
+
+func TestMigrate_NewerSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+
+	store, err := Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := store.db.Exec("INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, 'from the future', ?)", SchemaVersion+1, time.Now()); err != nil {
+		t.Fatalf("Failed to record future migration: %v", err)
+	}
+	if _, err := store.db.Exec("UPDATE writer_version SET version = 'v9.9.9', schema_version = ?", SchemaVersion+1); err != nil {
+		t.Fatalf("Failed to record future writer: %v", err)
+	}
+	store.Close()
+
+	_, err = Open(dbPath, 10)
+	var tooNew *ErrSchemaTooNew
+	if !errors.As(err, &tooNew) {
+		t.Fatalf("Expected ErrSchemaTooNew, got %v", err)
+	}
+	if tooNew.Version != SchemaVersion+1 {
+		t.Errorf("Expected version %d in error, got %d", SchemaVersion+1, tooNew.Version)
+	}
+	if tooNew.WrittenBy != "v9.9.9" || !strings.Contains(err.Error(), "install nclip v9.9.9 or later") {
+		t.Errorf("Expected the error to name the newer nclip, got %v", err)
+	}
+}
+
+func TestMigrate_RecordsWriter(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+
+	store, err := Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if writer := store.PreviousWriter(); writer != "" {
+		t.Errorf("Expected no previous writer for a new database, got %q", writer)
+	}
+	if _, err := store.db.Exec("UPDATE writer_version SET version = 'v0.1.0'"); err != nil {
+		t.Fatalf("Failed to record an older writer: %v", err)
+	}
+	store.Close()
+
+	store, err = Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+	if writer := store.PreviousWriter(); writer != "v0.1.0" {
+		t.Errorf("Expected the older writer to be reported, got %q", writer)
+	}
+	if writer := store.lastWriter(); writer != writerVersion() {
+		t.Errorf("Expected this build to be recorded as the writer, got %q", writer)
+	}
+}
+
+func TestMigrations_Ordered(t *testing.T) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("Migration %q has version %d, expected %d", m.description, m.version, i+1)
+		}
+	}
+}
+
+func TestMigrate_DetectsTextLanguages(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+
+	// A database from before text languages were stored
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("CREATE TABLE clipboard_items (id TEXT PRIMARY KEY, content TEXT NOT NULL, timestamp DATETIME NOT NULL)"); err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO clipboard_items (id, content, timestamp) VALUES ('1', 'Das ist nicht mein Fahrrad, aber ich kann es dir leihen.', ?)", time.Now()); err != nil {
+		t.Fatalf("Failed to insert legacy entry: %v", err)
+	}
+	db.Close()
+
+	store, err := Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed on legacy database: %v", err)
+	}
+	defer store.Close()
+
+	if meta := store.GetMeta("1"); meta == nil || meta.TextLanguage != "de" {
+		t.Errorf("Expected the legacy entry to be detected as German, got %+v", meta)
+	}
+}
+
+func TestMigrate_ConcurrentOpen(t *testing.T) {
+	// The TUI and the daemon often open a new or outdated database at the same moment
+	for round := 0; round < 10; round++ {
+		dbPath := filepath.Join(t.TempDir(), "history.db")
+
+		const openers = 4
+		errs := make(chan error, openers)
+		for i := 0; i < openers; i++ {
+			go func() {
+				store, err := Open(dbPath, 10)
+				if err == nil {
+					store.Close()
+				}
+				errs <- err
+			}()
+		}
+		for i := 0; i < openers; i++ {
+			if err := <-errs; err != nil {
+				t.Fatalf("Concurrent Open failed: %v", err)
+			}
+		}
+	}
+}
+
+func TestErrSchemaTooNew_UnknownWriter(t *testing.T) {
+	message := (&ErrSchemaTooNew{Version: SchemaVersion + 1}).Error()
+	if !strings.Contains(message, "install the newer nclip again") || strings.Contains(message, "or later again") {
+		t.Errorf("Unexpected message for an unrecorded writer: %s", message)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/enrich"
+)
+
+// NormalizeOptions controls how text is cleaned up before it is stored, so the same
+// text copied from editors with different conventions deduplicates to one entry
+type NormalizeOptions struct {
+	TrimTrailingWhitespace bool // Remove trailing spaces and tabs from each line and trailing blank lines
+	NormalizeLineEndings   bool // Convert \r\n and \r line endings to \n
+	StripQuotes            bool // Remove a pair of quotes enclosing the whole text
+	StripTrackingParams    bool // Remove utm_* and other tracking parameters when the text is a link
+}
+
+// quotePairs maps opening quotes to their closing counterpart
+var quotePairs = map[rune]rune{
+	'"':  '"',
+	'\'': '\'',
+	'`':  '`',
+	'“':  '”',
+	'‘':  '’',
+}
+
+// SetNormalization sets the cleanup applied to text added to the history
+func (s *Storage) SetNormalization(opts NormalizeOptions) {
+	s.normalize = opts
+}
+
+// Apply returns content with the enabled normalizations applied
+func (o NormalizeOptions) Apply(content string) string {
+	if o.NormalizeLineEndings {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+		content = strings.ReplaceAll(content, "\r", "\n")
+	}
+
+	if o.TrimTrailingWhitespace {
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t\r")
+		}
+		content = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	}
+
+	if o.StripQuotes {
+		content = stripEnclosingQuotes(content)
+	}
+
+	if o.StripTrackingParams {
+		if link, ok := enrich.LinkURL(content); ok {
+			if stripped, removed := enrich.StripTracking(link); removed > 0 {
+				content = stripped
+			}
+		}
+	}
+
+	return content
+}
+
+// stripEnclosingQuotes removes quotes around the whole text, but only when the quote
+// character doesn't also appear inside, so `"a" and "b"` is left alone
+func stripEnclosingQuotes(content string) string {
+	trimmed := strings.TrimSpace(content)
+	runes := []rune(trimmed)
+	if len(runes) < 2 {
+		return content
+	}
+
+	closing, ok := quotePairs[runes[0]]
+	if !ok || runes[len(runes)-1] != closing {
+		return content
+	}
+	inner := string(runes[1 : len(runes)-1])
+	if strings.ContainsRune(inner, runes[0]) || strings.ContainsRune(inner, closing) {
+		return content
+	}
+	return inner
+}
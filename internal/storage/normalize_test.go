@@ -0,0 +1,74 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import "testing"
+
+func TestNormalizeOptions_Apply(t *testing.T) {
+	all := NormalizeOptions{TrimTrailingWhitespace: true, NormalizeLineEndings: true, StripQuotes: true}
+	tests := []struct {
+		name     string
+		opts     NormalizeOptions
+		input    string
+		expected string
+	}{
+		{"disabled", NormalizeOptions{}, "a  \r\n\"b\"", "a  \r\n\"b\""},
+		{"line endings", NormalizeOptions{NormalizeLineEndings: true}, "a\r\nb\rc", "a\nb\nc"},
+		{"trailing whitespace", NormalizeOptions{TrimTrailingWhitespace: true}, "a \t\nb  \n\n", "a\nb"},
+		{"leading whitespace kept", NormalizeOptions{TrimTrailingWhitespace: true}, "  indented", "  indented"},
+		{"quotes", NormalizeOptions{StripQuotes: true}, `"value"`, "value"},
+		{"typographic quotes", NormalizeOptions{StripQuotes: true}, "“value”", "value"},
+		{"inner quotes kept", NormalizeOptions{StripQuotes: true}, `"a" and "b"`, `"a" and "b"`},
+		{"unbalanced quotes kept", NormalizeOptions{StripQuotes: true}, `"value'`, `"value'`},
+		{"tracking parameters", NormalizeOptions{StripTrackingParams: true}, "https://example.com/?utm_source=x&id=1", "https://example.com/?id=1"},
+		{"tracking in text kept", NormalizeOptions{StripTrackingParams: true}, "see https://example.com/?utm_source=x", "see https://example.com/?utm_source=x"},
+		{"combined", all, "'line one  \r\nline two'\r\n", "line one\nline two"},
+	}
+	for _, test := range tests {
+		if got := test.opts.Apply(test.input); got != test.expected {
+			t.Errorf("%s: Apply(%q) = %q, expected %q", test.name, test.input, got, test.expected)
+		}
+	}
+}
+
+func TestAddWithType_Normalization(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetNormalization(NormalizeOptions{TrimTrailingWhitespace: true, NormalizeLineEndings: true})
+
+	if err := storage.Add("first line\r\nsecond line  \r\n"); err != nil {
+		t.Fatalf("Failed to add content: %v", err)
+	}
+	if err := storage.Add("first line\nsecond line"); err != nil {
+		t.Fatalf("Failed to add content: %v", err)
+	}
+
+	items := storage.GetAll()
+	if len(items) != 1 {
+		t.Fatalf("Expected the two copies to deduplicate to one entry, got %d", len(items))
+	}
+	if items[0].Content != "first line\nsecond line" {
+		t.Errorf("Expected normalized content to be stored, got %q", items[0].Content)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+// NoteTag marks entries typed in by hand (nclip --note or N in the TUI) rather than copied
+const NoteTag = "note"
+
+// ErrEmptyNote is returned when a note has no text
+var ErrEmptyNote = errors.New("empty note")
+
+// AddNote stores manually typed text as a history entry tagged NoteTag and returns its
+// ID. Text that is already in the history is tagged and moved to the top instead.
+func (s *Storage) AddNote(content string) (string, error) {
+	if strings.TrimSpace(content) == "" {
+		return "", ErrEmptyNote
+	}
+	if err := s.Add(content); err != nil {
+		return "", err
+	}
+	id, err := s.findDuplicate(s.normalize.Apply(content), "text", nil)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", errors.New("note was not stored")
+	}
+	return id, s.AddTag(id, NoteTag)
+}
@@ -0,0 +1,65 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddNote(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	id, err := storage.AddNote("  call the plumber  ")
+	if err != nil {
+		t.Fatalf("AddNote failed: %v", err)
+	}
+	meta := storage.GetMeta(id)
+	if meta == nil || meta.Content != "  call the plumber  " {
+		t.Fatalf("Expected the note to be stored as typed, got %+v", meta)
+	}
+	if tags := storage.GetTags(id); len(tags) != 1 || tags[0] != NoteTag {
+		t.Errorf("Expected the note tag, got %v", tags)
+	}
+
+	// Existing text is tagged rather than duplicated
+	if err := storage.Add("already copied"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	again, err := storage.AddNote("already copied")
+	if err != nil {
+		t.Fatalf("AddNote failed: %v", err)
+	}
+	if storage.GetItemCount() != 2 {
+		t.Errorf("Expected the existing entry to be reused, got %d entries", storage.GetItemCount())
+	}
+	if tags := storage.GetTags(again); len(tags) != 1 || tags[0] != NoteTag {
+		t.Errorf("Expected the existing entry to be tagged, got %v", tags)
+	}
+
+	if _, err := storage.AddNote(" \n"); !errors.Is(err, ErrEmptyNote) {
+		t.Errorf("Expected ErrEmptyNote for blank text, got %v", err)
+	}
+}
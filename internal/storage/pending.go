@@ -0,0 +1,173 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// QuarantineTTL is how long content blocked by the capture policy stays in quarantine
+// before it is discarded
+const QuarantineTTL = time.Hour
+
+// PendingItem is captured content waiting for the user to keep or discard it before it
+// enters the history: either held back by capture mode "ask", or quarantined after the
+// capture policy blocked it
+type PendingItem struct {
+	ID          string
+	Content     string
+	ContentType string
+	ImageData   []byte
+	Timestamp   time.Time
+	Source      Source
+	Reason      string // Why the capture policy blocked the item, empty unless quarantined
+}
+
+// Quarantined reports whether the capture policy blocked the item
+func (p PendingItem) Quarantined() bool {
+	return p.Reason != ""
+}
+
+// pendingColumns are the pending_items columns read by scanPending
+const pendingColumns = "id, content, content_type, image_data, timestamp, source_app, source_title, source_selection, reason"
+
+// AddPending holds captured content back from the history until ApprovePending or
+// RejectPending is called, returning the ID of the pending item
+func (s *Storage) AddPending(content, contentType string, imageData []byte, source Source) (string, error) {
+	return s.addPending(content, contentType, imageData, source, "")
+}
+
+// Quarantine keeps content the capture policy blocked for QuarantineTTL, so a false
+// positive can be rescued with ApprovePending, returning the ID of the pending item
+func (s *Storage) Quarantine(content, contentType string, imageData []byte, source Source, reason string) (string, error) {
+	if reason == "" {
+		reason = "blocked"
+	}
+	return s.addPending(content, contentType, imageData, source, reason)
+}
+
+func (s *Storage) addPending(content, contentType string, imageData []byte, source Source, reason string) (string, error) {
+	id := fmt.Sprintf("%d", time.Now().UnixNano())
+	query := "INSERT INTO pending_items (" + pendingColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	if _, err := s.db.Exec(query, id, content, contentType, imageData, time.Now(), source.App, source.Title, source.Selection, reason); err != nil {
+		return "", fmt.Errorf("failed to store pending item: %w", err)
+	}
+	return id, nil
+}
+
+// GetPending returns the pending and quarantined items, oldest first
+func (s *Storage) GetPending() ([]PendingItem, error) {
+	rows, err := s.db.Query("SELECT " + pendingColumns + " FROM pending_items ORDER BY timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []PendingItem
+	for rows.Next() {
+		item, err := scanPending(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pending item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// PendingCount returns how many items held back by capture mode "ask" are waiting to be
+// kept or discarded
+func (s *Storage) PendingCount() int {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM pending_items WHERE reason = ''").Scan(&count); err != nil {
+		return 0
+	}
+	return count
+}
+
+// scanPending reads one row of pending_items
+func scanPending(row interface{ Scan(...interface{}) error }) (PendingItem, error) {
+	var item PendingItem
+	var app, title, selection, reason sql.NullString
+	err := row.Scan(&item.ID, &item.Content, &item.ContentType, &item.ImageData, &item.Timestamp, &app, &title, &selection, &reason)
+	item.Source = Source{App: app.String, Title: title.String, Selection: selection.String}
+	item.Reason = reason.String
+	return item, err
+}
+
+// ApprovePending stores a pending item in the history like newly copied content
+func (s *Storage) ApprovePending(id string) error {
+	row := s.db.QueryRow("SELECT "+pendingColumns+" FROM pending_items WHERE id = ?", id)
+	item, err := scanPending(row)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("pending item %s not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read pending item: %w", err)
+	}
+
+	if err := s.AddWithSource(item.Content, item.ContentType, item.ImageData, item.Source); err != nil {
+		return err
+	}
+	return s.RejectPending(id)
+}
+
+// RejectPending discards a pending item, shredding it when deleted entries are shredded
+func (s *Storage) RejectPending(id string) error {
+	if s.shred {
+		return s.shredDelete("pending_items", id)
+	}
+	_, err := s.db.Exec("DELETE FROM pending_items WHERE id = ?", id)
+	return err
+}
+
+// DeleteExpiredPending discards pending items older than maxAge and quarantined items
+// older than QuarantineTTL, returning how many were removed
+func (s *Storage) DeleteExpiredPending(maxAge time.Duration) (int, error) {
+	now := time.Now()
+	rows, err := s.db.Query("SELECT id FROM pending_items WHERE (reason = '' AND timestamp < ?) OR (reason != '' AND timestamp < ?)",
+		now.Add(-maxAge), now.Add(-QuarantineTTL))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired pending items: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	for i, id := range ids {
+		if err := s.RejectPending(id); err != nil {
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,120 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPending_ApproveAndReject(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	keepID, err := storage.AddPending("keep me", "text", nil, Source{App: "firefox"})
+	if err != nil {
+		t.Fatalf("AddPending failed: %v", err)
+	}
+	dropID, err := storage.AddPending("drop me", "text", nil, Source{})
+	if err != nil {
+		t.Fatalf("AddPending failed: %v", err)
+	}
+	if storage.GetItemCount() != 0 || storage.PendingCount() != 2 {
+		t.Fatalf("Expected pending items to stay out of the history, got %d items and %d pending",
+			storage.GetItemCount(), storage.PendingCount())
+	}
+
+	if err := storage.ApprovePending(keepID); err != nil {
+		t.Fatalf("ApprovePending failed: %v", err)
+	}
+	if err := storage.RejectPending(dropID); err != nil {
+		t.Fatalf("RejectPending failed: %v", err)
+	}
+
+	items := storage.GetAllMeta()
+	if len(items) != 1 || items[0].Content != "keep me" {
+		t.Fatalf("Expected only the approved item in the history, got %+v", items)
+	}
+	if source := storage.GetSource(items[0].ID); source.App != "firefox" {
+		t.Errorf("Expected the source to be kept, got %+v", source)
+	}
+	if storage.PendingCount() != 0 {
+		t.Errorf("Expected no pending items left, got %d", storage.PendingCount())
+	}
+	if err := storage.ApprovePending(keepID); err == nil {
+		t.Error("Expected an error approving an item that is no longer pending")
+	}
+}
+
+func TestPending_Expiry(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	oldID, _ := storage.AddPending("old", "text", nil, Source{})
+	if _, err := storage.db.Exec("UPDATE pending_items SET timestamp = ? WHERE id = ?", time.Now().Add(-2*time.Hour), oldID); err != nil {
+		t.Fatalf("Failed to age pending item: %v", err)
+	}
+	storage.AddPending("new", "text", nil, Source{})
+
+	removed, err := storage.DeleteExpiredPending(time.Hour)
+	if err != nil || removed != 1 {
+		t.Fatalf("Expected 1 expired item, got %d (%v)", removed, err)
+	}
+	pending, err := storage.GetPending()
+	if err != nil || len(pending) != 1 || pending[0].Content != "new" {
+		t.Errorf("Expected only the new item pending, got %+v (%v)", pending, err)
+	}
+}
+
+func TestPending_Quarantine(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	id, err := storage.Quarantine("123456", "text", nil, Source{App: "keepassxc"}, "ignore rule otp")
+	if err != nil {
+		t.Fatalf("Quarantine failed: %v", err)
+	}
+	storage.AddPending("asked", "text", nil, Source{})
+	if storage.PendingCount() != 1 {
+		t.Errorf("Expected quarantined items to be left out of the pending count, got %d", storage.PendingCount())
+	}
+
+	pending, err := storage.GetPending()
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("Expected 2 items, got %+v (%v)", pending, err)
+	}
+	if !pending[0].Quarantined() || pending[0].Reason != "ignore rule otp" || pending[1].Quarantined() {
+		t.Errorf("Expected only the first item quarantined, got %+v", pending)
+	}
+
+	// Quarantine expires after QuarantineTTL even when pending items are kept longer
+	if _, err := storage.db.Exec("UPDATE pending_items SET timestamp = ?", time.Now().Add(-QuarantineTTL-time.Minute)); err != nil {
+		t.Fatalf("Failed to age pending items: %v", err)
+	}
+	removed, err := storage.DeleteExpiredPending(24 * time.Hour)
+	if err != nil || removed != 1 {
+		t.Fatalf("Expected only the quarantined item to expire, got %d (%v)", removed, err)
+	}
+	if err := storage.ApprovePending(id); err == nil {
+		t.Error("Expected an error rescuing an expired item")
+	}
+}
@@ -0,0 +1,131 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// databaseFileSuffixes are the files SQLite keeps next to the database
+var databaseFileSuffixes = []string{"", "-wal", "-shm", "-journal"}
+
+// checkForeign refuses databases that belong to another application, so a wrong
+// path never gets nclip tables added to it
+func (s *Storage) checkForeign() error {
+	rows, err := s.db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return fmt.Errorf("not a readable SQLite database: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == "clipboard_items" || name == "schema_migrations" {
+			return nil
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(tables) > 0 {
+		sort.Strings(tables)
+		return fmt.Errorf("not an nclip history database (found tables: %s)", strings.Join(tables, ", "))
+	}
+	return nil
+}
+
+// Path returns the location of the database file
+func (s *Storage) Path() string {
+	return s.path
+}
+
+// PermissionWarnings describes ways the database or its directory can be read or
+// changed by other users. Clipboard history often contains passwords and tokens.
+func (s *Storage) PermissionWarnings() []string {
+	var warnings []string
+	dir := filepath.Dir(s.path)
+	if info, err := os.Stat(dir); err == nil {
+		if mode := info.Mode().Perm(); mode&0002 != 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is writable by all users (mode %04o)", dir, mode))
+		}
+		if uid, ok := fileOwner(info); ok && uid != os.Getuid() {
+			warnings = append(warnings, fmt.Sprintf("%s is owned by another user (uid %d)", dir, uid))
+		}
+	}
+
+	for _, suffix := range databaseFileSuffixes {
+		path := s.path + suffix
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		mode := info.Mode().Perm()
+		switch {
+		case mode&0006 != 0:
+			warnings = append(warnings, fmt.Sprintf("%s is readable by all users (mode %04o)", path, mode))
+		case mode&0060 != 0:
+			warnings = append(warnings, fmt.Sprintf("%s is accessible by its group (mode %04o)", path, mode))
+		}
+		if uid, ok := fileOwner(info); ok && uid != os.Getuid() {
+			warnings = append(warnings, fmt.Sprintf("%s is owned by another user (uid %d)", path, uid))
+		}
+	}
+	return warnings
+}
+
+// SecurePermissions restricts the database directory to 0700 and the database files to 0600
+func (s *Storage) SecurePermissions() error {
+	if err := os.Chmod(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	for _, suffix := range databaseFileSuffixes {
+		if err := os.Chmod(s.path+suffix, 0600); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// createPrivate creates a missing database file readable only by its owner, as
+// SQLite would otherwise create it with the umask (usually world-readable)
+func createPrivate(path string) error {
+	file, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
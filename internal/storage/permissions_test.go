@@ -0,0 +1,117 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpen_CreatesPrivateDatabase(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nclip")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	store, err := Open(filepath.Join(dir, "history.db"), 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+
+	info, err := os.Stat(store.Path())
+	if err != nil {
+		t.Fatalf("Failed to stat database: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("Expected new database with mode 0600, got %04o", mode)
+	}
+	if warnings := store.PermissionWarnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
+
+func TestPermissionWarnings_SecurePermissions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nclip")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	store, err := Open(filepath.Join(dir, "history.db"), 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+
+	if err := os.Chmod(dir, 0777); err != nil {
+		t.Fatalf("Failed to chmod dir: %v", err)
+	}
+	if err := os.Chmod(store.Path(), 0644); err != nil {
+		t.Fatalf("Failed to chmod database: %v", err)
+	}
+	warnings := store.PermissionWarnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Expected warnings for the directory and the database, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "writable by all users") || !strings.Contains(warnings[1], "readable by all users") {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+
+	if err := store.SecurePermissions(); err != nil {
+		t.Fatalf("SecurePermissions failed: %v", err)
+	}
+	if warnings := store.PermissionWarnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings after SecurePermissions, got %v", warnings)
+	}
+	if info, _ := os.Stat(dir); info.Mode().Perm() != 0700 {
+		t.Errorf("Expected directory mode 0700, got %04o", info.Mode().Perm())
+	}
+}
+
+func TestOpen_RefusesForeignDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "places.sqlite")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	db.Close()
+
+	_, err = Open(dbPath, 10)
+	if err == nil || !strings.Contains(err.Error(), "not an nclip history database (found tables: moz_places)") {
+		t.Fatalf("Expected foreign database error, got %v", err)
+	}
+
+	notDB := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(notDB, []byte(strings.Repeat("plain text, not SQLite\n", 10)), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := Open(notDB, 10); err == nil {
+		t.Error("Expected an error opening a file that is not a database")
+	}
+}
@@ -0,0 +1,332 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/textlang"
+)
+
+// Query is a search box query split into field terms such as type:image or
+// before:2025-01-01, which are applied in SQL, and free text for the fuzzy search
+type Query struct {
+	Text  string
+	Terms []QueryTerm
+}
+
+// QueryTerm is a single field:value condition, negated by a leading '-'
+type QueryTerm struct {
+	Field  string
+	Value  string
+	Negate bool
+	Time   time.Time // Bound for before and after
+	Size   int64     // Bound in bytes for size, compared using the operator in Value
+}
+
+// queryFields lists the supported fields; anything else with a colon (e.g. a URL) is free text
+var queryFields = map[string]bool{
+	"type":    true,
+	"threat":  true,
+	"tag":     true,
+	"app":     true,
+	"before":  true,
+	"after":   true,
+	"lang":    true,
+	"natlang": true,
+	"pinned":  true,
+	"safe":    true,
+	"size":    true,
+}
+
+// ParseQuery parses a search box query. Invalid terms are reported in the error and
+// left out of the returned query, so the rest of the query still applies.
+func ParseQuery(input string) (Query, error) {
+	return parseQueryAt(input, time.Now())
+}
+
+func parseQueryAt(input string, now time.Time) (Query, error) {
+	var query Query
+	var text []string
+	var problems []string
+
+	for _, token := range tokenizeQuery(input) {
+		body := token
+		negate := false
+		if len(body) > 1 && body[0] == '-' {
+			body, negate = body[1:], true
+		}
+
+		field, value, found := strings.Cut(body, ":")
+		field = strings.ToLower(field)
+		if !found || !queryFields[field] {
+			text = append(text, token)
+			continue
+		}
+		if value == "" {
+			continue // Still being typed
+		}
+
+		term, err := parseQueryTerm(field, value, now)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		term.Negate = negate
+		query.Terms = append(query.Terms, term)
+	}
+
+	query.Text = strings.Join(text, " ")
+	if len(problems) > 0 {
+		return query, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return query, nil
+}
+
+// tokenizeQuery splits on whitespace, keeping double quoted values together
+func tokenizeQuery(input string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// parseQueryTerm validates and normalizes the value of a field term
+func parseQueryTerm(field, value string, now time.Time) (QueryTerm, error) {
+	term := QueryTerm{Field: field, Value: strings.ToLower(value)}
+
+	switch field {
+	case "type":
+		if term.Value != "text" && term.Value != "image" {
+			return term, fmt.Errorf("type must be text or image")
+		}
+	case "threat":
+		switch term.Value {
+		case "none", "low", "medium", "high":
+		default:
+			return term, fmt.Errorf("threat must be none, low, medium or high")
+		}
+	case "pinned", "safe":
+		switch term.Value {
+		case "yes", "true":
+			term.Value = "true"
+		case "no", "false":
+			term.Value = "false"
+		default:
+			return term, fmt.Errorf("%s must be yes or no", field)
+		}
+	case "natlang":
+		code, ok := textlang.Lookup(term.Value)
+		if !ok {
+			return term, fmt.Errorf("natlang must be one of %s, or a language name", strings.Join(textlang.Codes(), ", "))
+		}
+		term.Value = code
+	case "tag":
+		tag, err := NormalizeTag(value)
+		if err != nil {
+			return term, err
+		}
+		term.Value = tag
+	case "size":
+		op, size, err := parseQuerySize(term.Value)
+		if err != nil {
+			return term, fmt.Errorf("size: %w", err)
+		}
+		term.Value, term.Size = op, size
+	case "before", "after":
+		t, err := parseQueryTime(term.Value, now)
+		if err != nil {
+			return term, fmt.Errorf("%s: %w", field, err)
+		}
+		term.Time = t
+	}
+	return term, nil
+}
+
+// parseQueryTime accepts a date (2025-01-01) or an age such as 12h, 7d or 2w
+func parseQueryTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	units := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if len(value) > 1 {
+		if unit, ok := units[value[len(value)-1]]; ok {
+			if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 {
+				return now.Add(-time.Duration(n) * unit), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected a date like 2025-01-01 or an age like 7d")
+}
+
+// parseQuerySize accepts a comparison and a size such as >1mb, <=500k or 2mb (meaning
+// at least 2 MB). Units are powers of 1024; a bare number is bytes.
+func parseQuerySize(value string) (string, int64, error) {
+	op := ">="
+	for _, prefix := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, prefix) {
+			op, value = prefix, value[len(prefix):]
+			break
+		}
+	}
+
+	number := strings.TrimRight(value, "kmgb")
+	multipliers := map[string]float64{"": 1, "b": 1, "k": 1 << 10, "kb": 1 << 10, "m": 1 << 20, "mb": 1 << 20, "g": 1 << 30, "gb": 1 << 30}
+	multiplier, ok := multipliers[value[len(number):]]
+	n, err := strconv.ParseFloat(number, 64)
+	if !ok || err != nil || n < 0 {
+		return "", 0, fmt.Errorf("expected a size like >1mb, <500kb or 2mb")
+	}
+	return op, int64(n * multiplier), nil
+}
+
+// compareSize applies a size term's operator
+func compareSize(op string, size, bound int64) bool {
+	switch op {
+	case ">":
+		return size > bound
+	case "<":
+		return size < bound
+	case "<=":
+		return size <= bound
+	default:
+		return size >= bound
+	}
+}
+
+// where builds the SQL condition for the field terms
+func (q Query) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	for _, term := range q.Terms {
+		var condition string
+		switch term.Field {
+		case "type":
+			condition, args = "content_type = ?", append(args, term.Value)
+		case "threat":
+			condition, args = "threat_level = ?", append(args, term.Value)
+		case "pinned":
+			condition, args = "is_pinned = ?", append(args, term.Value == "true")
+		case "safe":
+			condition, args = "safe_entry = ?", append(args, term.Value == "true")
+		case "tag":
+			// INSTR rather than LIKE, where the '_' tags may contain matches any character
+			condition, args = "INSTR(',' || COALESCE(tags, '') || ',', ?) > 0", append(args, ","+term.Value+",")
+		case "app":
+			// Substring match, so app:firefox finds org.mozilla.firefox
+			condition, args = "INSTR(LOWER(COALESCE(source_app, '')), ?) > 0", append(args, term.Value)
+		case "before":
+			condition, args = "timestamp < ?", append(args, term.Time)
+		case "after":
+			condition, args = "timestamp >= ?", append(args, term.Time)
+		case "size":
+			// The operator was validated by parseQuerySize
+			condition, args = "("+sizeColumn+") "+term.Value+" ?", append(args, term.Size)
+		case "natlang":
+			condition, args = "COALESCE(text_language, '') = ?", append(args, term.Value)
+		case "lang":
+			// Items without an override are matched by language detection in the TUI
+			if term.Negate {
+				conditions = append(conditions, "COALESCE(language_override, '') != ?")
+				args = append(args, term.Value)
+				continue
+			}
+			condition, args = "(content_type = 'text' AND COALESCE(language_override, '') IN (?, ''))", append(args, term.Value)
+		}
+
+		if term.Negate {
+			condition = "NOT " + condition
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// MatchMeta evaluates the field terms against metadata alone, for items that aren't in
+// the history table (the archive). Tags and sources aren't part of the metadata and never match.
+func (q Query) MatchMeta(item ClipboardItemMeta) bool {
+	for _, term := range q.Terms {
+		var match bool
+		switch term.Field {
+		case "type":
+			match = item.ContentType == term.Value
+		case "threat":
+			match = item.ThreatLevel == term.Value
+		case "pinned":
+			match = item.IsPinned == (term.Value == "true")
+		case "safe":
+			match = item.SafeEntry == (term.Value == "true")
+		case "before":
+			match = item.Timestamp.Before(term.Time)
+		case "after":
+			match = !item.Timestamp.Before(term.Time)
+		case "size":
+			match = compareSize(term.Value, item.Size, term.Size)
+		case "natlang":
+			language := item.TextLanguage
+			if language == "" {
+				language = textLanguage(item.Content, item.ContentType) // Archived items aren't stored with it
+			}
+			match = language == term.Value
+		case "lang":
+			continue // Left to language detection
+		}
+		if match == term.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchMeta returns metadata for the items matching all field terms of q
+func (s *Storage) SearchMeta(q Query) []ClipboardItemMeta {
+	where, args := q.where()
+	if where == "" {
+		where = "1 = 1"
+	}
+	return s.queryMeta("SELECT "+metaColumns+" FROM clipboard_items WHERE "+where+" "+metaOrder, args...)
+}
@@ -0,0 +1,233 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.Local)
+
+	query, err := parseQueryAt(`type:image -threat:high tag:Work "error log" https://example.com after:7d before:2025-01-01`, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query.Text != "error log https://example.com" {
+		t.Errorf("Expected free text to be kept, got %q", query.Text)
+	}
+
+	expected := []QueryTerm{
+		{Field: "type", Value: "image"},
+		{Field: "threat", Value: "high", Negate: true},
+		{Field: "tag", Value: "work"},
+		{Field: "after", Value: "7d", Time: now.Add(-7 * 24 * time.Hour)},
+		{Field: "before", Value: "2025-01-01", Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf("Unexpected terms:\n got %+v\nwant %+v", query.Terms, expected)
+	}
+}
+
+func TestParseQuery_InvalidTerms(t *testing.T) {
+	query, err := ParseQuery("type:video threat:high before:yesterday pinned:maybe tag:")
+	if err == nil {
+		t.Fatal("Expected an error for invalid terms")
+	}
+	// Valid terms still apply, an empty value is still being typed
+	if len(query.Terms) != 1 || query.Terms[0].Field != "threat" {
+		t.Errorf("Expected only the threat term, got %+v", query.Terms)
+	}
+}
+
+func TestSearchMeta(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	now := time.Now()
+	entries := []struct {
+		id, contentType, threatLevel string
+		age                          time.Duration
+	}{
+		{"old", "text", "none", 30 * 24 * time.Hour},
+		{"recent", "text", "high", time.Hour},
+		{"image", "image", "none", 2 * time.Hour},
+	}
+	for _, e := range entries {
+		if err := storage.insertDirectly(e.id, "content "+e.id, e.contentType, nil, now.Add(-e.age), e.threatLevel, false); err != nil {
+			t.Fatalf("Failed to insert %s: %v", e.id, err)
+		}
+	}
+	if err := storage.SetTags("old", []string{"Work", "notes", "work"}); err != nil {
+		t.Fatalf("Failed to set tags: %v", err)
+	}
+	if tags := storage.GetTags("old"); !reflect.DeepEqual(tags, []string{"notes", "work"}) {
+		t.Errorf("Expected normalized tags, got %v", tags)
+	}
+	if err := storage.AddTag("recent", "bad tag"); err == nil {
+		t.Error("Expected an invalid tag to be rejected")
+	}
+	storage.SetLanguageOverride("recent", "python")
+	if overrides := storage.GetLanguageOverrides(); !reflect.DeepEqual(overrides, map[string]string{"recent": "python"}) {
+		t.Errorf("Expected only the override of 'recent', got %v", overrides)
+	}
+
+	ids := func(input string) []string {
+		query, err := ParseQuery(input)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", input, err)
+		}
+		var result []string
+		for _, item := range storage.SearchMeta(query) {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"type:image", []string{"image"}},
+		{"-type:image", []string{"recent", "old"}},
+		{"threat:high", []string{"recent"}},
+		{"tag:work", []string{"old"}},
+		{"tag:wor", nil},
+		{"tag:wor_", nil}, // '_' is matched literally, not as a wildcard
+		{"after:7d", []string{"recent", "image"}},
+		{"before:7d type:text", []string{"old"}},
+		{"lang:python", []string{"recent", "old"}}, // "old" has no override and is left to detection
+		{"-lang:python", []string{"image", "old"}},
+	}
+	for _, test := range tests {
+		if got := ids(test.query); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("SearchMeta(%q) = %v, expected %v", test.query, got, test.expected)
+		}
+	}
+}
+
+func TestParseQuerySize(t *testing.T) {
+	tests := []struct {
+		value string
+		op    string
+		size  int64
+	}{
+		{">1mb", ">", 1 << 20},
+		{"<=500k", "<=", 500 << 10},
+		{"2mb", ">=", 2 << 20},
+		{">1.5kb", ">", 1536},
+		{"<100", "<", 100},
+		{">1g", ">", 1 << 30},
+	}
+	for _, test := range tests {
+		op, size, err := parseQuerySize(test.value)
+		if err != nil || op != test.op || size != test.size {
+			t.Errorf("parseQuerySize(%q) = %q, %d, %v, want %q, %d", test.value, op, size, err, test.op, test.size)
+		}
+	}
+	for _, value := range []string{">", "big", ">1tb", "-5mb", ">mb"} {
+		if _, _, err := parseQuerySize(value); err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+}
+
+func TestSearchMeta_Size(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	now := time.Now()
+	storage.insertDirectly("small", "short text", "text", nil, now.Add(-3*time.Minute), "none", false)
+	storage.insertDirectly("long", strings.Repeat("x", 4096), "text", nil, now.Add(-2*time.Minute), "none", false)
+	storage.insertDirectly("image", "Image", "image", make([]byte, 2<<20), now.Add(-time.Minute), "none", false)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"size:>1mb", []string{"image"}},
+		{"size:>1kb", []string{"image", "long"}},
+		{"size:<1kb", []string{"small"}},
+		{"-size:>1mb", []string{"long", "small"}},
+	}
+	for _, test := range tests {
+		query, err := ParseQuery(test.query)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", test.query, err)
+		}
+		var got []string
+		for _, item := range storage.SearchMeta(query) {
+			got = append(got, item.ID)
+			if !query.MatchMeta(item) {
+				t.Errorf("%q: MatchMeta disagrees with SearchMeta on %s", test.query, item.ID)
+			}
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("SearchMeta(%q) = %v, expected %v", test.query, got, test.expected)
+		}
+	}
+
+	if meta := storage.GetMeta("image"); meta == nil || meta.Size != 2<<20+int64(len("Image")) {
+		t.Errorf("Expected the image size in its metadata, got %+v", meta)
+	}
+}
+
+func TestSearchMeta_NaturalLanguage(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	storage.Add("Can you send me the report when it is ready? I would like to read it first.")
+	storage.Add("Kannst du mir den Bericht schicken, wenn er fertig ist? Ich will ihn noch lesen.")
+	storage.Add("SELECT id FROM users")
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"natlang:en", []string{"en"}},
+		{"natlang:German", []string{"de"}},
+		{"-natlang:de", []string{"", "en"}},
+	}
+	for _, test := range tests {
+		query, err := ParseQuery(test.query)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", test.query, err)
+		}
+		var got []string
+		for _, item := range storage.SearchMeta(query) {
+			got = append(got, item.TextLanguage)
+			if !query.MatchMeta(item) {
+				t.Errorf("%q: MatchMeta disagrees with SearchMeta on %q", test.query, item.Content)
+			}
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("SearchMeta(%q) languages = %v, expected %v", test.query, got, test.expected)
+		}
+	}
+
+	if _, err := ParseQuery("natlang:klingon"); err == nil {
+		t.Error("Expected an unknown language to be rejected")
+	}
+}
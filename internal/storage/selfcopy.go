@@ -0,0 +1,99 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+)
+
+// selfCopyExpiry is how long a copy made by nclip is remembered. The daemon sees the
+// change well within this; afterwards the same content copied elsewhere is stored.
+const selfCopyExpiry = 30 * time.Second
+
+// selfCopyHash identifies clipboard content the way duplicates are matched
+func selfCopyHash(content, contentType string, imageData []byte) string {
+	var sum [32]byte
+	if contentType == "image" {
+		sum = sha256.Sum256(imageData)
+	} else {
+		sum = sha256.Sum256([]byte(normalizeContentForDeduplication(content)))
+	}
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordCopy remembers content that nclip is about to put on the clipboard, so the
+// daemon can recognize it with ConsumeCopy instead of storing it again
+func (s *Storage) RecordCopy(content, contentType string, imageData []byte) error {
+	return s.recordCopy(content, contentType, imageData, false)
+}
+
+// RecordMove remembers content like RecordCopy for an item that is removed from the
+// history right after it is copied. The daemon skips it with ConsumeMove even when it
+// stores nclip's own copies, as storing it again would undo the removal.
+func (s *Storage) RecordMove(content, contentType string, imageData []byte) error {
+	return s.recordCopy(content, contentType, imageData, true)
+}
+
+// recordCopy replaces the remembered copy
+func (s *Storage) recordCopy(content, contentType string, imageData []byte, moved bool) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO self_copy (id, hash, copied_at, moved) VALUES (1, ?, ?, ?)",
+		selfCopyHash(content, contentType, imageData), time.Now(), moved)
+	return err
+}
+
+// ConsumeCopy reports whether the content is the last copy recorded by RecordCopy or
+// RecordMove, forgetting the record so later copies of the same content are stored
+func (s *Storage) ConsumeCopy(content, contentType string, imageData []byte) (bool, error) {
+	return s.consumeCopy(content, contentType, imageData, false)
+}
+
+// ConsumeMove is ConsumeCopy for copies recorded by RecordMove only
+func (s *Storage) ConsumeMove(content, contentType string, imageData []byte) (bool, error) {
+	return s.consumeCopy(content, contentType, imageData, true)
+}
+
+// consumeCopy matches content against the remembered copy, ignoring plain copies when
+// movesOnly is set
+func (s *Storage) consumeCopy(content, contentType string, imageData []byte, movesOnly bool) (bool, error) {
+	var hash string
+	var copiedAt time.Time
+	var moved bool
+	err := s.db.QueryRow("SELECT hash, copied_at, moved FROM self_copy WHERE id = 1").Scan(&hash, &copiedAt, &moved)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if time.Since(copiedAt) > selfCopyExpiry || hash != selfCopyHash(content, contentType, imageData) || (movesOnly && !moved) {
+		return false, nil
+	}
+
+	_, err = s.db.Exec("DELETE FROM self_copy WHERE id = 1 AND hash = ?", hash)
+	return true, err
+}
@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsumeCopy(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	if own, err := storage.ConsumeCopy("hello", "text", nil); err != nil || own {
+		t.Fatalf("Expected no recorded copy, got %v, %v", own, err)
+	}
+
+	if err := storage.RecordCopy("hello\n", "text", nil); err != nil {
+		t.Fatalf("RecordCopy failed: %v", err)
+	}
+	if own, _ := storage.ConsumeCopy("other", "text", nil); own {
+		t.Error("Expected different content not to match the recorded copy")
+	}
+	if own, _ := storage.ConsumeCopy("hello", "text", nil); !own {
+		t.Error("Expected the recorded copy to match, ignoring surrounding whitespace")
+	}
+	if own, _ := storage.ConsumeCopy("hello", "text", nil); own {
+		t.Error("Expected the record to be consumed by the first match")
+	}
+
+	image := []byte("\x89PNG fake image data")
+	if err := storage.RecordCopy("Image (20 bytes)", "image", image); err != nil {
+		t.Fatalf("RecordCopy failed: %v", err)
+	}
+	if own, _ := storage.ConsumeCopy("Image (20 bytes)", "image", image); !own {
+		t.Error("Expected the recorded image copy to match")
+	}
+}
+
+func TestConsumeMove(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	if err := storage.RecordCopy("hello", "text", nil); err != nil {
+		t.Fatalf("RecordCopy failed: %v", err)
+	}
+	if own, _ := storage.ConsumeMove("hello", "text", nil); own {
+		t.Error("Expected a plain copy not to count as a move")
+	}
+
+	if err := storage.RecordMove("secret", "text", nil); err != nil {
+		t.Fatalf("RecordMove failed: %v", err)
+	}
+	if own, _ := storage.ConsumeMove("secret", "text", nil); !own {
+		t.Error("Expected the recorded move to match")
+	}
+	if own, _ := storage.ConsumeCopy("secret", "text", nil); own {
+		t.Error("Expected the move to be consumed by the first match")
+	}
+}
+
+func TestConsumeCopy_Expired(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	if err := storage.RecordCopy("hello", "text", nil); err != nil {
+		t.Fatalf("RecordCopy failed: %v", err)
+	}
+	if _, err := storage.db.Exec("UPDATE self_copy SET copied_at = ?", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Failed to age the record: %v", err)
+	}
+	if own, _ := storage.ConsumeCopy("hello", "text", nil); own {
+		t.Error("Expected an expired copy record not to match")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SetShred makes Delete and DeleteArchived overwrite an entry with zeros before
+// removing it and release the freed pages, so deleted secrets cannot be recovered
+// from history.db. Retention, pruning and wipes zero what they remove as well.
+// Enabling it switches the database to incremental auto-vacuum, which rewrites the
+// file once.
+func (s *Storage) SetShred(enabled bool) error {
+	s.shred = enabled
+	if !enabled {
+		return nil
+	}
+
+	var mode int
+	if err := s.db.QueryRow("PRAGMA auto_vacuum").Scan(&mode); err != nil {
+		return fmt.Errorf("failed to read auto_vacuum mode: %w", err)
+	}
+	const incremental = 2
+	if mode == incremental {
+		return nil
+	}
+	// The mode of an existing database only changes with a full VACUUM
+	if _, err := s.db.Exec("PRAGMA auto_vacuum = INCREMENTAL"); err != nil {
+		return fmt.Errorf("failed to enable incremental vacuum: %w", err)
+	}
+	if _, err := s.db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
+
+// deleteConn returns a connection to remove entries on. With shredding enabled, SQLite
+// zeroes the space of removed cells on it; call releaseFreed once the removal is committed.
+func (s *Storage) deleteConn(ctx context.Context) (*sql.Conn, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// secure_delete is per connection, so it has to be set on the one doing the delete
+	if s.shred {
+		if _, err := conn.ExecContext(ctx, "PRAGMA secure_delete = ON"); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to enable secure delete: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// releaseFreed vacuums the pages freed by a removal on conn when shredding is enabled
+func (s *Storage) releaseFreed(ctx context.Context, conn *sql.Conn) error {
+	if !s.shred {
+		return nil
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA incremental_vacuum"); err != nil {
+		return fmt.Errorf("failed to vacuum freed pages: %w", err)
+	}
+	return nil
+}
+
+// shredDelete overwrites one row of table with zeros, deletes it and vacuums the freed pages
+func (s *Storage) shredDelete(table, id string) error {
+	ctx := context.Background()
+	conn, err := s.deleteConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	overwrite := fmt.Sprintf(`UPDATE %s SET
+		content = zeroblob(length(CAST(content AS BLOB))),
+		image_data = CASE WHEN image_data IS NULL THEN NULL ELSE zeroblob(length(image_data)) END
+		WHERE id = ?`, table)
+	if _, err := tx.ExecContext(ctx, overwrite, id); err != nil {
+		return fmt.Errorf("failed to overwrite entry: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = ?", table), id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return s.releaseFreed(ctx, conn)
+}
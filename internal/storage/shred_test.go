@@ -0,0 +1,108 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShredDelete(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+	store, err := Open(dbPath, 10)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := store.SetShred(true); err != nil {
+		t.Fatalf("SetShred failed: %v", err)
+	}
+
+	var mode int
+	store.db.QueryRow("PRAGMA auto_vacuum").Scan(&mode)
+	if mode != 2 {
+		t.Errorf("Expected incremental auto_vacuum, got %d", mode)
+	}
+
+	secret := "shred-me-7f3a9c2e4b1d"
+	if err := store.Add("keep this entry"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := store.Add(secret); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	items := store.GetAllMeta()
+	if err := store.Delete(items[0].ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if remaining := store.GetAllMeta(); len(remaining) != 1 || remaining[0].Content != "keep this entry" {
+		t.Errorf("Unexpected remaining items %+v", remaining)
+	}
+	store.Close()
+
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to read database file: %v", err)
+	}
+	if bytes.Contains(data, []byte(secret)) {
+		t.Error("Deleted content is still present in the database file")
+	}
+}
+
+func TestShredRetentionEviction(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+	store, err := Open(dbPath, 1)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := store.SetShred(true); err != nil {
+		t.Fatalf("SetShred failed: %v", err)
+	}
+
+	secret := "evict-me-5c8e1a7f9d2b"
+	if err := store.Add(secret); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	// The newer entry pushes the secret past the one entry limit
+	if err := store.Add("newer entry"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := store.EnforceRetention(); err != nil {
+		t.Fatalf("EnforceRetention failed: %v", err)
+	}
+	if remaining := store.GetAllMeta(); len(remaining) != 1 || remaining[0].Content != "newer entry" {
+		t.Fatalf("Unexpected remaining items %+v", remaining)
+	}
+	store.Close()
+
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to read database file: %v", err)
+	}
+	if bytes.Contains(data, []byte(secret)) {
+		t.Error("Evicted content is still present in the database file")
+	}
+}
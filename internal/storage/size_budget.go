@@ -0,0 +1,129 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Orders in which EnforceSizeBudget evicts unpinned items
+const (
+	SizeStrategyOldest  = "oldest"  // least recently copied first
+	SizeStrategyLargest = "largest" // biggest first, usually images, oldest first among equals
+)
+
+// budgetVictimOrders maps each strategy to the ORDER BY clause choosing the next victim
+var budgetVictimOrders = map[string]string{
+	SizeStrategyOldest:  "timestamp ASC",
+	SizeStrategyLargest: "size DESC, timestamp ASC",
+}
+
+// EvictedItem describes an item EnforceSizeBudget removed
+type EvictedItem struct {
+	ID          string
+	ContentType string
+	Timestamp   time.Time
+	Size        int64 // Bytes of content and image data
+}
+
+// UsedBytes returns the space the database's pages hold, excluding free pages SQLite
+// reuses for new data. The file itself only shrinks with VACUUM.
+func (s *Storage) UsedBytes() (int64, error) {
+	var pageCount, freePages, pageSize int64
+	if err := s.db.QueryRow("PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("failed to read page count: %w", err)
+	}
+	if err := s.db.QueryRow("PRAGMA freelist_count").Scan(&freePages); err != nil {
+		return 0, fmt.Errorf("failed to read free page count: %w", err)
+	}
+	if err := s.db.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("failed to read page size: %w", err)
+	}
+	return (pageCount - freePages) * pageSize, nil
+}
+
+// HistoryBytes returns the bytes of content and image data the history's items hold. The
+// archive, pending items and other tables are not counted.
+func (s *Storage) HistoryBytes() (int64, error) {
+	var used int64
+	if err := s.db.QueryRow("SELECT COALESCE(SUM(" + sizeColumn + "), 0) FROM clipboard_items").Scan(&used); err != nil {
+		return 0, fmt.Errorf("failed to measure history size: %w", err)
+	}
+	return used, nil
+}
+
+// EnforceSizeBudget deletes unpinned items in the given strategy's order until the history
+// holds at most maxBytes of content and image data, returning the deleted items. Only the
+// history is measured, since it is all eviction can shrink; evicted items are never
+// archived, since the archive lives in the same file.
+func (s *Storage) EnforceSizeBudget(maxBytes int64, strategy string) ([]EvictedItem, error) {
+	order, ok := budgetVictimOrders[strategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown size strategy %q", strategy)
+	}
+	used, err := s.HistoryBytes()
+	if err != nil || used <= maxBytes {
+		return nil, err
+	}
+
+	victims, err := s.budgetVictims(order, used-maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	var evicted []EvictedItem
+	for _, victim := range victims {
+		if err := s.Delete(victim.ID); err != nil {
+			return evicted, fmt.Errorf("failed to evict item: %w", err)
+		}
+		evicted = append(evicted, victim)
+	}
+	return evicted, nil
+}
+
+// budgetVictims returns unpinned items in order whose sizes add up to at least excess bytes
+func (s *Storage) budgetVictims(order string, excess int64) ([]EvictedItem, error) {
+	rows, err := s.db.Query(`
+		SELECT id, content_type, timestamp, ` + sizeColumn + ` AS size
+		FROM clipboard_items
+		WHERE is_pinned = FALSE
+		ORDER BY ` + order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find items to evict: %w", err)
+	}
+	defer rows.Close()
+
+	var victims []EvictedItem
+	var freed int64
+	for freed < excess && rows.Next() {
+		var item EvictedItem
+		if err := rows.Scan(&item.ID, &item.ContentType, &item.Timestamp, &item.Size); err != nil {
+			return nil, err
+		}
+		victims = append(victims, item)
+		freed += item.Size
+	}
+	return victims, rows.Err()
+}
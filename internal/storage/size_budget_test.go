@@ -0,0 +1,131 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnforceSizeBudget(t *testing.T) {
+	for _, strategy := range []string{SizeStrategyOldest, SizeStrategyLargest} {
+		t.Run(strategy, func(t *testing.T) {
+			storage, _ := createTestStorage(t)
+
+			base := time.Now().Add(-time.Hour)
+			// Old text first, then newer 64 KiB images
+			for i := 0; i < 5; i++ {
+				storage.insertDirectly(fmt.Sprintf("text%d", i), fmt.Sprintf("text %d", i), "text", nil, base.Add(time.Duration(i)*time.Minute), "none", true)
+			}
+			for i := 0; i < 8; i++ {
+				image := make([]byte, 64*1024)
+				image[0] = byte(i)
+				storage.insertDirectly(fmt.Sprintf("image%d", i), "Image", "image", image, base.Add(time.Duration(10+i)*time.Minute), "none", true)
+			}
+			if err := storage.PinItem("image0"); err != nil {
+				t.Fatalf("Failed to pin: %v", err)
+			}
+
+			before, err := storage.HistoryBytes()
+			if err != nil {
+				t.Fatalf("HistoryBytes failed: %v", err)
+			}
+			budget := before - 200*1024
+			evicted, err := storage.EnforceSizeBudget(budget, strategy)
+			if err != nil {
+				t.Fatalf("EnforceSizeBudget failed: %v", err)
+			}
+			if after, _ := storage.HistoryBytes(); after > budget {
+				t.Errorf("Expected at most %d bytes used, got %d", budget, after)
+			}
+			if len(evicted) == 0 {
+				t.Fatal("Expected items to be evicted")
+			}
+			if storage.GetMeta("image0") == nil {
+				t.Error("Expected the pinned image to be kept")
+			}
+
+			textLeft := 0
+			for i := 0; i < 5; i++ {
+				if storage.GetMeta(fmt.Sprintf("text%d", i)) != nil {
+					textLeft++
+				}
+			}
+			switch strategy {
+			case SizeStrategyOldest:
+				if textLeft != 0 || evicted[0].ID != "text0" {
+					t.Errorf("Expected the oldest items evicted first, got %d text left, first evicted %s", textLeft, evicted[0].ID)
+				}
+			case SizeStrategyLargest:
+				if textLeft != 5 || evicted[0].ID != "image1" {
+					t.Errorf("Expected the oldest large images evicted first, got %d text left, first evicted %s", textLeft, evicted[0].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestEnforceSizeBudget_WithinBudget(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.Add("some text")
+
+	evicted, err := storage.EnforceSizeBudget(1<<30, SizeStrategyOldest)
+	if err != nil || len(evicted) != 0 {
+		t.Errorf("Expected nothing evicted within budget, got %v, %v", evicted, err)
+	}
+	if _, err := storage.EnforceSizeBudget(1<<30, "random"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+func TestEnforceSizeBudget_IgnoresArchive(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(0, true)
+
+	// Five of the fifteen 64 KiB entries end up in the archive
+	for i := 0; i < 15; i++ {
+		if err := storage.Add(fmt.Sprintf("%02d %s", i, strings.Repeat("x", 64*1024))); err != nil {
+			t.Fatalf("Failed to add content %d: %v", i, err)
+		}
+	}
+	if count := storage.GetArchivedCount(); count != 5 {
+		t.Fatalf("Expected 5 archived items, got %d", count)
+	}
+
+	// The archive takes up space in the file but can't be evicted, so it doesn't count
+	used, err := storage.HistoryBytes()
+	if err != nil {
+		t.Fatalf("HistoryBytes failed: %v", err)
+	}
+	evicted, err := storage.EnforceSizeBudget(used, SizeStrategyOldest)
+	if err != nil || len(evicted) != 0 {
+		t.Errorf("Expected nothing evicted with the history within budget, got %d, %v", len(evicted), err)
+	}
+	if count := storage.GetItemCount(); count != 10 {
+		t.Errorf("Expected the history to keep 10 items, got %d", count)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemSnapshot is a complete copy of one row of the history or the archive, every column
+// included, so that a deleted or edited item can be put back exactly as it was
+type ItemSnapshot struct {
+	ID      string
+	table   string
+	columns []string
+	values  []interface{}
+}
+
+// Snapshot copies an item of the history, or of the archive when archived is set
+func (s *Storage) Snapshot(id string, archived bool) (*ItemSnapshot, error) {
+	table := "clipboard_items"
+	if archived {
+		table = "archived_items"
+	}
+	rows, err := s.db.Query("SELECT * FROM "+table+" WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item columns: %w", err)
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read item: %w", err)
+		}
+		return nil, fmt.Errorf("item %s not found", id)
+	}
+
+	// Scanning into interface values keeps each column as stored; byte slices are copied
+	values := make([]interface{}, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, fmt.Errorf("failed to read item: %w", err)
+	}
+	return &ItemSnapshot{ID: id, table: table, columns: columns, values: values}, nil
+}
+
+// RestoreSnapshot writes a snapshot back, replacing the item if it still exists
+func (s *Storage) RestoreSnapshot(snap *ItemSnapshot) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(snap.columns)), ", ")
+	query := "INSERT OR REPLACE INTO " + snap.table + " (" + strings.Join(snap.columns, ", ") + ") VALUES (" + placeholders + ")"
+	if _, err := s.db.Exec(query, snap.values...); err != nil {
+		return fmt.Errorf("failed to restore item: %w", err)
+	}
+	if snap.table != "clipboard_items" {
+		return nil
+	}
+
+	// The restored item may push older entries over the limit
+	_, err := s.EnforceRetention()
+	return err
+}
@@ -0,0 +1,92 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import "testing"
+
+func TestSnapshot_RestoresDeletedItem(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	if err := storage.AddWithSource("deploy --prod", "text", nil, Source{App: "kitty", Title: "shell"}); err != nil {
+		t.Fatalf("AddWithSource failed: %v", err)
+	}
+	id := storage.GetAllMeta()[0].ID
+	if err := storage.PinItem(id); err != nil {
+		t.Fatalf("PinItem failed: %v", err)
+	}
+	if err := storage.AddTag(id, "work"); err != nil {
+		t.Fatalf("AddTag failed: %v", err)
+	}
+	before := storage.GetMeta(id)
+
+	snap, err := storage.Snapshot(id, false)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if err := storage.Delete(id); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := storage.RestoreSnapshot(snap); err != nil {
+		t.Fatalf("RestoreSnapshot failed: %v", err)
+	}
+
+	after := storage.GetMeta(id)
+	if after == nil || after.Content != before.Content || !after.IsPinned || !after.Timestamp.Equal(before.Timestamp) {
+		t.Fatalf("Expected the item back as it was, got %+v, want %+v", after, before)
+	}
+	if tags := storage.GetTags(id); len(tags) != 1 || tags[0] != "work" {
+		t.Errorf("Expected the tags to be restored, got %v", tags)
+	}
+	if source := storage.GetSource(id); source.App != "kitty" {
+		t.Errorf("Expected the source to be restored, got %+v", source)
+	}
+}
+
+func TestSnapshot_RevertsEdit(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.Add("original text")
+	id := storage.GetAllMeta()[0].ID
+	if err := storage.UpdateSafeEntry(id, true); err != nil {
+		t.Fatalf("UpdateSafeEntry failed: %v", err)
+	}
+
+	snap, err := storage.Snapshot(id, false)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if err := storage.Update(id, "edited text"); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := storage.RestoreSnapshot(snap); err != nil {
+		t.Fatalf("RestoreSnapshot failed: %v", err)
+	}
+	if meta := storage.GetMeta(id); meta.Content != "original text" || !meta.SafeEntry || storage.GetItemCount() != 1 {
+		t.Errorf("Expected the original content and safe flag, got %+v", meta)
+	}
+
+	if _, err := storage.Snapshot("missing", false); err == nil {
+		t.Error("Expected an error for a missing item")
+	}
+}
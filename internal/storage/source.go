@@ -0,0 +1,72 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Source records where an item was copied: the focused application and window
+// at the time, and the selection it was read from
+type Source struct {
+	App       string // Application id (Wayland) or window class (X11), "" if unknown
+	Title     string // Window title
+	Selection string // "clipboard" or "primary"
+}
+
+// IsZero reports whether nothing is known about the source
+func (s Source) IsZero() bool {
+	return s == Source{}
+}
+
+// String describes the source for display, e.g. "firefox - Example Domain (clipboard)"
+func (s Source) String() string {
+	description := s.App
+	if s.Title != "" {
+		if description != "" {
+			description += " - "
+		}
+		description += s.Title
+	}
+	if s.Selection != "" {
+		if description == "" {
+			return s.Selection
+		}
+		description = fmt.Sprintf("%s (%s)", description, s.Selection)
+	}
+	return description
+}
+
+// GetSource returns the recorded source of an item, empty if unknown
+func (s *Storage) GetSource(id string) Source {
+	var app, title, selection sql.NullString
+	err := s.db.QueryRow("SELECT source_app, source_title, source_selection FROM clipboard_items WHERE id = ?", id).
+		Scan(&app, &title, &selection)
+	if err != nil {
+		return Source{}
+	}
+	return Source{App: app.String, Title: title.String, Selection: selection.String}
+}
@@ -0,0 +1,96 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import "testing"
+
+func TestAddWithSource(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	firefox := Source{App: "org.mozilla.firefox", Title: "Example Domain", Selection: "clipboard"}
+	if err := storage.AddWithSource("https://example.com", "text", nil, firefox); err != nil {
+		t.Fatalf("AddWithSource failed: %v", err)
+	}
+	id := storage.GetAllMeta()[0].ID
+	if got := storage.GetSource(id); got != firefox {
+		t.Errorf("Expected source %+v, got %+v", firefox, got)
+	}
+
+	// A duplicate without a known source keeps the recorded one
+	if err := storage.Add("https://example.com"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := storage.GetSource(id); got != firefox {
+		t.Errorf("Expected an unknown source not to overwrite %+v, got %+v", firefox, got)
+	}
+
+	// A duplicate from another application takes its source
+	terminal := Source{App: "kitty", Title: "~", Selection: "clipboard"}
+	if err := storage.AddWithSource("https://example.com", "text", nil, terminal); err != nil {
+		t.Fatalf("AddWithSource failed: %v", err)
+	}
+	if got := storage.GetSource(id); got != terminal {
+		t.Errorf("Expected source %+v, got %+v", terminal, got)
+	}
+}
+
+func TestSearchMeta_App(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	storage.AddWithSource("from the browser", "text", nil, Source{App: "org.mozilla.firefox"})
+	storage.AddWithSource("from the terminal", "text", nil, Source{App: "kitty"})
+	storage.Add("from somewhere")
+
+	query, err := ParseQuery("app:Firefox")
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	items := storage.SearchMeta(query)
+	if len(items) != 1 || items[0].Content != "from the browser" {
+		t.Errorf("Expected only the browser item, got %+v", items)
+	}
+
+	query, _ = ParseQuery("-app:firefox")
+	if items := storage.SearchMeta(query); len(items) != 2 {
+		t.Errorf("Expected 2 items not from firefox, got %+v", items)
+	}
+}
+
+func TestSourceString(t *testing.T) {
+	tests := []struct {
+		source Source
+		want   string
+	}{
+		{Source{}, ""},
+		{Source{Selection: "clipboard"}, "clipboard"},
+		{Source{App: "kitty", Selection: "clipboard"}, "kitty (clipboard)"},
+		{Source{App: "firefox", Title: "Example", Selection: "primary"}, "firefox - Example (primary)"},
+	}
+	for _, tt := range tests {
+		if got := tt.source.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
@@ -26,11 +26,13 @@ package storage
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/adaryorg/nclip/internal/security"
@@ -60,11 +62,33 @@ type ClipboardItemMeta struct {
 	SafeEntry   bool      `json:"safe_entry"`
 	IsPinned    bool      `json:"is_pinned"`
 	PinOrder    int       `json:"pin_order"`
+	Size        int64     `json:"size"`            // Bytes of content and image data
+	Title       string    `json:"title,omitempty"` // Page title fetched for a copied link
+
+	// Natural language of a text item as an ISO 639-1 code, e.g. "de"; "" if undetected
+	TextLanguage string `json:"text_language,omitempty"`
 }
 
 type Storage struct {
 	db         *sql.DB
+	path       string
 	maxEntries int
+	maxText    int           // Quota for text items, 0 counts them under maxEntries (see SetTypeLimits)
+	maxImages  int           // Quota for image items, 0 counts them under maxEntries
+	ttl        time.Duration // Maximum age of unpinned items, 0 disables expiry
+	archive    bool          // Move evicted items to the archive instead of deleting them
+	normalize  NormalizeOptions
+	shred      bool // Overwrite deleted entries and vacuum (see SetShred)
+
+	appRetention []AppRetentionRule // Per-application limits applied by EnforceAppRetention
+	otpTTL       time.Duration      // Expiry of one-time codes, 0 keeps them (see SetOTPExpiry)
+
+	appliedMigrations []string // Schema migrations applied by Open
+	previousWriter    string   // nclip version that opened the database before Open
+
+	// Dedicated connection for reading data_version (see DataVersion)
+	watchMu   sync.Mutex
+	watchConn *sql.Conn
 }
 
 func New(maxEntries int) (*Storage, error) {
@@ -78,7 +102,14 @@ func New(maxEntries int) (*Storage, error) {
 		return nil, fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	dbPath := filepath.Join(configDir, "history.db")
+	return Open(filepath.Join(configDir, "history.db"), maxEntries)
+}
+
+// Open opens (creating if needed) a history database at dbPath
+func Open(dbPath string, maxEntries int) (*Storage, error) {
+	if err := createPrivate(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
 
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
@@ -87,39 +118,23 @@ func New(maxEntries int) (*Storage, error) {
 
 	s := &Storage{
 		db:         db,
+		path:       dbPath,
 		maxEntries: maxEntries,
 	}
 
-	if err := s.createTable(); err != nil {
-		return nil, fmt.Errorf("failed to create table: %w", err)
+	if err := s.checkForeign(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("refusing to open %s: %w", dbPath, err)
 	}
 
-	return s, nil
-}
-
-func (s *Storage) createTable() error {
-	// First create the table with original schema if it doesn't exist
-	originalQuery := `
-		CREATE TABLE IF NOT EXISTS clipboard_items (
-			id TEXT PRIMARY KEY,
-			content TEXT NOT NULL,
-			timestamp DATETIME NOT NULL
-		)
-	`
-	_, err := s.db.Exec(originalQuery)
+	applied, err := s.migrate()
 	if err != nil {
-		return err
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
+	s.appliedMigrations = applied
 
-	// Then add new columns if they don't exist (for migration)
-	s.db.Exec("ALTER TABLE clipboard_items ADD COLUMN content_type TEXT DEFAULT 'text'")
-	s.db.Exec("ALTER TABLE clipboard_items ADD COLUMN image_data BLOB")
-	s.db.Exec("ALTER TABLE clipboard_items ADD COLUMN threat_level TEXT DEFAULT 'none'")
-	s.db.Exec("ALTER TABLE clipboard_items ADD COLUMN safe_entry BOOLEAN DEFAULT TRUE")
-	s.db.Exec("ALTER TABLE clipboard_items ADD COLUMN is_pinned BOOLEAN DEFAULT FALSE")
-	s.db.Exec("ALTER TABLE clipboard_items ADD COLUMN pin_order INTEGER DEFAULT 0")
-
-	return nil
+	return s, nil
 }
 
 // normalizeContentForDeduplication normalizes content for deduplication comparison
@@ -128,13 +143,19 @@ func normalizeContentForDeduplication(content string) string {
 	return strings.TrimSpace(content)
 }
 
+// ThreatLevel returns the threat level ("none", "low", "medium" or "high") stored for content
+func ThreatLevel(content string, contentType string) string {
+	level, _ := calculateThreatLevel(content, contentType)
+	return level
+}
+
 // calculateThreatLevel determines threat level based on security analysis
 func calculateThreatLevel(content string, contentType string) (string, bool) {
 	if contentType == "image" {
 		return "none", true // Images don't have security threats
 	}
 
-	detector := security.NewSecurityDetector()
+	detector := security.DefaultDetector()
 	threats := detector.DetectSecurity(content)
 
 	if len(threats) == 0 {
@@ -170,58 +191,43 @@ func (s *Storage) AddImage(imageData []byte, description string) error {
 }
 
 func (s *Storage) AddWithType(content, contentType string, imageData []byte) error {
-	if content == "" && len(imageData) == 0 {
-		return nil
-	}
+	return s.AddWithSource(content, contentType, imageData, Source{})
+}
+
+// AddWithSource stores content along with the application it was copied from. A
+// duplicate is moved to the top and takes the new source, unless source is empty.
+func (s *Storage) AddWithSource(content, contentType string, imageData []byte, source Source) error {
+	_, err := s.addWithSource(content, contentType, imageData, source)
+	return err
+}
 
-	// For text content, check if duplicate exists and update timestamp if found
+// addWithSource is AddWithSource returning the ID of the new or moved entry, or "" when
+// there was nothing to store
+func (s *Storage) addWithSource(content, contentType string, imageData []byte, source Source) (string, error) {
 	if contentType == "text" {
-		var existingID string
-		normalizedContent := normalizeContentForDeduplication(content)
-		
-		// Check for existing entries with the same normalized content
-		query := "SELECT id FROM clipboard_items WHERE TRIM(content) = ? AND content_type = ? LIMIT 1"
-		err := s.db.QueryRow(query, normalizedContent, contentType).Scan(&existingID)
-		if err == nil {
-			// Duplicate found, update timestamp
-			updateQuery := "UPDATE clipboard_items SET timestamp = ? WHERE id = ?"
-			_, err := s.db.Exec(updateQuery, time.Now(), existingID)
-			return err
-		}
-		if err != sql.ErrNoRows {
-			return err
-		}
-	} else if contentType == "image" {
-		// For images, we need to compare both content and image data
-		// Use a simpler approach to avoid database locks
-		query := "SELECT id, image_data FROM clipboard_items WHERE content = ? AND content_type = ?"
-		rows, err := s.db.Query(query, content, contentType)
-		if err != nil {
-			return err
-		}
+		content = s.normalize.Apply(content)
+	}
+	if content == "" && len(imageData) == 0 {
+		return "", nil
+	}
 
-		var existingID string
-		for rows.Next() {
-			var tempID string
-			var tempImageData []byte
-			if err := rows.Scan(&tempID, &tempImageData); err != nil {
-				rows.Close()
-				return err
-			}
-			// Compare image data
-			if bytes.Equal(imageData, tempImageData) {
-				existingID = tempID
-				break
-			}
+	existingID, err := s.findDuplicate(content, contentType, imageData)
+	if err != nil {
+		return "", err
+	}
+	if existingID != "" {
+		// Duplicate found, update timestamp
+		if source.IsZero() {
+			_, err = s.db.Exec("UPDATE clipboard_items SET timestamp = ? WHERE id = ?", time.Now(), existingID)
+		} else {
+			updateQuery := "UPDATE clipboard_items SET timestamp = ?, source_app = ?, source_title = ?, source_selection = ? WHERE id = ?"
+			_, err = s.db.Exec(updateQuery, time.Now(), source.App, source.Title, source.Selection, existingID)
 		}
-		rows.Close()
-
-		if existingID != "" {
-			// Duplicate found, update timestamp
-			updateQuery := "UPDATE clipboard_items SET timestamp = ? WHERE id = ?"
-			_, err := s.db.Exec(updateQuery, time.Now(), existingID)
-			return err
+		if err != nil || contentType != "text" {
+			return existingID, err
 		}
+		// Copying a code again restarts its expiry
+		return existingID, s.expireIfOTP(existingID, content)
 	}
 
 	// No duplicate found, create new entry
@@ -231,23 +237,90 @@ func (s *Storage) AddWithType(content, contentType string, imageData []byte) err
 	// Calculate threat level and initial safe entry flag
 	threatLevel, safeEntry := calculateThreatLevel(content, contentType)
 
-	query := "INSERT INTO clipboard_items (id, content, content_type, image_data, timestamp, threat_level, safe_entry, is_pinned, pin_order) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
-	_, err := s.db.Exec(query, id, content, contentType, imageData, timestamp, threatLevel, safeEntry, false, 0)
+	query := "INSERT INTO clipboard_items (id, content, content_type, image_data, timestamp, threat_level, safe_entry, is_pinned, pin_order, source_app, source_title, source_selection, text_language) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err = s.db.Exec(query, id, content, contentType, imageData, timestamp, threatLevel, safeEntry, false, 0, source.App, source.Title, source.Selection, textLanguage(content, contentType))
 	if err != nil {
-		return err
+		return "", err
+	}
+	if contentType == "text" {
+		if err := s.expireIfOTP(id, content); err != nil {
+			return "", err
+		}
 	}
 
 	// Keep only the latest maxEntries items
-	deleteQuery := `
-		DELETE FROM clipboard_items 
-		WHERE id NOT IN (
-			SELECT id FROM clipboard_items 
-			ORDER BY timestamp DESC 
-			LIMIT ?
-		)
-	`
-	_, err = s.db.Exec(deleteQuery, s.maxEntries)
-	return err
+	_, err = s.EnforceRetention()
+	return id, err
+}
+
+// findDuplicate returns the ID of an entry with the same content, or "" if there is none
+func (s *Storage) findDuplicate(content, contentType string, imageData []byte) (string, error) {
+	if contentType == "text" {
+		var existingID string
+		normalizedContent := normalizeContentForDeduplication(content)
+
+		// Check for existing entries with the same normalized content. TRIM is given the
+		// whitespace strings.TrimSpace removes, as by default it only removes spaces
+		query := "SELECT id FROM clipboard_items WHERE TRIM(content, ' ' || char(9, 10, 11, 12, 13)) = ? AND content_type = ? LIMIT 1"
+		err := s.db.QueryRow(query, normalizedContent, contentType).Scan(&existingID)
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return existingID, err
+	}
+
+	// For images, we need to compare both content and image data
+	// Use a simpler approach to avoid database locks
+	query := "SELECT id, image_data FROM clipboard_items WHERE content = ? AND content_type = ?"
+	rows, err := s.db.Query(query, content, contentType)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tempID string
+		var tempImageData []byte
+		if err := rows.Scan(&tempID, &tempImageData); err != nil {
+			return "", err
+		}
+		// Compare image data
+		if bytes.Equal(imageData, tempImageData) {
+			return tempID, nil
+		}
+	}
+	return "", rows.Err()
+}
+
+// Import stores an entry copied at timestamp, as read from another clipboard manager.
+// It reports false when the entry already exists; the existing entry then keeps the
+// newer of the two timestamps. Retention is not enforced, call EnforceRetention after
+// importing a batch.
+func (s *Storage) Import(content, contentType string, imageData []byte, timestamp time.Time) (bool, error) {
+	if contentType == "text" {
+		content = s.normalize.Apply(content)
+	}
+	if content == "" && len(imageData) == 0 {
+		return false, nil
+	}
+
+	existingID, err := s.findDuplicate(content, contentType, imageData)
+	if err != nil {
+		return false, err
+	}
+	if existingID != "" {
+		_, err := s.db.Exec("UPDATE clipboard_items SET timestamp = ? WHERE id = ? AND timestamp < ?", timestamp, existingID, timestamp)
+		return false, err
+	}
+
+	id := fmt.Sprintf("%d", time.Now().UnixNano())
+	threatLevel, safeEntry := calculateThreatLevel(content, contentType)
+
+	query := "INSERT INTO clipboard_items (id, content, content_type, image_data, timestamp, threat_level, safe_entry, is_pinned, pin_order, text_language) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	if _, err := s.db.Exec(query, id, content, contentType, imageData, timestamp, threatLevel, safeEntry, false, 0, textLanguage(content, contentType)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (s *Storage) GetAll() []ClipboardItem {
@@ -286,7 +359,7 @@ func (s *Storage) GetItemCount() int {
 
 // GetAllMeta returns lightweight metadata for all items (without image data)
 func (s *Storage) GetAllMeta() []ClipboardItemMeta {
-	query := "SELECT id, content, content_type, timestamp, threat_level, safe_entry, is_pinned, pin_order FROM clipboard_items ORDER BY is_pinned DESC, pin_order ASC, timestamp DESC"
+	query := "SELECT " + metaColumns + " FROM clipboard_items ORDER BY is_pinned DESC, pin_order ASC, timestamp DESC"
 	rows, err := s.db.Query(query)
 	if err != nil {
 		return []ClipboardItemMeta{}
@@ -296,7 +369,7 @@ func (s *Storage) GetAllMeta() []ClipboardItemMeta {
 	var items []ClipboardItemMeta
 	for rows.Next() {
 		var item ClipboardItemMeta
-		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder)
+		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder, &item.Size, &item.Title, &item.TextLanguage)
 		if err != nil {
 			continue
 		}
@@ -308,7 +381,7 @@ func (s *Storage) GetAllMeta() []ClipboardItemMeta {
 
 // GetPage returns a page of lightweight metadata items (without image data)
 func (s *Storage) GetPage(offset, limit int) []ClipboardItemMeta {
-	query := "SELECT id, content, content_type, timestamp, threat_level, safe_entry, is_pinned, pin_order FROM clipboard_items ORDER BY is_pinned DESC, pin_order ASC, timestamp DESC LIMIT ? OFFSET ?"
+	query := "SELECT " + metaColumns + " FROM clipboard_items ORDER BY is_pinned DESC, pin_order ASC, timestamp DESC LIMIT ? OFFSET ?"
 	rows, err := s.db.Query(query, limit, offset)
 	if err != nil {
 		return []ClipboardItemMeta{}
@@ -318,7 +391,7 @@ func (s *Storage) GetPage(offset, limit int) []ClipboardItemMeta {
 	var items []ClipboardItemMeta
 	for rows.Next() {
 		var item ClipboardItemMeta
-		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder)
+		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder, &item.Size, &item.Title, &item.TextLanguage)
 		if err != nil {
 			continue
 		}
@@ -339,6 +412,75 @@ func (s *Storage) GetImageData(id string) []byte {
 	return imageData
 }
 
+// sizeColumn is the bytes an item's content and image data take up
+const sizeColumn = "LENGTH(CAST(content AS BLOB)) + COALESCE(LENGTH(image_data), 0)"
+
+// metaColumns and metaOrder are shared by the filtered metadata queries
+const (
+	metaColumns = "id, content, content_type, timestamp, threat_level, safe_entry, is_pinned, pin_order, " + sizeColumn + ", title, text_language"
+	metaOrder   = "ORDER BY is_pinned DESC, pin_order ASC, timestamp DESC"
+)
+
+// queryMeta runs a metadata query and scans the rows, skipping any that fail to scan
+func (s *Storage) queryMeta(query string, args ...interface{}) []ClipboardItemMeta {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return []ClipboardItemMeta{}
+	}
+	defer rows.Close()
+
+	items := []ClipboardItemMeta{}
+	for rows.Next() {
+		var item ClipboardItemMeta
+		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder, &item.Size, &item.Title, &item.TextLanguage)
+		if err != nil {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// GetByThreatLevel returns metadata for items with the given threat level ("high", "medium", ...)
+func (s *Storage) GetByThreatLevel(level string) []ClipboardItemMeta {
+	return s.queryMeta("SELECT "+metaColumns+" FROM clipboard_items WHERE threat_level = ? "+metaOrder, level)
+}
+
+// GetImages returns metadata for all image items
+func (s *Storage) GetImages() []ClipboardItemMeta {
+	return s.queryMeta("SELECT "+metaColumns+" FROM clipboard_items WHERE content_type = 'image' "+metaOrder)
+}
+
+// GetSafeMarked returns metadata for items with a detected threat that were marked safe
+func (s *Storage) GetSafeMarked() []ClipboardItemMeta {
+	return s.queryMeta("SELECT "+metaColumns+" FROM clipboard_items WHERE threat_level != 'none' AND safe_entry = TRUE "+metaOrder)
+}
+
+// GetMeta returns lightweight metadata for a single item, or nil if it doesn't exist
+func (s *Storage) GetMeta(id string) *ClipboardItemMeta {
+	query := "SELECT " + metaColumns + " FROM clipboard_items WHERE id = ?"
+	row := s.db.QueryRow(query, id)
+
+	var item ClipboardItemMeta
+	err := row.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder, &item.Size, &item.Title, &item.TextLanguage)
+	if err != nil {
+		return nil
+	}
+
+	return &item
+}
+
+// LatestText returns the most recently copied text item, ignoring pin order, or nil if
+// there is none
+func (s *Storage) LatestText() *ClipboardItemMeta {
+	items := s.queryMeta("SELECT " + metaColumns + " FROM clipboard_items WHERE content_type = 'text' ORDER BY timestamp DESC LIMIT 1")
+	if len(items) == 0 {
+		return nil
+	}
+	return &items[0]
+}
+
 // GetFullItem returns a complete ClipboardItem including image data for a specific ID
 func (s *Storage) GetFullItem(id string) *ClipboardItem {
 	query := "SELECT id, content, content_type, image_data, timestamp, threat_level, safe_entry, is_pinned, pin_order FROM clipboard_items WHERE id = ?"
@@ -381,6 +523,7 @@ func (item *ClipboardItem) ToMeta() ClipboardItemMeta {
 		SafeEntry:   item.SafeEntry,
 		IsPinned:    item.IsPinned,
 		PinOrder:    item.PinOrder,
+		Size:        int64(len(item.Content) + len(item.ImageData)),
 	}
 }
 
@@ -410,8 +553,8 @@ func (s *Storage) Update(id string, newContent string) error {
 	// Recalculate threat level and safe entry for the new content
 	threatLevel, safeEntry := calculateThreatLevel(newContent, contentType)
 
-	query := "UPDATE clipboard_items SET content = ?, threat_level = ?, safe_entry = ? WHERE id = ?"
-	_, err = s.db.Exec(query, newContent, threatLevel, safeEntry, id)
+	query := "UPDATE clipboard_items SET content = ?, threat_level = ?, safe_entry = ?, text_language = ? WHERE id = ?"
+	_, err = s.db.Exec(query, newContent, threatLevel, safeEntry, textLanguage(newContent, contentType), id)
 	return err
 }
 
@@ -422,7 +565,45 @@ func (s *Storage) UpdateSafeEntry(id string, safeEntry bool) error {
 	return err
 }
 
+// GetLanguageOverride returns the syntax highlighting language forced for an item, or ""
+func (s *Storage) GetLanguageOverride(id string) string {
+	var language sql.NullString
+	err := s.db.QueryRow("SELECT language_override FROM clipboard_items WHERE id = ?", id).Scan(&language)
+	if err != nil {
+		return ""
+	}
+	return language.String
+}
+
+// GetLanguageOverrides returns the forced syntax highlighting language of every item that has one, by ID
+func (s *Storage) GetLanguageOverrides() map[string]string {
+	overrides := make(map[string]string)
+	rows, err := s.db.Query("SELECT id, language_override FROM clipboard_items WHERE COALESCE(language_override, '') != ''")
+	if err != nil {
+		return overrides
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, language string
+		if err := rows.Scan(&id, &language); err != nil {
+			continue
+		}
+		overrides[id] = language
+	}
+	return overrides
+}
+
+// SetLanguageOverride forces the syntax highlighting language for an item ("" restores detection)
+func (s *Storage) SetLanguageOverride(id string, language string) error {
+	_, err := s.db.Exec("UPDATE clipboard_items SET language_override = ? WHERE id = ?", language, id)
+	return err
+}
+
 func (s *Storage) Delete(id string) error {
+	if s.shred {
+		return s.shredDelete("clipboard_items", id)
+	}
 	query := "DELETE FROM clipboard_items WHERE id = ?"
 	_, err := s.db.Exec(query, id)
 	return err
@@ -451,7 +632,8 @@ func (s *Storage) DeduplicateExisting() (int, error) {
 	for _, item := range items {
 		// Create a key for text content
 		if item.ContentType == "text" {
-			normalizedContent := normalizeContentForDeduplication(item.Content)
+			// Entries stored before normalization was enabled still match
+			normalizedContent := normalizeContentForDeduplication(s.normalize.Apply(item.Content))
 			key := fmt.Sprintf("text:%s", normalizedContent)
 			if _, exists := seenContent[key]; exists {
 				// This is a duplicate, mark for deletion
@@ -548,7 +730,7 @@ func (s *Storage) UnpinItem(id string) error {
 
 // GetPinnedItems returns all pinned items in order
 func (s *Storage) GetPinnedItems() []ClipboardItemMeta {
-	query := "SELECT id, content, content_type, timestamp, threat_level, safe_entry, is_pinned, pin_order FROM clipboard_items WHERE is_pinned = TRUE ORDER BY pin_order ASC"
+	query := "SELECT " + metaColumns + " FROM clipboard_items WHERE is_pinned = TRUE ORDER BY pin_order ASC"
 	rows, err := s.db.Query(query)
 	if err != nil {
 		return []ClipboardItemMeta{}
@@ -558,7 +740,7 @@ func (s *Storage) GetPinnedItems() []ClipboardItemMeta {
 	var items []ClipboardItemMeta
 	for rows.Next() {
 		var item ClipboardItemMeta
-		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder)
+		err := rows.Scan(&item.ID, &item.Content, &item.ContentType, &item.Timestamp, &item.ThreatLevel, &item.SafeEntry, &item.IsPinned, &item.PinOrder, &item.Size, &item.Title, &item.TextLanguage)
 		if err != nil {
 			continue
 		}
@@ -578,10 +760,61 @@ func (s *Storage) GetPinnedCount() int {
 	return count
 }
 
+// rescanBatchSize is the number of rows read and updated per transaction during a rescan
+const rescanBatchSize = 500
+
+// RescanProgressFunc reports rescan progress after each batch
+type RescanProgressFunc func(scanned, total int)
+
 // RescanSecurityThreats re-scans all items and updates their threat levels
-// Returns statistics about the changes made
+// Returns statistics about the rescan operation
 func (s *Storage) RescanSecurityThreats() (map[string]int, error) {
-	stats := map[string]int{
+	return s.RescanSecurityThreatsContext(context.Background(), nil)
+}
+
+// RescanSecurityThreatsContext re-scans text items in batches without loading image data,
+// committing each batch in its own transaction. Progress is reported after every batch and
+// the scan stops at the next batch boundary when ctx is cancelled, keeping completed batches.
+func (s *Storage) RescanSecurityThreatsContext(ctx context.Context, progress RescanProgressFunc) (map[string]int, error) {
+	stats := newRescanStats()
+
+	stats["total_items"] = s.GetItemCount()
+
+	var textTotal int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM clipboard_items WHERE content_type != 'image'").Scan(&textTotal); err != nil {
+		return stats, fmt.Errorf("failed to count text items: %w", err)
+	}
+
+	lastID := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		batch, err := s.readRescanBatch(lastID)
+		if err != nil {
+			return stats, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastID = batch[len(batch)-1].ID
+
+		if err := s.rescanBatch(batch, stats, nil); err != nil {
+			return stats, err
+		}
+
+		if progress != nil {
+			progress(stats["items_scanned"], textTotal)
+		}
+	}
+
+	return stats, nil
+}
+
+// newRescanStats returns the counters a rescan reports, all zero
+func newRescanStats() map[string]int {
+	return map[string]int{
 		"total_items":     0,
 		"items_scanned":   0,
 		"threats_before":  0,
@@ -598,79 +831,94 @@ func (s *Storage) RescanSecurityThreats() (map[string]int, error) {
 		"upgraded":        0,
 		"unchanged":       0,
 	}
+}
 
-	// Get all items
-	items := s.GetAll()
-	stats["total_items"] = len(items)
+// rescanItem holds the columns a rescan needs, leaving image data in the database
+type rescanItem struct {
+	ID          string
+	Content     string
+	ThreatLevel string
+}
 
-	for _, item := range items {
-		// Skip images
-		if item.ContentType == "image" {
+// readRescanBatch reads the next batch of text items after lastID
+func (s *Storage) readRescanBatch(lastID string) ([]rescanItem, error) {
+	query := "SELECT id, content, threat_level FROM clipboard_items WHERE content_type != 'image' AND id > ? ORDER BY id LIMIT ?"
+	rows, err := s.db.Query(query, lastID, rescanBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read items: %w", err)
+	}
+	defer rows.Close()
+
+	var batch []rescanItem
+	for rows.Next() {
+		var item rescanItem
+		if err := rows.Scan(&item.ID, &item.Content, &item.ThreatLevel); err != nil {
 			continue
 		}
+		batch = append(batch, item)
+	}
+
+	return batch, rows.Err()
+}
+
+// rescanBatch recalculates threat levels for a batch and writes changes in one transaction.
+// The IDs of items whose threat level went up are appended to upgraded when it isn't nil.
+func (s *Storage) rescanBatch(batch []rescanItem, stats map[string]int, upgraded *[]string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
+	for _, item := range batch {
 		stats["items_scanned"]++
-		
+
 		// Record old threat level
 		oldThreatLevel := item.ThreatLevel
 		switch oldThreatLevel {
-		case "none":
-			stats["none_before"]++
-		case "low":
-			stats["low_before"]++
-		case "medium":
-			stats["medium_before"]++
-		case "high":
-			stats["high_before"]++
+		case "none", "low", "medium", "high":
+			stats[oldThreatLevel+"_before"]++
 		}
-
 		if oldThreatLevel != "none" {
 			stats["threats_before"]++
 		}
 
 		// Calculate new threat level
-		newThreatLevel, newSafeEntry := calculateThreatLevel(item.Content, item.ContentType)
-		
-		// Record new threat level
+		newThreatLevel, newSafeEntry := calculateThreatLevel(item.Content, "text")
 		switch newThreatLevel {
-		case "none":
-			stats["none_after"]++
-		case "low":
-			stats["low_after"]++
-		case "medium":
-			stats["medium_after"]++
-		case "high":
-			stats["high_after"]++
+		case "none", "low", "medium", "high":
+			stats[newThreatLevel+"_after"]++
 		}
-
 		if newThreatLevel != "none" {
 			stats["threats_after"]++
 		}
 
 		// Compare changes
-		if oldThreatLevel != newThreatLevel {
-			// Determine if it's an upgrade or downgrade
-			oldSeverity := threatLevelToSeverity(oldThreatLevel)
-			newSeverity := threatLevelToSeverity(newThreatLevel)
-			
-			if newSeverity > oldSeverity {
-				stats["upgraded"]++
-			} else {
-				stats["downgraded"]++
-			}
+		if oldThreatLevel == newThreatLevel {
+			stats["unchanged"]++
+			continue
+		}
 
-			// Update the database
-			query := "UPDATE clipboard_items SET threat_level = ?, safe_entry = ? WHERE id = ?"
-			_, err := s.db.Exec(query, newThreatLevel, newSafeEntry, item.ID)
-			if err != nil {
-				return stats, fmt.Errorf("failed to update item %s: %w", item.ID, err)
+		// Determine if it's an upgrade or downgrade
+		if threatLevelToSeverity(newThreatLevel) > threatLevelToSeverity(oldThreatLevel) {
+			stats["upgraded"]++
+			if upgraded != nil {
+				*upgraded = append(*upgraded, item.ID)
 			}
 		} else {
-			stats["unchanged"]++
+			stats["downgraded"]++
+		}
+
+		query := "UPDATE clipboard_items SET threat_level = ?, safe_entry = ? WHERE id = ?"
+		if _, err := tx.Exec(query, newThreatLevel, newSafeEntry, item.ID); err != nil {
+			return fmt.Errorf("failed to update item %s: %w", item.ID, err)
 		}
 	}
 
-	return stats, nil
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	return nil
 }
 
 // threatLevelToSeverity converts threat level to numeric severity for comparison
@@ -709,7 +957,15 @@ func (s *Storage) PruneDatabase(pruneEmptyData, pruneSingleChar bool) (int, erro
 	whereClause := strings.Join(conditions, " OR ")
 	query := fmt.Sprintf("DELETE FROM clipboard_items WHERE %s", whereClause)
 
-	result, err := s.db.Exec(query, args...)
+	// Pruned entries are shredded like deleted ones
+	ctx := context.Background()
+	conn, err := s.deleteConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to prune database: %w", err)
 	}
@@ -719,10 +975,11 @@ func (s *Storage) PruneDatabase(pruneEmptyData, pruneSingleChar bool) (int, erro
 		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	return int(rowsAffected), nil
+	return int(rowsAffected), s.releaseFreed(ctx, conn)
 }
 
 func (s *Storage) Close() error {
+	s.closeWatchConn()
 	if s.db != nil {
 		return s.db.Close()
 	}
@@ -0,0 +1,91 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tags are stored as a comma separated list in the tags column and are limited to
+// lowercase letters, digits, '-' and '_' so a tag never contains the separator.
+
+// NormalizeTag lowercases and validates a tag
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("empty tag")
+	}
+	for _, r := range tag {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return "", fmt.Errorf("invalid tag %q: use letters, digits, '-' and '_'", tag)
+		}
+	}
+	return tag, nil
+}
+
+// GetTags returns the tags of an item in sorted order
+func (s *Storage) GetTags(id string) []string {
+	var tags sql.NullString
+	if err := s.db.QueryRow("SELECT tags FROM clipboard_items WHERE id = ?", id).Scan(&tags); err != nil {
+		return nil
+	}
+	return splitTags(tags.String)
+}
+
+// SetTags replaces the tags of an item
+func (s *Storage) SetTags(id string, tags []string) error {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+
+	_, err := s.db.Exec("UPDATE clipboard_items SET tags = ? WHERE id = ?", strings.Join(normalized, ","), id)
+	return err
+}
+
+// AddTag adds a tag to an item, keeping its existing tags
+func (s *Storage) AddTag(id string, tag string) error {
+	return s.SetTags(id, append(s.GetTags(id), tag))
+}
+
+// splitTags parses the tags column
+func splitTags(column string) []string {
+	if column == "" {
+		return nil
+	}
+	return strings.Split(column, ",")
+}
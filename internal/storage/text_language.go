@@ -0,0 +1,75 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/adaryorg/nclip/internal/textlang"
+)
+
+// textLanguage returns the natural language stored for content, "" for images and text
+// whose language can't be told
+func textLanguage(content, contentType string) string {
+	if contentType != "text" {
+		return ""
+	}
+	return textlang.Detect(content)
+}
+
+// addTextLanguages adds the text_language column and detects the language of the text
+// items stored before it existed
+func addTextLanguages(tx *sql.Tx) error {
+	if err := addColumns("clipboard_items", "text_language TEXT DEFAULT ''")(tx); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT id, content FROM clipboard_items WHERE content_type = 'text'")
+	if err != nil {
+		return err
+	}
+	detected := make(map[string]string)
+	for rows.Next() {
+		var id, content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return err
+		}
+		if language := textlang.Detect(content); language != "" {
+			detected[id] = language
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, language := range detected {
+		if _, err := tx.Exec("UPDATE clipboard_items SET text_language = ? WHERE id = ?", language, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
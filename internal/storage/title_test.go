@@ -0,0 +1,47 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import "testing"
+
+func TestSetTextTitle(t *testing.T) {
+	storage, _ := createTestStorage(t)
+
+	if err := storage.Add("https://github.com/adaryorg/nclip"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := storage.SetTextTitle("https://github.com/adaryorg/nclip", "GitHub - adaryorg/nclip"); err != nil {
+		t.Fatalf("SetTextTitle failed: %v", err)
+	}
+	items := storage.GetAllMeta()
+	if len(items) != 1 || items[0].Title != "GitHub - adaryorg/nclip" {
+		t.Errorf("Expected the title in the item metadata, got %+v", items)
+	}
+
+	// Content that was deleted meanwhile is ignored
+	if err := storage.SetTextTitle("https://example.com", "Example"); err != nil {
+		t.Errorf("Expected no error for unknown content, got %v", err)
+	}
+}
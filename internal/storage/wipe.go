@@ -0,0 +1,73 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// WipeUnpinned removes every unpinned entry, the whole archive and all pending items in
+// one transaction, returning how many entries were removed. With shredding enabled the
+// removed content is zeroed and the freed pages are vacuumed.
+func (s *Storage) WipeUnpinned() (int, error) {
+	ctx := context.Background()
+	conn, err := s.deleteConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM clipboard_items WHERE is_pinned = FALSE")
+	if err != nil {
+		return 0, fmt.Errorf("failed to wipe history: %w", err)
+	}
+	removed, _ := result.RowsAffected()
+
+	result, err = tx.ExecContext(ctx, "DELETE FROM archived_items")
+	if err != nil {
+		return 0, fmt.Errorf("failed to wipe archive: %w", err)
+	}
+	archived, _ := result.RowsAffected()
+
+	result, err = tx.ExecContext(ctx, "DELETE FROM pending_items")
+	if err != nil {
+		return 0, fmt.Errorf("failed to wipe pending items: %w", err)
+	}
+	pending, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	total := int(removed + archived + pending)
+	return total, s.releaseFreed(ctx, conn)
+}
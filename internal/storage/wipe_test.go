@@ -0,0 +1,67 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWipeUnpinned(t *testing.T) {
+	storage, _ := createTestStorage(t)
+	storage.SetRetention(0, true)
+
+	for i := 0; i < 15; i++ {
+		if err := storage.Add(fmt.Sprintf("content %d", i)); err != nil {
+			t.Fatalf("Failed to add content %d: %v", i, err)
+		}
+	}
+	pinned := storage.GetAllMeta()[3]
+	if err := storage.PinItem(pinned.ID); err != nil {
+		t.Fatalf("PinItem failed: %v", err)
+	}
+	if _, err := storage.AddPending("not kept yet", "text", nil, Source{}); err != nil {
+		t.Fatalf("AddPending failed: %v", err)
+	}
+
+	removed, err := storage.WipeUnpinned()
+	if err != nil {
+		t.Fatalf("WipeUnpinned failed: %v", err)
+	}
+	if removed != 15 {
+		t.Errorf("Expected 15 removed entries, got %d", removed)
+	}
+	if count := storage.PendingCount(); count != 0 {
+		t.Errorf("Expected no pending items, got %d", count)
+	}
+
+	remaining := storage.GetAllMeta()
+	if len(remaining) != 1 || remaining[0].ID != pinned.ID {
+		t.Errorf("Expected only the pinned item to remain, got %+v", remaining)
+	}
+	if count := storage.GetArchivedCount(); count != 0 {
+		t.Errorf("Expected empty archive, got %d", count)
+	}
+}
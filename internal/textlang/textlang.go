@@ -0,0 +1,250 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package textlang guesses the natural language a text is written in, from its script
+// and from the most common words of each language. It needs no models or network access,
+// so it only tells apart a handful of widely used languages.
+package textlang
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxSample bounds how much of a text is looked at; the start is enough to tell
+const maxSample = 4096
+
+// Thresholds for Latin script languages, which are told apart by common words. Short
+// texts, code and lists of names rarely reach them and stay undetected.
+const (
+	minWords    = 3    // Words a text needs before its language is guessed
+	minHits     = 2    // Common words the best language needs
+	minHitRatio = 0.15 // Share of all words that must be common words of the best language
+)
+
+// names maps the detected ISO 639-1 codes to English language names
+var names = map[string]string{
+	"ar": "Arabic",
+	"de": "German",
+	"el": "Greek",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"sv": "Swedish",
+	"th": "Thai",
+	"uk": "Ukrainian",
+	"zh": "Chinese",
+}
+
+// commonWords lists frequent short words of the Latin script languages. A word may
+// count for several languages.
+var commonWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "for", "you", "was", "with", "on", "are", "this",
+		"be", "have", "not", "but", "they", "at", "from", "or", "we", "can", "will", "what", "there", "an", "my",
+		"your", "would", "just", "about", "if"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "du", "sie", "es", "ein", "eine", "zu", "mit", "den",
+		"dem", "von", "auf", "für", "sich", "auch", "wir", "ihr", "aber", "oder", "wie", "noch", "nur", "bei",
+		"wird", "sind", "war", "hat", "dass", "kann", "mein"},
+	"fr": {"le", "la", "les", "et", "est", "un", "une", "des", "du", "que", "qui", "pas", "je", "tu", "il",
+		"elle", "nous", "vous", "pour", "dans", "avec", "sur", "ce", "cette", "mais", "ou", "au", "aux", "sont",
+		"être", "très", "plus", "merci", "bonjour"},
+	"es": {"el", "la", "los", "las", "y", "es", "un", "una", "que", "de", "no", "en", "por", "para", "con", "se",
+		"lo", "como", "pero", "más", "muy", "está", "son", "yo", "tú", "usted", "del", "al", "hola", "gracias",
+		"también", "porque"},
+	"it": {"il", "lo", "la", "gli", "le", "e", "è", "un", "una", "che", "di", "non", "per", "con", "sono", "ma",
+		"come", "anche", "della", "del", "questo", "questa", "io", "tu", "lui", "lei", "noi", "voi", "grazie",
+		"ciao", "perché", "molto"},
+	"pt": {"o", "a", "os", "as", "e", "é", "um", "uma", "que", "de", "não", "em", "para", "com", "por", "se",
+		"mas", "como", "mais", "muito", "está", "são", "eu", "você", "do", "da", "dos", "das", "obrigado",
+		"obrigada", "também", "isso"},
+	"nl": {"de", "het", "een", "en", "is", "van", "niet", "dat", "die", "ik", "je", "jij", "we", "wij", "zijn",
+		"met", "voor", "op", "maar", "ook", "te", "er", "naar", "om", "heb", "hebben", "wat", "dit", "nog", "kan",
+		"bij", "geen", "dank"},
+	"sv": {"och", "att", "det", "som", "en", "ett", "är", "inte", "jag", "du", "vi", "de", "på", "med", "för",
+		"till", "av", "men", "har", "om", "den", "kan", "så", "var", "här", "tack", "hej"},
+}
+
+// wordLanguages maps each common word to the languages it counts for
+var wordLanguages = func() map[string][]string {
+	byWord := make(map[string][]string)
+	for language, words := range commonWords {
+		for _, word := range words {
+			byWord[word] = append(byWord[word], language)
+		}
+	}
+	return byWord
+}()
+
+// scripts lists the non-Latin scripts that identify a language on their own; Cyrillic,
+// Han and kana are told apart in Detect
+var scripts = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hangul, "ko"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// Detect returns the ISO 639-1 code of the language text is written in, or "" when it
+// can't tell, e.g. for code, URLs or a few words
+func Detect(text string) string {
+	if len(text) > maxSample {
+		text = text[:maxSample]
+	}
+
+	var latin, cyrillic, han, kana, letters int
+	ukrainian := false
+	scriptCounts := make([]int, len(scripts))
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			ukrainian = ukrainian || strings.ContainsRune("іїєґІЇЄҐ", r)
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		default:
+			for i, script := range scripts {
+				if unicode.Is(script.table, r) {
+					scriptCounts[i]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// A script covering most letters decides, except for Latin
+	majority := func(count int) bool { return count*2 > letters }
+	switch {
+	case majority(cyrillic):
+		if ukrainian {
+			return "uk"
+		}
+		return "ru"
+	case majority(han + kana):
+		// Japanese mixes kanji with kana; Chinese has no kana
+		if kana > 0 {
+			return "ja"
+		}
+		return "zh"
+	}
+	for i, script := range scripts {
+		if majority(scriptCounts[i]) {
+			return script.language
+		}
+	}
+	if !majority(latin) {
+		return ""
+	}
+	return detectLatin(text)
+}
+
+// detectLatin picks the Latin script language whose common words make up the largest
+// part of text, if it stands out clearly enough
+func detectLatin(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < minWords {
+		return ""
+	}
+
+	hits := make(map[string]int)
+	for _, word := range words {
+		for _, language := range wordLanguages[word] {
+			hits[language]++
+		}
+	}
+
+	best, second := "", 0
+	for language := range hits {
+		switch count := hits[language]; {
+		case best == "" || count > hits[best]:
+			best, second = language, hits[best]
+		case count > second:
+			second = count
+		}
+	}
+	if best == "" || hits[best] < minHits || hits[best] == second ||
+		float64(hits[best]) < minHitRatio*float64(len(words)) {
+		return ""
+	}
+	return best
+}
+
+// Name returns the English name of a language code, or the code itself if unknown
+func Name(code string) string {
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return code
+}
+
+// Lookup resolves a language code or English name, in any case, to the code Detect
+// returns for it
+func Lookup(language string) (string, bool) {
+	language = strings.ToLower(language)
+	if _, ok := names[language]; ok {
+		return language, true
+	}
+	for code, name := range names {
+		if strings.ToLower(name) == language {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// Codes returns the codes of all detected languages, sorted
+func Codes() []string {
+	codes := make([]string, 0, len(names))
+	for code := range names {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
@@ -0,0 +1,74 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package textlang
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Can you send me the report when it is ready? I would like to read it before the meeting.", "en"},
+		{"Kannst du mir bitte den Bericht schicken, wenn er fertig ist? Ich will ihn noch lesen.", "de"},
+		{"Est-ce que tu peux m'envoyer le rapport? Je voudrais le lire avant la réunion avec vous.", "fr"},
+		{"¿Puedes enviarme el informe cuando esté listo? Me gustaría leerlo antes de la reunión.", "es"},
+		{"Puoi mandarmi il rapporto quando è pronto? Vorrei leggerlo prima della riunione con lui.", "it"},
+		{"Você pode me enviar o relatório quando estiver pronto? Eu quero ler antes da reunião.", "pt"},
+		{"Kun je mij het rapport sturen als het klaar is? Ik wil het voor de vergadering lezen.", "nl"},
+		{"Kan du skicka rapporten till mig när den är klar? Jag vill läsa den före mötet.", "sv"},
+		{"Можешь прислать мне отчёт, когда он будет готов?", "ru"},
+		{"Чи можеш надіслати мені звіт, коли він буде готовий?", "uk"},
+		{"Μπορείς να μου στείλεις την αναφορά;", "el"},
+		{"报告准备好了请发给我", "zh"},
+		{"報告書ができたら送ってください", "ja"},
+		{"보고서가 준비되면 보내주세요", "ko"},
+		{"func main() { fmt.Println(x) }", ""},
+		{"https://example.com/path?query=1", ""},
+		{"hello world", ""},
+		{"12345 67890", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.text); got != tt.want {
+			t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := map[string]string{"de": "de", "German": "de", "ENGLISH": "en", "uk": "uk"}
+	for input, want := range tests {
+		if got, ok := Lookup(input); !ok || got != want {
+			t.Errorf("Lookup(%q) = %q, %v, want %q", input, got, ok, want)
+		}
+	}
+	if _, ok := Lookup("klingon"); ok {
+		t.Error("Expected an unknown language to be rejected")
+	}
+	if Name("fr") != "French" || Name("xx") != "xx" {
+		t.Errorf("Unexpected names %q and %q", Name("fr"), Name("xx"))
+	}
+}
@@ -0,0 +1,103 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// enableAccessibility switches the model to accessibility mode: indicators become text
+// labels, all colors are dropped (attributes like bold and reverse video stay, for high
+// contrast), frames lose their box drawing and status changes ring the terminal bell
+func (m *Model) enableAccessibility() {
+	m.accessible = true
+	caps := AccessibleCapabilities()
+	m.iconHelper = newSecurityIconHelperWithCaps(caps)
+	m.pinIconHelper = newPinIconHelperWithCaps(caps)
+	m.useBasicColors = true
+	m.themeService.SetHighContrast(true)
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// frameBorder returns the border drawn around dialogs; accessibility mode keeps the
+// spacing but draws nothing, so screen readers don't read out box-drawing characters
+func (m Model) frameBorder() lipgloss.Border {
+	if m.accessible {
+		return lipgloss.HiddenBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// separatorChar returns the character horizontal separators are drawn with
+func (m Model) separatorChar() string {
+	if m.accessible {
+		return "-"
+	}
+	return "─"
+}
+
+// selectionPrefix returns the indentation for a list row, marking the selected item's
+// first row in accessibility mode so the selection doesn't depend on colors
+func (m Model) selectionPrefix(selected bool, lineIndex int) string {
+	if m.accessible && selected && lineIndex == 0 {
+		return "> "
+	}
+	return "  "
+}
+
+// cue rings the terminal bell in accessibility mode to signal a state change
+func (m Model) cue() {
+	if m.accessible {
+		fmt.Print("\a")
+	}
+}
+
+// highContrastMainViewStyles returns main view styles that rely on text attributes only
+func highContrastMainViewStyles() MainViewStyles {
+	plain := lipgloss.NewStyle()
+	bold := lipgloss.NewStyle().Bold(true)
+	return MainViewStyles{
+		Border:              plain,
+		Header:              bold,
+		HeaderSeparator:     plain,
+		Text:                plain,
+		HighlightedText:     bold.Underline(true),
+		PinnedIndicator:     bold,
+		HighRiskIndicator:   bold,
+		MediumRiskIndicator: bold,
+		FooterSeparator:     plain,
+		FooterKey:           bold,
+		FooterAction:        plain,
+		FooterDivider:       plain,
+		FilterIndicator:     bold,
+		NormalBackground:    plain,
+		AlternateBackground: plain,
+		SelectedBackground:  lipgloss.NewStyle().Reverse(true),
+		GlobalBackground:    plain,
+	}
+}
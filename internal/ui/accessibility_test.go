@@ -0,0 +1,95 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func newAccessibleTestModel(t *testing.T) Model {
+	t.Helper()
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	cfg := &config.Config{}
+	m := Model{themeService: NewThemeService(&cfg.Theme)}
+	m.enableAccessibility()
+	return m
+}
+
+func TestAccessibility_TextLabels(t *testing.T) {
+	m := newAccessibleTestModel(t)
+
+	item := storage.ClipboardItem{Content: "hunter2", IsPinned: true, PinOrder: 1, ThreatLevel: "high"}
+	if got := m.buildPlainLineWithIcons(item, "hunter2"); got != "[PIN 1] [HIGH RISK] hunter2" {
+		t.Errorf("Expected text labels, got %q", got)
+	}
+
+	item = storage.ClipboardItem{Content: "token", ThreatLevel: "medium", SafeEntry: true}
+	if got := m.buildPlainLineWithIcons(item, "token"); got != "[SAFE] token" {
+		t.Errorf("Expected a safe label, got %q", got)
+	}
+
+	if !strings.Contains(m.iconHelper.GetIndicatorDescription(), "[HIGH RISK]") {
+		t.Errorf("Expected the help to describe the labels, got %q", m.iconHelper.GetIndicatorDescription())
+	}
+}
+
+func TestAccessibility_PlainLayout(t *testing.T) {
+	m := newAccessibleTestModel(t)
+
+	if m.separatorChar() != "-" {
+		t.Errorf("Expected an ASCII separator, got %q", m.separatorChar())
+	}
+	if m.frameBorder() != lipgloss.HiddenBorder() {
+		t.Error("Expected frames without box drawing")
+	}
+	if m.selectionPrefix(true, 0) != "> " || m.selectionPrefix(true, 1) != "  " || m.selectionPrefix(false, 0) != "  " {
+		t.Error("Expected only the selected item's first row to be marked")
+	}
+
+	styles := m.themeService.GetMainViewStyles()
+	if !styles.SelectedBackground.GetReverse() {
+		t.Error("Expected the selection to use reverse video")
+	}
+	if styles.Text.GetForeground() != (lipgloss.NoColor{}) {
+		t.Error("Expected no text color in high-contrast mode")
+	}
+}
+
+func TestAccessibility_DefaultUnchanged(t *testing.T) {
+	cfg := &config.Config{}
+	m := Model{themeService: NewThemeService(&cfg.Theme)}
+
+	if m.separatorChar() != "─" || m.frameBorder() != lipgloss.RoundedBorder() || m.selectionPrefix(true, 0) != "  " {
+		t.Error("Expected the regular layout outside accessibility mode")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// annotationTools are tried in order when editor.annotate_command is not set.
+// Tools without an {output} placeholder save over their input file.
+var annotationTools = []string{
+	"satty --filename {input} --output-filename {output} --early-exit",
+	"swappy -f {input} -o {output}",
+	"ksnip --edit {input}",
+}
+
+// lookPath is exec.LookPath, replaceable in tests
+var lookPath = exec.LookPath
+
+// errNoAnnotationTool is returned when no annotation command is configured or installed
+var errNoAnnotationTool = errors.New("no annotation tool found (install satty, swappy or ksnip, or set editor.annotate_command)")
+
+// annotateCommand returns the configured annotation command template, or the first installed known tool
+func annotateCommand(configured string) (string, error) {
+	if strings.TrimSpace(configured) != "" {
+		return configured, nil
+	}
+	for _, tool := range annotationTools {
+		if _, err := lookPath(strings.Fields(tool)[0]); err == nil {
+			return tool, nil
+		}
+	}
+	return "", errNoAnnotationTool
+}
+
+// expandAnnotateCommand substitutes {input} and {output} in the command template and
+// returns the arguments along with the file the annotated image will be read back from
+func expandAnnotateCommand(template, input, output string) ([]string, string, error) {
+	result := input
+	args, err := config.SplitCommand(template)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(args) == 0 {
+		return nil, "", errors.New("empty annotate command")
+	}
+	for i, arg := range args {
+		if strings.Contains(arg, "{output}") {
+			result = output
+		}
+		arg = strings.ReplaceAll(arg, "{input}", input)
+		args[i] = strings.ReplaceAll(arg, "{output}", output)
+	}
+	return args, result, nil
+}
+
+// annotateDoneMsg reports the outcome of an annotation session
+type annotateDoneMsg struct {
+	imported bool
+	err      error
+	written  ownWrite
+}
+
+// annotateImageCmd opens an image in the annotation tool, waits for it to close and stores
+// the annotated result as a new entry that is also placed on the clipboard
+func (m *Model) annotateImageCmd(item storage.ClipboardItem) tea.Cmd {
+	if len(item.ImageData) == 0 {
+		return nil
+	}
+	store := m.storage
+	configured := m.config.Editor.AnnotateCommand
+	temp := m.temp
+
+	return func() tea.Msg {
+		template, err := annotateCommand(configured)
+		if err != nil {
+			return annotateDoneMsg{err: err}
+		}
+
+		input, err := temp.create("annotate-*.png")
+		if err != nil {
+			return annotateDoneMsg{err: err}
+		}
+		_, writeErr := input.Write(item.ImageData)
+		input.Close()
+		defer os.Remove(input.Name())
+		if writeErr != nil {
+			return annotateDoneMsg{err: writeErr}
+		}
+		output := strings.TrimSuffix(input.Name(), ".png") + "-annotated.png"
+		defer os.Remove(output)
+
+		args, resultPath, err := expandAnnotateCommand(template, input.Name(), output)
+		if err != nil {
+			return annotateDoneMsg{err: err}
+		}
+		logging.Debug("Annotate: running %s", strings.Join(args, " "))
+		// Wait for the tool to exit; it is a GUI app so the TUI stays usable meanwhile
+		if err := exec.Command(args[0], args[1:]...).Run(); err != nil {
+			return annotateDoneMsg{err: fmt.Errorf("%s: %w", args[0], err)}
+		}
+
+		annotated, err := os.ReadFile(resultPath)
+		if os.IsNotExist(err) || (err == nil && (len(annotated) == 0 || bytes.Equal(annotated, item.ImageData))) {
+			// The tool was closed without saving
+			return annotateDoneMsg{}
+		}
+		if err != nil {
+			return annotateDoneMsg{err: err}
+		}
+
+		written, err := trackWrite(store, func() error {
+			if err := store.AddImage(annotated, fmt.Sprintf("Image (%d bytes)", len(annotated))); err != nil {
+				return err
+			}
+			if err := store.RecordCopy("", "image", annotated); err != nil {
+				logging.Warn("Failed to record copy: %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return annotateDoneMsg{err: err}
+		}
+		return annotateDoneMsg{imported: true, written: written, err: clipboard.CopyImage(annotated)}
+	}
+}
+
+// handleAnnotateDone reloads the list so the annotated image shows up and reports the result
+func (m *Model) handleAnnotateDone(msg annotateDoneMsg) tea.Cmd {
+	if msg.imported && !m.archiveMode {
+		m.recordOwnWrite(msg.written)
+		m.reloadKeepingSelection()
+	}
+
+	switch {
+	case msg.err != nil:
+		failure := errorToast("annotate", msg.err)
+		return m.showToast(failure.level, failure.text)
+	case msg.imported:
+		return m.showToast(toastSuccess, "Annotated image saved and copied")
+	default:
+		return m.showToast(toastInfo, "Annotation closed without changes")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestAnnotateCommand_ConfiguredWins(t *testing.T) {
+	got, err := annotateCommand("my-tool {input}")
+	if err != nil || got != "my-tool {input}" {
+		t.Errorf("Expected configured command, got %q, %v", got, err)
+	}
+}
+
+func TestAnnotateCommand_DetectsInstalledTool(t *testing.T) {
+	defer func(orig func(string) (string, error)) { lookPath = orig }(lookPath)
+
+	lookPath = func(name string) (string, error) {
+		if name == "swappy" {
+			return "/usr/bin/swappy", nil
+		}
+		return "", exec.ErrNotFound
+	}
+	got, err := annotateCommand("")
+	if err != nil || got != "swappy -f {input} -o {output}" {
+		t.Errorf("Expected swappy, got %q, %v", got, err)
+	}
+
+	lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	if _, err := annotateCommand(" "); !errors.Is(err, errNoAnnotationTool) {
+		t.Errorf("Expected errNoAnnotationTool, got %v", err)
+	}
+}
+
+func TestExpandAnnotateCommand(t *testing.T) {
+	args, result, err := expandAnnotateCommand("satty --filename {input} --output-filename {output}", "/tmp/in.png", "/tmp/out.png")
+	want := []string{"satty", "--filename", "/tmp/in.png", "--output-filename", "/tmp/out.png"}
+	if err != nil || !reflect.DeepEqual(args, want) || result != "/tmp/out.png" {
+		t.Errorf("Got %v reading %q", args, result)
+	}
+
+	// Tools that save in place are read back from the input file
+	args, result, err = expandAnnotateCommand("ksnip --edit '{input}'", "/tmp/in.png", "/tmp/out.png")
+	if err != nil || !reflect.DeepEqual(args, []string{"ksnip", "--edit", "/tmp/in.png"}) || result != "/tmp/in.png" {
+		t.Errorf("Got %v reading %q", args, result)
+	}
+
+	if _, _, err := expandAnnotateCommand("satty --filename '{input}", "/tmp/in.png", "/tmp/out.png"); err == nil {
+		t.Error("Expected an error for an unterminated quote")
+	}
+}
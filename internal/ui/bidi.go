@@ -0,0 +1,196 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Most terminals draw characters strictly left to right, so Hebrew and Arabic would
+// appear reversed. Lines are wrapped and truncated in logical order and only then
+// reordered for display, so an ellipsis always marks the logical end of the text.
+
+// mirroredBrackets maps brackets to their counterpart, shown in right-to-left runs
+var mirroredBrackets = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+// isRTLRune reports whether r is a strong right-to-left character
+func isRTLRune(r rune) bool {
+	if r < 0x0590 {
+		return false
+	}
+	props, _ := bidi.LookupRune(r)
+	class := props.Class()
+	return class == bidi.R || class == bidi.AL
+}
+
+// hasRTL reports whether s contains right-to-left text
+func hasRTL(s string) bool {
+	for _, r := range s {
+		if isRTLRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRTLParagraph reports whether the first strong character of s is right-to-left
+func isRTLParagraph(s string) bool {
+	for _, r := range s {
+		props, _ := bidi.LookupRune(r)
+		switch props.Class() {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// hasStrongLTR reports whether runes contain a strong left-to-right character
+func hasStrongLTR(runes []rune) bool {
+	for _, r := range runes {
+		if props, _ := bidi.LookupRune(r); props.Class() == bidi.L {
+			return true
+		}
+	}
+	return false
+}
+
+// runeLevels resolves the embedding level of every rune in s. Left-to-right runs
+// without strong characters between right-to-left runs (numbers in Hebrew text)
+// nest inside them.
+func runeLevels(s string, rtlParagraph bool) []int {
+	var p bidi.Paragraph
+	if _, err := p.SetString(s); err != nil {
+		return nil
+	}
+	order, err := p.Order()
+	if err != nil {
+		return nil
+	}
+
+	levels := make([]int, 0, len(s))
+	for i := 0; i < order.NumRuns(); i++ {
+		run := order.Run(i)
+		runes := []rune(run.String())
+
+		level := 0
+		switch {
+		case run.Direction() == bidi.RightToLeft:
+			level = 1
+		case rtlParagraph:
+			level = 2
+		case i > 0 && i < order.NumRuns()-1 && !hasStrongLTR(runes):
+			level = 2
+		}
+		for range runes {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+// visualOrder reorders a single display line containing right-to-left text into the
+// left-to-right order the terminal draws it in. Lines with escape sequences are
+// returned unchanged.
+func visualOrder(line string) string {
+	if !hasRTL(line) || containsEscapes(line) {
+		return line
+	}
+
+	levels := runeLevels(line, isRTLParagraph(line))
+	if levels == nil {
+		return line
+	}
+
+	// Reverse whole grapheme clusters so combining marks stay on their base character
+	type cluster struct {
+		text  string
+		level int
+	}
+	var clusters []cluster
+	runeIndex, maxLevel := 0, 0
+	state := -1
+	for rest := line; len(rest) > 0; {
+		var text string
+		text, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		level := 0
+		if runeIndex < len(levels) {
+			level = levels[runeIndex]
+		}
+		if level%2 == 1 {
+			if r := []rune(text); len(r) == 1 {
+				if mirrored, ok := mirroredBrackets[r[0]]; ok {
+					text = string(mirrored)
+				}
+			}
+		}
+		clusters = append(clusters, cluster{text, level})
+		maxLevel = max(maxLevel, level)
+		runeIndex += len([]rune(text))
+	}
+
+	// From the highest level down to the lowest odd level, reverse every sequence
+	// of clusters at that level or above
+	for level := maxLevel; level >= 1; level-- {
+		for i := 0; i < len(clusters); {
+			if clusters[i].level < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(clusters) && clusters[j].level >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				clusters[a], clusters[b] = clusters[b], clusters[a]
+			}
+			i = j
+		}
+	}
+
+	out := make([]byte, 0, len(line))
+	for _, c := range clusters {
+		out = append(out, c.text...)
+	}
+	return string(out)
+}
+
+// displayOrder returns line in the order it should be drawn, reordering right-to-left
+// text unless the terminal does it itself
+func (m Model) displayOrder(line string) string {
+	if !m.reorderBidi {
+		return line
+	}
+	return visualOrder(line)
+}
@@ -0,0 +1,109 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ascii unchanged", "hello world", "hello world"},
+		{"hebrew", "שלום", "םולש"},
+		{"rtl in ltr", "hello שלום world", "hello םולש world"},
+		{"number in rtl paragraph", "שלום 123", "123 םולש"},
+		{"number between rtl words", "abc שלום 123 עולם def", "abc םלוע 123 םולש def"},
+		{"mirrored brackets", "(שלום)", "(םולש)"},
+		{"combining marks", "שָׁלוֹם", "םוֹלשָׁ"},
+		{"arabic", "مرحبا", "ابحرم"},
+	}
+	for _, test := range tests {
+		if got := visualOrder(test.input); got != test.expected {
+			t.Errorf("%s: visualOrder(%q) = %q, expected %q", test.name, test.input, got, test.expected)
+		}
+	}
+}
+
+func TestDisplayOrder_TerminalMode(t *testing.T) {
+	m := Model{}
+	if got := m.displayOrder("שלום"); got != "שלום" {
+		t.Errorf("Expected logical order when the terminal handles bidi, got %q", got)
+	}
+	m.reorderBidi = true
+	if got := m.displayOrder("שלום"); got != "םולש" {
+		t.Errorf("Expected visual order, got %q", got)
+	}
+}
+
+func TestVisualOrder_TruncatesLogicalEnd(t *testing.T) {
+	// Truncation happens before reordering, so the ellipsis replaces the logical end
+	// of the text, which is drawn on the left for right-to-left text
+	lines := wrapText("אבגדה וזחטי כלמנס", 10, 1)
+	if len(lines) != 1 {
+		t.Fatalf("Expected one line, got %q", lines)
+	}
+	if got := visualOrder(lines[0]); got != "...בא" {
+		t.Errorf("Expected the ellipsis on the left, got %q", got)
+	}
+}
+
+func TestHandleSearchEditKey(t *testing.T) {
+	m := Model{}
+	typeRunes := func(s string) {
+		m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+
+	typeRunes("שלם")
+	m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyLeft})
+	typeRunes("ו")
+	if m.searchQuery != "שלום" {
+		t.Errorf("Expected insertion at the logical cursor, got %q", m.searchQuery)
+	}
+	if m.searchCursor != 3 {
+		t.Errorf("Expected cursor at 3, got %d", m.searchCursor)
+	}
+
+	m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyHome})
+	m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyDelete})
+	if m.searchQuery != "לום" {
+		t.Errorf("Expected the first rune deleted, got %q", m.searchQuery)
+	}
+
+	m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyEnd})
+	m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.searchQuery != "לו" || m.searchCursor != 2 {
+		t.Errorf("Expected %q with cursor 2, got %q with cursor %d", "לו", m.searchQuery, m.searchCursor)
+	}
+
+	if m.handleSearchEditKey(tea.KeyMsg{Type: tea.KeyEnter}) {
+		t.Error("Expected enter not to be handled as search input")
+	}
+}
@@ -0,0 +1,244 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// bulkAction is a security action applied to every text item matching the current filter
+type bulkAction int
+
+const (
+	bulkNone bulkAction = iota
+	bulkMarkSafe
+	bulkMarkUnsafe
+	bulkRescan
+)
+
+// bulkKeys maps list keys to bulk actions; pressing the key again confirms
+var bulkKeys = map[string]bulkAction{
+	"S": bulkMarkSafe,
+	"U": bulkMarkUnsafe,
+	"R": bulkRescan,
+}
+
+// key returns the key that starts and confirms the action
+func (a bulkAction) key() string {
+	for key, action := range bulkKeys {
+		if action == a {
+			return key
+		}
+	}
+	return ""
+}
+
+// prompt asks to confirm the action for n items
+func (a bulkAction) prompt(n int) string {
+	switch a {
+	case bulkMarkSafe:
+		return fmt.Sprintf("Mark %d filtered items safe?", n)
+	case bulkMarkUnsafe:
+		return fmt.Sprintf("Mark %d filtered items unsafe?", n)
+	case bulkRescan:
+		return fmt.Sprintf("Rescan %d filtered items?", n)
+	}
+	return ""
+}
+
+// bulkProgress tracks a running rescan
+type bulkProgress struct {
+	scanned int
+	total   int
+	cancel  context.CancelFunc
+}
+
+// bulkProgressMsg reports how far a rescan has got
+type bulkProgressMsg struct {
+	scanned int
+	total   int
+	updates <-chan tea.Msg
+}
+
+// bulkDoneMsg reports the outcome of a bulk action
+type bulkDoneMsg struct {
+	action   bulkAction
+	changed  int            // Items whose safe flag changed
+	stats    map[string]int // Rescan results
+	upgraded []string       // Items the rescan flagged as more dangerous, to review
+	err      error
+}
+
+// bulkTargetIDs returns the text items the action applies to. Images carry no
+// security information.
+func (m Model) bulkTargetIDs() []string {
+	var ids []string
+	for _, item := range m.filteredItems {
+		if item.ContentType != "image" {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// requestBulk asks for confirmation before applying an action to the filtered items
+func (m *Model) requestBulk(action bulkAction) tea.Cmd {
+	if m.bulk != nil {
+		return m.showToast(toastWarning, "A rescan is already running, press esc to cancel it")
+	}
+	if len(m.bulkTargetIDs()) == 0 {
+		return m.showToast(toastInfo, "No text items match the filter")
+	}
+	if !m.confirmBulk() {
+		return m.startBulk(action)
+	}
+	m.bulkPending = action
+	m.currentMode = modeConfirmBulk
+	m.cue()
+	return nil
+}
+
+// handleBulkConfirmKey starts the pending action when its key is pressed again
+func (m *Model) handleBulkConfirmKey(key string) tea.Cmd {
+	action := m.bulkPending
+	m.bulkPending = bulkNone
+	m.currentMode = modeList
+
+	switch key {
+	case action.key():
+		return m.startBulk(action)
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil // Any other key cancels
+}
+
+// startBulk runs the action on the filtered items in the background
+func (m *Model) startBulk(action bulkAction) tea.Cmd {
+	ids := m.bulkTargetIDs()
+	store := m.storage
+
+	m.pendingOps++
+	var run tea.Cmd
+	if action == bulkRescan {
+		ctx, cancel := context.WithCancel(context.Background())
+		m.bulk = &bulkProgress{total: len(ids), cancel: cancel}
+		run = rescanCmd(ctx, store, ids)
+	} else {
+		safe := action == bulkMarkSafe
+		run = func() tea.Msg {
+			changed, err := store.UpdateSafeEntries(ids, safe)
+			return bulkDoneMsg{action: action, changed: changed, err: err}
+		}
+	}
+
+	// Only start ticking when the spinner isn't already running
+	if m.pendingOps == 1 {
+		return tea.Batch(run, m.opSpinner.Tick)
+	}
+	return run
+}
+
+// rescanCmd rescans ids in a goroutine, delivering progress and the result as messages
+func rescanCmd(ctx context.Context, store *storage.Storage, ids []string) tea.Cmd {
+	updates := make(chan tea.Msg, 1)
+	go func() {
+		defer close(updates)
+		stats, upgraded, err := store.RescanSecurityThreatsForIDs(ctx, ids, func(scanned, total int) {
+			// Progress is only shown, so an update still waiting to be read can be dropped
+			select {
+			case updates <- bulkProgressMsg{scanned: scanned, total: total, updates: updates}:
+			default:
+			}
+		})
+		updates <- bulkDoneMsg{action: bulkRescan, stats: stats, upgraded: upgraded, err: err}
+	}()
+	return waitForBulk(updates)
+}
+
+// waitForBulk delivers the next message from a running rescan
+func waitForBulk(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+// handleBulkProgress records rescan progress and waits for the next update
+func (m *Model) handleBulkProgress(msg bulkProgressMsg) tea.Cmd {
+	if m.bulk != nil {
+		m.bulk.scanned, m.bulk.total = msg.scanned, msg.total
+	}
+	return waitForBulk(msg.updates)
+}
+
+// cancelBulk stops a running rescan at its next batch, keeping finished batches
+func (m *Model) cancelBulk() bool {
+	if m.bulk == nil {
+		return false
+	}
+	m.bulk.cancel()
+	return true
+}
+
+// handleBulkDone reloads the list and reports what the action changed
+func (m *Model) handleBulkDone(msg bulkDoneMsg) tea.Cmd {
+	if m.pendingOps > 0 {
+		m.pendingOps--
+	}
+	m.bulk = nil
+	m.reloadKeepingSelection()
+
+	switch {
+	case msg.action == bulkRescan && errors.Is(msg.err, context.Canceled):
+		return m.showToast(toastWarning, fmt.Sprintf("Rescan cancelled after %d items", msg.stats["items_scanned"]))
+	case msg.err != nil:
+		failure := errorToast("bulk update", msg.err)
+		return m.showToast(failure.level, failure.text)
+	case msg.action == bulkRescan:
+		toast := m.showToast(toastSuccess, fmt.Sprintf("Rescanned %d items: %d upgraded, %d downgraded",
+			msg.stats["items_scanned"], msg.stats["upgraded"], msg.stats["downgraded"]))
+		if len(msg.upgraded) > 0 {
+			return tea.Batch(toast, m.startRescanReview(msg.upgraded))
+		}
+		return toast
+	case msg.action == bulkMarkSafe:
+		return m.showToast(toastSuccess, fmt.Sprintf("Marked %d items safe", msg.changed))
+	}
+	return m.showToast(toastSuccess, fmt.Sprintf("Marked %d items unsafe", msg.changed))
+}
+
+// bulkStatus returns the footer indicator for a running rescan
+func (m Model) bulkStatus() string {
+	if m.bulk == nil {
+		return ""
+	}
+	return fmt.Sprintf("[%s rescanning %d/%d, esc: cancel]", m.opSpinner.View(), m.bulk.scanned, m.bulk.total)
+}
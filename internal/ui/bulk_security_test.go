@@ -0,0 +1,140 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBulkAction_RequiresConfirmation(t *testing.T) {
+	m, _ := newStorageTestModel(t, "first", "second")
+
+	m.requestBulk(bulkMarkUnsafe)
+	if m.currentMode != modeConfirmBulk || m.bulkPending != bulkMarkUnsafe {
+		t.Fatalf("Expected a confirmation prompt, got mode %d", m.currentMode)
+	}
+	if prompt := m.bulkPending.prompt(len(m.bulkTargetIDs())); prompt != "Mark 2 filtered items unsafe?" {
+		t.Errorf("Unexpected prompt %q", prompt)
+	}
+
+	// Any other key cancels
+	if cmd := m.handleBulkConfirmKey("x"); cmd != nil || m.currentMode != modeList || m.pendingOps != 0 {
+		t.Errorf("Expected the action to be cancelled")
+	}
+
+	m.requestBulk(bulkMarkUnsafe)
+	if cmd := m.handleBulkConfirmKey("U"); cmd == nil || m.pendingOps != 1 {
+		t.Errorf("Expected the action to start after pressing its key again")
+	}
+}
+
+func TestBulkMarkUnsafe_FilteredItemsOnly(t *testing.T) {
+	m, s := newStorageTestModel(t, "keep", "match one", "match two")
+	m.searchQuery = "match"
+	m.filterItems()
+	if len(m.filteredItems) != 2 {
+		t.Fatalf("Expected 2 filtered items, got %d", len(m.filteredItems))
+	}
+
+	msg := runBulk(t, m.startBulk(bulkMarkUnsafe))
+	done, ok := msg.(bulkDoneMsg)
+	if !ok || done.err != nil || done.changed != 2 {
+		t.Fatalf("Expected 2 items marked unsafe, got %+v", msg)
+	}
+
+	for _, item := range s.GetAllMeta() {
+		if item.SafeEntry == strings.HasPrefix(item.Content, "match") {
+			t.Errorf("Item %q has safe_entry = %v", item.Content, item.SafeEntry)
+		}
+	}
+
+	m.handleBulkDone(done)
+	if m.pendingOps != 0 || m.toast == nil || m.toast.text != "Marked 2 items unsafe" {
+		t.Errorf("Expected a summary toast, got %+v", m.toast)
+	}
+}
+
+func TestBulkRescan_ReportsProgress(t *testing.T) {
+	m, _ := newStorageTestModel(t, "first", "second", "third")
+
+	cmd := m.startBulk(bulkRescan)
+	if m.bulk == nil || m.bulk.total != 3 {
+		t.Fatalf("Expected a running rescan of 3 items, got %+v", m.bulk)
+	}
+	if !strings.Contains(m.statusIndicator(), "rescanning 0/3") {
+		t.Errorf("Expected rescan progress in the footer, got %q", m.statusIndicator())
+	}
+
+	msg := runBulk(t, cmd)
+	for {
+		progress, ok := msg.(bulkProgressMsg)
+		if !ok {
+			break
+		}
+		msg = m.handleBulkProgress(progress)()
+	}
+
+	done, ok := msg.(bulkDoneMsg)
+	if !ok || done.err != nil || done.stats["items_scanned"] != 3 {
+		t.Fatalf("Expected a finished rescan of 3 items, got %+v", msg)
+	}
+	m.handleBulkDone(done)
+	if m.bulk != nil || m.statusIndicator() != "" {
+		t.Error("Expected the progress indicator to go away")
+	}
+	if m.toast == nil || !strings.HasPrefix(m.toast.text, "Rescanned 3 items") {
+		t.Errorf("Expected a rescan summary, got %+v", m.toast)
+	}
+}
+
+func TestBulkRescan_Cancel(t *testing.T) {
+	m, _ := newStorageTestModel(t, "first")
+	m.bulk, m.pendingOps = &bulkProgress{total: 10, cancel: func() {}}, 1
+	if !m.cancelBulk() {
+		t.Error("Expected a running rescan to be cancelled")
+	}
+
+	m.handleBulkDone(bulkDoneMsg{action: bulkRescan, stats: map[string]int{"items_scanned": 4}, err: context.Canceled})
+	if m.toast == nil || m.toast.text != "Rescan cancelled after 4 items" {
+		t.Errorf("Expected a cancellation toast, got %+v", m.toast)
+	}
+	if m.cancelBulk() {
+		t.Error("Expected nothing to cancel once the rescan finished")
+	}
+}
+
+// runBulk runs the command starting a bulk action, skipping the spinner tick
+func runBulk(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		return batch[0]()
+	}
+	return msg
+}
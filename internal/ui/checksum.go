@@ -0,0 +1,226 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package ui
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// checksumAlgorithm is a hash function a checksum is recognized as, by its length
+type checksumAlgorithm struct {
+	name string
+	new  func() hash.Hash
+}
+
+// checksumAlgorithms maps hex digest lengths to the hash functions publishing them
+var checksumAlgorithms = map[int]checksumAlgorithm{
+	32:  {"MD5", md5.New},
+	40:  {"SHA-1", sha1.New},
+	64:  {"SHA-256", sha256.New},
+	128: {"SHA-512", sha512.New},
+}
+
+// checksum is a hex digest parsed from a copied item
+type checksum struct {
+	sum       string // Lowercase hex
+	algorithm checksumAlgorithm
+}
+
+// parseChecksum recognizes a single hex digest, bare, prefixed like "sha256:" or as
+// one line of sha256sum or BSD-style "SHA256 (file) = ..." output
+func parseChecksum(content string) (checksum, bool) {
+	text := strings.TrimSpace(content)
+	if text == "" || strings.Contains(text, "\n") {
+		return checksum{}, false
+	}
+	if index := strings.LastIndex(text, " = "); index >= 0 {
+		text = text[index+3:]
+	} else if fields := strings.Fields(text); len(fields) > 0 {
+		text = fields[0]
+	}
+	if index := strings.IndexByte(text, ':'); index >= 0 {
+		text = text[index+1:]
+	}
+
+	algorithm, ok := checksumAlgorithms[len(text)]
+	if !ok {
+		return checksum{}, false
+	}
+	if _, err := hex.DecodeString(text); err != nil {
+		return checksum{}, false
+	}
+	return checksum{sum: strings.ToLower(text), algorithm: algorithm}, true
+}
+
+// checksumPath returns the file an item names: an absolute path, one under ~/ or a
+// file:// URI as file managers copy them
+func checksumPath(content string) (string, bool) {
+	text := strings.TrimSpace(content)
+	if text == "" || strings.Contains(text, "\n") {
+		return "", false
+	}
+	switch {
+	case strings.HasPrefix(text, "file://"):
+		parsed, err := url.Parse(text)
+		if err != nil || parsed.Path == "" {
+			return "", false
+		}
+		return parsed.Path, true
+	case strings.HasPrefix(text, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		return filepath.Join(home, text[2:]), true
+	case filepath.IsAbs(text):
+		return text, true
+	}
+	return "", false
+}
+
+// checksumResult is the outcome of comparing a checksum with a file or a second checksum
+type checksumResult struct {
+	algorithm string
+	expected  string
+	actual    string
+	file      string // Base name of the hashed file, "" when two checksums were compared
+	err       error  // Reading the file failed
+}
+
+// checksumResultMsg carries the result of hashing a file in the background
+type checksumResultMsg struct {
+	result checksumResult
+}
+
+// pickChecksumItem picks the first item to compare, or compares the picked item with
+// this one. Picking the same item again cancels.
+func (m *Model) pickChecksumItem(item storage.ClipboardItem) tea.Cmd {
+	if item.ContentType == "image" {
+		return m.showToast(toastWarning, "Not a checksum or file path")
+	}
+	if m.compareFrom == nil {
+		_, isChecksum := parseChecksum(item.Content)
+		_, isPath := checksumPath(item.Content)
+		if !isChecksum && !isPath {
+			return m.showToast(toastWarning, "Not a checksum or file path")
+		}
+		m.compareFrom = &item
+		return nil
+	}
+
+	first := *m.compareFrom
+	m.compareFrom = nil
+	if first.ID == item.ID {
+		return nil
+	}
+	return m.compareChecksumsCmd(first, item)
+}
+
+// compareChecksumsCmd compares two checksums right away, or hashes the file one item
+// names off the Update path and compares that with the other
+func (m *Model) compareChecksumsCmd(first, second storage.ClipboardItem) tea.Cmd {
+	expected, ok := parseChecksum(first.Content)
+	other := second
+	if !ok {
+		expected, ok = parseChecksum(second.Content)
+		other = first
+	}
+	if !ok {
+		return m.showToast(toastWarning, "Pick a checksum and a file path or second checksum")
+	}
+
+	if actual, ok := parseChecksum(other.Content); ok {
+		if actual.algorithm.name != expected.algorithm.name {
+			return m.showToast(toastWarning, fmt.Sprintf("Can't compare a %s checksum with a %s one",
+				expected.algorithm.name, actual.algorithm.name))
+		}
+		return m.handleChecksumResult(checksumResultMsg{result: checksumResult{
+			algorithm: expected.algorithm.name,
+			expected:  expected.sum,
+			actual:    actual.sum,
+		}})
+	}
+
+	path, ok := checksumPath(other.Content)
+	if !ok {
+		return m.showToast(toastWarning, "Pick a checksum and a file path or second checksum")
+	}
+	return func() tea.Msg {
+		actual, err := hashFile(path, expected.algorithm.new())
+		return checksumResultMsg{result: checksumResult{
+			algorithm: expected.algorithm.name,
+			expected:  expected.sum,
+			actual:    actual,
+			file:      filepath.Base(path),
+			err:       err,
+		}}
+	}
+}
+
+// hashFile returns the hex digest of a file's contents
+func hashFile(path string, digest hash.Hash) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(digest, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// handleChecksumResult reports a match or mismatch
+func (m *Model) handleChecksumResult(msg checksumResultMsg) tea.Cmd {
+	result := msg.result
+	if result.err != nil {
+		failure := errorToast("hash file", result.err)
+		return m.showToast(failure.level, failure.text)
+	}
+
+	subject, verb := result.algorithm+" checksums", " match"
+	if result.file != "" {
+		subject, verb = result.algorithm+" of "+result.file, " matches"
+	}
+	if result.actual == result.expected {
+		return m.showToast(toastSuccess, subject+verb)
+	}
+	return m.showToast(toastError, fmt.Sprintf("%s mismatch: %s, expected %s",
+		subject, shortHash(result.actual), shortHash(result.expected)))
+}
@@ -0,0 +1,155 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestParseChecksum(t *testing.T) {
+	tests := map[string]string{
+		helloWorldSHA256:                              "SHA-256",
+		strings.ToUpper(helloWorldSHA256):             "SHA-256",
+		"sha256:" + helloWorldSHA256:                  "SHA-256",
+		helloWorldSHA256 + "  nclip.tar.gz\n":         "SHA-256",
+		"SHA256 (nclip.tar.gz) = " + helloWorldSHA256: "SHA-256",
+		"5eb63bbbe01eeed093cb22bb8f5acdc3":            "MD5",
+		"2aae6c35c94fcfb415dbe95f408b9ce91ee846ed":    "SHA-1",
+		"not a checksum":                              "",
+		helloWorldSHA256[:60]:                         "",
+		strings.Repeat("z", 64):                       "",
+		helloWorldSHA256 + "\n" + helloWorldSHA256:    "",
+	}
+	for content, expected := range tests {
+		sum, ok := parseChecksum(content)
+		if sum.algorithm.name != expected || ok != (expected != "") {
+			t.Errorf("parseChecksum(%q) = %q, %v, want %q", content, sum.algorithm.name, ok, expected)
+		}
+		if ok && sum.sum != strings.ToLower(sum.sum) {
+			t.Errorf("Expected %q to be lowercased", sum.sum)
+		}
+	}
+}
+
+func TestChecksumPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := map[string]string{
+		"/tmp/nclip.iso":               "/tmp/nclip.iso",
+		"  /tmp/nclip.iso\n":           "/tmp/nclip.iso",
+		"file:///tmp/with%20space.iso": "/tmp/with space.iso",
+		"~/Downloads/nclip.iso":        filepath.Join(home, "Downloads/nclip.iso"),
+		"relative/nclip.iso":           "",
+		"/tmp/a\n/tmp/b":               "",
+	}
+	for content, expected := range tests {
+		path, ok := checksumPath(content)
+		if path != expected || ok != (expected != "") {
+			t.Errorf("checksumPath(%q) = %q, %v, want %q", content, path, ok, expected)
+		}
+	}
+}
+
+func newChecksumTestModel() Model {
+	return Model{config: &config.Config{}, themeService: NewThemeService(&config.ThemeConfig{})}
+}
+
+func TestCompareChecksums_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "download.bin")
+	if err := os.WriteFile(path, []byte("hello world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sum := storage.ClipboardItem{ID: "1", Content: helloWorldSHA256, ContentType: "text"}
+	file := storage.ClipboardItem{ID: "2", Content: path, ContentType: "text"}
+
+	// Either item may be picked first
+	for _, pair := range [][2]storage.ClipboardItem{{sum, file}, {file, sum}} {
+		m := newChecksumTestModel()
+		m.pickChecksumItem(pair[0])
+		if m.compareFrom == nil || m.compareFrom.ID != pair[0].ID {
+			t.Fatalf("Expected %q to be picked", pair[0].Content)
+		}
+		cmd := m.pickChecksumItem(pair[1])
+		if m.compareFrom != nil || cmd == nil {
+			t.Fatal("Expected the second pick to start the comparison")
+		}
+		m.handleChecksumResult(cmd().(checksumResultMsg))
+		if m.toast == nil || m.toast.level != toastSuccess || m.toast.text != "SHA-256 of download.bin matches" {
+			t.Errorf("Expected a match, got %+v", m.toast)
+		}
+	}
+
+	m := newChecksumTestModel()
+	m.pickChecksumItem(storage.ClipboardItem{ID: "3", Content: strings.Repeat("0", 64), ContentType: "text"})
+	cmd := m.pickChecksumItem(file)
+	m.handleChecksumResult(cmd().(checksumResultMsg))
+	if m.toast == nil || m.toast.level != toastError || !strings.Contains(m.toast.text, "mismatch: b94d27b9934d, expected 000000000000") {
+		t.Errorf("Expected a mismatch, got %+v", m.toast)
+	}
+}
+
+func TestCompareChecksums_TwoChecksums(t *testing.T) {
+	m := newChecksumTestModel()
+	m.pickChecksumItem(storage.ClipboardItem{ID: "1", Content: helloWorldSHA256, ContentType: "text"})
+	m.pickChecksumItem(storage.ClipboardItem{ID: "2", Content: helloWorldSHA256 + "  nclip.tar.gz", ContentType: "text"})
+	if m.toast == nil || m.toast.text != "SHA-256 checksums match" {
+		t.Errorf("Expected a match, got %+v", m.toast)
+	}
+
+	m.pickChecksumItem(storage.ClipboardItem{ID: "1", Content: helloWorldSHA256, ContentType: "text"})
+	m.pickChecksumItem(storage.ClipboardItem{ID: "2", Content: "5eb63bbbe01eeed093cb22bb8f5acdc3", ContentType: "text"})
+	if m.toast == nil || m.toast.level != toastWarning {
+		t.Errorf("Expected a warning for different algorithms, got %+v", m.toast)
+	}
+}
+
+func TestPickChecksumItem_Rejects(t *testing.T) {
+	m := newChecksumTestModel()
+	m.pickChecksumItem(storage.ClipboardItem{ID: "1", Content: "just text", ContentType: "text"})
+	if m.compareFrom != nil || m.toast == nil || m.toast.level != toastWarning {
+		t.Errorf("Expected plain text to be rejected, got %+v", m.toast)
+	}
+
+	// Picking the same item again cancels
+	item := storage.ClipboardItem{ID: "1", Content: helloWorldSHA256, ContentType: "text"}
+	m.pickChecksumItem(item)
+	if cmd := m.pickChecksumItem(item); cmd != nil || m.compareFrom != nil {
+		t.Error("Expected picking the same item twice to cancel")
+	}
+
+	// Two paths leave nothing to check against
+	m.pickChecksumItem(storage.ClipboardItem{ID: "1", Content: "/tmp/a", ContentType: "text"})
+	m.pickChecksumItem(storage.ClipboardItem{ID: "2", Content: "/tmp/b", ContentType: "text"})
+	if m.toast == nil || !strings.HasPrefix(m.toast.text, "Pick a checksum") {
+		t.Errorf("Expected a hint for two paths, got %+v", m.toast)
+	}
+}
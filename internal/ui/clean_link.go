@@ -0,0 +1,76 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/enrich"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// cleanLinkTimeout bounds the shortener lookups of the clean-link action
+const cleanLinkTimeout = 5 * time.Second
+
+// cleanLinkMsg carries a link with its tracking removed and its shortener resolved
+type cleanLinkMsg struct {
+	item    storage.ClipboardItem
+	cleaned string
+	changed bool
+	err     error
+}
+
+// cleanLinkCmd cleans the selected link off the Update path, as resolving a shortened
+// link takes a network request
+func (m *Model) cleanLinkCmd(item storage.ClipboardItem) tea.Cmd {
+	link, ok := enrich.LinkURL(item.Content)
+	if item.ContentType != "text" || !ok {
+		return m.showToast(toastWarning, "Not a link")
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cleanLinkTimeout)
+		defer cancel()
+		cleaned, changed, err := enrich.CleanLink(ctx, http.DefaultClient, link, true)
+		return cleanLinkMsg{item: item, cleaned: cleaned, changed: changed, err: err}
+	}
+}
+
+// handleCleanLink copies the cleaned link, leaving the stored entry unchanged
+func (m *Model) handleCleanLink(msg cleanLinkMsg) tea.Cmd {
+	if msg.err != nil {
+		failure := errorToast("clean link", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+	if !msg.changed {
+		return m.showToast(toastInfo, "No tracking to remove from this link")
+	}
+	item := msg.item
+	item.Content = msg.cleaned
+	return m.copyItemCmd(item)
+}
@@ -0,0 +1,61 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestCleanLinkCmd_RejectsNonLinks(t *testing.T) {
+	m := Model{themeService: NewThemeService(&config.ThemeConfig{})}
+	m.cleanLinkCmd(storage.ClipboardItem{Content: "just text", ContentType: "text"})
+	if m.toast == nil || m.toast.text != "Not a link" {
+		t.Errorf("Expected a 'Not a link' toast, got %+v", m.toast)
+	}
+}
+
+func TestHandleCleanLink(t *testing.T) {
+	m := Model{config: &config.Config{}, themeService: NewThemeService(&config.ThemeConfig{})}
+	item := storage.ClipboardItem{ID: "1", Content: "https://example.com/?utm_source=x", ContentType: "text"}
+
+	m.handleCleanLink(cleanLinkMsg{item: item, cleaned: item.Content})
+	if m.toast == nil || m.toast.level != toastInfo {
+		t.Errorf("Expected an info toast for an unchanged link, got %+v", m.toast)
+	}
+	m.handleCleanLink(cleanLinkMsg{item: item, err: errors.New("timeout")})
+	if m.toast == nil || m.toast.level != toastError {
+		t.Errorf("Expected an error toast, got %+v", m.toast)
+	}
+
+	m.SetInsertMode(true) // Hands the copied text back instead of using the clipboard
+	msg, ok := m.handleCleanLink(cleanLinkMsg{item: item, cleaned: "https://example.com/", changed: true})().(insertItemMsg)
+	if !ok || msg.content != "https://example.com/" {
+		t.Errorf("Expected the cleaned link to be copied, got %+v", msg)
+	}
+}
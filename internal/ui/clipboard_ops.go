@@ -0,0 +1,154 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// copyConfirmDelay is how long the copy confirmation stays visible before the TUI exits
+const copyConfirmDelay = 600 * time.Millisecond
+
+// copyDoneMsg reports the outcome of a clipboard copy run in the background
+type copyDoneMsg struct {
+	err error
+
+	// Set when the item was removed from history after copying ("copy and remove")
+	removedID string
+	removeErr error
+	written   ownWrite
+}
+
+// quitAfterCopyMsg quits the program once the copy confirmation has been shown
+type quitAfterCopyMsg struct{}
+
+// copyItemCmd copies an item to the clipboard off the Update path so slow clipboard
+// backends never block key handling
+func (m *Model) copyItemCmd(item storage.ClipboardItem) tea.Cmd {
+	if m.insertMode {
+		return m.insertItemCmd(item, false)
+	}
+	store := m.storage
+	return func() tea.Msg {
+		return copyItem(store, item, false)
+	}
+}
+
+// moveItemCmd copies an item and then removes it from history, for one-time secrets
+// and queue-like use. The copy is recorded as a move first, so the daemon doesn't store
+// it again, even when suppress_own_copies is off.
+func (m *Model) moveItemCmd(item storage.ClipboardItem) tea.Cmd {
+	if m.insertMode {
+		return m.insertItemCmd(item, true)
+	}
+	store := m.storage
+	archive := m.archiveMode
+	return func() tea.Msg {
+		msg := copyItem(store, item, true)
+
+		// Only remove what made it to the clipboard. Without a display the text is
+		// written to the terminal later, which isn't worth keeping the item for.
+		if done, ok := msg.(copyDoneMsg); ok && done.err != nil {
+			return msg
+		}
+		written, removeErr := trackWrite(store, func() error {
+			return deleteStoredItem(store, archive, item.ID)
+		})
+
+		switch msg := msg.(type) {
+		case headlessCopyMsg:
+			msg.removedID, msg.removeErr, msg.written = item.ID, removeErr, written
+			return msg
+		case copyDoneMsg:
+			msg.removedID, msg.removeErr, msg.written = item.ID, removeErr, written
+			return msg
+		}
+		return msg
+	}
+}
+
+// copyItem puts an item on the clipboard, returning a copyDoneMsg, or a headlessCopyMsg
+// when the text has to be copied through the terminal instead. move records the copy of
+// an item that is removed right after.
+func copyItem(store *storage.Storage, item storage.ClipboardItem, move bool) tea.Msg {
+	// Let the daemon recognize this copy, so the item isn't stored again and bumped
+	record := store.RecordCopy
+	if move {
+		record = store.RecordMove
+	}
+	if err := record(item.Content, item.ContentType, item.ImageData); err != nil {
+		logging.Warn("Failed to record copy: %v", err)
+	}
+	if clipboard.Headless() {
+		if item.ContentType == "image" {
+			return copyDoneMsg{err: errNoDisplayImage}
+		}
+		return headlessCopyMsg{content: item.Content}
+	}
+	if item.ContentType == "image" && len(item.ImageData) > 0 {
+		return copyDoneMsg{err: clipboard.CopyImage(item.ImageData)}
+	}
+	if item.ThreatLevel == "high" {
+		return copyDoneMsg{err: clipboard.CopySensitive(item.Content)}
+	}
+	return copyDoneMsg{err: clipboard.Copy(item.Content)}
+}
+
+// handleCopyDone shows the copy result and schedules the exit unless stay-open is enabled
+func (m *Model) handleCopyDone(msg copyDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		failure := errorToast("copy", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+
+	// The copy worked but the item is still in history; stay so the user sees why
+	if msg.removeErr != nil {
+		failure := errorToast("remove after copy", msg.removeErr)
+		return m.showToast(failure.level, failure.text)
+	}
+
+	confirmation := "Copied"
+	if msg.removedID != "" {
+		m.applyItemChange(msg.removedID, msg.written)
+		confirmation = "Copied and removed"
+	}
+	if m.iconHelper != nil && m.iconHelper.GetCapabilities().SupportsUnicode {
+		confirmation += " ✔"
+	}
+	showConfirmation := m.showToast(toastSuccess, confirmation)
+
+	if m.stayOpen {
+		return showConfirmation
+	}
+	return tea.Batch(showConfirmation, tea.Tick(copyConfirmDelay, func(time.Time) tea.Msg {
+		return quitAfterCopyMsg{}
+	}))
+}
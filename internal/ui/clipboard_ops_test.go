@@ -0,0 +1,109 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+func TestHandleCopyDone_QuitsAfterConfirmation(t *testing.T) {
+	m := Model{}
+
+	cmd := m.handleCopyDone(copyDoneMsg{})
+	if m.toast == nil || !strings.HasPrefix(m.toast.text, "Copied") || m.toast.level != toastSuccess {
+		t.Errorf("Expected copy confirmation toast, got %+v", m.toast)
+	}
+	if cmd == nil {
+		t.Error("Expected a delayed quit command after a successful copy")
+	}
+}
+
+func TestHandleCopyDone_StayOpen(t *testing.T) {
+	m := Model{stayOpen: true}
+
+	m.handleCopyDone(copyDoneMsg{})
+	if m.toast == nil || !strings.HasPrefix(m.toast.text, "Copied") || m.toast.level != toastSuccess {
+		t.Errorf("Expected copy confirmation toast, got %+v", m.toast)
+	}
+}
+
+func TestHandleCopyDone_Error(t *testing.T) {
+	m := Model{}
+
+	if cmd := m.handleCopyDone(copyDoneMsg{err: errors.New("no clipboard")}); cmd == nil {
+		t.Error("Expected a dismiss command for the error toast")
+	}
+	if m.toast == nil || m.toast.text != "copy failed: no clipboard" || m.toast.level != toastError {
+		t.Errorf("Expected copy error toast, got %+v", m.toast)
+	}
+}
+
+func TestMoveItemCmd_RemovesAfterCopy(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	m, s := newStorageTestModel(t, "one-time secret", "keep me")
+	m.config = &config.Config{}
+	m.cursor = 1 // "one-time secret"
+
+	item := m.getCurrentItem()
+	if item == nil || item.Content != "one-time secret" {
+		t.Fatalf("Expected the secret to be selected, got %+v", item)
+	}
+
+	// Without a display the text is handed to the terminal via OSC 52
+	msg, ok := m.moveItemCmd(*item)().(headlessCopyMsg)
+	if !ok || msg.content != "one-time secret" || msg.removedID != item.ID || msg.removeErr != nil {
+		t.Fatalf("Expected a headless copy of the removed item, got %+v", msg)
+	}
+	for _, stored := range s.GetAll() {
+		if stored.ID == item.ID {
+			t.Error("Expected the copied item to be removed from history")
+		}
+	}
+
+	m.handleCopyDone(copyDoneMsg{removedID: msg.removedID})
+	if len(m.filteredItems) != 1 || m.filteredItems[0].Content != "keep me" {
+		t.Errorf("Expected the list to drop the removed item, got %+v", m.filteredItems)
+	}
+	if m.toast == nil || !strings.HasPrefix(m.toast.text, "Copied and removed") {
+		t.Errorf("Expected a copy and remove confirmation, got %+v", m.toast)
+	}
+}
+
+func TestHandleCopyDone_RemoveFailed(t *testing.T) {
+	m := Model{}
+
+	if cmd := m.handleCopyDone(copyDoneMsg{removedID: "1", removeErr: errors.New("database is locked")}); cmd == nil {
+		t.Error("Expected a dismiss command for the error toast")
+	}
+	if m.toast == nil || m.toast.level != toastError || !strings.Contains(m.toast.text, "database is locked") {
+		t.Errorf("Expected a removal error toast, got %+v", m.toast)
+	}
+}
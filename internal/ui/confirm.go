@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+// confirmDelete reports whether x must be pressed twice to delete an item
+func (m Model) confirmDelete() bool {
+	return m.config == nil || m.config.Confirm.Delete
+}
+
+// confirmBulk reports whether the bulk security actions must be pressed twice
+func (m Model) confirmBulk() bool {
+	return m.config == nil || m.config.Confirm.Bulk
+}
+
+// confirmBlockHash reports whether blocking or unblocking a content hash needs a second x
+func (m Model) confirmBlockHash() bool {
+	return m.config == nil || m.config.Confirm.BlockHash
+}
+
+// typedWipe reports whether the panic wipe is confirmed by typing a word
+func (m Model) typedWipe() bool {
+	return m.config != nil && m.config.Confirm.Wipe == config.WipeConfirmTyped
+}
+
+// requestPanic asks for confirmation of the panic wipe
+func (m *Model) requestPanic() {
+	m.confirmInput = ""
+	m.currentMode = modeConfirmPanic
+	m.cue()
+}
+
+// handlePanicConfirmKey runs the panic wipe once confirmed: by pressing ! again, or by
+// typing the confirmation word and Enter when confirm.wipe is "typed"
+func (m *Model) handlePanicConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "ctrl+c" {
+		return tea.Quit
+	}
+	if !m.typedWipe() {
+		m.currentMode = modeList
+		if msg.String() == "!" {
+			return m.panicCmd()
+		}
+		return nil // Any other key cancels
+	}
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		typed := m.confirmInput
+		m.confirmInput = ""
+		m.currentMode = modeList
+		if typed == config.WipeConfirmWord {
+			return m.panicCmd()
+		}
+		return m.showToast(toastInfo, "Wipe cancelled")
+	case tea.KeyEsc:
+		m.confirmInput = ""
+		m.currentMode = modeList
+	case tea.KeyBackspace:
+		if runes := []rune(m.confirmInput); len(runes) > 0 {
+			m.confirmInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.confirmInput += string(msg.Runes)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+// typeKeys sends text to the panic confirmation one key at a time
+func typeKeys(m *Model, text string) {
+	for _, r := range text {
+		m.handlePanicConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestPanicConfirm_PressAgain(t *testing.T) {
+	m := Model{}
+	m.requestPanic()
+	if cmd := m.handlePanicConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd != nil || m.currentMode != modeList {
+		t.Fatal("Expected another key to cancel the wipe")
+	}
+	m.requestPanic()
+	if cmd := m.handlePanicConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}}); cmd == nil {
+		t.Error("Expected a second ! to start the wipe")
+	}
+}
+
+func TestPanicConfirm_Typed(t *testing.T) {
+	cfg := &config.Config{Confirm: config.ConfirmConfig{Wipe: config.WipeConfirmTyped}}
+	m := Model{config: cfg, themeService: NewThemeService(&config.ThemeConfig{})}
+
+	// A second ! is just typed text
+	m.requestPanic()
+	typeKeys(&m, "!wipf")
+	m.handlePanicConfirmKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.confirmInput != "!wip" || m.currentMode != modeConfirmPanic {
+		t.Fatalf("Expected the typed text to be edited in place, got %q in mode %d", m.confirmInput, m.currentMode)
+	}
+	m.handlePanicConfirmKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.currentMode != modeList || m.toast == nil {
+		t.Fatal("Expected the wrong word to cancel the wipe")
+	}
+
+	m.requestPanic()
+	typeKeys(&m, config.WipeConfirmWord)
+	if cmd := m.handlePanicConfirmKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || m.currentMode != modeList {
+		t.Error("Expected the confirmation word to start the wipe")
+	}
+}
+
+func TestConfirmPolicy_SinglePress(t *testing.T) {
+	off := &config.Config{Confirm: config.ConfirmConfig{Wipe: config.WipeConfirmPress}}
+
+	hashModel, _ := newHashViewTestModel(t, "first secret", "second secret")
+	hashModel.config = off
+	hashModel.openHashView()
+	hashModel.handleHashViewKey("x")
+	if len(hashModel.hashView.entries) != 1 {
+		t.Errorf("Expected a single x to unblock with block_hash off, got %d entries left", len(hashModel.hashView.entries))
+	}
+
+	bulkModel, _ := newStorageTestModel(t, "first", "second")
+	bulkModel.config = off
+	if cmd := bulkModel.requestBulk(bulkMarkUnsafe); cmd == nil || bulkModel.currentMode == modeConfirmBulk {
+		t.Error("Expected the bulk action to start at once with bulk off")
+	}
+}
@@ -0,0 +1,158 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// SetDebugMode enables the F12 debug overlay (nclip --debug)
+func (m *Model) SetDebugMode(enabled bool) {
+	m.debugMode = enabled
+}
+
+// modeName returns a readable name for the current mode
+func (m Model) modeName() string {
+	switch m.currentMode {
+	case modeList:
+		return "list"
+	case modeSearch:
+		return "search"
+	case modeConfirmDelete:
+		return "confirm-delete"
+	case modeImageView:
+		return "image-view"
+	case modeSecurityWarning:
+		return "security-warning"
+	case modeHelp:
+		return "help"
+	case modeTextView:
+		return "text-view"
+	case modeImageSecurityWarning:
+		return "image-security-warning"
+	case modeConfirmPanic:
+		return "confirm-panic"
+	case modeConfirmBulk:
+		return "confirm-bulk"
+	case modeRescanReview:
+		return "rescan-review"
+	case modeHashStore:
+		return "hash-store"
+	case modeNote:
+		return "note"
+	case modeHostInfo:
+		return "host-info"
+	case modePending:
+		return "pending"
+	case modeStats:
+		return "stats"
+	case modeSpellCheck:
+		return "spell-check"
+	case modeFormat:
+		return "format"
+	default:
+		return fmt.Sprintf("unknown(%d)", m.currentMode)
+	}
+}
+
+// debugStateLines describes the model state shown at the top of the debug overlay
+func (m Model) debugStateLines() []string {
+	lines := []string{
+		fmt.Sprintf("Mode: %s | Cursor: %d | Items: %d | Filtered: %d", m.modeName(), m.cursor, len(m.items), len(m.filteredItems)),
+		fmt.Sprintf("Filter: %q | Search: %q | Archive: %v | Stay open: %v", m.filterMode, m.searchQuery, m.archiveMode, m.stayOpen),
+		fmt.Sprintf("Terminal: %dx%d | TERM_PROGRAM=%s | Kitty: %v", m.width, m.height, os.Getenv("TERM_PROGRAM"), detectKittySupport()),
+		fmt.Sprintf("Pending storage ops: %d", m.pendingOps),
+	}
+
+	if m.iconHelper != nil {
+		caps := m.iconHelper.GetCapabilities()
+		lines = append(lines, fmt.Sprintf("Unicode: %v | Color: %v", caps.SupportsUnicode, caps.SupportsColor))
+	}
+
+	if m.cache != nil {
+		stats := m.cache.GetCacheStats()
+		lines = append(lines, fmt.Sprintf("Cache: %v items, %v images cached (%v/%v bytes)",
+			stats["total_items"], stats["cached_images"], stats["image_cache_bytes"], stats["max_image_bytes"]))
+	}
+
+	if m.viewingImage != nil {
+		lines = append(lines, imageDebugLines(*m.viewingImage)...)
+	}
+
+	return lines
+}
+
+// imageDebugLines describes an image item for diagnosing rendering problems
+func imageDebugLines(item storage.ClipboardItem) []string {
+	lines := []string{fmt.Sprintf("Image: %d bytes, %q", len(item.ImageData), item.Content)}
+	if len(item.ImageData) > 0 {
+		width, height, format, err := getImageDimensions(item.ImageData)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("Image format detection failed: %v", err))
+		} else {
+			lines = append(lines, fmt.Sprintf("Image format: %s, %dx%d pixels", format, width, height))
+		}
+	}
+	return lines
+}
+
+// renderDebugOverlay renders model state and recent log lines in a full-screen frame
+func (m Model) renderDebugOverlay() string {
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+
+	lines := m.debugStateLines()
+	lines = append(lines, strings.Repeat(m.separatorChar(), contentWidth))
+
+	// Fill the remaining space with the newest log lines
+	logSpace := contentHeight - len(lines)
+	if logSpace > 0 {
+		recent := logging.Recent(logSpace)
+		if len(recent) == 0 {
+			recent = []string{"(no log output yet)"}
+		}
+		lines = append(lines, recent...)
+	}
+	if len(lines) > contentHeight {
+		lines = lines[:contentHeight]
+	}
+
+	var content strings.Builder
+	for _, line := range lines {
+		line = truncateToWidth(line, contentWidth)
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+	for i := len(lines); i < contentHeight; i++ {
+		content.WriteString("\n")
+	}
+
+	frameContent := m.buildFrameContent("Debug", content.String(), "f12: close | esc: close", contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
@@ -0,0 +1,57 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDebugOverlay_RequiresDebugMode(t *testing.T) {
+	m := Model{}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyF12})
+	if updated.(Model).showDebugOverlay {
+		t.Error("Expected F12 to be ignored without --debug")
+	}
+}
+
+func TestDebugOverlay_Toggle(t *testing.T) {
+	m := Model{}
+	m.SetDebugMode(true)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyF12})
+	m = updated.(Model)
+	if !m.showDebugOverlay {
+		t.Fatal("Expected F12 to open the debug overlay")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showDebugOverlay {
+		t.Error("Expected esc to close the debug overlay")
+	}
+}
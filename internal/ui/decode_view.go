@@ -0,0 +1,65 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"time"
+
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+// decoding is the decoded form of an encoded item in the text viewer
+type decoding struct {
+	label    string // What was decoded: "JWT", "base64" or "hex"
+	text     string
+	language string // Highlighting language of text, "" to detect it
+}
+
+// viewerDecoding decodes the item in the text viewer if it is a JWT or a base64 or
+// hex payload wrapping text
+func (m Model) viewerDecoding() (decoding, bool) {
+	if m.viewingText == nil {
+		return decoding{}, false
+	}
+	content := m.viewingText.Content
+	if claims, ok := decodeJWT(content, time.Now()); ok {
+		return decoding{label: "JWT", text: claims, language: "yaml"}, true
+	}
+	if text, encoding, ok := security.DecodeEncoded(content); ok {
+		return decoding{label: encoding, text: text}, true
+	}
+	return decoding{}, false
+}
+
+// textViewContent returns the text the viewer shows and its highlighting language:
+// the item itself, or its decoded form when decoding is toggled on
+func (m Model) textViewContent() (string, string) {
+	if m.showDecoded {
+		if decoded, ok := m.viewerDecoding(); ok {
+			return decoded.text, decoded.language
+		}
+	}
+	return m.viewingText.Content, m.languageOverride
+}
@@ -0,0 +1,92 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func newDecodeTestModel(content string) Model {
+	m := Model{
+		themeService: NewThemeService(&config.ThemeConfig{}),
+		codeDetector: NewCodeDetector(),
+		width:        100,
+		height:       30,
+		currentMode:  modeTextView,
+	}
+	m.setViewingText(&storage.ClipboardItem{ID: "1", Content: content})
+	return m
+}
+
+func TestTextView_DecodeToggle(t *testing.T) {
+	token := testJWT(`{"alg":"HS256"}`, `{"iss":"issuer-x"}`)
+	m := newDecodeTestModel(token)
+
+	decoded, ok := m.viewerDecoding()
+	if !ok || decoded.label != "JWT" {
+		t.Fatalf("Expected the viewer to offer JWT decoding, got %+v", decoded)
+	}
+	if content, _ := m.textViewContent(); content != token {
+		t.Error("Expected the token to be shown before decoding")
+	}
+
+	m.showDecoded = true
+	content, language := m.textViewContent()
+	if !strings.Contains(content, "issuer: issuer-x") || language != "yaml" {
+		t.Errorf("Expected the decoded claims, got %q (%s)", content, language)
+	}
+	if !strings.Contains(strings.Join(m.getTextViewLines(), "\n"), "issuer-x") {
+		t.Error("Expected the viewer lines to show the claims")
+	}
+}
+
+func TestTextView_DecodeBase64AndHex(t *testing.T) {
+	secret := "db_password=hunter2-but-longer"
+	for encoding, content := range map[string]string{
+		"base64": base64.StdEncoding.EncodeToString([]byte(secret)),
+		"hex":    hex.EncodeToString([]byte(secret)),
+	} {
+		m := newDecodeTestModel(content)
+		decoded, ok := m.viewerDecoding()
+		if !ok || decoded.label != encoding {
+			t.Errorf("Expected %s decoding to be offered, got %+v", encoding, decoded)
+			continue
+		}
+		m.showDecoded = true
+		if text, _ := m.textViewContent(); text != secret {
+			t.Errorf("Expected the decoded %s text, got %q", encoding, text)
+		}
+	}
+
+	if _, ok := newDecodeTestModel("just a sentence with words").viewerDecoding(); ok {
+		t.Error("Expected plain text not to offer decoding")
+	}
+}
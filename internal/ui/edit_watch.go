@@ -0,0 +1,209 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+var (
+	// editWatchInterval is how often a file open in an external editor is checked for saves
+	editWatchInterval = 500 * time.Millisecond
+	// editWatchTimeout bounds how long a file is watched when the editor process can't be waited on
+	editWatchTimeout = 30 * time.Minute
+	// detachedEditorThreshold is how quickly an editor has to return to be treated as having
+	// handed the file to an already running instance (gimp, gedit, code)
+	detachedEditorThreshold = 2 * time.Second
+)
+
+// editWatch follows a temp file handed to an external editor and reports every save
+type editWatch struct {
+	path     string
+	item     storage.ClipboardItem
+	done     <-chan struct{} // closed when the editor exits; nil when it can't be waited on
+	started  time.Time
+	deadline time.Time
+	modTime  time.Time
+	size     int64
+	last     []byte
+}
+
+// newEditWatch starts watching path, which holds the item's original content
+func newEditWatch(path string, item storage.ClipboardItem, original []byte, done <-chan struct{}) *editWatch {
+	w := &editWatch{path: path, item: item, done: done, started: time.Now(), last: original}
+	if done == nil {
+		w.deadline = w.started.Add(editWatchTimeout)
+	}
+	if info, err := os.Stat(path); err == nil {
+		w.modTime, w.size = info.ModTime(), info.Size()
+	}
+	return w
+}
+
+// editSavedMsg reports content saved to a watched temp file
+type editSavedMsg struct {
+	watch *editWatch
+	data  []byte
+}
+
+// editImportedMsg reports the outcome of importing a saved edit
+type editImportedMsg struct {
+	change  *undoEntry // Set when a text edit replaced the original content
+	written ownWrite
+	err     error
+}
+
+// next blocks until the watched file is saved with new content, returning an editSavedMsg,
+// or until the watch ends, in which case the temp file is removed and nil is returned
+func (w *editWatch) next() tea.Msg {
+	ticker := time.NewTicker(editWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		exited := false
+		select {
+		case <-w.done:
+			if time.Since(w.started) < detachedEditorThreshold {
+				// The launcher returned straight away; keep watching until the deadline instead
+				w.done = nil
+				w.deadline = time.Now().Add(editWatchTimeout)
+			} else {
+				exited = true
+			}
+		case <-ticker.C:
+		}
+
+		if data, ok := w.changed(); ok {
+			return editSavedMsg{watch: w, data: data}
+		}
+		if exited || (!w.deadline.IsZero() && time.Now().After(w.deadline)) {
+			logging.Debug("Edit watch: done with %s", w.path)
+			os.Remove(w.path)
+			return nil
+		}
+	}
+}
+
+// changed reports the file's content when it was saved with something new since the last check
+func (w *editWatch) changed() ([]byte, bool) {
+	info, err := os.Stat(w.path)
+	if err != nil || (info.ModTime().Equal(w.modTime) && info.Size() == w.size) {
+		return nil, false
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	data, err := os.ReadFile(w.path)
+	if err != nil || len(data) == 0 || bytes.Equal(data, w.last) {
+		// Editors may truncate before writing; the completed save shows up on a later check
+		return nil, false
+	}
+	w.last = data
+	return data, true
+}
+
+// handleEditSaved offers a saved edit for import and keeps watching for further saves
+func (m *Model) handleEditSaved(msg editSavedMsg) tea.Cmd {
+	m.pendingImport = &msg
+
+	var toastCmd tea.Cmd
+	if m.currentMode != modeList {
+		toastCmd = m.showToast(toastInfo, "Edit saved, press 'y' in the list to import it")
+	}
+	return tea.Batch(toastCmd, msg.watch.next)
+}
+
+// importPendingEdit stores the pending saved edit and puts it on the clipboard. Text edits
+// update the original entry like a regular edit; edited images are added as a new entry.
+func (m *Model) importPendingEdit() tea.Cmd {
+	pending := m.pendingImport
+	m.pendingImport = nil
+	if pending == nil {
+		return nil
+	}
+
+	store := m.storage
+	item := pending.watch.item
+	data := pending.data
+	return func() tea.Msg {
+		if item.ContentType == "image" {
+			written, err := trackWrite(store, func() error {
+				if err := store.AddImage(data, fmt.Sprintf("Image (%d bytes)", len(data))); err != nil {
+					return err
+				}
+				if err := store.RecordCopy("", "image", data); err != nil {
+					logging.Warn("Failed to record copy: %v", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return editImportedMsg{err: err}
+			}
+			return editImportedMsg{written: written, err: clipboard.CopyImage(data)}
+		}
+
+		content := strings.TrimSpace(string(data))
+		var change *undoEntry
+		written, err := trackWrite(store, func() (err error) {
+			if change, err = updateContent(store, item.ID, content); err != nil {
+				return err
+			}
+			if err := store.RecordCopy(content, item.ContentType, nil); err != nil {
+				logging.Warn("Failed to record copy: %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return editImportedMsg{err: err}
+		}
+		return editImportedMsg{change: change, written: written, err: clipboard.Copy(content)}
+	}
+}
+
+// handleEditImported reloads the list so the imported edit shows up and reports the result
+func (m *Model) handleEditImported(msg editImportedMsg) tea.Cmd {
+	if msg.change != nil {
+		m.recordChange(msg.change)
+	}
+	if !m.archiveMode {
+		m.recordOwnWrite(msg.written)
+		m.reloadKeepingSelection()
+	}
+
+	if msg.err != nil {
+		failure := errorToast("import edit", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+	return m.showToast(toastSuccess, "Edit imported and copied")
+}
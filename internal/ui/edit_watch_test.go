@@ -0,0 +1,120 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func useFastEditWatch(t *testing.T) {
+	t.Helper()
+	interval, threshold := editWatchInterval, detachedEditorThreshold
+	editWatchInterval, detachedEditorThreshold = 5*time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() { editWatchInterval, detachedEditorThreshold = interval, threshold })
+}
+
+func TestEditWatch_ReportsSaves(t *testing.T) {
+	useFastEditWatch(t)
+	path := filepath.Join(t.TempDir(), "edit.txt")
+	if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	w := newEditWatch(path, storage.ClipboardItem{ID: "1"}, []byte("original"), nil)
+	msgs := make(chan any, 1)
+	go func() { msgs <- w.next() }()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("edited"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-msgs:
+		saved, ok := msg.(editSavedMsg)
+		if !ok || string(saved.data) != "edited" || saved.watch != w {
+			t.Errorf("Expected editSavedMsg with the new content, got %#v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Save was not reported")
+	}
+}
+
+func TestEditWatch_EndsWhenEditorExits(t *testing.T) {
+	useFastEditWatch(t)
+	path := filepath.Join(t.TempDir(), "edit.png")
+	if err := os.WriteFile(path, []byte("image"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	w := newEditWatch(path, storage.ClipboardItem{ID: "1"}, []byte("image"), done)
+	w.started = time.Now().Add(-time.Minute)
+	close(done)
+
+	if msg := w.next(); msg != nil {
+		t.Errorf("Expected the watch to end, got %#v", msg)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the temp file to be removed when the watch ends")
+	}
+}
+
+func TestEditWatch_DetachedEditorKeepsWatching(t *testing.T) {
+	useFastEditWatch(t)
+	path := filepath.Join(t.TempDir(), "edit.png")
+	if err := os.WriteFile(path, []byte("image"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The launcher exits at once, as gimp does when an instance is already running
+	done := make(chan struct{})
+	close(done)
+	w := newEditWatch(path, storage.ClipboardItem{ID: "1"}, []byte("image"), done)
+	msgs := make(chan any, 1)
+	go func() { msgs <- w.next() }()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("edited image"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-msgs:
+		if _, ok := msg.(editSavedMsg); !ok {
+			t.Errorf("Expected the save to be reported, got %#v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Save was not reported")
+	}
+	if w.deadline.IsZero() {
+		t.Error("Expected a deadline once the editor detached")
+	}
+}
@@ -0,0 +1,50 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+// editorCommand builds the command that opens file with an editor or viewer command template
+func editorCommand(template, file string) (*exec.Cmd, error) {
+	args, err := config.ExpandCommand(template, file)
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+// textEditorCommand opens file in $EDITOR when set, otherwise in the configured text editor
+func (m *Model) textEditorCommand(file string) (*exec.Cmd, error) {
+	editor := m.config.Editor.TextEditor
+	if envEditor := os.Getenv("EDITOR"); envEditor != "" {
+		editor = envEditor
+	}
+	return editorCommand(editor, file)
+}
@@ -0,0 +1,102 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/enrich"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// entryKind tells which [keys] setting applies to an item: image, link or text
+func entryKind(item storage.ClipboardItem) string {
+	if item.ContentType == "image" {
+		return config.EntryImage
+	}
+	if _, ok := enrich.LinkURL(item.Content); ok {
+		return config.EntryLink
+	}
+	return config.EntryText
+}
+
+// enterCmd runs the action configured for Enter, or alt+enter when alt is set, on the
+// selected item. The shell widget picker always inserts the item.
+func (m *Model) enterCmd(item storage.ClipboardItem, alt bool) tea.Cmd {
+	action := config.ActionCopy
+	if m.config != nil && !m.insertMode {
+		action = m.config.Keys.EnterAction(entryKind(item), alt)
+	}
+
+	switch action {
+	case config.ActionView:
+		return m.viewItem(&item)
+	case config.ActionOpen:
+		if item.ContentType == "image" {
+			return m.openImageInViewer(item)
+		}
+		return m.openLinkCmd(item)
+	}
+	return m.copyItemCmd(item)
+}
+
+// viewItem shows an item in the full-screen image or text viewer
+func (m *Model) viewItem(item *storage.ClipboardItem) tea.Cmd {
+	m.loadViewingSource(item.ID)
+	if item.ContentType == "image" {
+		m.viewingImage = item
+		m.currentMode = modeImageView
+		return nil
+	}
+	m.setViewingText(item)
+	m.textViewportReady = false
+	m.showDecoded = false
+	m.currentMode = modeTextView
+	m.loadLanguageOverride()
+	return m.highlightTextViewCmd()
+}
+
+// openLinkCmd opens the selected link with the configured browser, leaving the TUI open
+func (m *Model) openLinkCmd(item storage.ClipboardItem) tea.Cmd {
+	link, ok := enrich.LinkURL(item.Content)
+	if !ok {
+		return m.showToast(toastWarning, "Not a link")
+	}
+	browser := m.config.Editor.Browser
+	return func() tea.Msg {
+		cmd, err := editorCommand(browser, link)
+		if err == nil {
+			err = cmd.Start()
+		}
+		if err != nil {
+			return errorToast("open link", err)
+		}
+		logging.Debug("Open link: %s started with pid %d", browser, cmd.Process.Pid)
+		go cmd.Wait() // Reap the browser launcher when it exits
+		return toastMsg{level: toastSuccess, text: "Opened link in " + browser}
+	}
+}
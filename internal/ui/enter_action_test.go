@@ -0,0 +1,74 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestEntryKind(t *testing.T) {
+	tests := []struct {
+		item storage.ClipboardItem
+		want string
+	}{
+		{storage.ClipboardItem{Content: "https://example.com/", ContentType: "text"}, config.EntryLink},
+		{storage.ClipboardItem{Content: "see https://example.com/", ContentType: "text"}, config.EntryText},
+		{storage.ClipboardItem{Content: "[Image]", ContentType: "image"}, config.EntryImage},
+	}
+	for _, test := range tests {
+		if got := entryKind(test.item); got != test.want {
+			t.Errorf("entryKind(%q) = %q, want %q", test.item.Content, got, test.want)
+		}
+	}
+}
+
+func TestEnterCmd_FollowsKeysConfig(t *testing.T) {
+	cfg := &config.Config{Keys: config.KeysConfig{
+		EnterText:    config.ActionView,
+		AltEnterText: config.ActionCopy,
+	}}
+	text := storage.ClipboardItem{ID: "1", Content: "plain text", ContentType: "text"}
+
+	m := Model{config: cfg, themeService: NewThemeService(&config.ThemeConfig{})}
+	m.enterCmd(text, false)
+	if m.currentMode != modeTextView || m.viewingText == nil || m.viewingText.Content != "plain text" {
+		t.Errorf("Expected Enter to open the text viewer, got mode %d", m.currentMode)
+	}
+
+	// alt+enter copies; in insert mode the copy is handed back instead of using the clipboard
+	m = Model{config: cfg, themeService: NewThemeService(&config.ThemeConfig{})}
+	m.SetInsertMode(true)
+	if _, ok := m.enterCmd(text, true)().(insertItemMsg); !ok {
+		t.Error("Expected alt+enter to copy the text")
+	}
+
+	// The shell widget picker always inserts, whatever Enter is set to
+	if _, ok := m.enterCmd(text, false)().(insertItemMsg); !ok || m.currentMode == modeTextView {
+		t.Error("Expected Enter to insert the item in the shell widget picker")
+	}
+}
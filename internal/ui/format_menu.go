@@ -0,0 +1,308 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/enrich"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// formatTitleTimeout bounds the page title request of a link template
+const formatTitleTimeout = 5 * time.Second
+
+// fetchLinkTitle requests a link's page title from a public address, replaceable in tests
+var fetchLinkTitle = func(ctx context.Context, link string) (string, error) {
+	return enrich.FetchTitle(ctx, enrich.PublicClient(formatTitleTimeout), link)
+}
+
+// templateFields are the values a format template interpolates
+type templateFields struct {
+	content  string
+	link     bool   // The content is a single http or https link
+	title    string // Page title of a link, "" until fetched
+	language string // Highlighting language, "" for plain text
+	date     string
+	app      string
+}
+
+// Escapers for the values of Markdown and Org links, so a bracket or parenthesis in a page
+// title or link doesn't end the link early
+var (
+	markdownTextEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+	markdownLinkEscaper = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20")
+	orgTextEscaper      = strings.NewReplacer("[", "{", "]", "}")
+	orgLinkEscaper      = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+)
+
+// render fills in a template's placeholders; a link without a title stands in for it.
+// Values are escaped for the Markdown or Org link, or the code fence, the format contains.
+func (fields templateFields) render(format string) string {
+	content, title := fields.content, fields.title
+	if title == "" {
+		title = content
+	}
+	switch {
+	case strings.Contains(format, "[["):
+		content, title = orgLinkEscaper.Replace(content), orgTextEscaper.Replace(title)
+	case strings.Contains(format, "]("):
+		content, title = markdownLinkEscaper.Replace(content), markdownTextEscaper.Replace(title)
+	case strings.Contains(format, "```"):
+		format = strings.ReplaceAll(format, "```", codeFence(content))
+	}
+	return strings.NewReplacer(
+		"{content}", content,
+		"{title}", title,
+		"{language}", fields.language,
+		"{date}", fields.date,
+		"{app}", fields.app,
+	).Replace(format)
+}
+
+// codeFence returns a Markdown code fence longer than any run of backticks in content,
+// so the content can't close the block
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// matches reports whether a template applies to the entry
+func (fields templateFields) matches(template config.TemplateConfig) bool {
+	switch template.Match {
+	case config.TemplateMatchLink:
+		return fields.link
+	case config.TemplateMatchCode:
+		return fields.language != ""
+	default:
+		return true
+	}
+}
+
+// formatMenu lists the templates the selected entry can be formatted with
+type formatMenu struct {
+	item      storage.ClipboardItem
+	fields    templateFields
+	templates []config.TemplateConfig
+	cursor    int
+	fetching  bool               // Waiting for the link's page title
+	cancel    context.CancelFunc // Stops the title request when the menu is closed
+}
+
+// formatReadyMsg carries the formatted text once a link's title has been fetched
+type formatReadyMsg struct {
+	menu *formatMenu           // The menu the title was fetched for
+	item storage.ClipboardItem // The entry with the formatted text as its content
+}
+
+// openFormatMenu collects the fields of the selected text entry and shows the templates
+// that apply to it
+func (m *Model) openFormatMenu(item storage.ClipboardItem, meta storage.ClipboardItemMeta) tea.Cmd {
+	if item.ContentType != "text" {
+		return m.showToast(toastWarning, "Only text can be formatted")
+	}
+	_, link := enrich.LinkURL(item.Content)
+	fields := templateFields{
+		content:  strings.TrimSpace(item.Content),
+		link:     link,
+		title:    meta.Title,
+		language: m.itemLanguage(meta),
+		date:     item.Timestamp.Format("2006-01-02"),
+	}
+	if m.storage != nil && !m.archiveMode {
+		fields.app = m.storage.GetSource(item.ID).App
+	}
+	if !link {
+		fields.content = item.Content // Whitespace is part of text and code
+	}
+
+	templates := config.DefaultTemplates
+	if m.config != nil && len(m.config.Templates) > 0 {
+		templates = m.config.Templates
+	}
+	var applicable []config.TemplateConfig
+	for _, template := range templates {
+		if fields.matches(template) {
+			applicable = append(applicable, template)
+		}
+	}
+	if len(applicable) == 0 {
+		return m.showToast(toastInfo, "No templates apply to this entry")
+	}
+
+	m.formatMenu = &formatMenu{item: item, fields: fields, templates: applicable}
+	m.currentMode = modeFormat
+	return nil
+}
+
+// closeFormatMenu returns to the list, giving up on a title still being fetched
+func (m *Model) closeFormatMenu() {
+	if m.formatMenu != nil && m.formatMenu.cancel != nil {
+		m.formatMenu.cancel()
+	}
+	m.formatMenu = nil
+	m.currentMode = modeList
+}
+
+// handleFormatMenuKey moves through the templates and copies the chosen one
+func (m *Model) handleFormatMenuKey(key string) tea.Cmd {
+	menu := m.formatMenu
+	if menu == nil {
+		m.currentMode = modeList
+		return nil
+	}
+	if menu.fetching {
+		switch key {
+		case "esc":
+			m.closeFormatMenu()
+		case "ctrl+c":
+			return tea.Quit
+		}
+		return nil
+	}
+	switch key {
+	case "up", "k":
+		if menu.cursor > 0 {
+			menu.cursor--
+		}
+	case "down", "j":
+		if menu.cursor < len(menu.templates)-1 {
+			menu.cursor++
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		index := int(key[0] - '1')
+		if index < len(menu.templates) {
+			menu.cursor = index
+			return m.applyFormat()
+		}
+	case "enter":
+		return m.applyFormat()
+	case "esc", "q", "F":
+		m.closeFormatMenu()
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// applyFormat copies the entry formatted with the selected template, first fetching the
+// page title when the template needs one the link doesn't have yet
+func (m *Model) applyFormat() tea.Cmd {
+	menu := m.formatMenu
+	template := menu.templates[menu.cursor]
+	// Links with a query string or fragment may hold a one-time token a request uses up
+	needsTitle := menu.fields.link && menu.fields.title == "" && strings.Contains(template.Format, "{title}") &&
+		!enrich.HasQuery(menu.fields.content)
+	if !needsTitle {
+		item := menu.item
+		item.Content = menu.fields.render(template.Format)
+		m.closeFormatMenu()
+		return m.copyItemCmd(item)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), formatTitleTimeout)
+	menu.fetching, menu.cancel = true, cancel
+	fields, item, store := menu.fields, menu.item, m.storage
+	return func() tea.Msg {
+		defer cancel()
+		title, err := fetchLinkTitle(ctx, fields.content)
+		if err != nil {
+			logging.Debug("Failed to fetch link title, using the link: %v", err)
+		} else if title != "" && store != nil {
+			fields.title = title
+			// Keep the title, so the list shows it and the next format needs no request
+			if err := store.SetTextTitle(item.Content, title); err != nil {
+				logging.Warn("Failed to save link title: %v", err)
+			}
+		}
+		item.Content = fields.render(template.Format)
+		return formatReadyMsg{menu: menu, item: item}
+	}
+}
+
+// handleFormatReady copies the formatted text, unless the menu was closed meanwhile
+func (m *Model) handleFormatReady(msg formatReadyMsg) tea.Cmd {
+	if m.formatMenu == nil || m.formatMenu != msg.menu || !m.formatMenu.fetching {
+		return nil
+	}
+	m.closeFormatMenu()
+	return m.copyItemCmd(msg.item)
+}
+
+// renderFormatMenu lists the templates with a preview of the selected one
+func (m Model) renderFormatMenu() string {
+	menu := m.formatMenu
+	if menu == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	var lines []string
+	for i, template := range menu.templates {
+		line := truncateWithEllipsis(fmt.Sprintf("%d. %s", i+1, template.Name), contentWidth-2)
+		if i == menu.cursor {
+			lines = append(lines, m.selectionPrefix(true, 0)+mainStyles.SelectedBackground.Render(line))
+		} else {
+			lines = append(lines, "  "+mainStyles.Text.Render(line))
+		}
+	}
+	lines = append(lines, "", "  "+mainStyles.Header.Render("Preview"))
+	preview := menu.fields.render(menu.templates[menu.cursor].Format)
+	for _, line := range strings.Split(sanitizeForDisplay(preview), "\n") {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		lines = append(lines, "  "+mainStyles.Text.Render(truncateWithEllipsis(line, contentWidth-2)))
+	}
+
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		if i < len(lines) {
+			content.WriteString(lines[i])
+		}
+		content.WriteString("\n")
+	}
+
+	footerText := "enter/1-9: copy | j/k: move | esc: close"
+	if menu.fetching {
+		footerText = "fetching page title... | esc: cancel"
+	}
+	frameContent := m.buildFrameContent("Format As", content.String(), footerText, contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
@@ -0,0 +1,147 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestTemplateFields_Render(t *testing.T) {
+	fields := templateFields{content: "https://example.com", link: true, date: "2026-01-02", app: "firefox"}
+	if got := fields.render("[{title}]({content}) {date} {app}"); got != "[https://example.com](https://example.com) 2026-01-02 firefox" {
+		t.Errorf("Expected the link to stand in for a missing title, got %q", got)
+	}
+	fields.title = "Example Domain"
+	if got := fields.render("[{title}]({content})"); got != "[Example Domain](https://example.com)" {
+		t.Errorf("Unexpected Markdown link %q", got)
+	}
+}
+
+func TestTemplateFields_RenderEscapes(t *testing.T) {
+	fields := templateFields{content: "https://example.com/a_(b)", link: true, title: "Arrays [1] (draft)"}
+	if got := fields.render("[{title}]({content})"); got != `[Arrays \[1\] (draft)](https://example.com/a_%28b%29)` {
+		t.Errorf("Unexpected Markdown link %q", got)
+	}
+	fields.title = "Lists [[nested]]"
+	if got := fields.render("[[{content}][{title}]]"); got != "[[https://example.com/a_(b)][Lists {{nested}}]]" {
+		t.Errorf("Unexpected Org link %q", got)
+	}
+
+	code := templateFields{content: "see ```go\nfmt.Println()\n```", language: "markdown"}
+	if got := code.render("```{language}\n{content}\n```"); got != "````markdown\n"+code.content+"\n````" {
+		t.Errorf("Expected a longer fence around backticks, got %q", got)
+	}
+}
+
+func TestOpenFormatMenu_OffersMatchingTemplates(t *testing.T) {
+	m := Model{config: &config.Config{}, themeService: NewThemeService(&config.ThemeConfig{})}
+
+	m.openFormatMenu(storage.ClipboardItem{Content: "just some words", ContentType: "text"}, storage.ClipboardItemMeta{})
+	if m.currentMode != modeFormat || len(m.formatMenu.templates) != 1 || m.formatMenu.templates[0].Name != "Markdown code block" {
+		t.Fatalf("Expected only the code block template for plain text, got %+v", m.formatMenu)
+	}
+	m.handleFormatMenuKey("esc")
+
+	m.openFormatMenu(storage.ClipboardItem{Content: "https://example.com", ContentType: "text"}, storage.ClipboardItemMeta{})
+	if len(m.formatMenu.templates) != 3 {
+		t.Errorf("Expected the link templates too, got %+v", m.formatMenu.templates)
+	}
+	m.handleFormatMenuKey("esc")
+
+	m.openFormatMenu(storage.ClipboardItem{ContentType: "image"}, storage.ClipboardItemMeta{})
+	if m.currentMode != modeList || m.toast == nil {
+		t.Error("Expected images to be refused with a toast")
+	}
+}
+
+func TestFormatMenu_FetchesTitleAndCopies(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	var requested string
+	original := fetchLinkTitle
+	fetchLinkTitle = func(ctx context.Context, link string) (string, error) {
+		requested = link
+		return "Example Domain", nil
+	}
+	defer func() { fetchLinkTitle = original }()
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("https://example.com")
+
+	m := Model{storage: s, config: &config.Config{}, themeService: NewThemeService(&config.ThemeConfig{})}
+	item := storage.ClipboardItem{Content: "https://example.com", ContentType: "text", Timestamp: time.Now()}
+	m.openFormatMenu(item, storage.ClipboardItemMeta{Content: item.Content, ContentType: "text"})
+
+	cmd := m.handleFormatMenuKey("1") // Markdown link
+	if cmd == nil || !m.formatMenu.fetching {
+		t.Fatal("Expected the page title to be fetched first")
+	}
+	ready := cmd().(formatReadyMsg)
+	if requested != "https://example.com" || ready.item.Content != "[Example Domain](https://example.com)" {
+		t.Errorf("Expected a Markdown link with the fetched title, got %q", ready.item.Content)
+	}
+	if meta := s.GetAllMeta(); len(meta) != 1 || meta[0].Title != "Example Domain" {
+		t.Errorf("Expected the fetched title to be saved, got %+v", meta)
+	}
+
+	copied, ok := m.handleFormatReady(ready)().(headlessCopyMsg)
+	if !ok || copied.content != "[Example Domain](https://example.com)" || m.currentMode != modeList {
+		t.Errorf("Expected the formatted link to be copied and the menu closed, got %+v", copied)
+	}
+}
+
+func TestFormatMenu_EscCancelsFetch(t *testing.T) {
+	original := fetchLinkTitle
+	fetchLinkTitle = func(ctx context.Context, link string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	defer func() { fetchLinkTitle = original }()
+
+	m := Model{config: &config.Config{}, themeService: NewThemeService(&config.ThemeConfig{})}
+	item := storage.ClipboardItem{Content: "https://example.com", ContentType: "text", Timestamp: time.Now()}
+	m.openFormatMenu(item, storage.ClipboardItemMeta{Content: item.Content, ContentType: "text"})
+
+	cmd := m.handleFormatMenuKey("1")
+	m.handleFormatMenuKey("esc")
+	if m.currentMode != modeList || m.formatMenu != nil {
+		t.Fatal("Expected esc to close the menu while the title is fetched")
+	}
+	// The request returns once cancelled, and its result is dropped
+	if copyCmd := m.handleFormatReady(cmd().(formatReadyMsg)); copyCmd != nil {
+		t.Error("Expected nothing to be copied after cancelling")
+	}
+}
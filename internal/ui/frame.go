@@ -37,7 +37,7 @@ func (m Model) createFramedDialog(width, height int, content string) string {
 	
 	// Create dialog style with proper background inheritance
 	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(m.frameBorder()).
 		BorderForeground(mainStyles.Border.GetForeground()).
 		Padding(0, 1).
 		Width(width).
@@ -81,21 +81,7 @@ func (m Model) createFramedDialog(width, height int, content string) string {
 
 // calculateDialogDimensions returns standard dialog dimensions for consistent sizing across all views
 func (m Model) calculateDialogDimensions() (dialogWidth, dialogHeight, contentWidth, contentHeight int) {
-	// Standard dialog sizing used by all views
-	dialogWidth = m.width - 2   // 1 char padding left and right
-	dialogHeight = m.height - 2 // 1 char padding top and bottom
-	contentWidth = dialogWidth - 4   // Border + internal padding
-	contentHeight = dialogHeight - 4 // Border + header + footer
-	
-	// Apply minimum constraints
-	if contentWidth < 20 {
-		contentWidth = 20
-	}
-	if contentHeight < 5 {
-		contentHeight = 5
-	}
-	
-	return dialogWidth, dialogHeight, contentWidth, contentHeight
+	return m.layout.dialog(m.width, m.height)
 }
 
 // buildFrameContent builds content for a framed dialog with header, content area, and footer
@@ -114,24 +100,32 @@ func (m Model) buildFrameContent(headerText, contentText, footerText string, con
 		content.WriteString(mainStyles.Header.Render(headerText))
 	}
 	content.WriteString("\n")
-	content.WriteString(mainStyles.HeaderSeparator.Render(strings.Repeat("─", contentWidth)))
+	content.WriteString(mainStyles.HeaderSeparator.Render(strings.Repeat(m.separatorChar(), contentWidth)))
 	content.WriteString("\n")
 
 	// Content area - just add the content as-is since buildMainContent already sized it correctly
 	content.WriteString(contentText)
 
 	// Footer separator and controls  
-	content.WriteString(mainStyles.FooterSeparator.Render(strings.Repeat("─", contentWidth)))
+	content.WriteString(mainStyles.FooterSeparator.Render(strings.Repeat(m.separatorChar(), contentWidth)))
 	content.WriteString("\n")
 
 	// Footer - parse and style key-action pairs
-	if len(footerText) > contentWidth {
-		footerText = footerText[:contentWidth-3] + "..."
-	}
+	footerText = truncateWithEllipsis(footerText, contentWidth)
 	
 	// Parse and style the footer text
 	items, filterIndicator := m.parseFooterText(footerText)
 	styledFooter := m.buildStyledFooter(items, filterIndicator, mainStyles)
+
+	// Append the toast, or let it take over the footer when there is no room beside it
+	if m.toast != nil {
+		remaining := contentWidth - lipgloss.Width(styledFooter) - 3
+		if remaining >= 20 {
+			styledFooter += mainStyles.FooterDivider.Render(" | ") + m.renderToast(remaining, mainStyles)
+		} else {
+			styledFooter = m.renderToast(contentWidth, mainStyles)
+		}
+	}
 	content.WriteString(styledFooter)
 
 	return content.String()
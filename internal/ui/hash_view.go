@@ -0,0 +1,184 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+// hashView lists the content hashes the user has blocked
+type hashView struct {
+	entries       []security.SecurityHash
+	cursor        int
+	offset        int  // First entry shown
+	removePending bool // 'x' pressed once on the selected entry
+}
+
+// openHashView loads the blocked hashes and switches to the hash store screen
+func (m *Model) openHashView() tea.Cmd {
+	if m.hashStore == nil {
+		return m.showToast(toastWarning, "Security hash store is not available")
+	}
+	entries, err := m.hashStore.GetAllHashes("")
+	if err != nil {
+		failure := errorToast("load blocked hashes", err)
+		return m.showToast(failure.level, failure.text)
+	}
+	m.hashView = &hashView{entries: entries}
+	m.currentMode = modeHashStore
+	return nil
+}
+
+// closeHashView returns to the list
+func (m *Model) closeHashView() {
+	m.hashView = nil
+	m.currentMode = modeList
+}
+
+// handleHashViewKey moves through the blocked hashes and removes them on request
+func (m *Model) handleHashViewKey(key string) tea.Cmd {
+	view := m.hashView
+	if view == nil {
+		m.currentMode = modeList
+		return nil
+	}
+
+	if key != "x" {
+		view.removePending = false
+	}
+	switch key {
+	case "up", "k":
+		if view.cursor > 0 {
+			view.cursor--
+		}
+	case "down", "j":
+		if view.cursor < len(view.entries)-1 {
+			view.cursor++
+		}
+	case "g", "home":
+		view.cursor = 0
+	case "G", "end":
+		view.cursor = max(len(view.entries)-1, 0)
+	case "x":
+		if len(view.entries) == 0 {
+			return nil
+		}
+		if !view.removePending && m.confirmBlockHash() {
+			view.removePending = true
+			return nil
+		}
+		return m.removeSelectedHash()
+	case "esc", "q", "B":
+		m.closeHashView()
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// removeSelectedHash unblocks the selected hash so that content can be stored again
+func (m *Model) removeSelectedHash() tea.Cmd {
+	view := m.hashView
+	view.removePending = false
+	entry := view.entries[view.cursor]
+	if err := m.hashStore.RemoveHash(entry.Hash); err != nil {
+		failure := errorToast("remove blocked hash", err)
+		return m.showToast(failure.level, failure.text)
+	}
+
+	view.entries = append(view.entries[:view.cursor], view.entries[view.cursor+1:]...)
+	if view.cursor >= len(view.entries) && view.cursor > 0 {
+		view.cursor--
+	}
+	m.cue()
+	return m.showToast(toastSuccess, "Unblocked "+shortHash(entry.Hash))
+}
+
+// shortHash abbreviates a content hash for display
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// hashEntryLine describes one blocked hash on a single line
+func hashEntryLine(entry security.SecurityHash) string {
+	hits := "hit"
+	if entry.Count != 1 {
+		hits = "hits"
+	}
+	return fmt.Sprintf("%-12s  %-10s  added %s  %d %s  %s",
+		shortHash(entry.Hash), entry.ThreatType, entry.FirstSeen.Format("2006-01-02"), entry.Count, hits, entry.Reason)
+}
+
+// renderHashView shows the blocked hashes, keeping the selected one in view
+func (m Model) renderHashView() string {
+	view := m.hashView
+	if view == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	// Scroll so the cursor stays visible
+	if view.cursor < view.offset {
+		view.offset = view.cursor
+	}
+	if contentHeight > 0 && view.cursor >= view.offset+contentHeight {
+		view.offset = view.cursor - contentHeight + 1
+	}
+
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		index := view.offset + i
+		switch {
+		case len(view.entries) == 0 && i == 0:
+			content.WriteString("  " + mainStyles.Text.Render("No blocked hashes"))
+		case index < len(view.entries):
+			line := truncateWithEllipsis(hashEntryLine(view.entries[index]), contentWidth-2)
+			if index == view.cursor {
+				content.WriteString(m.selectionPrefix(true, 0) + mainStyles.SelectedBackground.Render(line))
+			} else {
+				content.WriteString("  " + mainStyles.Text.Render(line))
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	headerText := fmt.Sprintf("Blocked Hashes (%d)", len(view.entries))
+	footerText := "x: remove | j/k: move | esc: close"
+	if view.removePending {
+		headerText += " - Remove: " + shortHash(view.entries[view.cursor].Hash)
+		footerText = "Press 'x' again to unblock, any other key to cancel"
+	}
+	frameContent := m.buildFrameContent(headerText, content.String(), footerText, contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
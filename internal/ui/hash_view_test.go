@@ -0,0 +1,102 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+func newHashViewTestModel(t *testing.T, contents ...string) (Model, *security.HashStore) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	hashStore, err := security.NewHashStore()
+	if err != nil {
+		t.Fatalf("Failed to create hash store: %v", err)
+	}
+	t.Cleanup(func() { hashStore.Close() })
+	for _, content := range contents {
+		threat := security.SecurityThreat{Type: "api_key", Confidence: 0.9, Reason: "test"}
+		if err := hashStore.AddHash(security.CreateHash(content), threat); err != nil {
+			t.Fatalf("Failed to add hash: %v", err)
+		}
+	}
+
+	m := Model{hashStore: hashStore, themeService: NewThemeService(&config.ThemeConfig{}), width: 100, height: 24}
+	return m, hashStore
+}
+
+func TestHashView_ListsBlockedHashes(t *testing.T) {
+	m, _ := newHashViewTestModel(t, "first secret", "second secret")
+
+	m.openHashView()
+	if m.currentMode != modeHashStore || len(m.hashView.entries) != 2 {
+		t.Fatalf("Expected 2 blocked hashes, got mode %d", m.currentMode)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Blocked Hashes (2)") || !strings.Contains(view, "1 hit") {
+		t.Errorf("Expected the entries with their hit counts in the view:\n%s", view)
+	}
+
+	m.handleHashViewKey("esc")
+	if m.currentMode != modeList || m.hashView != nil {
+		t.Error("Expected esc to return to the list")
+	}
+}
+
+func TestHashView_RemoveRequiresConfirmation(t *testing.T) {
+	m, hashStore := newHashViewTestModel(t, "first secret", "second secret")
+	m.openHashView()
+	m.handleHashViewKey("j")
+	removed := m.hashView.entries[1].Hash
+
+	m.handleHashViewKey("x")
+	m.handleHashViewKey("k")
+	if len(m.hashView.entries) != 2 || m.hashView.removePending {
+		t.Fatal("Expected another key to cancel the removal")
+	}
+
+	m.handleHashViewKey("j")
+	m.handleHashViewKey("x")
+	m.handleHashViewKey("x")
+	if len(m.hashView.entries) != 1 || m.hashView.cursor != 0 {
+		t.Fatalf("Expected one entry left with the cursor on it, got %d at %d", len(m.hashView.entries), m.hashView.cursor)
+	}
+	if blocked, _ := hashStore.HasHash(removed); blocked {
+		t.Error("Expected the hash to be removed from the store")
+	}
+}
+
+func TestHashView_NoStore(t *testing.T) {
+	m := Model{}
+	m.openHashView()
+	if m.currentMode != modeList || m.toast == nil {
+		t.Error("Expected a warning when the hash store is unavailable")
+	}
+}
@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// errNoDisplayImage explains why images can't be copied in a headless session
+var errNoDisplayImage = errors.New("images need a display to be copied; OSC 52 only carries text")
+
+// headlessCopyMsg carries text to copy when there is no system clipboard
+type headlessCopyMsg struct {
+	content string
+
+	// Set when the item was removed from history ("copy and remove")
+	removedID string
+	removeErr error
+	written   ownWrite
+}
+
+// headlessCopyMode returns the configured copy fallback for sessions without a display
+func (m *Model) headlessCopyMode() string {
+	if m.config != nil && m.config.Behavior.HeadlessCopy == config.HeadlessCopyPrint {
+		return config.HeadlessCopyPrint
+	}
+	return config.HeadlessCopyOSC52
+}
+
+// headlessNotice tells the user at startup how copying works without a display
+func (m *Model) headlessNotice() tea.Cmd {
+	text := "No display: copied text goes to the terminal clipboard (OSC 52)"
+	if m.headlessCopyMode() == config.HeadlessCopyPrint {
+		text = "No display: copying quits and prints the text"
+	}
+	return func() tea.Msg { return toastMsg{level: toastWarning, text: text} }
+}
+
+// handleHeadlessCopy copies text without a system clipboard, either through the terminal
+// or by printing it once the TUI has exited
+func (m *Model) handleHeadlessCopy(msg headlessCopyMsg) tea.Cmd {
+	if m.headlessCopyMode() == config.HeadlessCopyPrint {
+		// There is no screen left to show a toast on, so a failed removal is only logged
+		if msg.removeErr != nil {
+			logging.Error("Failed to remove copied item %s: %v", msg.removedID, msg.removeErr)
+		}
+		m.printOnExit = &msg.content
+		return tea.Quit
+	}
+
+	if err := clipboard.CopyOSC52(os.Stdout, msg.content); err != nil {
+		return m.handleCopyDone(copyDoneMsg{err: fmt.Errorf("OSC 52: %w", err)})
+	}
+	return m.handleCopyDone(copyDoneMsg{removedID: msg.removedID, removeErr: msg.removeErr, written: msg.written})
+}
+
+// PrintOnExit returns the text copied in "print" headless mode, to be written to stdout
+// after the TUI has released the terminal
+func (m Model) PrintOnExit() (string, bool) {
+	if m.printOnExit == nil {
+		return "", false
+	}
+	return *m.printOnExit, true
+}
@@ -0,0 +1,87 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestHandleHeadlessCopy_PrintMode(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Behavior.HeadlessCopy = config.HeadlessCopyPrint
+	m := Model{config: cfg}
+
+	if _, ok := m.PrintOnExit(); ok {
+		t.Fatal("Expected nothing to print before a copy")
+	}
+	if cmd := m.handleHeadlessCopy(headlessCopyMsg{content: "copied text"}); cmd == nil {
+		t.Fatal("Expected the TUI to quit so the text can be printed")
+	}
+	if text, ok := m.PrintOnExit(); !ok || text != "copied text" {
+		t.Errorf("Expected the copied text to be printed on exit, got %q, %v", text, ok)
+	}
+}
+
+func TestHeadlessNotice(t *testing.T) {
+	m := Model{config: &config.Config{}}
+	msg, ok := m.headlessNotice()().(toastMsg)
+	if !ok || !strings.Contains(msg.text, "OSC 52") || msg.level != toastWarning {
+		t.Errorf("Expected an OSC 52 notice, got %+v", msg)
+	}
+
+	m.config.Behavior.HeadlessCopy = config.HeadlessCopyPrint
+	if msg := m.headlessNotice()().(toastMsg); !strings.Contains(msg.text, "prints") {
+		t.Errorf("Expected a print notice, got %+v", msg)
+	}
+}
+
+func TestInsertMode_PrintsPickedText(t *testing.T) {
+	m := Model{config: &config.Config{}, stayOpen: true}
+	m.SetInsertMode(true)
+	if !m.compactList || m.stayOpen {
+		t.Fatal("Expected insert mode to use the compact list and quit after picking")
+	}
+
+	msg, ok := m.copyItemCmd(storage.ClipboardItem{ID: "1", Content: "git status", ContentType: "text"})().(insertItemMsg)
+	if !ok {
+		t.Fatal("Expected insert mode to skip the clipboard")
+	}
+	if cmd := m.handleInsertItem(msg); cmd == nil {
+		t.Fatal("Expected the picker to quit so the text can be printed")
+	}
+	if text, ok := m.PrintOnExit(); !ok || text != "git status" {
+		t.Errorf("Expected the picked text to be printed on exit, got %q, %v", text, ok)
+	}
+
+	done, ok := m.copyItemCmd(storage.ClipboardItem{ID: "2", ContentType: "image"})().(copyDoneMsg)
+	if !ok || !errors.Is(done.err, errInsertImage) {
+		t.Errorf("Expected images to be refused, got %+v", done)
+	}
+}
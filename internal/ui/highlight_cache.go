@@ -0,0 +1,221 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"container/list"
+	"hash/fnv"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// textLinesCacheSize is how many rendered text views and preview panes are kept
+	textLinesCacheSize = 16
+
+	// asyncHighlightThreshold is the content size above which highlighting runs in
+	// the background, showing plain text until it finishes
+	asyncHighlightThreshold = 32 * 1024
+)
+
+// textLinesKey identifies a rendering of an item's text: highlighting depends on the
+// content and theme, wrapping on the width
+type textLinesKey struct {
+	id          string
+	contentHash uint64
+	theme       string
+	width       int
+	basicColors bool
+	language    string // Forced language, "" when detected
+	rawANSI     bool
+}
+
+// textLinesEntry holds the highlighted, wrapped lines of a text view
+type textLinesEntry struct {
+	language string
+	isCode   bool
+	colored  bool // Lines carry colors from terminal output in the content
+	lines    []string
+}
+
+// highlightDoneMsg delivers text view lines highlighted in the background
+type highlightDoneMsg struct {
+	key   textLinesKey
+	entry textLinesEntry
+}
+
+// textLinesCache is a small LRU of rendered text views, shared by all copies of the Model
+type textLinesCache struct {
+	mu      sync.Mutex
+	entries map[textLinesKey]*list.Element
+	order   *list.List // Most recently used first, values are textLinesKey
+	values  map[textLinesKey]textLinesEntry
+	pending map[textLinesKey]bool // Keys being highlighted in the background
+}
+
+func newTextLinesCache() *textLinesCache {
+	return &textLinesCache{
+		entries: make(map[textLinesKey]*list.Element),
+		order:   list.New(),
+		values:  make(map[textLinesKey]textLinesEntry),
+		pending: make(map[textLinesKey]bool),
+	}
+}
+
+// get returns the cached entry for key and marks it as recently used
+func (c *textLinesCache) get(key textLinesKey) (textLinesEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return textLinesEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return c.values[key], true
+}
+
+// put stores an entry, evicting the least recently used one when full
+func (c *textLinesCache) put(key textLinesKey, entry textLinesEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, key)
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		c.values[key] = entry
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(key)
+	c.values[key] = entry
+	for c.order.Len() > textLinesCacheSize {
+		oldest := c.order.Back()
+		oldestKey := oldest.Value.(textLinesKey)
+		c.order.Remove(oldest)
+		delete(c.entries, oldestKey)
+		delete(c.values, oldestKey)
+	}
+}
+
+// clear removes all rendered text views
+func (c *textLinesCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[textLinesKey]*list.Element)
+	c.order.Init()
+	c.values = make(map[textLinesKey]textLinesEntry)
+	c.pending = make(map[textLinesKey]bool)
+}
+
+// markPending records that key is being highlighted, returning false if it already was
+func (c *textLinesCache) markPending(key textLinesKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending[key] {
+		return false
+	}
+	c.pending[key] = true
+	return true
+}
+
+// textLinesKey builds the cache key for the item in the text view
+func (m Model) textLinesKey() textLinesKey {
+	_, _, contentWidth, _ := m.calculateDialogDimensions()
+	content, language := m.textViewContent()
+	return m.textLinesKeyFor(m.viewingText.ID, content, contentWidth, language, m.showRawANSI)
+}
+
+// textLinesKeyFor builds the cache key for rendering content at a width
+func (m Model) textLinesKeyFor(id, content string, width int, language string, rawANSI bool) textLinesKey {
+	hash := fnv.New64a()
+	hash.Write([]byte(content))
+
+	var theme string
+	if m.themeService != nil {
+		theme = m.themeService.config.Chroma.Theme
+	}
+
+	return textLinesKey{
+		id:          id,
+		contentHash: hash.Sum64(),
+		theme:       theme,
+		width:       width,
+		basicColors: m.useBasicColors,
+		language:    language,
+		rawANSI:     rawANSI,
+	}
+}
+
+// textViewEntry returns the lines for the text view from the cache, computing them for
+// small items. Large items that aren't cached yet get plain lines and false until
+// highlightTextViewCmd delivers the highlighted version.
+func (m Model) textViewEntry() (textLinesEntry, bool) {
+	if m.viewingText == nil {
+		return textLinesEntry{lines: []string{}}, true
+	}
+	if m.textLines == nil {
+		return m.computeTextViewLines(true), true
+	}
+
+	key := m.textLinesKey()
+	if entry, ok := m.textLines.get(key); ok {
+		return entry, true
+	}
+	if len(m.viewingText.Content) >= asyncHighlightThreshold {
+		return m.computeTextViewLines(false), false
+	}
+
+	entry := m.computeTextViewLines(true)
+	m.textLines.put(key, entry)
+	return entry, true
+}
+
+// highlightTextViewCmd highlights a large text view item in the background
+func (m Model) highlightTextViewCmd() tea.Cmd {
+	if m.viewingText == nil || m.textLines == nil || len(m.viewingText.Content) < asyncHighlightThreshold {
+		return nil
+	}
+
+	key := m.textLinesKey()
+	if _, ok := m.textLines.get(key); ok || !m.textLines.markPending(key) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return highlightDoneMsg{key: key, entry: m.computeTextViewLines(true)}
+	}
+}
+
+// handleHighlightDone stores background highlighting and redraws the text view if it still applies
+func (m *Model) handleHighlightDone(msg highlightDoneMsg) {
+	m.textLines.put(msg.key, msg.entry)
+	if m.currentMode == modeTextView && m.viewingText != nil && m.textLinesKey() == msg.key {
+		m.textViewportReady = false
+	}
+}
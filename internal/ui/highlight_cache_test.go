@@ -0,0 +1,120 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func newTextViewTestModel(content string) Model {
+	cfg := &config.Config{}
+	return Model{
+		width:        100,
+		height:       40,
+		codeDetector: NewCodeDetector(),
+		themeService: NewThemeService(&cfg.Theme),
+		textLines:    newTextLinesCache(),
+		viewingText:  &storage.ClipboardItem{ID: "1", Content: content},
+		currentMode:  modeTextView,
+	}
+}
+
+func TestTextLinesCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newTextLinesCache()
+	for i := 0; i <= textLinesCacheSize; i++ {
+		cache.put(textLinesKey{id: fmt.Sprint(i)}, textLinesEntry{lines: []string{fmt.Sprint(i)}})
+		if i == 0 {
+			continue
+		}
+		// Keep the first entry in use
+		cache.get(textLinesKey{id: "0"})
+	}
+
+	if _, ok := cache.get(textLinesKey{id: "0"}); !ok {
+		t.Error("Expected recently used entry to be kept")
+	}
+	if _, ok := cache.get(textLinesKey{id: "1"}); ok {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+}
+
+func TestTextViewEntry_CachesPerWidth(t *testing.T) {
+	m := newTextViewTestModel("package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n")
+
+	first, ready := m.textViewEntry()
+	if !ready {
+		t.Fatal("Expected small items to be highlighted synchronously")
+	}
+	if _, ok := m.textLines.get(m.textLinesKey()); !ok {
+		t.Fatal("Expected the rendered lines to be cached")
+	}
+
+	second, _ := m.textViewEntry()
+	if len(first.lines) == 0 || &first.lines[0] != &second.lines[0] {
+		t.Error("Expected the cached lines to be reused")
+	}
+
+	// A different width is a different rendering
+	m.width = 60
+	if _, ok := m.textLines.get(m.textLinesKey()); ok {
+		t.Error("Expected no cached lines for a new width")
+	}
+}
+
+func TestTextViewEntry_HighlightsLargeItemsInBackground(t *testing.T) {
+	content := strings.Repeat("func main() { println(\"hello\") }\n", asyncHighlightThreshold/30)
+	m := newTextViewTestModel("package main\n" + content)
+
+	if _, ready := m.textViewEntry(); ready {
+		t.Fatal("Expected plain lines while a large item is highlighted")
+	}
+
+	cmd := m.highlightTextViewCmd()
+	if cmd == nil {
+		t.Fatal("Expected a background highlighting command")
+	}
+	if again := m.highlightTextViewCmd(); again != nil {
+		t.Error("Expected no second command while highlighting is pending")
+	}
+
+	msg, ok := cmd().(highlightDoneMsg)
+	if !ok {
+		t.Fatal("Expected a highlightDoneMsg")
+	}
+	m.textViewportReady = true
+	m.handleHighlightDone(msg)
+
+	if _, ready := m.textViewEntry(); !ready {
+		t.Error("Expected highlighted lines after the background job finished")
+	}
+	if m.textViewportReady {
+		t.Error("Expected the text viewport to be rebuilt with highlighted lines")
+	}
+}
@@ -0,0 +1,207 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/enrich"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// hostInfoTimeout bounds the DNS and whois lookups of the host info action
+const hostInfoTimeout = 10 * time.Second
+
+// lookupHostInfo looks up an IP address or hostname through the host info cache;
+// replaced in tests
+var lookupHostInfo = func(ctx context.Context, target string, refresh bool) (enrich.HostInfo, error) {
+	path, err := enrich.HostInfoCachePath()
+	if err != nil {
+		return enrich.LookupHostInfo(ctx, target, net.DefaultResolver, enrich.RunWhois), nil
+	}
+	lookup := enrich.HostLookup{CachePath: path, Resolver: net.DefaultResolver, Whois: enrich.RunWhois}
+	return lookup.Info(ctx, target, refresh)
+}
+
+// hostInfoView shows what is known about a copied IP address or hostname
+type hostInfoView struct {
+	target  string
+	info    *enrich.HostInfo // Nil while the lookup runs
+	offset  int              // First line shown
+	loading bool
+}
+
+// hostInfoMsg carries the result of a host info lookup
+type hostInfoMsg struct {
+	info enrich.HostInfo
+	err  error // Cache write failure; the info is still valid
+}
+
+// openHostInfo starts looking up the selected IP address or hostname and switches to the
+// host info screen
+func (m *Model) openHostInfo(item storage.ClipboardItem) tea.Cmd {
+	target, ok := enrich.HostTarget(item.Content)
+	if item.ContentType != "text" || !ok {
+		return m.showToast(toastWarning, "Not an IP address or hostname")
+	}
+	m.hostInfo = &hostInfoView{target: target}
+	m.currentMode = modeHostInfo
+	return m.hostInfoCmd(false)
+}
+
+// hostInfoCmd runs the lookup off the Update path, as it takes network requests
+func (m *Model) hostInfoCmd(refresh bool) tea.Cmd {
+	view := m.hostInfo
+	view.loading = true
+	target := view.target
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), hostInfoTimeout)
+		defer cancel()
+		info, err := lookupHostInfo(ctx, target, refresh)
+		return hostInfoMsg{info: info, err: err}
+	}
+}
+
+// handleHostInfo shows the lookup result, unless the screen was closed or moved on to
+// another target in the meantime
+func (m *Model) handleHostInfo(msg hostInfoMsg) tea.Cmd {
+	view := m.hostInfo
+	if view == nil || view.target != msg.info.Target {
+		return nil
+	}
+	view.info = &msg.info
+	view.loading = false
+	view.offset = 0
+	if msg.err != nil {
+		failure := errorToast("save host info", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+	return nil
+}
+
+// closeHostInfo returns to the list
+func (m *Model) closeHostInfo() {
+	m.hostInfo = nil
+	m.currentMode = modeList
+}
+
+// handleHostInfoKey scrolls the host info and refreshes it on request
+func (m *Model) handleHostInfoKey(key string) tea.Cmd {
+	view := m.hostInfo
+	if view == nil {
+		m.currentMode = modeList
+		return nil
+	}
+	switch key {
+	case "up", "k":
+		if view.offset > 0 {
+			view.offset--
+		}
+	case "down", "j":
+		if view.offset < len(view.lines())-1 {
+			view.offset++
+		}
+	case "r":
+		if !view.loading {
+			return m.hostInfoCmd(true)
+		}
+	case "esc", "q", "I":
+		m.closeHostInfo()
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// lines lays out the host info for display
+func (view *hostInfoView) lines() []string {
+	info := view.info
+	if info == nil {
+		return []string{"Looking up " + view.target + "..."}
+	}
+
+	var lines []string
+	section := func(title string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+		lines = append(lines, title)
+		for _, entry := range entries {
+			lines = append(lines, "  "+entry)
+		}
+		lines = append(lines, "")
+	}
+	section("Addresses", info.Addresses)
+	section("Reverse DNS", info.ReverseDNS)
+	var whois []string
+	for _, field := range info.Whois {
+		whois = append(whois, fmt.Sprintf("%-13s %s", field.Label, field.Value))
+	}
+	section("Whois", whois)
+	section("Hints", info.Hints)
+	section("Lookup problems", info.Problems)
+
+	looked := "Looked up " + info.LookedUpAt.Format("2006-01-02 15:04")
+	switch {
+	case info.Stale:
+		looked += " (cached; a new lookup found nothing, so this may be out of date)"
+	case info.Cached:
+		looked += " (cached)"
+	}
+	return append(lines, looked)
+}
+
+// renderHostInfo shows the host info in a dialog
+func (m Model) renderHostInfo() string {
+	view := m.hostInfo
+	if view == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	lines := view.lines()
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		if index := view.offset + i; index < len(lines) {
+			content.WriteString("  " + mainStyles.Text.Render(truncateWithEllipsis(lines[index], contentWidth-2)))
+		}
+		content.WriteString("\n")
+	}
+
+	headerText := "Host Info - " + view.target
+	footerText := "r: refresh | j/k: scroll | esc: close"
+	if view.loading && view.info != nil {
+		footerText = "refreshing... | j/k: scroll | esc: close"
+	}
+	frameContent := m.buildFrameContent(headerText, content.String(), footerText, contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
@@ -0,0 +1,90 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/enrich"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestOpenHostInfo_RejectsOtherContent(t *testing.T) {
+	m := Model{themeService: NewThemeService(&config.ThemeConfig{})}
+	m.openHostInfo(storage.ClipboardItem{Content: "not a host", ContentType: "text"})
+	if m.toast == nil || m.currentMode != modeList {
+		t.Errorf("Expected a warning toast and no host info screen, got %+v in mode %d", m.toast, m.currentMode)
+	}
+}
+
+func TestHostInfo_LookupAndRefresh(t *testing.T) {
+	var refreshes []bool
+	original := lookupHostInfo
+	lookupHostInfo = func(ctx context.Context, target string, refresh bool) (enrich.HostInfo, error) {
+		refreshes = append(refreshes, refresh)
+		return enrich.HostInfo{
+			Target:     target,
+			ReverseDNS: []string{"dns.google"},
+			Hints:      []string{"Public address"},
+			LookedUpAt: time.Now(),
+			Cached:     !refresh,
+		}, nil
+	}
+	defer func() { lookupHostInfo = original }()
+
+	m := Model{themeService: NewThemeService(&config.ThemeConfig{})}
+	cmd := m.openHostInfo(storage.ClipboardItem{Content: "8.8.8.8:53", ContentType: "text"})
+	if m.currentMode != modeHostInfo || m.hostInfo.target != "8.8.8.8" {
+		t.Fatalf("Expected the host info screen for 8.8.8.8, got mode %d", m.currentMode)
+	}
+	if lines := m.hostInfo.lines(); !strings.HasPrefix(lines[0], "Looking up") {
+		t.Errorf("Expected a loading line before the lookup finishes, got %v", lines)
+	}
+
+	m.handleHostInfo(cmd().(hostInfoMsg))
+	text := strings.Join(m.hostInfo.lines(), "\n")
+	if !strings.Contains(text, "dns.google") || !strings.Contains(text, "(cached)") {
+		t.Errorf("Expected the reverse DNS name from the cache, got:\n%s", text)
+	}
+
+	m.handleHostInfo(m.handleHostInfoKey("r")().(hostInfoMsg))
+	if len(refreshes) != 2 || !refreshes[1] {
+		t.Errorf("Expected r to force a fresh lookup, got %v", refreshes)
+	}
+
+	m.handleHostInfoKey("esc")
+	if m.currentMode != modeList || m.hostInfo != nil {
+		t.Error("Expected esc to close the host info screen")
+	}
+	// A lookup finishing after the screen closed is dropped
+	m.handleHostInfo(hostInfoMsg{info: enrich.HostInfo{Target: "8.8.8.8"}})
+	if m.hostInfo != nil {
+		t.Error("Expected a late lookup result to be ignored")
+	}
+}
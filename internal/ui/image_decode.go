@@ -0,0 +1,237 @@
+//go:build !noimages
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"strings"
+
+	// Additional image format support
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+
+	// For image resizing
+	"golang.org/x/image/draw"
+)
+
+// getImageDimensions extracts width, height, and format from image data
+func getImageDimensions(imageData []byte) (int, int, string, error) {
+	reader := bytes.NewReader(imageData)
+	config, format, err := image.DecodeConfig(reader)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return config.Width, config.Height, format, nil
+}
+
+// resizeImageIfNeeded resizes very large images to prevent terminal buffer overflow
+func resizeImageIfNeeded(imageData []byte, maxWidth, maxHeight int) ([]byte, error) {
+	// Decode the image
+	reader := bytes.NewReader(imageData)
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return imageData, err // Return original if we can't decode
+	}
+
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+
+	// Check if resizing is needed
+	if width <= maxWidth && height <= maxHeight {
+		return imageData, nil // No resizing needed
+	}
+
+	// Calculate new dimensions maintaining aspect ratio
+	scaleX := float64(maxWidth) / float64(width)
+	scaleY := float64(maxHeight) / float64(height)
+	scale := scaleX
+	if scaleY < scaleX {
+		scale = scaleY
+	}
+
+	newWidth := int(float64(width) * scale)
+	newHeight := int(float64(height) * scale)
+
+	// Create a new image with the target size
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+
+	// Resize the image using high-quality scaling
+	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	// Encode as PNG
+	var buf bytes.Buffer
+	err = png.Encode(&buf, dst)
+	if err != nil {
+		return imageData, err // Return original if encoding fails
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resizeImageToExactDimensions resizes an image to exact target dimensions
+// Note: The target dimensions should already maintain aspect ratio from caller's calculation
+func resizeImageToExactDimensions(imageData []byte, targetWidth, targetHeight int) ([]byte, error) {
+	// Decode the image
+	reader := bytes.NewReader(imageData)
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return imageData, err // Return original if we can't decode
+	}
+
+	bounds := img.Bounds()
+	currentWidth := bounds.Dx()
+	currentHeight := bounds.Dy()
+
+	// Check if resizing is actually needed
+	if currentWidth == targetWidth && currentHeight == targetHeight {
+		return imageData, nil // No resizing needed
+	}
+
+	// Create a new image with the exact target size
+	dst := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+
+	// Resize the image using high-quality scaling
+	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	// Encode as PNG
+	var buf bytes.Buffer
+	err = png.Encode(&buf, dst)
+	if err != nil {
+		return imageData, err // Return original if encoding fails
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodePreviewSource decodes image data for a text preview
+func decodePreviewSource(data []byte, cols, rows int) (image.Image, error) {
+	if cols <= 0 || rows <= 0 {
+		return nil, fmt.Errorf("no room for a preview")
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if src.Bounds().Dx() == 0 || src.Bounds().Dy() == 0 {
+		return nil, fmt.Errorf("empty image")
+	}
+	return src, nil
+}
+
+// renderHalfBlockPreview draws an image in at most cols x rows cells using the upper half
+// block character, with the top pixel as foreground and the bottom pixel as background
+// color. Needs a terminal with 24-bit color.
+func renderHalfBlockPreview(data []byte, cols, rows int) ([]string, error) {
+	src, err := decodePreviewSource(data, cols, rows)
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+
+	// Each cell holds two vertically stacked, roughly square pixels
+	scale := min(float64(cols)/float64(bounds.Dx()), float64(rows*2)/float64(bounds.Dy()))
+	w := max(1, int(float64(bounds.Dx())*scale))
+	h := max(1, int(float64(bounds.Dy())*scale))
+
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.BiLinear.Scale(rgba, rgba.Bounds(), src, bounds, draw.Src, nil)
+
+	var lines []string
+	for y := 0; y < h; y += 2 {
+		var line strings.Builder
+		for x := 0; x < w; x++ {
+			top := rgba.RGBAAt(x, y)
+			fmt.Fprintf(&line, "\x1b[38;2;%d;%d;%dm", top.R, top.G, top.B)
+			if y+1 < h {
+				bottom := rgba.RGBAAt(x, y+1)
+				fmt.Fprintf(&line, "\x1b[48;2;%d;%d;%dm", bottom.R, bottom.G, bottom.B)
+			} else {
+				line.WriteString("\x1b[49m") // Odd height: nothing below the last row
+			}
+			line.WriteString("▀")
+		}
+		line.WriteString("\x1b[0m")
+		lines = append(lines, line.String())
+	}
+	return lines, nil
+}
+
+// renderImagePreview draws a rough preview of an image in at most cols x rows cells, for
+// terminals without a graphics protocol. Braille uses a 2x4 dot grid per cell; the ASCII
+// fallback uses one character per cell. Bright pixels are drawn, to suit dark terminals.
+func renderImagePreview(data []byte, cols, rows int, braille bool) ([]string, error) {
+	src, err := decodePreviewSource(data, cols, rows)
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+
+	// Terminal cells are about twice as tall as wide: a braille dot is roughly square,
+	// while an ASCII cell covers one pixel across and two down
+	cellW, cellH := 1, 2
+	if braille {
+		cellW, cellH = 2, 4
+	}
+	scale := min(float64(cols*cellW)/float64(bounds.Dx()), float64(rows*cellH)/float64(bounds.Dy()))
+	w := max(1, int(float64(bounds.Dx())*scale))
+	h := max(1, int(float64(bounds.Dy())*scale))
+	if !braille {
+		h = max(1, h/2)
+	}
+
+	gray := image.NewGray(image.Rect(0, 0, w, h))
+	draw.BiLinear.Scale(gray, gray.Bounds(), src, bounds, draw.Src, nil)
+	level := normalizedLevels(gray)
+
+	if braille {
+		return brailleLines(level, w, h), nil
+	}
+	return asciiLines(level, w, h), nil
+}
+
+// normalizedLevels returns the brightness of each pixel stretched to the image's own range
+// 0-1, so low-contrast screenshots still produce a visible preview
+func normalizedLevels(gray *image.Gray) func(x, y int) float64 {
+	lo, hi := uint8(255), uint8(0)
+	for _, v := range gray.Pix {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	span := float64(hi) - float64(lo)
+	return func(x, y int) float64 {
+		if span == 0 {
+			return 0.5
+		}
+		return (float64(gray.GrayAt(x, y).Y) - float64(lo)) / span
+	}
+}
@@ -0,0 +1,57 @@
+//go:build noimages
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import "errors"
+
+// errImagesDisabled is returned by the image helpers in builds without image support
+var errImagesDisabled = errors.New("image support not compiled in (built with -tags noimages)")
+
+// getImageDimensions is not available without image support
+func getImageDimensions(imageData []byte) (int, int, string, error) {
+	return 0, 0, "", errImagesDisabled
+}
+
+// resizeImageIfNeeded returns the image unchanged without image support
+func resizeImageIfNeeded(imageData []byte, maxWidth, maxHeight int) ([]byte, error) {
+	return imageData, errImagesDisabled
+}
+
+// resizeImageToExactDimensions returns the image unchanged without image support
+func resizeImageToExactDimensions(imageData []byte, targetWidth, targetHeight int) ([]byte, error) {
+	return imageData, errImagesDisabled
+}
+
+// renderHalfBlockPreview is not available without image support
+func renderHalfBlockPreview(data []byte, cols, rows int) ([]string, error) {
+	return nil, errImagesDisabled
+}
+
+// renderImagePreview is not available without image support
+func renderImagePreview(data []byte, cols, rows int, braille bool) ([]string, error) {
+	return nil, errImagesDisabled
+}
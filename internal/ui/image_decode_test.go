@@ -0,0 +1,130 @@
+//go:build !noimages
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// halfWhitePNG returns a w x h PNG that is black on the left half and white on the right
+func halfWhitePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := w / 2; x < w; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRenderImagePreview_Braille(t *testing.T) {
+	lines, err := renderImagePreview(halfWhitePNG(t, 100, 50), 40, 40, true)
+	if err != nil {
+		t.Fatalf("renderImagePreview failed: %v", err)
+	}
+
+	// 100x50 pixels fit 80x40 braille dots: 40 columns by 10 rows
+	if len(lines) != 10 {
+		t.Fatalf("Expected 10 rows, got %d", len(lines))
+	}
+	for _, line := range lines {
+		runes := []rune(line)
+		if len(runes) != 40 {
+			t.Fatalf("Expected 40 columns, got %d", len(runes))
+		}
+		if runes[2] != 0x2800 || runes[37] != 0x28FF {
+			t.Errorf("Expected empty cells on the dark half and full cells on the bright half, got %q", line)
+		}
+	}
+}
+
+func TestRenderImagePreview_ASCII(t *testing.T) {
+	lines, err := renderImagePreview(halfWhitePNG(t, 40, 40), 20, 20, false)
+	if err != nil {
+		t.Fatalf("renderImagePreview failed: %v", err)
+	}
+
+	// One pixel across and two down per cell: 20 columns by 10 rows
+	if len(lines) != 10 || len(lines[0]) != 20 {
+		t.Fatalf("Expected 20x10 characters, got %dx%d", len(lines[0]), len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "  ") || !strings.HasSuffix(lines[0], "@@") {
+		t.Errorf("Expected blank on the dark half and dense on the bright half, got %q", lines[0])
+	}
+}
+
+func TestRenderImagePreview_Errors(t *testing.T) {
+	if _, err := renderImagePreview([]byte("not an image"), 40, 20, true); err == nil {
+		t.Error("Expected an error for undecodable data")
+	}
+	if _, err := renderImagePreview(halfWhitePNG(t, 10, 10), 0, 20, true); err == nil {
+		t.Error("Expected an error without room for a preview")
+	}
+}
+
+func TestRenderHalfBlockPreview(t *testing.T) {
+	// Red on top, blue below, each one pixel tall
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+		img.Set(x, 1, color.RGBA{B: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+
+	lines, err := renderHalfBlockPreview(buf.Bytes(), 4, 10)
+	if err != nil {
+		t.Fatalf("renderHalfBlockPreview failed: %v", err)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected one row for a two pixel tall image, got %d", len(lines))
+	}
+	if lipgloss.Width(lines[0]) != 4 || strings.Count(lines[0], "▀") != 4 {
+		t.Errorf("Expected four half-block cells, got %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀") || !strings.HasSuffix(lines[0], "\x1b[0m") {
+		t.Errorf("Expected red over blue cells ending in a reset, got %q", lines[0])
+	}
+
+	// Scaled down to fit: 100x100 pixels in 10 columns become 10x10 pixels, 5 rows
+	lines, err = renderHalfBlockPreview(halfWhitePNG(t, 100, 100), 10, 50)
+	if err != nil || len(lines) != 5 || lipgloss.Width(lines[0]) != 10 {
+		t.Errorf("Expected a 10x5 preview, got %d rows, %v", len(lines), err)
+	}
+}
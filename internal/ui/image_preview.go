@@ -0,0 +1,169 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// bayer4 is a 4x4 ordered dithering matrix, so gradients show up as dot density
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// brailleDots maps a dot position within a braille cell (x 0-1, y 0-3) to its bit
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// asciiRamp orders characters from empty to dense for the ASCII preview
+const asciiRamp = " .:-=+*#%@"
+
+// previewMode selects how images are drawn on terminals without a graphics protocol
+type previewMode int
+
+const (
+	previewASCII     previewMode = iota // density characters, one per cell
+	previewBraille                      // braille dots, 2x4 per cell
+	previewHalfBlock                    // truecolor upper half blocks, two pixels per cell
+)
+
+// previewModeFor picks the best preview the terminal can show
+func previewModeFor(caps TerminalCapabilities, trueColor bool) previewMode {
+	switch {
+	case caps.SupportsUnicode && caps.SupportsColor && trueColor:
+		return previewHalfBlock
+	case caps.SupportsUnicode:
+		return previewBraille
+	default:
+		return previewASCII
+	}
+}
+
+// brailleLines renders w x h dots as braille characters with ordered dithering
+func brailleLines(level func(x, y int) float64, w, h int) []string {
+	var lines []string
+	for cy := 0; cy < h; cy += 4 {
+		var line strings.Builder
+		for cx := 0; cx < w; cx += 2 {
+			cell := rune(0x2800)
+			for dy := 0; dy < 4 && cy+dy < h; dy++ {
+				for dx := 0; dx < 2 && cx+dx < w; dx++ {
+					x, y := cx+dx, cy+dy
+					if level(x, y) > (bayer4[y%4][x%4]+0.5)/16 {
+						cell |= brailleDots[dy][dx]
+					}
+				}
+			}
+			line.WriteRune(cell)
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// asciiLines renders w x h cells from the density ramp
+func asciiLines(level func(x, y int) float64, w, h int) []string {
+	lines := make([]string, h)
+	for y := 0; y < h; y++ {
+		var line strings.Builder
+		for x := 0; x < w; x++ {
+			index := int(level(x, y) * float64(len(asciiRamp)-1))
+			line.WriteByte(asciiRamp[index])
+		}
+		lines[y] = line.String()
+	}
+	return lines
+}
+
+// imagePreviewCache keeps the last rendered preview, since the view is redrawn on every update
+type imagePreviewCache struct {
+	mu    sync.Mutex
+	key   string
+	lines []string
+	err   error
+}
+
+// get returns the preview for key, rendering it with render on a miss
+func (c *imagePreviewCache) get(key string, render func() ([]string, error)) ([]string, error) {
+	if c == nil {
+		return render()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.key != key {
+		c.lines, c.err = render()
+		c.key = key
+	}
+	return c.lines, c.err
+}
+
+// imagePreviewLines renders the preview of the image being viewed, cached per size
+func (m Model) imagePreviewLines(cols, rows int) ([]string, error) {
+	return m.imagePreviewLinesFor(m.viewingImage, cols, rows)
+}
+
+// imagePreviewLinesFor renders the preview of an image, cached per size
+func (m Model) imagePreviewLinesFor(item *storage.ClipboardItem, cols, rows int) ([]string, error) {
+	var caps TerminalCapabilities
+	if m.iconHelper != nil {
+		caps = m.iconHelper.GetCapabilities()
+	}
+	mode := previewModeFor(caps, lipgloss.ColorProfile() == termenv.TrueColor)
+
+	key := fmt.Sprintf("%s:%d:%dx%d:%d", item.ID, len(item.ImageData), cols, rows, mode)
+	return m.imagePreview.get(key, func() ([]string, error) {
+		if mode == previewHalfBlock {
+			return renderHalfBlockPreview(item.ImageData, cols, rows)
+		}
+		return renderImagePreview(item.ImageData, cols, rows, mode == previewBraille)
+	})
+}
+
+// centerPreview centers preview lines, which may contain color codes, horizontally and vertically in a cols x rows area
+func centerPreview(lines []string, cols, rows int) []string {
+	var centered []string
+	for i := 0; i < (rows-len(lines))/2; i++ {
+		centered = append(centered, "")
+	}
+	for _, line := range lines {
+		padding := (cols - lipgloss.Width(line)) / 2
+		centered = append(centered, strings.Repeat(" ", max(0, padding))+line)
+	}
+	return centered
+}
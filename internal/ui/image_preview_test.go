@@ -0,0 +1,72 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestImagePreviewCache(t *testing.T) {
+	cache := &imagePreviewCache{}
+	renders := 0
+	render := func() ([]string, error) {
+		renders++
+		return []string{"preview"}, nil
+	}
+
+	cache.get("a", render)
+	cache.get("a", render)
+	if renders != 1 {
+		t.Errorf("Expected one render for the same key, got %d", renders)
+	}
+	cache.get("b", render)
+	if renders != 2 {
+		t.Errorf("Expected a new render for a new key, got %d", renders)
+	}
+}
+
+func TestCenterPreview(t *testing.T) {
+	lines := centerPreview([]string{"⣿⣿"}, 6, 3)
+	if len(lines) != 2 || lines[0] != "" || lines[1] != "  ⣿⣿" || utf8.RuneCountInString(lines[1]) != 4 {
+		t.Errorf("Unexpected centering %q", lines)
+	}
+}
+
+func TestPreviewModeFor(t *testing.T) {
+	full := TerminalCapabilities{SupportsUnicode: true, SupportsColor: true}
+	if previewModeFor(full, true) != previewHalfBlock {
+		t.Error("Expected half blocks on a truecolor terminal")
+	}
+	if previewModeFor(full, false) != previewBraille {
+		t.Error("Expected braille without truecolor")
+	}
+	if previewModeFor(TerminalCapabilities{SupportsColor: true}, true) != previewASCII {
+		t.Error("Expected ASCII without Unicode")
+	}
+	if previewModeFor(AccessibleCapabilities(), true) != previewASCII {
+		t.Error("Expected ASCII in accessibility mode")
+	}
+}
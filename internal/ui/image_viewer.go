@@ -54,11 +54,12 @@ func (m Model) renderImageViewNew() string {
 	// Create header text
 	var headerText string
 	if err == nil && format != "" {
-		headerText = fmt.Sprintf("Image View (%dx%d %s, %d bytes)",
-			imageWidth, imageHeight, strings.ToUpper(format), len(m.viewingImage.ImageData))
+		headerText = fmt.Sprintf("Image View (%dx%d %s, %s)",
+			imageWidth, imageHeight, strings.ToUpper(format), FormatSize(int64(len(m.viewingImage.ImageData))))
 	} else {
-		headerText = fmt.Sprintf("Image View (%d bytes)", len(m.viewingImage.ImageData))
+		headerText = fmt.Sprintf("Image View (%s)", FormatSize(int64(len(m.viewingImage.ImageData))))
 	}
+	headerText += m.sourceSuffix()
 
 	// Reserve space for image - fill content area completely to push footer to bottom (same pattern as other views)
 	var imageSpaceContent strings.Builder
@@ -73,8 +74,10 @@ func (m Model) renderImageViewNew() string {
 	var footerText string
 	if m.imageDeletePending {
 		footerText = "Press 'x' again to confirm deletion, any other key to cancel"
+	} else if m.archiveMode {
+		footerText = "enter: copy | x: delete | o: open"
 	} else {
-		footerText = "enter: copy | x: delete | e: edit | o: open"
+		footerText = "enter: copy | x: delete | e: edit | a: annotate | o: open"
 	}
 
 	// Build frame content using shared function
@@ -103,14 +106,17 @@ func (m Model) renderImageViewNew() string {
 	availableWidth := contentWidth
 	availableHeight := contentHeight
 
-	// Render image with size constraints
-	imageData := m.viewingImage.ImageData
-	if err == nil && (imageWidth > availableWidth*8 || imageHeight > availableHeight*16) {
-		// Need to scale down
-		imageData = m.scaleImageForFrame(imageData, imageWidth, imageHeight, availableWidth, availableHeight)
-	}
-
-	imageDisplay := renderSimpleKittyImage(imageData)
+	// Scale large images down to the frame before transmitting them, once per image and size
+	cellWidth, cellHeight := cellPixelSize()
+	key := fmt.Sprintf("%s:%d:%dx%d:%dx%d", m.viewingImage.ID, len(m.viewingImage.ImageData),
+		availableWidth, availableHeight, cellWidth, cellHeight)
+	imageDisplay := m.kittyImage.get(key, func() string {
+		imageData := m.viewingImage.ImageData
+		if err == nil && (imageWidth > availableWidth*cellWidth || imageHeight > availableHeight*cellHeight) {
+			imageData = m.scaleImageForFrame(imageData, imageWidth, imageHeight, availableWidth, availableHeight)
+		}
+		return renderSimpleKittyImage(imageData)
+	})
 	result.WriteString(imageDisplay)
 
 	// After rendering the image, position cursor at the bottom of the screen to avoid affecting frame layout
@@ -0,0 +1,84 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// errInsertImage explains why images can't be picked for the shell widget
+var errInsertImage = errors.New("images can't be inserted at the shell cursor")
+
+// insertItemMsg carries the text picked in insert mode, to be printed for the shell widget
+type insertItemMsg struct {
+	content string
+
+	// Set when the item was removed from history ("copy and remove")
+	removedID string
+	removeErr error
+}
+
+// SetInsertMode turns the TUI into a compact picker for the shell widget (nclip --zle):
+// choosing an item quits and hands its text back through PrintOnExit instead of
+// putting it on the clipboard
+func (m *Model) SetInsertMode(enabled bool) {
+	m.insertMode = enabled
+	if enabled {
+		m.compactList = true
+		m.stayOpen = false
+	}
+}
+
+// insertItemCmd picks an item for insertion, optionally removing it from history
+func (m *Model) insertItemCmd(item storage.ClipboardItem, remove bool) tea.Cmd {
+	store := m.storage
+	archive := m.archiveMode
+	return func() tea.Msg {
+		if item.ContentType == "image" {
+			return copyDoneMsg{err: errInsertImage}
+		}
+		msg := insertItemMsg{content: item.Content}
+		if remove {
+			msg.removedID = item.ID
+			msg.removeErr = deleteStoredItem(store, archive, item.ID)
+		}
+		return msg
+	}
+}
+
+// handleInsertItem quits so the picked text can be printed once the terminal is released
+func (m *Model) handleInsertItem(msg insertItemMsg) tea.Cmd {
+	// There is no screen left to show a toast on, so a failed removal is only logged
+	if msg.removeErr != nil {
+		logging.Error("Failed to remove inserted item %s: %v", msg.removedID, msg.removeErr)
+	}
+	m.printOnExit = &msg.content
+	return tea.Quit
+}
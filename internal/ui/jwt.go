@@ -0,0 +1,162 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// decodeJWT decodes the header and claims of a JWT, optionally prefixed with "Bearer ",
+// into a readable summary followed by both parts pretty-printed. The signature is not
+// verified and nothing leaves the process.
+func decodeJWT(content string, now time.Time) (string, bool) {
+	token := strings.TrimSpace(content)
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	if strings.ContainsAny(token, " \t\n") || strings.Count(token, ".") != 2 {
+		return "", false
+	}
+	parts := strings.Split(token, ".")
+
+	header, ok := decodeJWTPart(parts[0])
+	if !ok {
+		return "", false
+	}
+	claims, ok := decodeJWTPart(parts[1])
+	if !ok {
+		return "", false
+	}
+	var headerFields map[string]any
+	var claimFields map[string]any
+	if json.Unmarshal(header, &headerFields) != nil || json.Unmarshal(claims, &claimFields) != nil {
+		return "", false
+	}
+	if _, ok := headerFields["alg"]; !ok {
+		return "", false
+	}
+
+	var out strings.Builder
+	out.WriteString("# JWT decoded locally, the signature is not verified\n")
+	for _, line := range jwtSummary(claimFields, now) {
+		out.WriteString(line + "\n")
+	}
+	out.WriteString("\nheader: ")
+	out.WriteString(indentJSON(header))
+	out.WriteString("\nclaims: ")
+	out.WriteString(indentJSON(claims))
+	return out.String(), true
+}
+
+// decodeJWTPart decodes one base64url part of a JWT, with or without padding
+func decodeJWTPart(part string) ([]byte, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+// indentJSON pretty-prints a JSON object
+func indentJSON(data []byte) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return out.String()
+}
+
+// jwtSummary describes the claims that say how sensitive a token is: who issued it
+// and for whom, what it grants and how long it is valid
+func jwtSummary(claims map[string]any, now time.Time) []string {
+	var lines []string
+	for _, field := range []struct{ claim, label string }{
+		{"iss", "issuer"}, {"sub", "subject"}, {"aud", "audience"},
+	} {
+		if value, ok := claims[field.claim]; ok {
+			lines = append(lines, fmt.Sprintf("%s: %s", field.label, claimString(value)))
+		}
+	}
+	for _, claim := range []string{"scope", "scp", "scopes", "roles"} {
+		if value, ok := claims[claim]; ok {
+			lines = append(lines, fmt.Sprintf("scopes: %s", claimString(value)))
+			break
+		}
+	}
+	for _, field := range []struct{ claim, label string }{
+		{"iat", "issued"}, {"nbf", "not before"}, {"exp", "expires"},
+	} {
+		seconds, ok := claims[field.claim].(float64)
+		if !ok {
+			continue
+		}
+		at := time.Unix(int64(seconds), 0)
+		line := fmt.Sprintf("%s: %s", field.label, at.Format("2006-01-02 15:04:05 MST"))
+		if field.claim == "exp" {
+			if at.Before(now) {
+				line += fmt.Sprintf(" (expired %s ago)", roundDuration(now.Sub(at)))
+			} else {
+				line += fmt.Sprintf(" (valid for %s)", roundDuration(at.Sub(now)))
+			}
+		}
+		lines = append(lines, line)
+	}
+	if _, ok := claims["exp"]; !ok {
+		lines = append(lines, "expires: never (no exp claim)")
+	}
+	return lines
+}
+
+// claimString shows a claim value, joining lists with spaces
+func claimString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, len(v))
+		for i, part := range v {
+			parts[i] = claimString(part)
+		}
+		return strings.Join(parts, " ")
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// roundDuration shortens a duration to its largest units
+func roundDuration(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	case d >= time.Hour:
+		return d.Round(time.Minute).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
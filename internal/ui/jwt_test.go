@@ -0,0 +1,82 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testJWT builds an unsigned-looking JWT from raw JSON parts
+func testJWT(header, claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(header)) + "." + encode([]byte(claims)) + ".c2lnbmF0dXJl"
+}
+
+func TestDecodeJWT(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	token := testJWT(`{"alg":"HS256","typ":"JWT"}`,
+		`{"iss":"https://auth.example.com","sub":"user-1","aud":["api","web"],"scope":"read write","exp":1699996400}`)
+
+	decoded, ok := decodeJWT("Bearer "+token, now)
+	if !ok {
+		t.Fatal("Expected the token to decode")
+	}
+	for _, want := range []string{
+		"issuer: https://auth.example.com",
+		"audience: api web",
+		"scopes: read write",
+		"(expired 1h0m0s ago)",
+		`"alg": "HS256"`,
+		`"sub": "user-1"`,
+	} {
+		if !strings.Contains(decoded, want) {
+			t.Errorf("Expected %q in the decoded token:\n%s", want, decoded)
+		}
+	}
+}
+
+func TestDecodeJWT_NoExpiry(t *testing.T) {
+	decoded, ok := decodeJWT(testJWT(`{"alg":"none"}`, `{"sub":"x"}`), time.Now())
+	if !ok || !strings.Contains(decoded, "expires: never") {
+		t.Errorf("Expected a token without exp to be flagged as never expiring, got:\n%s", decoded)
+	}
+}
+
+func TestDecodeJWT_Rejects(t *testing.T) {
+	for _, content := range []string{
+		"a.b.c",
+		"example.com.au",
+		testJWT(`{"typ":"JWT"}`, `{"sub":"x"}`), // No alg header
+		testJWT(`{"alg":"HS256"}`, `not json`),
+		testJWT(`{"alg":"HS256"}`, `{}`) + " trailing words",
+	} {
+		if _, ok := decodeJWT(content, time.Now()); ok {
+			t.Errorf("Expected %q not to decode as a JWT", content)
+		}
+	}
+}
@@ -25,27 +25,21 @@ SOFTWARE.
 package ui
 
 import (
-	"bytes"
 	"encoding/base64"
 	"fmt"
-	"image"
-	_ "image/gif"
-	_ "image/jpeg"
-	"image/png"
+	"io"
 	"os"
 	"strings"
-
-	// Additional image format support
-	_ "golang.org/x/image/bmp"
-	_ "golang.org/x/image/tiff"
-	_ "golang.org/x/image/webp"
-
-	// For image resizing
-	"golang.org/x/image/draw"
+	"sync"
 )
 
 // detectKittySupport checks if the terminal supports Kitty image protocol
 func detectKittySupport() bool {
+	// An answer to the graphics query beats any guess from the environment
+	if probed != nil {
+		return probed.Kitty
+	}
+
 	// Check specific terminal programs that support the protocol
 	termProgram := os.Getenv("TERM_PROGRAM")
 	if termProgram == "kitty" || termProgram == "ghostty" || termProgram == "WezTerm" ||
@@ -80,110 +74,30 @@ func detectKittySupport() bool {
 		return true
 	}
 
-	// Test actual protocol capability by sending a query
-	return testKittyProtocol()
-}
-
-// testKittyProtocol sends a capability query to test if terminal supports graphics
-func testKittyProtocol() bool {
-	// Send a query to check if terminal supports graphics protocol
-	// This is a non-invasive query that asks for terminal capabilities
-	fmt.Print("\x1b_Gi=1,a=q;\x1b\\")
-
-	// For now, we'll assume it works if we get here
-	// In a full implementation, we'd read the response
-	// but that requires more complex terminal handling
-	return false // Conservative default for unknown terminals
-}
-
-// getImageDimensions extracts width, height, and format from image data
-func getImageDimensions(imageData []byte) (int, int, string, error) {
-	reader := bytes.NewReader(imageData)
-	config, format, err := image.DecodeConfig(reader)
-	if err != nil {
-		return 0, 0, "", err
-	}
-	return config.Width, config.Height, format, nil
+	// Unknown terminals are asked directly by the capability probe
+	return false
 }
 
-// resizeImageIfNeeded resizes very large images to prevent terminal buffer overflow
-func resizeImageIfNeeded(imageData []byte, maxWidth, maxHeight int) ([]byte, error) {
-	// Decode the image
-	reader := bytes.NewReader(imageData)
-	img, _, err := image.Decode(reader)
-	if err != nil {
-		return imageData, err // Return original if we can't decode
-	}
-
-	bounds := img.Bounds()
-	width := bounds.Dx()
-	height := bounds.Dy()
-
-	// Check if resizing is needed
-	if width <= maxWidth && height <= maxHeight {
-		return imageData, nil // No resizing needed
-	}
-
-	// Calculate new dimensions maintaining aspect ratio
-	scaleX := float64(maxWidth) / float64(width)
-	scaleY := float64(maxHeight) / float64(height)
-	scale := scaleX
-	if scaleY < scaleX {
-		scale = scaleY
-	}
-
-	newWidth := int(float64(width) * scale)
-	newHeight := int(float64(height) * scale)
-
-	// Create a new image with the target size
-	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
-
-	// Resize the image using high-quality scaling
-	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
-
-	// Encode as PNG
-	var buf bytes.Buffer
-	err = png.Encode(&buf, dst)
-	if err != nil {
-		return imageData, err // Return original if encoding fails
-	}
-
-	return buf.Bytes(), nil
-}
+// Cell size in pixels assumed when the terminal doesn't report one, on the small side so
+// images are never scaled beyond the frame
+const (
+	defaultCellWidth  = 8
+	defaultCellHeight = 16
+)
 
-// resizeImageToExactDimensions resizes an image to exact target dimensions
-// Note: The target dimensions should already maintain aspect ratio from caller's calculation
-func resizeImageToExactDimensions(imageData []byte, targetWidth, targetHeight int) ([]byte, error) {
-	// Decode the image
-	reader := bytes.NewReader(imageData)
-	img, _, err := image.Decode(reader)
-	if err != nil {
-		return imageData, err // Return original if we can't decode
+// cellPixelSize returns the size of one terminal cell in pixels, so images can be scaled
+// to fit the frame exactly whatever the font size or display scaling. The window size the
+// terminal reports is current even after zooming; the XTWINOPS answer saved by the
+// terminal probe covers terminals that report no pixel size.
+func cellPixelSize() (width, height int) {
+	cols, rows, pixelWidth, pixelHeight, ok := windowPixelSize()
+	if ok && cols > 0 && rows > 0 && pixelWidth >= cols && pixelHeight >= rows {
+		return pixelWidth / cols, pixelHeight / rows
 	}
-
-	bounds := img.Bounds()
-	currentWidth := bounds.Dx()
-	currentHeight := bounds.Dy()
-
-	// Check if resizing is actually needed
-	if currentWidth == targetWidth && currentHeight == targetHeight {
-		return imageData, nil // No resizing needed
+	if probed != nil && probed.CellWidth > 0 && probed.CellHeight > 0 {
+		return probed.CellWidth, probed.CellHeight
 	}
-
-	// Create a new image with the exact target size
-	dst := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
-
-	// Resize the image using high-quality scaling
-	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
-
-	// Encode as PNG
-	var buf bytes.Buffer
-	err = png.Encode(&buf, dst)
-	if err != nil {
-		return imageData, err // Return original if encoding fails
-	}
-
-	return buf.Bytes(), nil
+	return defaultCellWidth, defaultCellHeight
 }
 
 // renderKittyImage creates a Kitty terminal escape sequence to display an image
@@ -216,41 +130,44 @@ func renderSimpleKittyImage(imageData []byte) string {
 		return ""
 	}
 
-	// Encode image data as base64
-	encoded := base64.StdEncoding.EncodeToString(imageData)
-
-	// Use simple transmission protocol
-	const chunkSize = 4096
-
-	if len(encoded) <= chunkSize {
-		// Single chunk - basic transmission
-		return fmt.Sprintf("\x1b_Ga=T,f=100;%s\x1b\\", encoded)
-	}
-
-	// Multi-chunk transmission
 	var result strings.Builder
-	chunks := []string{}
+	writeKittyImage(&result, "a=T,f=100", imageData)
+	return result.String()
+}
 
-	for i := 0; i < len(encoded); i += chunkSize {
-		end := i + chunkSize
-		if end > len(encoded) {
-			end = len(encoded)
+// kittyChunkSize is the raw payload of one graphics escape; it encodes to 4096 base64 bytes,
+// the most Kitty accepts per chunk
+const kittyChunkSize = 3072
+
+// writeKittyImage writes PNG data to w as Kitty graphics escapes. Each chunk is encoded into
+// one reused buffer, so the only full copy is the output itself, grown once when w is a
+// strings.Builder; the escapes still end up in memory because View returns a string.
+// control holds the keys of the first escape.
+func writeKittyImage(w io.Writer, control string, imageData []byte) {
+	if sized, ok := w.(interface{ Grow(int) }); ok {
+		chunks := (len(imageData) + kittyChunkSize - 1) / kittyChunkSize
+		sized.Grow(base64.StdEncoding.EncodedLen(len(imageData)) + chunks*16 + len(control))
+	}
+
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(kittyChunkSize))
+	for start := 0; start < len(imageData); start += kittyChunkSize {
+		end := min(start+kittyChunkSize, len(imageData))
+		n := base64.StdEncoding.EncodedLen(end - start)
+		base64.StdEncoding.Encode(encoded, imageData[start:end])
+
+		keys := control
+		switch {
+		case start == 0 && end == len(imageData):
+			// A single chunk needs no continuation flag
+		case start == 0:
+			keys += ",m=1"
+		case end < len(imageData):
+			keys = "m=1"
+		default:
+			keys = "m=0" // The final chunk triggers the display
 		}
-		chunks = append(chunks, encoded[i:end])
-	}
-
-	// First chunk
-	result.WriteString(fmt.Sprintf("\x1b_Ga=T,f=100,m=1;%s\x1b\\", chunks[0]))
-
-	// Middle chunks
-	for i := 1; i < len(chunks)-1; i++ {
-		result.WriteString(fmt.Sprintf("\x1b_Gm=1;%s\x1b\\", chunks[i]))
+		fmt.Fprintf(w, "\x1b_G%s;%s\x1b\\", keys, encoded[:n])
 	}
-
-	// Final chunk
-	result.WriteString(fmt.Sprintf("\x1b_Gm=0;%s\x1b\\", chunks[len(chunks)-1]))
-
-	return result.String()
 }
 
 // renderKittyImageDirect renders image data directly with Kitty protocol
@@ -259,51 +176,15 @@ func renderKittyImageDirect(imageData []byte, displayCols, displayRows int) stri
 		return ""
 	}
 
-	// Encode image data as base64
-	encoded := base64.StdEncoding.EncodeToString(imageData)
-
-	// For large images, we need to split into chunks
-	const chunkSize = 4096
+	// a=T: transmit and display immediately
+	// f=100: PNG format
+	// s=<width>,<height>: Scale image to fit within these pixel dimensions
+	cellWidth, cellHeight := cellPixelSize()
+	maxPixelWidth := displayCols * cellWidth
+	maxPixelHeight := displayRows * cellHeight
 
 	var result strings.Builder
-
-	if len(encoded) <= chunkSize {
-		// Single chunk with explicit size constraints
-		// a=T: transmit and display immediately
-		// f=100: PNG format
-		// s=<width>,<height>: Scale image to fit within these pixel dimensions
-		// Calculate max pixel dimensions based on terminal cells
-		maxPixelWidth := displayCols * 10  // ~10 pixels per column
-		maxPixelHeight := displayRows * 18 // ~18 pixels per row
-		command := fmt.Sprintf("\x1b_Ga=T,f=100,s=%d,%d;%s\x1b\\", maxPixelWidth, maxPixelHeight, encoded)
-		result.WriteString(command)
-		return result.String()
-	}
-
-	// Multi-chunk transmission
-	chunks := []string{}
-
-	for i := 0; i < len(encoded); i += chunkSize {
-		end := i + chunkSize
-		if end > len(encoded) {
-			end = len(encoded)
-		}
-		chunks = append(chunks, encoded[i:end])
-	}
-
-	// First chunk with format specification and size constraints
-	maxPixelWidth := displayCols * 10  // ~10 pixels per column
-	maxPixelHeight := displayRows * 18 // ~18 pixels per row
-	result.WriteString(fmt.Sprintf("\x1b_Ga=T,f=100,s=%d,%d,m=1;%s\x1b\\", maxPixelWidth, maxPixelHeight, chunks[0]))
-
-	// Middle chunks
-	for i := 1; i < len(chunks)-1; i++ {
-		result.WriteString(fmt.Sprintf("\x1b_Gm=1;%s\x1b\\", chunks[i]))
-	}
-
-	// Final chunk - this triggers the display
-	result.WriteString(fmt.Sprintf("\x1b_Gm=0;%s\x1b\\", chunks[len(chunks)-1]))
-
+	writeKittyImage(&result, fmt.Sprintf("a=T,f=100,s=%d,%d", maxPixelWidth, maxPixelHeight), imageData)
 	return result.String()
 }
 
@@ -313,47 +194,32 @@ func renderKittyImageWithPlacement(imageData []byte, cellWidth, cellHeight int)
 		return ""
 	}
 
-	// Encode image data as base64
-	encoded := base64.StdEncoding.EncodeToString(imageData)
-
 	// Use Kitty's placement parameters to position and scale the image
 	// C=1: use cell units for placement
 	// c=cols,rows: scale image to fit within specified cell dimensions
-	const chunkSize = 4096
-
-	if len(encoded) <= chunkSize {
-		// Single chunk with placement parameters
-		// a=T: transmit and display immediately
-		// f=100: PNG format
-		// C=1: use cell units
-		// c=<cols>,<rows>: scale to fit within specified cell dimensions
-		command := fmt.Sprintf("\x1b_Ga=T,f=100,C=1,c=%d,%d;%s\x1b\\", cellWidth, cellHeight, encoded)
-		return command
-	}
-
-	// Multi-chunk transmission with placement
 	var result strings.Builder
-	chunks := []string{}
-
-	for i := 0; i < len(encoded); i += chunkSize {
-		end := i + chunkSize
-		if end > len(encoded) {
-			end = len(encoded)
-		}
-		chunks = append(chunks, encoded[i:end])
-	}
+	writeKittyImage(&result, fmt.Sprintf("a=T,f=100,C=1,c=%d,%d", cellWidth, cellHeight), imageData)
+	return result.String()
+}
 
-	// First chunk with placement parameters
-	result.WriteString(fmt.Sprintf("\x1b_Ga=T,f=100,C=1,c=%d,%d,m=1;%s\x1b\\", cellWidth, cellHeight, chunks[0]))
+// kittyImageCache keeps the escapes of the image last shown in the viewer, since the view
+// is redrawn on every update and scaling and encoding a large image is slow
+type kittyImageCache struct {
+	mu     sync.Mutex
+	key    string
+	escape string
+}
 
-	// Middle chunks
-	for i := 1; i < len(chunks)-1; i++ {
-		result.WriteString(fmt.Sprintf("\x1b_Gm=1;%s\x1b\\", chunks[i]))
+// get returns the escapes for key, rendering them with render on a miss
+func (c *kittyImageCache) get(key string, render func() string) string {
+	if c == nil {
+		return render()
 	}
-
-	// Final chunk
-	result.WriteString(fmt.Sprintf("\x1b_Gm=0;%s\x1b\\", chunks[len(chunks)-1]))
-
-	return result.String()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.key != key {
+		c.escape = render()
+		c.key = key
+	}
+	return c.escape
 }
-
@@ -0,0 +1,93 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var kittyEscape = regexp.MustCompile(`\x1b_G([^;]*);([^\x1b]*)\x1b\\`)
+
+func TestWriteKittyImage_Chunks(t *testing.T) {
+	data := make([]byte, 3*kittyChunkSize+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	var out strings.Builder
+	writeKittyImage(&out, "a=T,f=100", data)
+
+	escapes := kittyEscape.FindAllStringSubmatch(out.String(), -1)
+	if len(escapes) != 4 {
+		t.Fatalf("Expected 4 chunks, got %d", len(escapes))
+	}
+	wantKeys := []string{"a=T,f=100,m=1", "m=1", "m=1", "m=0"}
+	for i, escape := range escapes {
+		if escape[1] != wantKeys[i] {
+			t.Errorf("Chunk %d: expected keys %q, got %q", i, wantKeys[i], escape[1])
+		}
+		if len(escape[2]) > 4096 {
+			t.Errorf("Chunk %d: payload of %d bytes exceeds 4096", i, len(escape[2]))
+		}
+	}
+
+	// Each chunk is padded on its own, so decode them one at a time
+	var decoded []byte
+	for _, escape := range escapes {
+		chunk, err := base64.StdEncoding.DecodeString(escape[2])
+		if err != nil {
+			t.Fatalf("Failed to decode chunk: %v", err)
+		}
+		decoded = append(decoded, chunk...)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Error("Expected the chunks to carry the image data unchanged")
+	}
+}
+
+func TestWriteKittyImage_SingleChunk(t *testing.T) {
+	if got := renderSimpleKittyImage([]byte("png")); got != "\x1b_Ga=T,f=100;cG5n\x1b\\" {
+		t.Errorf("Unexpected escape %q", got)
+	}
+}
+
+func TestKittyImageCache(t *testing.T) {
+	cache := &kittyImageCache{}
+	renders := 0
+	render := func() string {
+		renders++
+		return "image"
+	}
+	cache.get("a:80x24", render)
+	cache.get("a:80x24", render)
+	cache.get("a:100x30", render)
+	if renders != 2 {
+		t.Errorf("Expected a render per size, got %d", renders)
+	}
+}
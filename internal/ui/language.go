@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// plainTextLanguage forces a text view item to be shown without highlighting
+const plainTextLanguage = "plaintext"
+
+// languageCycle is the order the text viewer's l key steps through; "" is auto-detection
+var languageCycle = []string{
+	"", "go", "python", "bash", "javascript", "typescript", "json", "yaml", "toml", "sql",
+	"rust", "c", "cpp", "java", "html", "css", "markdown", "diff", "dockerfile", plainTextLanguage,
+}
+
+// nextLanguage returns the language after current in languageCycle
+func nextLanguage(current string) string {
+	for i, language := range languageCycle {
+		if language == current {
+			return languageCycle[(i+1)%len(languageCycle)]
+		}
+	}
+	// Unknown overrides (e.g. set by an older version) restart the cycle
+	return languageCycle[1]
+}
+
+// loadLanguageOverride reads the remembered language for the item opened in the text viewer
+func (m *Model) loadLanguageOverride() {
+	m.languageOverride = ""
+	if m.viewingText != nil && m.storage != nil && !m.archiveMode {
+		m.languageOverride = m.storage.GetLanguageOverride(m.viewingText.ID)
+	}
+}
+
+// cycleLanguageOverride forces the next highlighting language and remembers it for the item
+func (m *Model) cycleLanguageOverride() tea.Cmd {
+	if m.viewingText == nil || m.archiveMode {
+		return nil
+	}
+
+	m.languageOverride = nextLanguage(m.languageOverride)
+	m.textViewportReady = false
+
+	id := m.viewingText.ID
+	language := m.languageOverride
+	return tea.Batch(
+		m.startStorageOp("set language", id, func(s *storage.Storage) error {
+			return s.SetLanguageOverride(id, language)
+		}),
+		m.highlightTextViewCmd(),
+	)
+}
@@ -0,0 +1,101 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestNextLanguage(t *testing.T) {
+	if next := nextLanguage(""); next != languageCycle[1] {
+		t.Errorf("Expected auto-detection to move to %q, got %q", languageCycle[1], next)
+	}
+	if next := nextLanguage(plainTextLanguage); next != "" {
+		t.Errorf("Expected the cycle to wrap back to auto-detection, got %q", next)
+	}
+	if next := nextLanguage("cobol"); next != languageCycle[1] {
+		t.Errorf("Expected an unknown override to restart the cycle, got %q", next)
+	}
+}
+
+func TestComputeTextViewLines_LanguageOverride(t *testing.T) {
+	m := newTextViewTestModel("SELECT id FROM items")
+
+	m.languageOverride = "sql"
+	if entry := m.computeTextViewLines(true); !entry.isCode || entry.language != "sql" {
+		t.Errorf("Expected forced sql highlighting, got %q (code=%v)", entry.language, entry.isCode)
+	}
+
+	m.languageOverride = plainTextLanguage
+	if entry := m.computeTextViewLines(true); entry.isCode {
+		t.Errorf("Expected plaintext override to disable highlighting, got %q", entry.language)
+	}
+}
+
+func TestApplyQueryTerms_LanguageOverrides(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-ui-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("print('forced')")
+	s.Add("plain words")
+	items := s.GetAllMeta()
+	forced := items[1]
+	// Overrides are stored by their canonical name, as the language cycle sets them
+	if err := s.SetLanguageOverride(forced.ID, canonicalLanguage("py")); err != nil {
+		t.Fatalf("Failed to set override: %v", err)
+	}
+
+	// Without a detector only the forced language counts
+	m := Model{storage: s}
+	query, err := storage.ParseQuery("lang:py")
+	if err != nil {
+		t.Fatalf("Failed to parse query: %v", err)
+	}
+	typed := append([]storage.QueryTerm(nil), query.Terms...)
+
+	filtered := m.applyQueryTerms(items, query)
+	if len(filtered) != 1 || filtered[0].ID != forced.ID {
+		t.Errorf("Expected only the item forced to Python, got %+v", filtered)
+	}
+	if !reflect.DeepEqual(query.Terms, typed) {
+		t.Errorf("Expected the caller's terms to stay as typed, got %+v", query.Terms)
+	}
+}
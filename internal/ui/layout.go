@@ -0,0 +1,170 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+// Smallest pane widths before the split layout falls back to the list alone
+const (
+	minSplitListWidth    = 30
+	minSplitPreviewWidth = 20
+)
+
+// splitDivider separates the list from the preview in the split layout, with a
+// plain-text version for accessibility mode
+const (
+	splitDivider      = " │ "
+	splitDividerPlain = " | "
+)
+
+// dialogLayout decides where the main dialog goes and how the list shares it
+type dialogLayout struct {
+	mode         string // config.LayoutCentered, LayoutFullScreen or LayoutSplit
+	marginX      int
+	marginY      int
+	splitPercent int
+}
+
+// newDialogLayout builds the layout from the [display] settings
+func newDialogLayout(display config.DisplayConfig) dialogLayout {
+	layout := dialogLayout{
+		mode:         display.Layout,
+		marginX:      display.MarginX,
+		marginY:      display.MarginY,
+		splitPercent: display.SplitPercent,
+	}
+	if layout.mode == "" {
+		layout.mode = config.LayoutCentered
+	}
+	if layout.splitPercent == 0 {
+		layout.splitPercent = 50
+	}
+	return layout
+}
+
+// next returns the layout with the following mode, for cycling at runtime
+func (l dialogLayout) next() dialogLayout {
+	switch l.mode {
+	case config.LayoutCentered:
+		l.mode = config.LayoutFullScreen
+	case config.LayoutFullScreen:
+		l.mode = config.LayoutSplit
+	default:
+		l.mode = config.LayoutCentered
+	}
+	return l
+}
+
+// dialog sizes the dialog for a screen, leaving out the margins unless full screen
+func (l dialogLayout) dialog(screenWidth, screenHeight int) (dialogWidth, dialogHeight, contentWidth, contentHeight int) {
+	marginX, marginY := l.marginX, l.marginY
+	if l.mode == config.LayoutFullScreen {
+		marginX, marginY = 0, 0
+	}
+
+	// Margins never squeeze the dialog below a usable size
+	if screenWidth-2*marginX < 30 {
+		marginX = 0
+	}
+	if screenHeight-2*marginY < 12 {
+		marginY = 0
+	}
+
+	dialogWidth = screenWidth - 2 - 2*marginX   // The border takes the remaining column on each side
+	dialogHeight = screenHeight - 2 - 2*marginY // and the remaining row above and below
+	contentWidth = dialogWidth - 4              // Border + internal padding
+	contentHeight = dialogHeight - 4            // Border + header + footer
+
+	// Apply minimum constraints
+	if contentWidth < 20 {
+		contentWidth = 20
+	}
+	if contentHeight < 5 {
+		contentHeight = 5
+	}
+
+	return dialogWidth, dialogHeight, contentWidth, contentHeight
+}
+
+// split divides the content width between list and preview. The preview width
+// is 0 when the layout isn't split or the dialog is too narrow for both panes.
+func (l dialogLayout) split(contentWidth int) (listWidth, previewWidth int) {
+	if l.mode != config.LayoutSplit {
+		return contentWidth, 0
+	}
+	available := contentWidth - lipgloss.Width(splitDivider)
+	listWidth = available * l.splitPercent / 100
+	previewWidth = available - listWidth
+	if listWidth < minSplitListWidth || previewWidth < minSplitPreviewWidth {
+		return contentWidth, 0
+	}
+	return listWidth, previewWidth
+}
+
+// label describes the layout mode for toasts
+func (l dialogLayout) label() string {
+	switch l.mode {
+	case config.LayoutFullScreen:
+		return "full screen"
+	case config.LayoutSplit:
+		return "split"
+	}
+	return "centered"
+}
+
+// buildSplitContent renders the list and a preview of the selection side by side
+func (m Model) buildSplitContent(listWidth, previewWidth, contentHeight int) string {
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	list := strings.Split(strings.TrimSuffix(m.buildMainContent(listWidth, contentHeight), "\n"), "\n")
+	preview := m.selectionPreviewLines(previewWidth, contentHeight)
+	divider := splitDivider
+	if m.accessible {
+		divider = splitDividerPlain
+	}
+	divider = mainStyles.Border.Render(divider)
+
+	var content strings.Builder
+	for row := 0; row < contentHeight; row++ {
+		var listLine, previewLine string
+		if row < len(list) {
+			listLine = list[row]
+		}
+		if row < len(preview) {
+			previewLine = preview[row]
+		}
+		content.WriteString(m.padLineToWidth(listLine, listWidth))
+		content.WriteString(divider)
+		content.WriteString(previewLine)
+		content.WriteString("\n")
+	}
+	return content.String()
+}
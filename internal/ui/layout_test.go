@@ -0,0 +1,90 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+func TestDialogLayoutDimensions(t *testing.T) {
+	tests := []struct {
+		name                      string
+		layout                    dialogLayout
+		wantDialogW, wantDialogH  int
+		wantContentW, wantContent int
+	}{
+		{"unconfigured matches the original sizing", dialogLayout{}, 98, 38, 94, 34},
+		{"centered with margins", dialogLayout{mode: config.LayoutCentered, marginX: 5, marginY: 2}, 88, 34, 84, 30},
+		{"full screen ignores margins", dialogLayout{mode: config.LayoutFullScreen, marginX: 5, marginY: 2}, 98, 38, 94, 34},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dw, dh, cw, ch := tt.layout.dialog(100, 40)
+			if dw != tt.wantDialogW || dh != tt.wantDialogH || cw != tt.wantContentW || ch != tt.wantContent {
+				t.Errorf("dialog(100, 40) = %d, %d, %d, %d", dw, dh, cw, ch)
+			}
+		})
+	}
+
+	// Margins are dropped rather than leaving a dialog too small to use
+	l := dialogLayout{mode: config.LayoutCentered, marginX: 20, marginY: 10}
+	if dw, dh, _, _ := l.dialog(50, 20); dw != 48 || dh != 18 {
+		t.Errorf("Expected margins to be ignored on a small screen, got %dx%d", dw, dh)
+	}
+}
+
+func TestDialogLayoutSplit(t *testing.T) {
+	l := dialogLayout{mode: config.LayoutSplit, splitPercent: 60}
+	list, preview := l.split(103)
+	if list != 60 || preview != 40 {
+		t.Errorf("split(103) = %d, %d, want 60, 40", list, preview)
+	}
+
+	if list, preview := l.split(40); list != 40 || preview != 0 {
+		t.Errorf("Expected a narrow dialog to show only the list, got %d, %d", list, preview)
+	}
+
+	l.mode = config.LayoutCentered
+	if list, preview := l.split(103); list != 103 || preview != 0 {
+		t.Errorf("Expected no preview outside the split layout, got %d, %d", list, preview)
+	}
+}
+
+func TestDialogLayoutCycle(t *testing.T) {
+	l := newDialogLayout(config.DisplayConfig{})
+	var modes []string
+	for i := 0; i < 3; i++ {
+		l = l.next()
+		modes = append(modes, l.mode)
+	}
+	want := []string{config.LayoutFullScreen, config.LayoutSplit, config.LayoutCentered}
+	if strings.Join(modes, ",") != strings.Join(want, ",") {
+		t.Errorf("Layout cycle = %v, want %v", modes, want)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// changePollInterval is how often the TUI checks the database for writes by the daemon
+const changePollInterval = time.Second
+
+// dataVersionMsg carries the database change counter read by pollChangesCmd
+type dataVersionMsg struct {
+	version int64
+	err     error
+}
+
+// pollChangesCmd reads the database change counter after changePollInterval
+func pollChangesCmd(s *storage.Storage) tea.Cmd {
+	return tea.Tick(changePollInterval, func(time.Time) tea.Msg {
+		version, err := s.DataVersion()
+		return dataVersionMsg{version: version, err: err}
+	})
+}
+
+// handleDataVersion reloads the list when the database changed and schedules the next poll
+func (m *Model) handleDataVersion(msg dataVersionMsg) tea.Cmd {
+	if msg.err != nil {
+		logging.Warn("Live refresh: %v", msg.err)
+		return pollChangesCmd(m.storage)
+	}
+
+	if msg.version != m.dataVersion {
+		m.dataVersion = msg.version
+		m.reloadKeepingSelection()
+	}
+	return pollChangesCmd(m.storage)
+}
+
+// ownWrite is the change counter read right before and right after a write by this TUI
+type ownWrite struct {
+	before, after int64
+	ok            bool // Both reads succeeded
+}
+
+// trackWrite runs write between two reads of the change counter, so recordOwnWrite can
+// tell the TUI's own change from one made by the daemon
+func trackWrite(s *storage.Storage, write func() error) (ownWrite, error) {
+	before, beforeErr := s.DataVersion()
+	err := write()
+	after, afterErr := s.DataVersion()
+	return ownWrite{before: before, after: after, ok: beforeErr == nil && afterErr == nil}, err
+}
+
+// recordOwnWrite moves the live refresh counter past a write this TUI made, so the next
+// poll doesn't reload the list for a change that is already shown. data_version moves
+// once per read rather than once per commit, so this only happens when the last poll had
+// seen everything up to the write; otherwise a daemon write is still pending and the poll
+// has to reload for it.
+func (m *Model) recordOwnWrite(w ownWrite) {
+	if w.ok && w.before == m.dataVersion {
+		m.dataVersion = w.after
+	}
+}
+
+// reloadKeepingSelection re-reads items from storage and keeps the cursor on the same item
+func (m *Model) reloadKeepingSelection() {
+	var selectedID string
+	if meta := m.getItemMeta(m.cursor); meta != nil {
+		selectedID = meta.ID
+	}
+
+	if !m.archiveMode {
+		m.cache.ForceRefresh()
+	}
+	m.refreshItems()
+
+	for i, item := range m.filteredItems {
+		if item.ID == selectedID {
+			m.cursor = i
+			return
+		}
+	}
+
+	// The selected item is gone, stay at the same position
+	if m.cursor >= len(m.filteredItems) {
+		m.cursor = len(m.filteredItems) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
@@ -0,0 +1,139 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"os"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestHandleDataVersion_ShowsNewItemsAndKeepsSelection(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-ui-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("first")
+	s.Add("second")
+
+	cache := storage.NewItemCache(s, 5)
+	m := Model{storage: s, cache: cache, items: cache.GetAllMeta()}
+	m.filterItems()
+	m.dataVersion, _ = s.DataVersion()
+	m.cursor = 1 // "first"
+
+	// The daemon stores a new item through its own connection
+	daemon, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to open second storage: %v", err)
+	}
+	defer daemon.Close()
+	daemon.Add("third")
+
+	version, err := s.DataVersion()
+	if err != nil {
+		t.Fatalf("Failed to read data version: %v", err)
+	}
+	if cmd := m.handleDataVersion(dataVersionMsg{version: version}); cmd == nil {
+		t.Error("Expected the next poll to be scheduled")
+	}
+
+	if len(m.filteredItems) != 3 || m.filteredItems[0].Content != "third" {
+		t.Fatalf("Expected the new item at the top, got %+v", m.filteredItems)
+	}
+	if m.filteredItems[m.cursor].Content != "first" {
+		t.Errorf("Expected cursor to stay on 'first', got '%s'", m.filteredItems[m.cursor].Content)
+	}
+}
+
+func TestRecordOwnWrite_KeepsPendingDaemonWrite(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-ui-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("first")
+
+	cache := storage.NewItemCache(s, 5)
+	m := Model{storage: s, cache: cache, items: cache.GetAllMeta()}
+	m.filterItems()
+	m.dataVersion, _ = s.DataVersion()
+
+	// Our own pin is recorded, so the next poll has nothing to reload
+	id := m.filteredItems[0].ID
+	written, err := trackWrite(s, func() error { return s.PinItem(id) })
+	if err != nil {
+		t.Fatalf("Failed to pin item: %v", err)
+	}
+	m.applyItemChange(id, written)
+	if version, _ := s.DataVersion(); version != m.dataVersion {
+		t.Errorf("Expected our own write to be recorded, got %d want %d", m.dataVersion, version)
+	}
+
+	// The daemon stores an item before the poll runs, then we unpin
+	daemon, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to open second storage: %v", err)
+	}
+	defer daemon.Close()
+	daemon.Add("second")
+
+	written, err = trackWrite(s, func() error { return s.UnpinItem(id) })
+	if err != nil {
+		t.Fatalf("Failed to unpin item: %v", err)
+	}
+	m.applyItemChange(id, written)
+
+	version, err := s.DataVersion()
+	if err != nil {
+		t.Fatalf("Failed to read data version: %v", err)
+	}
+	m.handleDataVersion(dataVersionMsg{version: version})
+	if len(m.filteredItems) != 2 {
+		t.Errorf("Expected the daemon's item after the poll, got %+v", m.filteredItems)
+	}
+}
@@ -90,7 +90,7 @@ func (m Model) buildMainContent(contentWidth, contentHeight int) string {
 	for itemIndex := pageStart; itemIndex < len(m.filteredItems) && linesRendered < availableContentLines; itemIndex++ {
 		itemMeta := m.filteredItems[itemIndex]
 		item := itemMeta.ToClipboardItem()
-		displayLines := m.getItemDisplayLines(item, contentWidth)
+		displayLines := m.listItemLines(itemMeta, contentWidth)
 
 		// Render as many lines of this item as fit
 		for lineIndex, line := range displayLines {
@@ -103,10 +103,10 @@ func (m Model) buildMainContent(contentWidth, contentHeight int) string {
 				if lineIndex == 0 && (item.IsPinned || item.ThreatLevel != "none" || item.SafeEntry) {
 					// First line with icons - build plain text line, then apply selected background uniformly
 					plainLine := m.buildPlainLineWithIcons(item, line)
-					content.WriteString("  " + mainStyles.SelectedBackground.Render(plainLine))
+					content.WriteString(m.selectionPrefix(true, lineIndex) + mainStyles.SelectedBackground.Render(plainLine))
 				} else {
 					// Other lines - apply selected background to plain text
-					content.WriteString("  " + mainStyles.SelectedBackground.Render(line))
+					content.WriteString(m.selectionPrefix(true, lineIndex) + mainStyles.SelectedBackground.Render(line))
 				}
 			} else {
 				// Non-selected items
@@ -124,8 +124,8 @@ func (m Model) buildMainContent(contentWidth, contentHeight int) string {
 		}
 
 		// Add separator if we have space and this isn't the last item we'll show
-		if linesRendered < availableContentLines && itemIndex < len(m.filteredItems)-1 {
-			separatorChar := "─"
+		if m.itemSeparatorLines() > 0 && linesRendered < availableContentLines && itemIndex < len(m.filteredItems)-1 {
+			separatorChar := m.separatorChar()
 			separatorWidth := contentWidth - 4 // Account for padding
 			if separatorWidth > 0 {
 				separator := strings.Repeat(separatorChar, separatorWidth)
@@ -155,11 +155,10 @@ func (m Model) calculatePageStart(availableContentLines, contentWidth int) int {
 	// Calculate lines needed for each item (including separator)
 	itemLines := make([]int, len(m.filteredItems))
 	for i, itemMeta := range m.filteredItems {
-		item := itemMeta.ToClipboardItem()
-		lines := len(m.getItemDisplayLines(item, contentWidth))
-		// Add 1 for separator (except for last item)
+		lines := len(m.listItemLines(itemMeta, contentWidth))
+		// Add separator (except for last item)
 		if i < len(m.filteredItems)-1 {
-			lines++
+			lines += m.itemSeparatorLines()
 		}
 		itemLines[i] = lines
 	}
@@ -196,12 +195,15 @@ func (m Model) calculatePageStart(availableContentLines, contentWidth int) int {
 
 // getItemDisplayLines gets the display lines for an item
 func (m Model) getItemDisplayLines(item storage.ClipboardItem, availableWidth int) []string {
-	// Handle multiline content - limit to 5 lines max for better fit
-	lines := strings.Split(item.Content, "\n")
+	// Escape sequences in copied terminal output must not reach the terminal
+	content := sanitizeForDisplay(item.Content)
+
+	// Handle multiline content - limit to the configured preview rows for better fit
+	lines := m.previewSourceLines(content)
 	isMultiline := len(lines) > 1
 
 	var displayLines []string
-	maxLines := 5
+	maxLines := m.previewLineLimit()
 	// Account for padding
 	effectiveWidth := availableWidth - 4
 	if effectiveWidth <= 0 {
@@ -215,7 +217,7 @@ func (m Model) getItemDisplayLines(item storage.ClipboardItem, availableWidth in
 	// Handle image items differently
 	if item.ContentType == "image" {
 		// Show descriptive text line for images (Content already includes size info)
-		imageDesc := fmt.Sprintf("%s - Press 'v' to view, 'e' to edit", item.Content)
+		imageDesc := fmt.Sprintf("%s - Press 'v' to view, 'e' to edit", content)
 		// Note: icons will be added later by buildStyledLineWithIcons
 		displayLines = wrapText(imageDesc, firstLineWidth, maxLines)
 	} else {
@@ -245,10 +247,15 @@ func (m Model) getItemDisplayLines(item storage.ClipboardItem, availableWidth in
 		} else {
 			// Single line entry - wrap to show full content up to 5 lines
 			// Icons will be added later by buildStyledLineWithIcons
-			displayLines = wrapText(item.Content, firstLineWidth, maxLines)
+			displayLines = wrapText(lines[0], firstLineWidth, maxLines)
 		}
 	}
 
+	// Reorder right-to-left text only after wrapping, so each row is reordered on its own
+	for i, line := range displayLines {
+		displayLines[i] = m.displayOrder(line)
+	}
+
 	return displayLines
 }
 
@@ -306,11 +313,7 @@ func (m Model) buildPlainLineWithIcons(item storage.ClipboardItem, line string)
 	
 	// Show safe marker if item has been marked as safe but has a threat level
 	if item.SafeEntry && item.ThreatLevel != "none" {
-		if m.iconHelper.GetCapabilities().SupportsUnicode {
-			securityIcon = "✓"
-		} else {
-			securityIcon = "[s]"
-		}
+		securityIcon = m.iconHelper.indicators.Safe
 	} else if item.SafeEntry {
 		// Don't show security warnings if item has been marked as safe with no threat
 		securityIcon = ""
@@ -318,17 +321,9 @@ func (m Model) buildPlainLineWithIcons(item storage.ClipboardItem, line string)
 		// Use stored threat level for display
 		switch item.ThreatLevel {
 		case "high":
-			if m.iconHelper.GetCapabilities().SupportsUnicode {
-				securityIcon = "⚠"
-			} else {
-				securityIcon = "[h]"
-			}
+			securityIcon = m.iconHelper.indicators.HighRisk
 		case "medium":
-			if m.iconHelper.GetCapabilities().SupportsUnicode {
-				securityIcon = "⚡"
-			} else {
-				securityIcon = "[m]"
-			}
+			securityIcon = m.iconHelper.indicators.MediumRisk
 		default:
 			securityIcon = ""
 		}
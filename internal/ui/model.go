@@ -28,17 +28,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"regexp"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sahilm/fuzzy"
 
 	"github.com/adaryorg/nclip/internal/clipboard"
 	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
 	"github.com/adaryorg/nclip/internal/security"
 	"github.com/adaryorg/nclip/internal/storage"
 )
@@ -54,6 +56,16 @@ const (
 	modeHelp
 	modeTextView
 	modeImageSecurityWarning
+	modeConfirmPanic
+	modeConfirmBulk
+	modeRescanReview
+	modeHashStore
+	modeNote
+	modeHostInfo
+	modePending
+	modeStats
+	modeSpellCheck
+	modeFormat
 )
 
 type Model struct {
@@ -61,13 +73,24 @@ type Model struct {
 	config          *config.Config
 	cache           *storage.ItemCache     // Memory-efficient cache
 	items           []storage.ClipboardItemMeta // Lightweight metadata only
+	itemsGeneration uint64                      // Cache generation items was read at
 	filteredItems   []storage.ClipboardItemMeta // Filtered lightweight metadata
 	cursor          int
 	searchQuery     string
+	searchCursor    int   // Rune index of the cursor in searchQuery
+	noteInput       string // Note being typed in the quick-entry prompt (N)
+	noteCursor      int    // Rune index of the cursor in noteInput
+	queryError      error // Invalid field terms in searchQuery, which are ignored
 	currentMode     mode
 	
+	// Archive browsing (nclip --archive)
+	archiveMode     bool
+
 	// Content filtering
 	filterMode      string // "", "images", "security-high", "security-medium", "security-safe"
+	filterResult    *filterResult // Last database query for filterMode
+	sortBySize      bool          // List the largest items first, toggled with z
+	sortByLanguage  bool          // Group items by the natural language of their text, toggled with l
 	width           int
 	height          int
 	deleteCandidate *storage.ClipboardItem
@@ -87,6 +110,19 @@ type Model struct {
 
 	// Syntax highlighting
 	codeDetector *CodeDetector
+	textLines    *textLinesCache // Highlighted and wrapped text view lines
+
+	// Language forced for the item in the text viewer, "" for auto-detection
+	languageOverride string
+
+	// Where the item open in the text or image viewer was copied from
+	viewingSource storage.Source
+
+	// Show escape sequences in terminal output as text instead of rendering colors
+	showRawANSI bool
+
+	// Show the decoded form of a JWT, base64 or hex item instead of the item
+	showDecoded bool
 
 	// Help screen state
 	helpScrollOffset int
@@ -95,10 +131,12 @@ type Model struct {
 
 	// Text viewer state
 	viewingText        *storage.ClipboardItem
+	viewingStats       textStats // Counts and hash of viewingText, computed when it is opened
 	textViewport       viewport.Model
 	textViewportReady  bool
 	textDeletePending  bool // Track if delete confirmation is pending in text view
 	imageDeletePending bool // Track if delete confirmation is pending in image view
+	confirmInput       string // Text typed to confirm the panic wipe (confirm.wipe = "typed")
 
 	// Security viewer state  
 	securityDeletePending bool // Track if delete confirmation is pending in security view
@@ -112,35 +150,139 @@ type Model struct {
 	
 	// Theme service for comprehensive styling
 	themeService *ThemeService
+	themeWarning string // Why the configured syntax highlighting isn't used, shown on start
+
+	// Asynchronous storage operation state
+	pendingOps    int           // Storage operations still running
+	opSpinner     spinner.Model // Shown in the footer while operations are pending
+
+	// Transient status and error messages
+	toast    *toast
+	toastSeq int
+
+	// Last seen database change counter, used to pick up items stored by the daemon
+	dataVersion int64
+
+	// Private temp files handed to external editors and viewers
+	temp *tempFiles
+
+	// Last text preview of an image, for terminals without graphics support
+	imagePreview *imagePreviewCache
+
+	// Last image shown in the viewer with the Kitty graphics protocol
+	kittyImage *kittyImageCache
+
+	// Accessibility mode: text labels, no colors or box drawing, bell cues
+	accessible bool
+
+	// Text to print after exit when copying without a display in "print" mode,
+	// or the item picked in insert mode
+	printOnExit *string
+
+	// Shell widget picker (nclip --zle): picking an item prints it instead of copying
+	insertMode bool
+
+	// Latest save from a watched external editor, waiting for 'y' to import it
+	pendingImport *editSavedMsg
+
+	// Debug overlay (nclip --debug, toggled with F12)
+	debugMode        bool
+	showDebugOverlay bool
+
+	// Keep running after copying an item (behavior.stay_open or --stay-open)
+	stayOpen bool
+
+	// Reorder right-to-left text for display (display.bidi = "app")
+	reorderBidi bool
+
+	// List preview layout (display.preview_lines, collapse_multiline and compact)
+	previewLines      int
+	collapseMultiline bool
+	compactList       bool
+
+	// Dialog placement and split list/preview (display.layout, cycled with L)
+	layout dialogLayout
+
+	// Security actions on the filtered items: awaiting confirmation, and a running rescan
+	bulkPending bulkAction
+	bulk        *bulkProgress
+	review      *rescanReview
+	hashView    *hashView
+	pendingView *pendingView
+	hostInfo    *hostInfoView
+	statsView   *statsView
+	spellView   *spellView
+	formatMenu  *formatMenu
+
+	// Checksum or file path picked with V, compared with the next item picked
+	compareFrom *storage.ClipboardItem
+
+	// Feature use counts (behavior.usage_insights), nil when disabled
+	usage *UsageStats
+
+	// Changes made in this session, reverted with ctrl+z and repeated with ctrl+r
+	undoStack []*undoEntry
+	redoStack []*undoEntry
 }
 
 
 func NewModel(s *storage.Storage, cfg *config.Config, basicTerminal bool) Model {
-	// Create memory-efficient cache (cache up to 20 images by default)
-	cache := storage.NewItemCache(s, 20)
+	// Create memory-efficient cache, limiting cached images by total size
+	cache := storage.NewItemCacheWithBudget(s, cfg.Cache.ImageBudgetMB)
 	items := cache.GetAllMeta()
 	hashStore, _ := security.NewHashStore() // Initialize security hash store
 	iconHelper := NewSecurityIconHelper(basicTerminal)   // Initialize terminal detection
 	pinIconHelper := NewPinIconHelper(basicTerminal)     // Initialize pin icon helper
 	useBasicColors := !iconHelper.GetCapabilities().SupportsColor
 	codeDetector := NewCodeDetector() // Initialize syntax highlighting
-	themeService := NewThemeService(&cfg.Theme) // Initialize theme service
+	// The fallback is kept in a copy, so the caller's configuration stays as it was loaded
+	theme := cfg.Theme
+	var themeWarning string
+	if err := theme.ValidateChromaTheme(); err != nil {
+		logging.Warn("Using default syntax highlighting: %v", err)
+		themeWarning = "Using default syntax highlighting: " + err.Error()
+		theme.Chroma.Theme = ""
+	}
+	if theme.Chroma.Theme == config.CustomChromaTheme {
+		if err := codeDetector.SetCustomStyle(theme.CodeHighlight); err != nil {
+			logging.Warn("Using default syntax highlighting: %v", err)
+			themeWarning = "Using default syntax highlighting: " + err.Error()
+		}
+	}
+	themeService := NewThemeService(&theme) // Initialize theme service
 
 	model := Model{
-		storage:        s,
-		config:         cfg,
-		cache:          cache,
-		items:          items,
-		filteredItems:  items,
-		cursor:         0,
-		currentMode:    modeList,
-		hashStore:      hashStore,
-		iconHelper:     iconHelper,
-		pinIconHelper:  pinIconHelper,
-		useBasicColors: useBasicColors,
-		codeDetector:   codeDetector,
-		themeService:   themeService,
-	}
+		storage:           s,
+		config:            cfg,
+		cache:             cache,
+		items:             items,
+		filteredItems:     items,
+		cursor:            0,
+		currentMode:       modeList,
+		hashStore:         hashStore,
+		iconHelper:        iconHelper,
+		pinIconHelper:     pinIconHelper,
+		useBasicColors:    useBasicColors,
+		codeDetector:      codeDetector,
+		textLines:         newTextLinesCache(),
+		themeService:      themeService,
+		themeWarning:      themeWarning,
+		opSpinner:         newOpSpinner(),
+		stayOpen:          cfg.Behavior.StayOpen,
+		reorderBidi:       cfg.Display.Bidi != config.BidiTerminal,
+		previewLines:      cfg.Display.PreviewLines,
+		collapseMultiline: cfg.Display.CollapseMultiline,
+		compactList:       cfg.Display.Compact,
+		temp:              newTempFiles(cfg.Editor.TempDir),
+		imagePreview:      &imagePreviewCache{},
+		kittyImage:        &kittyImageCache{},
+		layout:            newDialogLayout(cfg.Display),
+	}
+	if cfg.Display.Accessible {
+		model.enableAccessibility()
+	}
+	model.itemsGeneration = cache.Generation()
+	model.dataVersion, _ = s.DataVersion()
 	
 	// Initial preload of images around cursor
 	go model.preloadImagesAroundCursor()
@@ -148,8 +290,31 @@ func NewModel(s *storage.Storage, cfg *config.Config, basicTerminal bool) Model
 	return model
 }
 
+// NewArchiveModel creates a model that browses archived items instead of the live history
+func NewArchiveModel(s *storage.Storage, cfg *config.Config, basicTerminal bool) Model {
+	model := NewModel(s, cfg, basicTerminal)
+	model.archiveMode = true
+	model.items = s.GetArchivedMeta()
+	model.filteredItems = model.items
+	return model
+}
+
 func (m Model) Init() tea.Cmd {
-	return nil
+	// Watch the database so items stored by the daemon show up while the TUI is open
+	cmds := []tea.Cmd{pollChangesCmd(m.storage), m.uncleanShutdownNotice(), m.themeNotice()}
+	if clipboard.Headless() && !m.insertMode {
+		cmds = append(cmds, m.headlessNotice())
+	}
+	return tea.Batch(cmds...)
+}
+
+// themeNotice reports a chroma theme or custom style that couldn't be used
+func (m Model) themeNotice() tea.Cmd {
+	if m.themeWarning == "" {
+		return nil
+	}
+	text := m.themeWarning
+	return func() tea.Msg { return toastMsg{level: toastWarning, text: text} }
 }
 
 // getItemByIndex returns a full ClipboardItem for the given filtered index
@@ -158,6 +323,9 @@ func (m *Model) getItemByIndex(index int) *storage.ClipboardItem {
 		return nil
 	}
 	meta := m.filteredItems[index]
+	if m.archiveMode {
+		return m.storage.GetArchivedItem(meta.ID)
+	}
 	return m.cache.GetFullItem(meta.ID)
 }
 
@@ -168,10 +336,30 @@ func (m *Model) getCurrentItem() *storage.ClipboardItem {
 
 // refreshItems reloads the items list and applies current filters
 func (m *Model) refreshItems() {
-	m.items = m.cache.GetAllMeta()
+	if m.archiveMode {
+		m.items = m.storage.GetArchivedMeta()
+	} else {
+		// Nothing to re-filter if the cache hasn't changed since the last read
+		generation := m.cache.Generation()
+		if m.items != nil && generation == m.itemsGeneration {
+			return
+		}
+		m.items = m.cache.GetAllMeta()
+		m.itemsGeneration = generation
+	}
 	m.filterItems()
 }
 
+// applyItemChange updates the list after this TUI changed a single item, applying
+// just that row to the cache instead of reloading all metadata
+func (m *Model) applyItemChange(id string, written ownWrite) {
+	if !m.archiveMode {
+		m.cache.RefreshItem(id)
+		m.recordOwnWrite(written)
+	}
+	m.refreshItems()
+}
+
 // applyFilters is an alias for filterItems to maintain compatibility
 func (m *Model) applyFilters() {
 	m.filterItems()
@@ -189,24 +377,17 @@ func (m *Model) getItemMeta(index int) *storage.ClipboardItemMeta {
 func (m *Model) preloadImagesAroundCursor() {
 	const bufferSize = 10 // Preload ±10 items around cursor
 	
-	var imageIDs []string
-	start := m.cursor - bufferSize
-	end := m.cursor + bufferSize
-	
-	if start < 0 {
-		start = 0
+	// Collect image IDs nearest the cursor first, so the byte budget is spent
+	// on the images most likely to be viewed next
+	indices := []int{m.cursor}
+	for offset := 1; offset <= bufferSize; offset++ {
+		indices = append(indices, m.cursor+offset, m.cursor-offset)
 	}
-	if end >= len(m.filteredItems) {
-		end = len(m.filteredItems) - 1
-	}
-	
-	// Collect image IDs in the buffer range
-	for i := start; i <= end; i++ {
-		if i < len(m.filteredItems) {
-			item := &m.filteredItems[i]
-			if item.ContentType == "image" {
-				imageIDs = append(imageIDs, item.ID)
-			}
+
+	var imageIDs []string
+	for _, i := range indices {
+		if i >= 0 && i < len(m.filteredItems) && m.filteredItems[i].ContentType == "image" {
+			imageIDs = append(imageIDs, m.filteredItems[i].ID)
 		}
 	}
 	
@@ -444,6 +625,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Initialize viewports based on current mode
 		if m.currentMode == modeTextView && m.viewingText != nil {
 			m.initTextViewport()
+			// Wrapping depends on the width, so large items are re-highlighted
+			return m, m.highlightTextViewCmd()
 		} else if m.currentMode == modeHelp {
 			m.initHelpViewport()
 		} else if m.currentMode == modeSecurityWarning {
@@ -459,15 +642,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		oldCursor := m.cursor
 		editedID := msg.editedItemID
 
-		m.cache.ForceRefresh()
-		m.items = m.cache.GetAllMeta()
-		m.filterItems()
+		var toastCmd tea.Cmd
+		if msg.err != nil {
+			failure := errorToast("edit", msg.err)
+			toastCmd = m.showToast(failure.level, failure.text)
+		} else if msg.watch != nil {
+			toastCmd = msg.watch.next
+		} else if msg.change != nil {
+			m.recordChange(msg.change)
+		}
+
+		m.applyItemChange(editedID, msg.written)
 
 		// Try to find the edited item and position cursor on it
 		for i, item := range m.filteredItems {
 			if item.ID == editedID {
 				m.cursor = i
-				return m, nil
+				return m, toastCmd
 			}
 		}
 
@@ -480,14 +671,134 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = 0
 		}
 
+		return m, toastCmd
+
+	case storageOpMsg:
+		return m, m.handleStorageOpResult(msg)
+
+	case dataVersionMsg:
+		return m, m.handleDataVersion(msg)
+
+	case highlightDoneMsg:
+		m.handleHighlightDone(msg)
 		return m, nil
 
+	case toastMsg:
+		return m, m.showToast(msg.level, msg.text)
+
+	case toastExpiredMsg:
+		m.dismissToast(msg.id)
+		return m, nil
+
+	case copyDoneMsg:
+		return m, m.handleCopyDone(msg)
+
+	case bulkProgressMsg:
+		return m, m.handleBulkProgress(msg)
+
+	case bulkDoneMsg:
+		return m, m.handleBulkDone(msg)
+
+	case headlessCopyMsg:
+		return m, m.handleHeadlessCopy(msg)
+
+	case insertItemMsg:
+		return m, m.handleInsertItem(msg)
+
+	case panicDoneMsg:
+		return m, m.handlePanicDone(msg)
+
+	case annotateDoneMsg:
+		return m, m.handleAnnotateDone(msg)
+
+	case noteSavedMsg:
+		return m, m.handleNoteSaved(msg)
+
+	case cleanLinkMsg:
+		return m, m.handleCleanLink(msg)
+
+	case spellCheckMsg:
+		return m, m.handleSpellCheck(msg)
+
+	case formatReadyMsg:
+		return m, m.handleFormatReady(msg)
+
+	case checksumResultMsg:
+		return m, m.handleChecksumResult(msg)
+
+	case spellSaveFailedMsg:
+		failure := errorToast("save corrected text", msg.err)
+		return m, m.showToast(failure.level, failure.text)
+
+	case undoDoneMsg:
+		return m, m.handleUndoDone(msg)
+
+	case pendingResolvedMsg:
+		return m, m.handlePendingResolved(msg)
+
+	case hostInfoMsg:
+		return m, m.handleHostInfo(msg)
+
+	case editSavedMsg:
+		return m, m.handleEditSaved(msg)
+
+	case editImportedMsg:
+		return m, m.handleEditImported(msg)
+
+	case quitAfterCopyMsg:
+		return m, tea.Quit
+
+	case spinner.TickMsg:
+		// Keep the spinner animating only while operations are pending
+		if m.pendingOps == 0 {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.opSpinner, cmd = m.opSpinner.Update(msg)
+		return m, cmd
+
 	case textViewEditCompleteMsg:
 		// Handle text view editing completion - stay in text view mode
-		// The text content has already been updated in the editTextViewEntry function
+		if msg.err != nil {
+			failure := errorToast("edit", msg.err)
+			return m, m.showToast(failure.level, failure.text)
+		}
+		if msg.change != nil {
+			m.recordChange(msg.change)
+		}
+		if msg.success && m.viewingText != nil && m.viewingText.ID == msg.editedItemID {
+			// Show the new content and refresh the main items list
+			updatedItem := *m.viewingText
+			updatedItem.Content = msg.content
+			m.setViewingText(&updatedItem)
+			m.textViewportReady = false
+			m.applyItemChange(msg.editedItemID, msg.written)
+			return m, m.highlightTextViewCmd()
+		}
+		if msg.watch != nil {
+			return m, msg.watch.next
+		}
 		return m, nil
 
 	case tea.KeyMsg:
+		// The debug overlay sits above every mode and swallows keys while open
+		if m.debugMode && msg.String() == "f12" {
+			m.showDebugOverlay = !m.showDebugOverlay
+			if m.showDebugOverlay && m.currentMode == modeImageView {
+				fmt.Print("\x1b_Ga=d;\x1b\\") // Delete all Kitty images so the overlay is readable
+			}
+			return m, nil
+		}
+		if m.showDebugOverlay {
+			switch msg.String() {
+			case "esc", "q":
+				m.showDebugOverlay = false
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		if m.currentMode == modeSecurityWarning {
 			// In security warning mode - use same logic as text view
 			contentLines := m.getSecurityViewLines()
@@ -509,15 +820,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "s":
 				// Mark as safe
+				var cmd tea.Cmd
 				if m.securityItem != nil {
-					m.storage.UpdateSafeEntry(m.securityItem.ID, true)
-					// Refresh items list
-					m.refreshItems()
-					// Update the security item with new status
-					updatedItem := m.storage.GetByID(m.securityItem.ID)
-					if updatedItem != nil {
-						m.securityItem = updatedItem
-					}
+					cmd = m.markSafeCmd(m.securityItem.ID, true, m.securityItem.SafeEntry)
 				}
 				// Exit security view after marking
 				m.currentMode = modeList
@@ -526,18 +831,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.securityItem = nil
 				m.securityDeletePending = false
 				m.securityViewportReady = false
-				return m, nil
+				return m, cmd
 			case "u":
 				// Mark as unsafe
+				var cmd tea.Cmd
 				if m.securityItem != nil {
-					m.storage.UpdateSafeEntry(m.securityItem.ID, false)
-					// Refresh items list
-					m.refreshItems()
-					// Update the security item with new status
-					updatedItem := m.storage.GetByID(m.securityItem.ID)
-					if updatedItem != nil {
-						m.securityItem = updatedItem
-					}
+					cmd = m.markSafeCmd(m.securityItem.ID, false, m.securityItem.SafeEntry)
 				}
 				// Exit security view after marking
 				m.currentMode = modeList
@@ -546,29 +845,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.securityItem = nil
 				m.securityDeletePending = false
 				m.securityViewportReady = false
-				return m, nil
+				return m, cmd
 			case "x":
-				if m.securityDeletePending {
+				if m.securityDeletePending || !m.confirmBlockHash() {
 					// Confirm deletion
 					// Remove from main database and add to security hash store
-					if m.hashStore != nil {
-						contentHash := security.CreateHash(m.securityContent)
-						if len(m.securityThreats) > 0 {
-							m.hashStore.AddHash(contentHash, m.securityThreats[0])
-						}
-					}
-
-					// Find and remove the item from main database
+					var cmd tea.Cmd
 					if m.securityItem != nil {
-						m.storage.Delete(m.securityItem.ID)
-					}
-
-					// Refresh items list
-					m.refreshItems()
-					if m.cursor >= len(m.filteredItems) && len(m.filteredItems) > 0 {
-						m.cursor = len(m.filteredItems) - 1
-					} else if len(m.filteredItems) == 0 {
-						m.cursor = 0
+						id := m.securityItem.ID
+						hashStore := m.hashStore
+						contentHash := security.CreateHash(m.securityContent)
+						threats := m.securityThreats
+						archive := m.archiveMode
+						cmd = m.startStorageOp("delete", id, func(s *storage.Storage) error {
+							if hashStore != nil && len(threats) > 0 {
+								if err := hashStore.AddHash(contentHash, threats[0]); err != nil {
+									return err
+								}
+							}
+							return deleteStoredItem(s, archive, id)
+						})
 					}
 
 					m.currentMode = modeList
@@ -577,10 +873,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.securityItem = nil
 					m.securityDeletePending = false
 					m.securityViewportReady = false
-					return m, nil
+					return m, cmd
 				} else {
 					// First 'x' press - enter delete confirmation mode
 					m.securityDeletePending = true
+					m.cue()
 					return m, nil
 				}
 			case "ctrl+c":
@@ -670,60 +967,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.textViewport, _ = m.textViewport.Update(msg)
 				}
 			case "enter":
-				// Copy text to clipboard and exit
+				// Copy text to clipboard and return to the list to show the confirmation
 				if m.viewingText != nil {
-					err := clipboard.Copy(m.viewingText.Content)
-					if err == nil {
-						return m, tea.Quit
-					}
+					cmd := m.copyItemCmd(*m.viewingText)
+					m.currentMode = modeList
+					m.viewingText = nil
+					m.textViewportReady = false
+					m.textDeletePending = false
+					return m, cmd
 				}
 				return m, nil
-			case "e":
-				// Edit text
+			case "h":
+				// Copy the SHA-256 shown in the header, in full
 				if m.viewingText != nil {
+					cmd := m.copyTextHashCmd()
+					m.currentMode = modeList
+					m.viewingText = nil
+					m.textViewportReady = false
+					m.textDeletePending = false
+					return m, cmd
+				}
+				return m, nil
+			case "e":
+				// Edit text (archived items are read-only)
+				if m.viewingText != nil && !m.archiveMode {
 					return m, m.editTextViewEntry(*m.viewingText)
 				}
 				return m, nil
+			case "l":
+				// Force the next highlighting language when detection guessed wrong
+				return m, m.cycleLanguageOverride()
+			case "a":
+				// Toggle between rendered colors and visible escape codes for terminal output
+				if m.viewingText != nil && containsEscapes(m.viewingText.Content) {
+					m.showRawANSI = !m.showRawANSI
+					m.textViewportReady = false
+					m.initTextViewport()
+					return m, m.highlightTextViewCmd()
+				}
+				return m, nil
+			case "d":
+				// Toggle between an encoded item (a JWT, base64 or hex) and its decoded form
+				if _, ok := m.viewerDecoding(); ok {
+					m.showDecoded = !m.showDecoded
+					m.textViewportReady = false
+					m.textDeletePending = false
+					m.initTextViewport()
+					return m, nil
+				}
+				// Like any other key, exits the viewer for anything else
+				m.currentMode = modeList
+				m.viewingText = nil
+				m.textViewportReady = false
+				m.textDeletePending = false
+				return m, nil
 			case "x":
 				// Delete text from database with confirmation
 				if m.viewingText != nil {
-					if m.textDeletePending {
+					if m.textDeletePending || !m.confirmDelete() {
 						// Second press - confirm deletion
-						err := m.storage.Delete(m.viewingText.ID)
-						if err == nil {
-							m.refreshItems()
-							// Adjust cursor if needed
-							if m.cursor >= len(m.filteredItems) && len(m.filteredItems) > 0 {
-								m.cursor = len(m.filteredItems) - 1
-							} else if len(m.filteredItems) == 0 {
-								m.cursor = 0
-							}
-						}
+						id := m.viewingText.ID
+						cmd := m.deleteItemCmd(id)
 						// Exit text view after deletion
 						m.currentMode = modeList
 						m.viewingText = nil
 						m.textViewportReady = false
 						m.textDeletePending = false
-						return m, nil
+						return m, cmd
 					} else {
 						// First press - show confirmation
 						m.textDeletePending = true
+						m.cue()
 						return m, nil
 					}
 				}
 				return m, nil
 			case "s":
 				// Mark as safe - only available for items with security warnings
-				if m.viewingText != nil && (m.viewingText.ThreatLevel == "high" || m.viewingText.ThreatLevel == "medium") {
-					err := m.storage.UpdateSafeEntry(m.viewingText.ID, true)
-					if err == nil {
-						// Update the current viewing item
-						m.viewingText.SafeEntry = true
-						m.viewingText.ThreatLevel = "none" // Clear threat level when marked as safe
-						
-						// Update the main items list
-						m.refreshItems()
-					}
+				if m.viewingText != nil && !m.archiveMode && (m.viewingText.ThreatLevel == "high" || m.viewingText.ThreatLevel == "medium") {
+					cmd := m.markSafeCmd(m.viewingText.ID, true, m.viewingText.SafeEntry)
+					// Update the current viewing item
+					m.viewingText.SafeEntry = true
+					m.viewingText.ThreatLevel = "none" // Clear threat level when marked as safe
+					return m, cmd
 				}
 				return m, nil
 			default:
@@ -749,19 +1075,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter":
 				// Copy image to clipboard
 				if m.viewingImage != nil && len(m.viewingImage.ImageData) > 0 {
-					// Copy image data back to clipboard
-					err := clipboard.CopyImage(m.viewingImage.ImageData)
-					if err == nil {
-						return m, tea.Quit
-					}
+					// Copy image data back to clipboard and return to the list to show the confirmation
+					cmd := m.copyItemCmd(*m.viewingImage)
+					fmt.Print("\x1b_Ga=d;\x1b\\") // Delete all Kitty images
+					m.currentMode = modeList
+					m.viewingImage = nil
+					m.imageDeletePending = false
+					return m, cmd
 				}
 				return m, nil
 			case "e":
-				// Edit image
-				if m.viewingImage != nil {
+				// Edit image (archived items are read-only)
+				if m.viewingImage != nil && !m.archiveMode {
 					return m, m.editImage(*m.viewingImage)
 				}
 				return m, nil
+			case "a":
+				// Annotate image and import the result as a new entry
+				if m.viewingImage != nil && !m.archiveMode {
+					return m, m.annotateImageCmd(*m.viewingImage)
+				}
+				return m, nil
 			case "o":
 				// Open in external image viewer
 				if m.viewingImage != nil {
@@ -771,27 +1105,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "x":
 				// Delete image from database with confirmation
 				if m.viewingImage != nil {
-					if m.imageDeletePending {
+					if m.imageDeletePending || !m.confirmDelete() {
 						// Second press - confirm deletion
-						err := m.storage.Delete(m.viewingImage.ID)
-						if err == nil {
-							m.refreshItems()
-							// Adjust cursor if needed
-							if m.cursor >= len(m.filteredItems) && len(m.filteredItems) > 0 {
-								m.cursor = len(m.filteredItems) - 1
-							} else if len(m.filteredItems) == 0 {
-								m.cursor = 0
-							}
-						}
+						id := m.viewingImage.ID
+						cmd := m.deleteItemCmd(id)
 						// Clear Kitty graphics and exit image view after deletion
 						fmt.Print("\x1b_Ga=d;\x1b\\") // Delete all Kitty images
 						m.currentMode = modeList
 						m.viewingImage = nil
 						m.imageDeletePending = false
-						return m, nil
+						return m, cmd
 					} else {
 						// First press - show confirmation
 						m.imageDeletePending = true
+						m.cue()
 						return m, nil
 					}
 				}
@@ -827,21 +1154,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "x":
 				// Confirm delete by pressing 'x' again
+				var cmd tea.Cmd
 				if m.deleteCandidate != nil {
-					err := m.storage.Delete(m.deleteCandidate.ID)
-					if err == nil {
-						m.refreshItems()
-						// Adjust cursor if needed
-						if m.cursor >= len(m.filteredItems) && len(m.filteredItems) > 0 {
-							m.cursor = len(m.filteredItems) - 1
-						} else if len(m.filteredItems) == 0 {
-							m.cursor = 0
-						}
-					}
+					id := m.deleteCandidate.ID
+					cmd = m.deleteItemCmd(id)
 				}
 				m.currentMode = modeList
 				m.deleteCandidate = nil
-				return m, nil
+				return m, cmd
 			case "ctrl+c":
 				return m, tea.Quit
 			default:
@@ -850,6 +1170,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteCandidate = nil
 				return m, nil
 			}
+		} else if m.currentMode == modeConfirmBulk {
+			return m, m.handleBulkConfirmKey(msg.String())
+		} else if m.currentMode == modeRescanReview {
+			return m, m.handleReviewKey(msg.String())
+		} else if m.currentMode == modeHashStore {
+			return m, m.handleHashViewKey(msg.String())
+		} else if m.currentMode == modePending {
+			return m, m.handlePendingViewKey(msg.String())
+		} else if m.currentMode == modeNote {
+			return m, m.handleNoteKey(msg)
+		} else if m.currentMode == modeHostInfo {
+			return m, m.handleHostInfoKey(msg.String())
+		} else if m.currentMode == modeStats {
+			return m, m.handleStatsViewKey(msg.String())
+		} else if m.currentMode == modeSpellCheck {
+			return m, m.handleSpellViewKey(msg.String())
+		} else if m.currentMode == modeFormat {
+			return m, m.handleFormatMenuKey(msg.String())
+		} else if m.currentMode == modeConfirmPanic {
+			return m, m.handlePanicConfirmKey(msg)
 		} else if m.currentMode == modeSearch {
 			// In search mode, handle filter input with real-time preview
 			switch msg.String() {
@@ -866,20 +1206,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.currentMode = modeList
 				m.cursor = 0
 				return m, nil
-			case "backspace":
-				if len(m.searchQuery) > 0 {
-					m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-					m.filterItems() // Update display in real-time
-				}
 			default:
-				// Only add printable characters to search query
-				if len(msg.String()) == 1 && msg.String() >= " " && msg.String() <= "~" {
-					m.searchQuery += msg.String()
-					m.filterItems() // Update display in real-time
-				}
+				m.handleSearchEditKey(msg)
 			}
 		} else {
 			// In list mode, handle all shortcuts
+			m.recordUsage(msg.String())
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
@@ -887,6 +1219,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "/":
 				m.currentMode = modeSearch
 				// Keep existing search query when re-entering search mode
+				m.searchCursor = len([]rune(m.searchQuery))
+				return m, nil
+
+			case "N":
+				// Quick entry: type a note to self straight into the history
+				if !m.archiveMode && !m.insertMode {
+					m.startNote()
+				}
 				return m, nil
 
 			case "c":
@@ -898,40 +1238,94 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
-			case "enter":
+			case "enter", "alt+enter":
+				// Copy, open or view, as set per kind of entry in [keys]
 				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
 					selectedItem := m.getCurrentItem()
 					if selectedItem == nil {
 						return m, nil
 					}
-					err := clipboard.Copy(selectedItem.Content)
-					if err != nil {
+					return m, m.enterCmd(*selectedItem, msg.String() == "alt+enter")
+				}
+
+			case "ctrl+z":
+				return m, m.undoCmd(false)
+
+			case "ctrl+r":
+				return m, m.undoCmd(true)
+
+			case "w":
+				// Copy the link without tracking parameters, resolving shorteners
+				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					selectedItem := m.getCurrentItem()
+					if selectedItem == nil {
 						return m, nil
 					}
-					return m, tea.Quit
+					return m, m.cleanLinkCmd(*selectedItem)
 				}
 
-			case "v":
-				// View entry in full-screen (images or text)
+			case "C":
+				// Spell check the text and offer the corrected version as a new entry
+				if !m.archiveMode && len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					selectedItem := m.getCurrentItem()
+					if selectedItem == nil {
+						return m, nil
+					}
+					return m, m.spellCheckCmd(*selectedItem)
+				}
+
+			case "V":
+				// Verify a checksum against a file path or a second checksum, picked in turn
 				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
 					selectedItem := m.getCurrentItem()
 					if selectedItem == nil {
 						return m, nil
 					}
-					if selectedItem.ContentType == "image" {
-						m.viewingImage = selectedItem
-						m.currentMode = modeImageView
+					return m, m.pickChecksumItem(*selectedItem)
+				}
+
+			case "F":
+				// Format as: copy the text wrapped in a template, e.g. a Markdown link
+				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					selectedItem := m.getCurrentItem()
+					if selectedItem == nil {
 						return m, nil
-					} else {
-						m.viewingText = selectedItem
-						m.textViewportReady = false
-						m.currentMode = modeTextView
+					}
+					return m, m.openFormatMenu(*selectedItem, m.filteredItems[m.cursor])
+				}
+
+			case "I":
+				// Show reverse DNS, whois and address hints for an IP address or hostname
+				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					selectedItem := m.getCurrentItem()
+					if selectedItem == nil {
 						return m, nil
 					}
+					return m, m.openHostInfo(*selectedItem)
 				}
 
-			case "e":
+			case "X":
+				// Copy and remove: move the item to the clipboard, leaving no trace in history
 				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					selectedItem := m.getCurrentItem()
+					if selectedItem == nil {
+						return m, nil
+					}
+					return m, m.moveItemCmd(*selectedItem)
+				}
+
+			case "v":
+				// View entry in full-screen (images or text)
+				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					selectedItem := m.getCurrentItem()
+					if selectedItem == nil {
+						return m, nil
+					}
+					return m, m.viewItem(selectedItem)
+				}
+
+			case "e":
+				if !m.archiveMode && len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
 					selectedItem := m.getCurrentItem()
 					if selectedItem == nil {
 						return m, nil
@@ -943,14 +1337,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 
+			case "a":
+				if !m.archiveMode {
+					if selectedItem := m.getCurrentItem(); selectedItem != nil && selectedItem.ContentType == "image" {
+						return m, m.annotateImageCmd(*selectedItem)
+					}
+				}
+
+			case "y":
+				// Import the content saved in a watched external editor
+				if m.pendingImport != nil {
+					return m, m.importPendingEdit()
+				}
+
+			case "n":
+				// Dismiss the saved edit; later saves are offered again
+				m.pendingImport = nil
+
 			case "x":
 				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
 					selectedItem := m.getCurrentItem()
 					if selectedItem == nil {
 						return m, nil
 					}
+					if !m.confirmDelete() {
+						return m, m.deleteItemCmd(selectedItem.ID)
+					}
 					m.deleteCandidate = selectedItem
 					m.currentMode = modeConfirmDelete
+					m.cue()
 					return m, nil
 				}
 
@@ -1042,7 +1457,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, nil
 					}
 					if selectedItem.ContentType != "image" {
-						detector := security.NewSecurityDetector()
+						detector := security.DefaultDetector()
 						threats := detector.DetectSecurity(selectedItem.Content)
 						// Always show security dialog, even if no threats found
 						m.ShowSecurityWarning(*selectedItem, threats)
@@ -1070,6 +1485,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.filterItems()
 				return m, nil
 				
+			case "z":
+				// Toggle listing the largest items first
+				m.sortBySize = !m.sortBySize
+				m.sortByLanguage = false
+				m.filterItems()
+				return m, nil
+
+			case "l":
+				// Toggle grouping the items by natural language
+				m.sortByLanguage = !m.sortByLanguage
+				m.sortBySize = false
+				m.filterItems()
+				return m, nil
+
 			case "h":
 				// Toggle high-risk security filter
 				if m.filterMode == "security-high" {
@@ -1102,31 +1531,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case "p":
 				// Toggle pin/unpin for current item
-				if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+				if !m.archiveMode && len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
 					selectedItem := m.getCurrentItem()
 					if selectedItem == nil {
 						return m, nil
 					}
 					
-					if selectedItem.IsPinned {
-						// Unpin the item
-						err := m.storage.UnpinItem(selectedItem.ID)
-						if err == nil {
-							// Force cache refresh and update UI
-							m.cache.ForceRefresh()
-							m.items = m.cache.GetAllMeta()
-							m.filterItems()
-						}
-					} else {
-						// Pin the item
-						err := m.storage.PinItem(selectedItem.ID)
-						if err == nil {
-							// Force cache refresh and update UI
-							m.cache.ForceRefresh()
-							m.items = m.cache.GetAllMeta()
-							m.filterItems()
-						}
-					}
+					return m, m.pinCmd(selectedItem.ID, !selectedItem.IsPinned)
 				}
 				return m, nil
 
@@ -1156,29 +1567,85 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					pinIndex = 10
 				}
 				
+				if m.archiveMode {
+					return m, nil
+				}
+
 				pinnedItems := m.storage.GetPinnedItems()
 				if pinIndex > 0 && pinIndex <= len(pinnedItems) {
 					selectedItem := pinnedItems[pinIndex-1]
 					fullItem := m.storage.GetByID(selectedItem.ID)
 					if fullItem != nil {
-						var err error
-						if fullItem.ContentType == "image" && len(fullItem.ImageData) > 0 {
-							err = clipboard.CopyImage(fullItem.ImageData)
-						} else {
-							err = clipboard.Copy(fullItem.Content)
-						}
-						if err == nil {
-							return m, tea.Quit
-						}
+						return m, m.copyItemCmd(*fullItem)
 					}
 				}
 				return m, nil
 
+			case "r":
+				// Restore archived item back into the clipboard history
+				if m.archiveMode && len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
+					id := m.filteredItems[m.cursor].ID
+					return m, m.startStorageOp("restore", id, func(s *storage.Storage) error {
+						return s.RestoreArchived(id)
+					})
+				}
+				return m, nil
+
+			case "L":
+				// Cycle centered, full screen and split layouts
+				m.layout = m.layout.next()
+				return m, m.showToast(toastInfo, "Layout: "+m.layout.label())
+
 			case "?":
 				// Show help screen
 				m.currentMode = modeHelp
 				m.helpViewportReady = false
 				return m, nil
+
+			case "T":
+				// Show history totals and usage insights
+				m.openStatsView()
+				return m, nil
+
+			case "S", "U", "R":
+				// Mark the filtered items safe or unsafe, or rescan them, after confirmation
+				if !m.archiveMode {
+					return m, m.requestBulk(bulkKeys[msg.String()])
+				}
+				return m, nil
+
+			case "B":
+				// Manage the hashes of content blocked from being stored
+				return m, m.openHashView()
+
+			case "P":
+				// Keep or discard content the daemon holds back in capture mode "ask"
+				if !m.archiveMode {
+					return m, m.openPendingView(false)
+				}
+				return m, nil
+
+			case "Q":
+				// Rescue or discard content the capture policy blocked and quarantined
+				if !m.archiveMode {
+					return m, m.openPendingView(true)
+				}
+				return m, nil
+
+			case "esc":
+				if m.compareFrom != nil {
+					m.compareFrom = nil
+					return m, nil
+				}
+				if m.cancelBulk() {
+					return m, m.showToast(toastInfo, "Cancelling rescan...")
+				}
+				return m, nil
+
+			case "!":
+				// Panic: clear the clipboard and wipe unpinned history after confirmation
+				m.requestPanic()
+				return m, nil
 			}
 		}
 	}
@@ -1192,15 +1659,21 @@ func (m *Model) filterItems() {
 	
 	// Apply content filtering first
 	if m.filterMode != "" {
-		items = m.applyContentFilter(items)
+		items = m.contentFilterItems()
 	}
 	
 	// Apply search query if present
 	if m.searchQuery == "" {
+		m.queryError = nil
 		m.filteredItems = items
 	} else {
 		m.filteredItems = m.applySearchFilter(items)
 	}
+	if m.sortBySize {
+		m.filteredItems = sortLargestFirst(m.filteredItems)
+	} else if m.sortByLanguage {
+		m.filteredItems = sortByTextLanguage(m.filteredItems)
+	}
 	
 	// Reset cursor if it's out of bounds
 	if m.cursor >= len(m.filteredItems) {
@@ -1208,6 +1681,44 @@ func (m *Model) filterItems() {
 	}
 }
 
+// filterResult remembers a filtered query so typing a search doesn't re-run it
+type filterResult struct {
+	mode       string
+	generation uint64 // Cache generation the query was run at
+	items      []storage.ClipboardItemMeta
+}
+
+// contentFilterItems returns the items matching filterMode using the indexed storage
+// queries, re-running them only when the filter or the cached history changes
+func (m *Model) contentFilterItems() []storage.ClipboardItemMeta {
+	// The archive isn't indexed or cached, filter it in memory
+	if m.archiveMode || m.storage == nil || m.cache == nil {
+		return m.applyContentFilter(m.items)
+	}
+
+	generation := m.cache.Generation()
+	if m.filterResult != nil && m.filterResult.mode == m.filterMode && m.filterResult.generation == generation {
+		return m.filterResult.items
+	}
+
+	var items []storage.ClipboardItemMeta
+	switch m.filterMode {
+	case "images":
+		items = m.storage.GetImages()
+	case "security-high":
+		items = m.storage.GetByThreatLevel("high")
+	case "security-medium":
+		items = m.storage.GetByThreatLevel("medium")
+	case "security-safe":
+		items = m.storage.GetSafeMarked()
+	default:
+		return m.items
+	}
+
+	m.filterResult = &filterResult{mode: m.filterMode, generation: generation, items: items}
+	return items
+}
+
 // applyContentFilter filters items based on content type or security status
 func (m *Model) applyContentFilter(items []storage.ClipboardItemMeta) []storage.ClipboardItemMeta {
 	var filtered []storage.ClipboardItemMeta
@@ -1248,8 +1759,18 @@ func (m *Model) applyContentFilter(items []storage.ClipboardItemMeta) []storage.
 	return filtered
 }
 
-// applySearchFilter applies fuzzy search filtering to items
+// applySearchFilter applies the query's field terms (type:image, tag:work, ...) and
+// fuzzy search filtering of its free text to items
 func (m *Model) applySearchFilter(items []storage.ClipboardItemMeta) []storage.ClipboardItemMeta {
+	query, err := storage.ParseQuery(m.searchQuery)
+	m.queryError = err
+	if len(query.Terms) > 0 {
+		items = m.applyQueryTerms(items, query)
+	}
+	if query.Text == "" {
+		return items
+	}
+
 	// When searching, only include text items (images can't be searched)
 	var textItems []storage.ClipboardItemMeta
 	var searchTargets []string
@@ -1261,11 +1782,11 @@ func (m *Model) applySearchFilter(items []storage.ClipboardItemMeta) []storage.C
 		}
 	}
 
-	matches := fuzzy.Find(m.searchQuery, searchTargets)
+	matches := fuzzy.Find(query.Text, searchTargets)
 
 	// Filter out weak matches by checking if the search term actually appears in the content
 	var filteredMatches []storage.ClipboardItemMeta
-	lowerQuery := strings.ToLower(m.searchQuery)
+	lowerQuery := strings.ToLower(query.Text)
 
 	for _, match := range matches {
 		item := textItems[match.Index]
@@ -1280,46 +1801,77 @@ func (m *Model) applySearchFilter(items []storage.ClipboardItemMeta) []storage.C
 
 type editCompleteMsg struct {
 	editedItemID string
+	watch        *editWatch // Set when the editor detached and the file is still being watched
+	change       *undoEntry // Set when the content was changed
+	written      ownWrite
+	err          error
 }
 
 func (m *Model) editEntry(item storage.ClipboardItem) tea.Cmd {
 	// Create temporary file with the content
-	tmpFile, err := ioutil.TempFile("", "clip-edit-*.txt")
+	tmpFile, err := m.temp.create("edit-*.txt")
 	if err != nil {
-		return tea.Cmd(func() tea.Msg { return nil })
+		return func() tea.Msg { return errorToast("edit", err) }
 	}
 
 	tmpFile.WriteString(item.Content)
 	tmpFile.Close()
 	tmpFilePath := tmpFile.Name()
 
-	// Get editor from config, environment, or use default
-	editor := m.config.Editor.TextEditor
-	if envEditor := os.Getenv("EDITOR"); envEditor != "" {
-		editor = envEditor
+	// Get editor from environment or config
+	editorCmd, err := m.textEditorCommand(tmpFilePath)
+	if err != nil {
+		os.Remove(tmpFilePath)
+		return func() tea.Msg { return errorToast("edit", err) }
 	}
 
-	return tea.ExecProcess(exec.Command(editor, tmpFilePath), func(err error) tea.Msg {
+	started := time.Now()
+	return tea.ExecProcess(editorCmd, func(err error) tea.Msg {
 		// After editing, read the file and add to storage
-		defer os.Remove(tmpFilePath)
+		var watch *editWatch
+		defer func() {
+			if watch == nil {
+				os.Remove(tmpFilePath)
+			}
+		}()
+
+		if err != nil {
+			return editCompleteMsg{editedItemID: item.ID, err: err}
+		}
 
 		content, readErr := ioutil.ReadFile(tmpFilePath)
 		if readErr != nil {
-			return editCompleteMsg{}
+			return editCompleteMsg{editedItemID: item.ID, err: readErr}
 		}
 
 		newContent := strings.TrimSpace(string(content))
 		originalContent := strings.TrimSpace(item.Content)
 
+		if newContent == originalContent && time.Since(started) < detachedEditorThreshold {
+			// A GUI editor handed the file to a running instance; watch it for saves instead
+			watch = newEditWatch(tmpFilePath, item, content, nil)
+			return editCompleteMsg{editedItemID: item.ID, watch: watch}
+		}
+
 		if newContent != originalContent && newContent != "" {
 			// Update the existing entry instead of creating a new one
-			m.storage.Update(item.ID, newContent)
+			var change *undoEntry
+			written, updateErr := trackWrite(m.storage, func() (err error) {
+				change, err = updateContent(m.storage, item.ID, newContent)
+				return err
+			})
+			if updateErr != nil {
+				return editCompleteMsg{editedItemID: item.ID, err: updateErr}
+			}
+			return editCompleteMsg{editedItemID: item.ID, change: change, written: written}
 		}
 
 		return editCompleteMsg{editedItemID: item.ID}
 	})
 }
 
+// editImage opens the image in the configured editor and watches the temp file, so that
+// saves can be offered for import while the editor is running
 func (m *Model) editImage(item storage.ClipboardItem) tea.Cmd {
 	if len(item.ImageData) == 0 {
 		return tea.Cmd(func() tea.Msg { return nil })
@@ -1327,11 +1879,9 @@ func (m *Model) editImage(item storage.ClipboardItem) tea.Cmd {
 
 	return tea.Cmd(func() tea.Msg {
 		// Create temporary image file
-		tmpFile, err := ioutil.TempFile("", "nclip-image-*.png")
+		tmpFile, err := m.temp.create("image-*.png")
 		if err != nil {
-			// Debug: write error to file
-			os.WriteFile("/tmp/nclip_editor_debug.txt", []byte(fmt.Sprintf("Failed to create temp file: %v\n", err)), 0644)
-			return nil
+			return errorToast("image edit", err)
 		}
 
 		// Write image data to temporary file
@@ -1341,35 +1891,32 @@ func (m *Model) editImage(item storage.ClipboardItem) tea.Cmd {
 
 		if writeErr != nil {
 			os.Remove(tmpFilePath)
-			os.WriteFile("/tmp/nclip_editor_debug.txt", []byte(fmt.Sprintf("Failed to write image data: %v\n", writeErr)), 0644)
-			return nil
+			return errorToast("image edit", writeErr)
 		}
 
 		// Get image editor from config
 		imageEditor := m.config.Editor.ImageEditor
-
-		// Debug: log what we're trying to execute
-		debugInfo := fmt.Sprintf("Attempting to launch: %s %s\nTemp file exists: %v\nFile size: %d bytes\n",
-			imageEditor, tmpFilePath, fileExists(tmpFilePath), len(item.ImageData))
+		logging.Debug("Image edit: launching %s %s (%d bytes)", imageEditor, tmpFilePath, len(item.ImageData))
 
 		// Launch GUI image editor in background (non-blocking)
-		cmd := exec.Command(imageEditor, tmpFilePath)
-		err = cmd.Start() // Use Start() instead of Run() to not block
+		cmd, err := editorCommand(imageEditor, tmpFilePath)
+		if err == nil {
+			err = cmd.Start() // Use Start() instead of Run() to not block
+		}
 		if err != nil {
-			debugInfo += fmt.Sprintf("Launch failed: %v\n", err)
-			os.WriteFile("/tmp/nclip_editor_debug.txt", []byte(debugInfo), 0644)
 			os.Remove(tmpFilePath)
-			return nil
+			return errorToast("image edit", err)
 		}
 
-		debugInfo += "Launch successful!\n"
-		os.WriteFile("/tmp/nclip_editor_debug.txt", []byte(debugInfo), 0644)
-
-		// Note: We don't wait for the editor to close or monitor file changes
-		// The user can manually add the edited image back to clipboard if needed
-		// This keeps the TUI responsive and handles GUI apps properly
+		logging.Debug("Image edit: %s started with pid %d", imageEditor, cmd.Process.Pid)
 
-		return nil
+		// Watch the file until the editor exits; each save is offered for import
+		done := make(chan struct{})
+		go func() {
+			cmd.Wait()
+			close(done)
+		}()
+		return newEditWatch(tmpFilePath, item, item.ImageData, done).next()
 	})
 }
 
@@ -1380,9 +1927,9 @@ func (m *Model) openImageInViewer(item storage.ClipboardItem) tea.Cmd {
 
 	return tea.Cmd(func() tea.Msg {
 		// Create temporary image file
-		tmpFile, err := ioutil.TempFile("", "nclip-view-*.png")
+		tmpFile, err := m.temp.create("view-*.png")
 		if err != nil {
-			return nil
+			return errorToast("open image", err)
 		}
 
 		// Write image data to temporary file
@@ -1392,18 +1939,20 @@ func (m *Model) openImageInViewer(item storage.ClipboardItem) tea.Cmd {
 
 		if writeErr != nil {
 			os.Remove(tmpFilePath)
-			return nil
+			return errorToast("open image", writeErr)
 		}
 
 		// Get image viewer from config
 		imageViewer := m.config.Editor.ImageViewer
 
 		// Launch image viewer in background (non-blocking)
-		cmd := exec.Command(imageViewer, tmpFilePath)
-		err = cmd.Start() // Use Start() instead of Run() to not block
+		cmd, err := editorCommand(imageViewer, tmpFilePath)
+		if err == nil {
+			err = cmd.Start() // Use Start() instead of Run() to not block
+		}
 		if err != nil {
 			os.Remove(tmpFilePath)
-			return nil
+			return errorToast("open image", err)
 		}
 
 		// Start a background goroutine to clean up the temp file after viewer exits
@@ -1416,45 +1965,11 @@ func (m *Model) openImageInViewer(item storage.ClipboardItem) tea.Cmd {
 	})
 }
 
-func (m *Model) dumpImageDebug(item storage.ClipboardItem) tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		// Create debug info
-		var debug strings.Builder
-		debug.WriteString("=== IMAGE DEBUG INFO ===\n")
-		debug.WriteString(fmt.Sprintf("Terminal: %s\n", os.Getenv("TERM_PROGRAM")))
-		debug.WriteString(fmt.Sprintf("Kitty support: %v\n", detectKittySupport()))
-		debug.WriteString(fmt.Sprintf("Image data size: %d bytes\n", len(item.ImageData)))
-		debug.WriteString(fmt.Sprintf("Content type: %s\n", item.ContentType))
-		debug.WriteString(fmt.Sprintf("Description: %s\n", item.Content))
-
-		// Get image dimensions and format
-		if len(item.ImageData) > 0 {
-			width, height, format, err := getImageDimensions(item.ImageData)
-			if err != nil {
-				debug.WriteString(fmt.Sprintf("Image format detection failed: %v\n", err))
-			} else {
-				debug.WriteString(fmt.Sprintf("Image format: %s\n", format))
-				debug.WriteString(fmt.Sprintf("Image dimensions: %dx%d pixels\n", width, height))
-			}
-		}
-
-		debug.WriteString(fmt.Sprintf("Terminal size: %dx%d\n", m.width, m.height))
-		debug.WriteString("========================\n")
-
-		// Write to file
-		debugFile := "/tmp/nclip_debug.txt"
-		err := os.WriteFile(debugFile, []byte(debug.String()), 0644)
-		if err == nil {
-			fmt.Printf("\nDebug info written to %s\n", debugFile)
-		} else {
-			fmt.Printf("\nFailed to write debug file: %v\n", err)
-		}
-
-		return nil
-	})
-}
-
 func (m Model) View() string {
+	if m.showDebugOverlay {
+		return m.renderDebugOverlay()
+	}
+
 	// Handle special modes with their own rendering
 	if m.currentMode == modeImageView && m.viewingImage != nil {
 		return m.renderImageView()
@@ -1472,6 +1987,34 @@ func (m Model) View() string {
 		return m.renderHelp()
 	}
 
+	if m.currentMode == modeRescanReview {
+		return m.renderRescanReview()
+	}
+
+	if m.currentMode == modeHashStore {
+		return m.renderHashView()
+	}
+
+	if m.currentMode == modePending {
+		return m.renderPendingView()
+	}
+
+	if m.currentMode == modeHostInfo {
+		return m.renderHostInfo()
+	}
+
+	if m.currentMode == modeStats {
+		return m.renderStatsView()
+	}
+
+	if m.currentMode == modeSpellCheck {
+		return m.renderSpellView()
+	}
+
+	if m.currentMode == modeFormat {
+		return m.renderFormatMenu()
+	}
+
 	if m.currentMode == modeTextView {
 		return m.renderTextView()
 	}
@@ -1486,40 +2029,86 @@ func (m Model) renderMainWindow() string {
 	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
 
 	// Create header text
+	title := "Clipboard Manager"
+	if m.archiveMode {
+		title = "Clipboard Archive"
+	}
 	var headerText string
-	if m.currentMode == modeSearch {
+	if m.currentMode == modeNote {
+		headerText = title + " - Note: " + m.noteInputDisplay()
+	} else if m.currentMode == modeSearch {
 		// In search mode, always show filter with cursor
-		headerText = "Clipboard Manager - Filter: " + m.searchQuery + "█"
+		headerText = title + " - Filter: " + m.searchInputDisplay()
+		if m.queryError != nil {
+			headerText += " (" + m.queryError.Error() + ")"
+		}
 	} else if m.searchQuery != "" {
 		// Has active filter but not in search mode
-		headerText = "Clipboard Manager - Filter: " + m.searchQuery + " (press 'c' to clear)"
+		headerText = title + " - Filter: " + m.displayOrder(m.searchQuery) + " (press 'c' to clear)"
 	} else {
-		headerText = "Clipboard Manager"
+		headerText = title
 	}
 
 	// Add delete confirmation to header if needed
 	if m.currentMode == modeConfirmDelete && m.deleteCandidate != nil {
-		preview := m.deleteCandidate.Content
-		if len(preview) > 30 {
-			preview = preview[:27] + "..."
-		}
-		preview = strings.ReplaceAll(preview, "\n", " ")
+		preview := sanitizeForDisplay(m.deleteCandidate.Content)
+		preview = truncateWithEllipsis(strings.ReplaceAll(preview, "\n", " "), 30)
 		headerText += " - Delete: " + preview
 	}
 
-	// Build main content area (scrolling content only)
+	if m.currentMode == modeConfirmPanic {
+		if m.typedWipe() {
+			headerText += " - PANIC: type " + config.WipeConfirmWord + " to wipe: " + m.confirmInput + "█"
+		} else {
+			headerText += " - PANIC: wipe unpinned history?"
+		}
+	}
+
+	if m.currentMode == modeConfirmBulk {
+		headerText += " - " + m.bulkPending.prompt(len(m.bulkTargetIDs()))
+	}
+
+	if m.currentMode == modeList && m.pendingImport != nil {
+		headerText += " - Edit saved: y import, n dismiss"
+	}
+
+	if m.currentMode == modeList && m.compareFrom != nil {
+		preview := sanitizeForDisplay(m.compareFrom.Content)
+		preview = truncateWithEllipsis(strings.ReplaceAll(preview, "\n", " "), 30)
+		headerText += " - Compare: " + preview
+	}
+
+	// Build main content area (scrolling content only), beside the preview in the split layout
 	mainContent := m.buildMainContent(contentWidth, contentHeight)
+	if listWidth, previewWidth := m.layout.split(contentWidth); previewWidth > 0 {
+		mainContent = m.buildSplitContent(listWidth, previewWidth, contentHeight)
+	}
 
 	// Create footer text
 	var footerText string
 	switch m.currentMode {
 	case modeConfirmDelete:
 		footerText = "Press 'x' again to delete, any other key to cancel"
+	case modeConfirmPanic:
+		footerText = "Press '!' again to clear the clipboard and wipe unpinned history, any other key to cancel"
+		if m.typedWipe() {
+			footerText = "Type '" + config.WipeConfirmWord + "' and press enter to clear the clipboard and wipe unpinned history | esc: cancel"
+		}
+	case modeConfirmBulk:
+		footerText = fmt.Sprintf("Press '%s' again to confirm, any other key to cancel", m.bulkPending.key())
 	case modeSearch:
 		footerText = "type filter text | enter: apply filter | esc: cancel"
+	case modeNote:
+		footerText = "type a note | enter: save | alt+enter: new line | esc: cancel"
 	default:
 		// Build base footer text
 		baseFooter := "enter: copy | x: delete | v: view | e: edit | ?: help"
+		if m.archiveMode {
+			baseFooter = "enter: copy | r: restore | x: delete | v: view | ?: help"
+		}
+		if m.compareFrom != nil {
+			baseFooter = "V: compare with the selected item | esc: cancel"
+		}
 		
 		// Add filter status if active using proper formatting
 		var filterIndicator string
@@ -1533,7 +2122,27 @@ func (m Model) renderMainWindow() string {
 		case "security-safe":
 			filterIndicator = "[SAFE ITEMS ONLY]"
 		}
+		if m.sortBySize {
+			if filterIndicator != "" {
+				filterIndicator += " "
+			}
+			filterIndicator += "[LARGEST FIRST]"
+		}
+		if m.sortByLanguage {
+			if filterIndicator != "" {
+				filterIndicator += " "
+			}
+			filterIndicator += "[BY LANGUAGE]"
+		}
 		
+		// Operation status shares the bracketed indicator section with the filter
+		if status := m.statusIndicator(); status != "" {
+			if filterIndicator != "" {
+				filterIndicator += " "
+			}
+			filterIndicator += status
+		}
+
 		// Build footer text properly
 		if filterIndicator != "" {
 			footerText = baseFooter + " | " + filterIndicator
@@ -1550,7 +2159,7 @@ func (m Model) renderMainWindow() string {
 }
 
 
-// wrapText wraps text to fit within the given width, up to maxLines
+// wrapText wraps text to fit within the given width in cells, up to maxLines
 func wrapText(text string, width int, maxLines int) []string {
 	if width <= 0 {
 		width = 80 // fallback width
@@ -1560,32 +2169,17 @@ func wrapText(text string, width int, maxLines int) []string {
 	remaining := text
 
 	for len(remaining) > 0 && len(lines) < maxLines {
-		if len(remaining) <= width {
-			lines = append(lines, remaining)
-			break
-		}
-
-		// Find the best break point within the width
-		breakPoint := width
-
-		// Try to break at a space if possible
-		for i := width - 1; i >= width/2; i-- {
-			if i < len(remaining) && remaining[i] == ' ' {
-				breakPoint = i
-				break
-			}
-		}
-
-		lines = append(lines, remaining[:breakPoint])
-		remaining = strings.TrimLeft(remaining[breakPoint:], " ")
+		var line string
+		line, remaining = splitAtWidth(remaining, width)
+		lines = append(lines, line)
 	}
 
 	// If there's still text remaining and we've hit maxLines, add ellipsis
 	if len(remaining) > 0 && len(lines) == maxLines {
 		if len(lines) > 0 {
 			lastLine := lines[len(lines)-1]
-			if len(lastLine) > 3 {
-				lines[len(lines)-1] = lastLine[:len(lastLine)-3] + "..."
+			if lastWidth := stringWidth(lastLine); lastWidth > 3 {
+				lines[len(lines)-1] = truncateToWidth(lastLine, lastWidth-3) + "..."
 			}
 		}
 	}
@@ -1607,6 +2201,22 @@ func (m Model) renderSimpleImageView() string {
 	// Use standard dialog dimensions (consistent with all other views)
 	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
 
+	// Draw a rough text preview so the image can still be recognized
+	preview, err := m.imagePreviewLines(contentWidth, contentHeight-2)
+	if err == nil {
+		headerText = "Image View - Preview"
+		if width, height, format, err := getImageDimensions(m.viewingImage.ImageData); err == nil {
+			headerText = fmt.Sprintf("Image View - Preview (%dx%d %s, %d bytes)",
+				width, height, strings.ToUpper(format), len(m.viewingImage.ImageData))
+		}
+		headerText += m.sourceSuffix()
+		note := fmt.Sprintf("No graphics support in this terminal; 'o' opens the image in %s", m.config.Editor.ImageViewer)
+		contentLines := centerPreview(preview, contentWidth, contentHeight-2)
+		contentLines = append(contentLines, "", truncateWithEllipsis(note, contentWidth))
+		return m.renderSimpleImageFrame(headerText, contentLines, dialogWidth, dialogHeight, contentWidth, contentHeight)
+	}
+	logging.Debug("Image preview unavailable: %v", err)
+
 	// Build content lines
 	contentLines := []string{
 		"Your terminal does not support the Kitty graphics protocol.",
@@ -1616,11 +2226,16 @@ func (m Model) renderSimpleImageView() string {
 		fmt.Sprintf("'o' open image in external viewer (%s)", m.config.Editor.ImageViewer),
 		"'enter' copy image to clipboard",
 		"'e' edit in external editor",
+		"'a' annotate and import the result",
 		"'d' delete image from database",
 		"",
 		fmt.Sprintf("Image: %d bytes", len(m.viewingImage.ImageData)),
 	}
+	return m.renderSimpleImageFrame(headerText, contentLines, dialogWidth, dialogHeight, contentWidth, contentHeight)
+}
 
+// renderSimpleImageFrame frames the lines shown for images on terminals without graphics support
+func (m Model) renderSimpleImageFrame(headerText string, contentLines []string, dialogWidth, dialogHeight, contentWidth, contentHeight int) string {
 	// Build content and pad to fill content area (like help view does)
 	var contentBuilder strings.Builder
 	for _, line := range contentLines {
@@ -1641,7 +2256,7 @@ func (m Model) renderSimpleImageView() string {
 	if m.imageDeletePending {
 		footerText = "Press 'x' again to confirm deletion, any other key to cancel"
 	} else {
-		footerText = "enter: copy | x: delete | e: edit | o: open"
+		footerText = "enter: copy | x: delete | e: edit | a: annotate | o: open"
 	}
 
 	// Build frame content using standard function (like help view)
@@ -1652,9 +2267,10 @@ func (m Model) renderSimpleImageView() string {
 
 // scaleImageForFrame scales an image to fit within the frame if necessary
 func (m Model) scaleImageForFrame(imageData []byte, imgWidth, imgHeight, frameWidth, frameHeight int) []byte {
-	// Convert frame dimensions to approximate pixels
-	frameWidthPixels := frameWidth * 8    // Conservative estimate
-	frameHeightPixels := frameHeight * 16 // Conservative estimate
+	// Convert frame dimensions to pixels
+	cellWidth, cellHeight := cellPixelSize()
+	frameWidthPixels := frameWidth * cellWidth
+	frameHeightPixels := frameHeight * cellHeight
 
 	// Check if scaling is needed
 	if imgWidth <= frameWidthPixels && imgHeight <= frameHeightPixels {
@@ -1849,13 +2465,20 @@ func (m Model) getSecurityViewLines() []string {
 		return []string{"[No content available]"}
 	}
 
+	// Show any escape sequences as text so the content can be inspected safely
+	content = escapeControlSequences(content)
+
 	// Apply security highlighting if threats are detected
 	if len(m.securityThreats) > 0 {
 		content = m.highlightSecurityThreats(content)
 	}
 
-	// Split content into lines and apply theme styling
-	rawLines := strings.Split(content, "\n")
+	// Split content into lines below the rules that matched it, and apply theme styling
+	var rawLines []string
+	for _, line := range m.securityExplanationLines() {
+		rawLines = append(rawLines, escapeControlSequences(line))
+	}
+	rawLines = append(rawLines, strings.Split(content, "\n")...)
 	warningStyles := m.themeService.GetWarningStyles()
 	lines := make([]string, len(rawLines))
 	for i, line := range rawLines {
@@ -2118,7 +2741,7 @@ func (m Model) calculateLinesUpToCursor() int {
 
 		// Add separator line (except for last item)
 		if i < len(m.filteredItems)-1 {
-			totalLines++
+			totalLines += m.itemSeparatorLines()
 		}
 	}
 
@@ -2153,7 +2776,7 @@ func (m Model) canFitCursorFromStart(start int, contentHeight int) bool {
 
 		// Add separator line (except for last item)
 		if i < len(m.filteredItems)-1 && linesUsed < contentHeight {
-			linesUsed++
+			linesUsed += m.itemSeparatorLines()
 		}
 	}
 
@@ -2174,9 +2797,9 @@ func (m Model) calculateItemLines(item storage.ClipboardItem, availableWidth int
 	}
 
 	// Handle multiline content
-	lines := strings.Split(item.Content, "\n")
+	lines := m.previewSourceLines(item.Content)
 	isMultiline := len(lines) > 1
-	maxLines := 5
+	maxLines := m.previewLineLimit()
 
 	var displayLines []string
 	if isMultiline {
@@ -2198,9 +2821,9 @@ func (m Model) calculateItemLines(item storage.ClipboardItem, availableWidth int
 			displayLines = append(displayLines, wrappedLines...)
 		}
 	} else {
-		contentWithIcon := item.Content
+		contentWithIcon := lines[0]
 		if securityIcon != "" {
-			contentWithIcon = securityIcon + " " + item.Content
+			contentWithIcon = securityIcon + " " + lines[0]
 		}
 		displayLines = wrapText(contentWithIcon, availableWidth, maxLines)
 	}
@@ -2214,7 +2837,7 @@ func (m Model) isHighRiskSecurityContent(item storage.ClipboardItem) bool {
 		return false
 	}
 
-	detector := security.NewSecurityDetector()
+	detector := security.DefaultDetector()
 	threats := detector.DetectSecurity(item.Content)
 	return security.IsHighRiskThreat(threats)
 }
@@ -2356,9 +2979,7 @@ func (m Model) renderHelp() string {
 		helpViewStyles := m.themeService.GetViewStyles("help")
 		for i := 0; i < contentHeight && i < len(helpLines); i++ {
 			line := helpLines[i]
-			if len(line) > contentWidth {
-				line = line[:contentWidth-3] + "..."
-			}
+			line = truncateWithEllipsis(line, contentWidth)
 			// Apply proper styling with width for consistency
 			styledLine := helpViewStyles.Text.Width(contentWidth).Render(line)
 			helpContent.WriteString(styledLine)
@@ -2388,14 +3009,53 @@ func (m Model) renderHelp() string {
 
 // getTextViewLines splits the text content into lines for viewing with syntax highlighting
 func (m Model) getTextViewLines() []string {
+	entry, _ := m.textViewEntry()
+	return entry.lines
+}
+
+// computeTextViewLines highlights (when highlight is set) and wraps the viewed text
+func (m Model) computeTextViewLines(highlight bool) textLinesEntry {
 	if m.viewingText == nil {
-		return []string{}
+		return textLinesEntry{lines: []string{}}
 	}
 
-	content := m.viewingText.Content
+	// Use standard dialog dimensions for consistent content width
+	_, _, contentWidth, _ := m.calculateDialogDimensions()
+	content, language := m.textViewContent()
+	return m.computeTextLines(content, contentWidth, language, m.showRawANSI, highlight)
+}
+
+// computeTextLines highlights (when highlight is set) and wraps text to contentWidth.
+// languageOverride forces a language as in the text view, "" detects it.
+func (m Model) computeTextLines(content string, contentWidth int, languageOverride string, showRawANSI bool, highlight bool) textLinesEntry {
+
+	// Terminal output keeps its colors, or shows its escape codes when raw is toggled on.
+	// Anything else that could move the cursor or draw on the screen is removed.
+	renderedANSI := false
+	switch {
+	case containsEscapes(content) && showRawANSI:
+		content = escapeControlSequences(content)
+	case containsEscapes(content) && !m.accessible:
+		content = renderSGROnly(content)
+		renderedANSI = true
+		highlight = false // Already colored
+	default:
+		content = sanitizeForDisplay(content)
+	}
 
 	// Detect if this is source code and apply syntax highlighting
-	language, isCode := m.codeDetector.DetectLanguage(content)
+	var language string
+	var isCode bool
+	if highlight && !m.accessible {
+		switch languageOverride {
+		case "":
+			language, isCode = m.codeDetector.DetectLanguage(content)
+		case plainTextLanguage:
+			// Highlighting explicitly turned off for this item
+		default:
+			language, isCode = languageOverride, true
+		}
+	}
 	
 	var lines []string
 	if isCode {
@@ -2417,8 +3077,6 @@ func (m Model) getTextViewLines() []string {
 		lines = strings.Split(content, "\n")
 	}
 
-	// Use standard dialog dimensions for consistent content width
-	_, _, contentWidth, _ := m.calculateDialogDimensions()
 	if contentWidth < 10 {
 		contentWidth = 10
 	}
@@ -2432,9 +3090,9 @@ func (m Model) getTextViewLines() []string {
 		if visibleLen <= contentWidth {
 			wrappedLines = append(wrappedLines, line)
 		} else {
-			// For syntax-highlighted code, prefer not to wrap to preserve formatting
-			// Instead, truncate with indication
-			if isCode {
+			// For syntax-highlighted code and colored output, prefer not to wrap to
+			// preserve formatting. Instead, truncate with indication
+			if isCode || renderedANSI {
 				truncated := m.truncateWithANSI(line, contentWidth-3) + "..."
 				wrappedLines = append(wrappedLines, truncated)
 			} else {
@@ -2444,88 +3102,36 @@ func (m Model) getTextViewLines() []string {
 		}
 	}
 
-	return wrappedLines
+	// Plain text is reordered after wrapping, since bidi reordering applies per display line
+	if !isCode && !renderedANSI {
+		for i, line := range wrappedLines {
+			wrappedLines[i] = m.displayOrder(line)
+		}
+	}
+
+	return textLinesEntry{language: language, isCode: isCode, colored: renderedANSI, lines: wrappedLines}
 }
 
-// calculateVisibleLength calculates the visible length of a string excluding ANSI escape codes
+// calculateVisibleLength calculates the visible width of a string in cells, excluding ANSI escape codes
 func (m Model) calculateVisibleLength(s string) int {
-	// Simple ANSI escape sequence removal for length calculation
-	// This regex matches basic ANSI escape sequences
-	ansiRegex := regexp.MustCompile(`\x1b\[[0-9;]*m`)
-	clean := ansiRegex.ReplaceAllString(s, "")
-	return len(clean)
+	return stringWidth(s)
 }
 
-// truncateWithANSI truncates a string with ANSI codes while preserving color formatting
+// truncateWithANSI truncates a string with ANSI codes to maxLen cells while preserving color formatting
 func (m Model) truncateWithANSI(s string, maxLen int) string {
-	if maxLen <= 0 {
-		return ""
-	}
-	
-	visibleLen := m.calculateVisibleLength(s)
-	if visibleLen <= maxLen {
-		return s
-	}
-	
-	// Parse the string character by character, tracking ANSI sequences
-	var result strings.Builder
-	var visibleCount int
-	inEscape := false
-	
-	runes := []rune(s)
-	for i := 0; i < len(runes); i++ {
-		r := runes[i]
-		
-		// Start of ANSI escape sequence
-		if r == '\x1b' && i+1 < len(runes) && runes[i+1] == '[' {
-			inEscape = true
-		}
-		
-		// Always include escape sequences (they don't count toward visible length)
-		if inEscape {
-			result.WriteRune(r)
-			// End of escape sequence
-			if r == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		
-		// Regular character - check if we have room
-		if visibleCount >= maxLen {
-			break
-		}
-		
-		result.WriteRune(r)
-		visibleCount++
-	}
-	
-	return result.String()
+	return truncateToWidth(s, maxLen)
 }
 
 // wrapLongLine wraps a long line at word boundaries
 func (m Model) wrapLongLine(line string, contentWidth int) []string {
 	var wrapped []string
-	
-	for len(line) > 0 {
-		if len(line) <= contentWidth {
-			wrapped = append(wrapped, line)
-			break
-		}
-
-		// Find best break point
-		breakPoint := contentWidth
-		for i := contentWidth - 1; i >= contentWidth/2 && i < len(line); i-- {
-			if line[i] == ' ' {
-				breakPoint = i
-				break
-			}
-		}
 
-		wrapped = append(wrapped, line[:breakPoint])
-		line = strings.TrimLeft(line[breakPoint:], " ")
+	for len(line) > 0 {
+		var head string
+		head, line = splitAtWidth(line, contentWidth)
+		wrapped = append(wrapped, head)
 	}
-	
+
 	return wrapped
 }
 
@@ -2583,8 +3189,7 @@ func (m Model) renderTextView() string {
 	}
 
 	// Create title with security marking, length info and syntax highlighting status
-	lineCount := len(strings.Split(m.viewingText.Content, "\n"))
-	charCount := len(m.viewingText.Content)
+	stats := m.viewingStats.summary()
 	
 	// Build header with proper Lipgloss composition
 	var headerText string
@@ -2593,15 +3198,20 @@ func (m Model) renderTextView() string {
 	securityIcon := m.getThemedSecurityIcon(*m.viewingText)
 	
 	// Check if syntax highlighting was applied
-	language, isCode := m.codeDetector.DetectLanguage(m.viewingText.Content)
+	textEntry, highlighted := m.textViewEntry()
 	
 	// Build the text part of the header
 	var headerTextPart string
-	if isCode {
-		headerTextPart = fmt.Sprintf("Text View - %s (%d lines, %d chars)", strings.ToUpper(language), lineCount, charCount)
+	if !highlighted {
+		headerTextPart = fmt.Sprintf("Text View (%s) - highlighting...", stats)
+	} else if textEntry.isCode && m.languageOverride != "" {
+		headerTextPart = fmt.Sprintf("Text View - %s, forced (%s)", strings.ToUpper(textEntry.language), stats)
+	} else if textEntry.isCode {
+		headerTextPart = fmt.Sprintf("Text View - %s (%s)", strings.ToUpper(textEntry.language), stats)
 	} else {
-		headerTextPart = fmt.Sprintf("Text View (%d lines, %d chars)", lineCount, charCount)
+		headerTextPart = fmt.Sprintf("Text View (%s)", stats)
 	}
+	headerTextPart += m.sourceSuffix()
 	
 	// Compose header with proper styling
 	if securityIcon != "" {
@@ -2640,9 +3250,26 @@ func (m Model) renderTextView() string {
 	if m.textDeletePending {
 		footerText = "Press 'x' again to confirm deletion, any other key to cancel"
 	} else {
-		baseFooter := "enter: copy | x: delete | e: edit"
+		baseFooter := "enter: copy | h: copy sha256 | x: delete | e: edit | l: language"
+		if m.archiveMode {
+			baseFooter = "enter: copy | h: copy sha256 | x: delete"
+		}
+		if decoding, ok := m.viewerDecoding(); ok {
+			if m.showDecoded {
+				baseFooter += " | d: show encoded"
+			} else {
+				baseFooter += " | d: decode " + decoding.label
+			}
+		}
+		if containsEscapes(m.viewingText.Content) {
+			if m.showRawANSI {
+				baseFooter += " | a: show rendered"
+			} else {
+				baseFooter += " | a: show raw"
+			}
+		}
 		// Add security actions if this item has security warnings
-		if m.viewingText.ThreatLevel == "high" || m.viewingText.ThreatLevel == "medium" {
+		if !m.archiveMode && (m.viewingText.ThreatLevel == "high" || m.viewingText.ThreatLevel == "medium") {
 			baseFooter += " | s: mark as safe"
 		}
 		footerText = baseFooter + scrollInfo
@@ -2660,55 +3287,76 @@ func (m Model) renderTextView() string {
 // editTextViewEntry edits the text entry and returns to text view mode
 func (m *Model) editTextViewEntry(item storage.ClipboardItem) tea.Cmd {
 	// Create temporary file with the content
-	tmpFile, err := ioutil.TempFile("", "clip-textview-edit-*.txt")
+	tmpFile, err := m.temp.create("edit-*.txt")
 	if err != nil {
-		return tea.Cmd(func() tea.Msg { return nil })
+		return func() tea.Msg { return errorToast("edit", err) }
 	}
 
 	tmpFile.WriteString(item.Content)
 	tmpFile.Close()
 	tmpFilePath := tmpFile.Name()
 
-	// Get editor from config, environment, or use default
-	editor := m.config.Editor.TextEditor
-	if envEditor := os.Getenv("EDITOR"); envEditor != "" {
-		editor = envEditor
+	// Get editor from environment or config
+	editorCmd, err := m.textEditorCommand(tmpFilePath)
+	if err != nil {
+		os.Remove(tmpFilePath)
+		return func() tea.Msg { return errorToast("edit", err) }
 	}
 
-	return tea.ExecProcess(exec.Command(editor, tmpFilePath), func(err error) tea.Msg {
+	started := time.Now()
+	return tea.ExecProcess(editorCmd, func(err error) tea.Msg {
 		// After editing, read the file and update storage
-		defer os.Remove(tmpFilePath)
+		var watch *editWatch
+		defer func() {
+			if watch == nil {
+				os.Remove(tmpFilePath)
+			}
+		}()
+
+		if err != nil {
+			return textViewEditCompleteMsg{editedItemID: item.ID, err: err}
+		}
 
 		content, readErr := ioutil.ReadFile(tmpFilePath)
 		if readErr != nil {
-			return textViewEditCompleteMsg{editedItemID: item.ID, success: false}
+			return textViewEditCompleteMsg{editedItemID: item.ID, err: readErr}
 		}
 
 		newContent := strings.TrimSpace(string(content))
 		originalContent := strings.TrimSpace(item.Content)
 
-		if newContent != originalContent && newContent != "" {
-			// Update the existing entry
-			m.storage.Update(item.ID, newContent)
+		if newContent == originalContent && time.Since(started) < detachedEditorThreshold {
+			// A GUI editor handed the file to a running instance; watch it for saves instead
+			watch = newEditWatch(tmpFilePath, item, content, nil)
+			return textViewEditCompleteMsg{editedItemID: item.ID, watch: watch}
+		}
 
-			// Update the viewing text to show new content
-			updatedItem := item
-			updatedItem.Content = newContent
-			m.viewingText = &updatedItem
+		if newContent == originalContent || newContent == "" {
+			return textViewEditCompleteMsg{editedItemID: item.ID, success: false}
+		}
 
-			// Refresh main items list
-			m.cache.ForceRefresh()
-			m.items = m.cache.GetAllMeta()
-			m.filterItems()
+		// Update the existing entry; the model applies the new content when the message arrives
+		var change *undoEntry
+		written, updateErr := trackWrite(m.storage, func() (err error) {
+			change, err = updateContent(m.storage, item.ID, newContent)
+			return err
+		})
+		if updateErr != nil {
+			return textViewEditCompleteMsg{editedItemID: item.ID, err: updateErr}
 		}
 
-		return textViewEditCompleteMsg{editedItemID: item.ID, success: true}
+		return textViewEditCompleteMsg{editedItemID: item.ID, success: true, content: newContent, change: change, written: written}
 	})
 }
 
 type textViewEditCompleteMsg struct {
 	editedItemID string
 	success      bool
+	content      string     // Updated content when success is true
+	watch        *editWatch // Set when the editor detached and the file is still being watched
+	change       *undoEntry // Set when success is true
+	written      ownWrite
+	err          error
 }
 
 // generateHelpContent creates the help text lines
@@ -2726,9 +3374,19 @@ func (m Model) generateHelpContent() []string {
 	lines = append(lines, "  g                Go to first item")
 	lines = append(lines, "  G                Go to last item")
 	lines = append(lines, "  pgup/pgdown      Page up/down through items")
-	lines = append(lines, "  Enter        Copy selected item to clipboard and exit")
+	if m.stayOpen {
+		lines = append(lines, "  Enter        Copy selected item to clipboard")
+	} else {
+		lines = append(lines, "  Enter        Copy selected item to clipboard and exit")
+	}
+	lines = append(lines, "  alt+enter    Open links and images, view text ([keys] in nclip.toml sets both)")
 	lines = append(lines, "  q / Ctrl+C   Quit the application")
+	lines = append(lines, "  L            Cycle centered, full screen and split list/preview layouts")
 	lines = append(lines, "  ?            Show this help screen")
+	lines = append(lines, "  T            Show history totals and usage insights (behavior.usage_insights)")
+	if m.debugMode {
+		lines = append(lines, "  F12          Toggle the debug overlay")
+	}
 	lines = append(lines, "")
 
 	// Search and Filtering
@@ -2743,12 +3401,17 @@ func (m Model) generateHelpContent() []string {
 	lines = append(lines, "    h            Show only high-risk security items")
 	lines = append(lines, "    m            Show only medium-risk security items")
 	lines = append(lines, "    s            Show only safe security items")
+	lines = append(lines, "    z            Sort the largest items first, showing their sizes")
+	lines = append(lines, "    l            Group text by natural language (English, German, ...)")
 	lines = append(lines, "")
 	lines = append(lines, "  In search mode:")
 	lines = append(lines, "    Type         Filter items in real-time")
 	lines = append(lines, "    Enter        Apply filter and return to list")
 	lines = append(lines, "    Esc          Cancel search and clear filter")
 	lines = append(lines, "    Backspace    Delete characters from search")
+	lines = append(lines, "    ←/→ Home/End Move the cursor within the search")
+	lines = append(lines, "    field:value  type:image threat:high tag:work app:firefox lang:python pinned:yes")
+	lines = append(lines, "                 before:2025-01-01 after:7d size:>1mb natlang:de; prefix - to exclude")
 	lines = append(lines, "")
 
 	// Content Operations
@@ -2757,8 +3420,27 @@ func (m Model) generateHelpContent() []string {
 	lines = append(lines, "  Basic content operations:")
 	lines = append(lines, "    v            View text/image in full-screen viewer")
 	lines = append(lines, "    e            Edit selected item in external editor")
+	lines = append(lines, "    a            Annotate selected image and import the result")
+	lines = append(lines, "    y / n        Import or dismiss content saved in an external editor")
 	lines = append(lines, "    x            Delete item (press 'x' again to confirm)")
+	lines = append(lines, "    X            Copy and remove: copy the item, then delete it from history")
+	lines = append(lines, "    w            Copy a link without tracking parameters, resolving shorteners")
+	lines = append(lines, "    F            Format as: copy text wrapped in a template (Markdown link, code block, ...)")
+	lines = append(lines, "    V            Compare a checksum with a file path or second checksum (V on each)")
+	lines = append(lines, "    C            Spell check text, then save the corrected version as a new entry and copy it")
+	lines = append(lines, "    I            Host info for an IP or hostname: reverse DNS, whois, hints (r refreshes)")
+	lines = append(lines, "    N            Type a note to self into the history (tagged note)")
 	lines = append(lines, "    p            Pin/unpin item to top of list")
+	lines = append(lines, "    P            Keep or discard content held back by capture mode \"ask\"")
+	lines = append(lines, "    Q            Rescue or discard quarantined content blocked by the capture rules")
+	lines = append(lines, "    Ctrl+Z       Undo the last delete, edit, pin or mark safe (Ctrl+R redoes it)")
+	lines = append(lines, "    !            Panic: clear clipboard, wipe unpinned history and archive")
+	lines = append(lines, "")
+	lines = append(lines, "  Security actions on the filtered items (press again to confirm):")
+	lines = append(lines, "    S / U        Mark all filtered items safe / unsafe")
+	lines = append(lines, "    R            Rescan the filtered items for secrets (esc cancels)")
+	lines = append(lines, "                 Upgraded items are then reviewed one by one:")
+	lines = append(lines, "                 k keep, s mark safe, x delete, b block hash, esc stop")
 	lines = append(lines, "")
 	lines = append(lines, "  Quick access to pinned items:")
 	// Show pin icons based on terminal capabilities
@@ -2771,24 +3453,35 @@ func (m Model) generateHelpContent() []string {
 	lines = append(lines, "  In text view mode:")
 	lines = append(lines, "    up/down      Scroll through text content")
 	lines = append(lines, "    Enter        Copy text to clipboard and exit")
+	lines = append(lines, "    h            Copy the SHA-256 of the text and exit")
 	lines = append(lines, "    e            Edit text (returns to viewer after editing)")
 	lines = append(lines, "    x            Delete text from database")
 	lines = append(lines, "    s            Mark security-flagged item as safe")
+	lines = append(lines, "    l            Cycle the highlighting language (remembered per item)")
+	lines = append(lines, "    a            Show terminal output raw (escape codes) or rendered")
+	lines = append(lines, "    d            Decode a JWT (header and claims), base64 or hex item")
 	lines = append(lines, "    any other key Exit text viewer and return to list")
 	lines = append(lines, "")
 	lines = append(lines, "  In image view mode:")
 	lines = append(lines, "    Enter        Copy image to clipboard and exit")
 	lines = append(lines, "    o            Open image in external viewer")
 	lines = append(lines, "    e            Edit image in external editor")
+	lines = append(lines, "    a            Annotate image (satty/swappy/ksnip), save and copy the result")
 	lines = append(lines, "    x            Delete image from database")
 	lines = append(lines, "    any other key Exit image viewer and return to list")
 	lines = append(lines, "")
+	lines = append(lines, "  In archive mode (nclip --archive):")
+	lines = append(lines, "    r            Restore archived item to clipboard history")
+	lines = append(lines, "    x            Permanently delete item from archive")
+	lines = append(lines, "    e, a, p, 1-0 Not available for archived items")
+	lines = append(lines, "")
 
 	// Security Features
 	lines = append(lines, warningStyle.Render("SECURITY FEATURES"))
 	lines = append(lines, "")
 	lines = append(lines, "  Initialize content scanning:")
 	lines = append(lines, "    ctrl+s       Analyze current item for security threats")
+	lines = append(lines, "    B            List blocked content hashes (x twice removes one)")
 	lines = append(lines, "")
 	lines = append(lines, "  Security indicators automatically detect:")
 	lines = append(lines, "    - JWT tokens, API keys, SSH keys")
@@ -2854,10 +3547,11 @@ func (m *Model) initTextViewport() {
 	
 	// Set the content for the viewport
 	if m.viewingText != nil {
-		textLines := m.getTextViewLines()
+		textEntry, _ := m.textViewEntry()
+		textLines := textEntry.lines
 		
-		// Apply styling to each line before setting content
-		_, isCode := m.codeDetector.DetectLanguage(m.viewingText.Content)
+		// Apply styling to each line before setting content, keeping any colors it already has
+		isCode := textEntry.isCode || textEntry.colored
 		textViewStyles := m.themeService.GetViewStyles("text")
 		
 		var styledLines []string
@@ -3032,8 +3726,3 @@ func (m *Model) initWarningViewport() {
 	m.warningViewportReady = true
 }
 
-// fileExists checks if a file exists
-func fileExists(filename string) bool {
-	_, err := os.Stat(filename)
-	return !os.IsNotExist(err)
-}
@@ -241,6 +241,27 @@ func TestCreateStyle(t *testing.T) {
 	}
 }
 
+// newStorageTestModel returns a model listing contents, newest first, from a fresh
+// history in a temporary HOME
+func newStorageTestModel(t *testing.T, contents ...string) (Model, *storage.Storage) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	for _, content := range contents {
+		s.Add(content)
+	}
+
+	cache := storage.NewItemCache(s, 5)
+	m := Model{storage: s, cache: cache, items: cache.GetAllMeta(), opSpinner: newOpSpinner()}
+	m.filterItems()
+	return m, s
+}
+
 func TestNewModel(t *testing.T) {
 	// Test basic model creation with config
 	cfg := &config.Config{
@@ -277,6 +298,30 @@ func TestNewModel(t *testing.T) {
 	// In real usage, storage is always provided
 }
 
+func TestNewModel_UnknownChromaTheme(t *testing.T) {
+	if config.ChromaThemeNames() == nil {
+		t.Skip("syntax highlighting not compiled in")
+	}
+	t.Setenv("HOME", t.TempDir())
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	cfg := &config.Config{Theme: config.ThemeConfig{Chroma: config.ChromaConfig{Theme: "no-such-theme"}}}
+	m := NewModel(s, cfg, true)
+	if cfg.Theme.Chroma.Theme != "no-such-theme" {
+		t.Errorf("Expected the caller's configuration to be left alone, got %q", cfg.Theme.Chroma.Theme)
+	}
+	if m.themeService.config.Chroma.Theme != "" {
+		t.Errorf("Expected the default highlighting, got %q", m.themeService.config.Chroma.Theme)
+	}
+	if msg, ok := m.themeNotice()().(toastMsg); !ok || msg.level != toastWarning || !strings.Contains(msg.text, "no-such-theme") {
+		t.Errorf("Expected a warning toast about the theme, got %+v", msg)
+	}
+}
+
 func TestFilterItemsLogic(t *testing.T) {
 	// Test the filtering logic without requiring storage
 	items := []storage.ClipboardItem{
@@ -575,6 +620,50 @@ func TestApplyContentFilter(t *testing.T) {
 	}
 }
 
+// Test that content filters are served by storage queries and reused while searching
+func TestContentFilterItems_UsesStorage(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-ui-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("plain text")
+	s.AddImage([]byte("image data"), "screenshot")
+
+	cache := storage.NewItemCache(s, 5)
+	testModel := Model{storage: s, cache: cache, items: cache.GetAllMeta(), filterMode: "images"}
+	testModel.filterItems()
+
+	if len(testModel.filteredItems) != 1 || testModel.filteredItems[0].ContentType != "image" {
+		t.Fatalf("Expected only the image, got %+v", testModel.filteredItems)
+	}
+	first := testModel.filterResult
+
+	// Re-filtering without changes reuses the query result
+	testModel.filterItems()
+	if testModel.filterResult != first {
+		t.Error("Expected the filter query to be reused when nothing changed")
+	}
+
+	// A cache change re-runs the query
+	s.AddImage([]byte("more image data"), "another screenshot")
+	cache.ForceRefresh()
+	testModel.filterItems()
+	if len(testModel.filteredItems) != 2 {
+		t.Errorf("Expected 2 images after refresh, got %d", len(testModel.filteredItems))
+	}
+}
+
 // Test terminal capability detection
 func TestDetectTerminalCapabilities(t *testing.T) {
 	// Save original environment
@@ -639,3 +728,62 @@ func TestDetectTerminalCapabilities(t *testing.T) {
 	}
 }
 
+
+func TestApplySearchFilter_QueryTerms(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "nclip-ui-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("meeting notes for work")
+	s.Add("shopping list")
+	s.AddImage([]byte("image data"), "screenshot")
+
+	cache := storage.NewItemCache(s, 5)
+	items := cache.GetAllMeta()
+	for _, item := range items {
+		if item.Content == "meeting notes for work" {
+			if err := s.AddTag(item.ID, "work"); err != nil {
+				t.Fatalf("Failed to tag item: %v", err)
+			}
+		}
+	}
+
+	testModel := Model{storage: s, cache: cache, items: items, codeDetector: NewCodeDetector()}
+	filter := func(query string) []storage.ClipboardItemMeta {
+		testModel.searchQuery = query
+		testModel.filterItems()
+		return testModel.filteredItems
+	}
+
+	if got := filter("type:image"); len(got) != 1 || got[0].ContentType != "image" {
+		t.Errorf("Expected only the image for type:image, got %+v", got)
+	}
+	if got := filter("tag:work notes"); len(got) != 1 || got[0].Content != "meeting notes for work" {
+		t.Errorf("Expected the tagged item, got %+v", got)
+	}
+	if got := filter("tag:work shopping"); len(got) != 0 {
+		t.Errorf("Expected free text and terms to combine, got %+v", got)
+	}
+	if got := filter("-type:image list"); len(got) != 1 || got[0].Content != "shopping list" {
+		t.Errorf("Expected the shopping list, got %+v", got)
+	}
+
+	filter("type:video list")
+	if testModel.queryError == nil {
+		t.Error("Expected an error for an invalid type")
+	}
+	if len(testModel.filteredItems) != 1 {
+		t.Errorf("Expected the free text to still apply, got %+v", testModel.filteredItems)
+	}
+}
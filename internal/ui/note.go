@@ -0,0 +1,158 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// noteSavedMsg reports the result of storing a note typed in the quick-entry prompt
+type noteSavedMsg struct {
+	id      string
+	written ownWrite
+	err     error
+}
+
+// startNote opens the quick-entry prompt for a note to self
+func (m *Model) startNote() {
+	m.noteInput = ""
+	m.noteCursor = 0
+	m.currentMode = modeNote
+}
+
+// handleNoteKey edits the note being typed: enter saves it, alt+enter starts a new line
+// and esc discards it
+func (m *Model) handleNoteKey(msg tea.KeyMsg) tea.Cmd {
+	note := []rune(m.noteInput)
+	m.noteCursor = max(0, min(m.noteCursor, len(note)))
+
+	switch msg.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "esc":
+		m.currentMode = modeList
+		m.noteInput = ""
+		return nil
+	case "enter":
+		m.currentMode = modeList
+		if strings.TrimSpace(m.noteInput) == "" {
+			return nil
+		}
+		return m.addNoteCmd(m.noteInput)
+	case "alt+enter":
+		m.insertNoteText([]rune{'\n'})
+		return nil
+	case "left", "ctrl+b":
+		m.noteCursor = max(0, m.noteCursor-1)
+		return nil
+	case "right", "ctrl+f":
+		m.noteCursor = min(len(note), m.noteCursor+1)
+		return nil
+	case "home", "ctrl+a":
+		m.noteCursor = 0
+		return nil
+	case "end", "ctrl+e":
+		m.noteCursor = len(note)
+		return nil
+	case "backspace":
+		if m.noteCursor > 0 {
+			m.noteInput = string(append(note[:m.noteCursor-1], note[m.noteCursor:]...))
+			m.noteCursor--
+		}
+		return nil
+	case "delete", "ctrl+d":
+		if m.noteCursor < len(note) {
+			m.noteInput = string(append(note[:m.noteCursor], note[m.noteCursor+1:]...))
+		}
+		return nil
+	}
+
+	if msg.Alt || (msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace) {
+		return nil
+	}
+	// Pasted text may span lines; other control characters are dropped
+	var typed []rune
+	for _, r := range msg.Runes {
+		if !isControlRune(r) {
+			typed = append(typed, r)
+		}
+	}
+	m.insertNoteText(typed)
+	return nil
+}
+
+// insertNoteText inserts runes at the note cursor
+func (m *Model) insertNoteText(typed []rune) {
+	note := []rune(m.noteInput)
+	updated := make([]rune, 0, len(note)+len(typed))
+	updated = append(updated, note[:m.noteCursor]...)
+	updated = append(updated, typed...)
+	updated = append(updated, note[m.noteCursor:]...)
+	m.noteInput = string(updated)
+	m.noteCursor += len(typed)
+}
+
+// noteInputDisplay renders the note being typed on one line, with the cursor
+func (m Model) noteInputDisplay() string {
+	note := []rune(m.noteInput)
+	cursor := max(0, min(m.noteCursor, len(note)))
+	text := string(note[:cursor]) + "█" + string(note[cursor:])
+	return m.displayOrder(strings.NewReplacer("\n", "↵", "\t", " ").Replace(text))
+}
+
+// addNoteCmd stores a note off the Update path
+func (m *Model) addNoteCmd(content string) tea.Cmd {
+	store := m.storage
+	return func() tea.Msg {
+		var id string
+		written, err := trackWrite(store, func() (err error) {
+			id, err = store.AddNote(content)
+			return err
+		})
+		return noteSavedMsg{id: id, written: written, err: err}
+	}
+}
+
+// handleNoteSaved shows the new note selected at the top of the list
+func (m *Model) handleNoteSaved(msg noteSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		failure := errorToast("save note", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+	m.noteInput = ""
+	m.recordOwnWrite(msg.written)
+	m.reloadKeepingSelection()
+	for i, item := range m.filteredItems {
+		if item.ID == msg.id {
+			m.cursor = i
+			break
+		}
+	}
+	return m.showToast(toastSuccess, "Note saved (tag:"+storage.NoteTag+")")
+}
@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestHandleNoteKey_EditsAndSaves(t *testing.T) {
+	m, s := newStorageTestModel(t, "older")
+	m.startNote()
+
+	m.handleNoteKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("buy milk")})
+	m.handleNoteKey(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	m.handleNoteKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("eggz")})
+	m.handleNoteKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.handleNoteKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.noteInput != "buy milk\neggs" {
+		t.Fatalf("Unexpected note input %q", m.noteInput)
+	}
+
+	cmd := m.handleNoteKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.currentMode != modeList {
+		t.Fatal("Expected enter to leave the prompt and save the note")
+	}
+	msg, ok := cmd().(noteSavedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("Expected the note to be saved, got %+v", msg)
+	}
+	m.handleNoteSaved(msg)
+
+	selected := m.getItemMeta(m.cursor)
+	if selected == nil || selected.Content != "buy milk\neggs" {
+		t.Fatalf("Expected the new note to be selected, got %+v", selected)
+	}
+	if tags := s.GetTags(selected.ID); len(tags) != 1 || tags[0] != storage.NoteTag {
+		t.Errorf("Expected the note tag, got %v", tags)
+	}
+}
+
+func TestHandleNoteKey_EscapeDiscards(t *testing.T) {
+	m := Model{}
+	m.startNote()
+	m.handleNoteKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("draft")})
+	if cmd := m.handleNoteKey(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil {
+		t.Error("Expected nothing to be saved")
+	}
+	if m.currentMode != modeList || m.noteInput != "" {
+		t.Errorf("Expected the draft to be discarded, got mode %d input %q", m.currentMode, m.noteInput)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// Panic clears the system clipboard, wipes every unpinned entry and the archive,
+// and removes the saved TUI state, returning how many entries were removed.
+// The wipe still runs when clearing the clipboard fails; both errors are reported.
+func Panic(store *storage.Storage) (int, error) {
+	var errs []error
+	if err := clipboard.Clear(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to clear clipboard: %w", err))
+	}
+
+	removed, err := store.WipeUnpinned()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// The state file holds the last search query, which may quote wiped content
+	if path, err := StatePath(); err == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("failed to remove state file: %w", err))
+		}
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+// panicDoneMsg reports the outcome of a panic wipe run in the background
+type panicDoneMsg struct {
+	removed int
+	written ownWrite
+	err     error
+}
+
+// panicCmd runs Panic off the Update path
+func (m *Model) panicCmd() tea.Cmd {
+	store := m.storage
+	return func() tea.Msg {
+		var removed int
+		written, err := trackWrite(store, func() (err error) {
+			removed, err = Panic(store)
+			return err
+		})
+		return panicDoneMsg{removed: removed, written: written, err: err}
+	}
+}
+
+// handlePanicDone drops everything the TUI holds in memory and reloads the remaining items
+func (m *Model) handlePanicDone(msg panicDoneMsg) tea.Cmd {
+	m.cache.Clear()
+	m.textLines.clear()
+	m.clearUndo() // The wiped entries must not come back
+	m.searchQuery = ""
+	m.searchCursor = 0
+	m.queryError = nil
+	m.cursor = 0
+
+	m.recordOwnWrite(msg.written)
+	m.items = nil
+	m.refreshItems()
+
+	if msg.err != nil {
+		failure := errorToast("panic", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+	return m.showToast(toastSuccess, fmt.Sprintf("Clipboard cleared, %d entries wiped", msg.removed))
+}
@@ -0,0 +1,238 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// pendingView lists content the daemon holds back in capture mode "ask" until it is kept,
+// or the content it quarantined after the capture policy blocked it
+type pendingView struct {
+	entries    []storage.PendingItem
+	quarantine bool // Lists quarantined items instead of pending ones
+	cursor     int
+	offset     int // First entry shown
+}
+
+// openPendingView loads the pending items, or the quarantined ones when quarantine is
+// set, and switches to the pending screen
+func (m *Model) openPendingView(quarantine bool) tea.Cmd {
+	items, err := m.storage.GetPending()
+	if err != nil {
+		failure := errorToast("load pending items", err)
+		return m.showToast(failure.level, failure.text)
+	}
+	var entries []storage.PendingItem
+	for _, item := range items {
+		if item.Quarantined() == quarantine {
+			entries = append(entries, item)
+		}
+	}
+	m.pendingView = &pendingView{entries: entries, quarantine: quarantine}
+	m.currentMode = modePending
+	return nil
+}
+
+// closePendingView returns to the list
+func (m *Model) closePendingView() {
+	m.pendingView = nil
+	m.currentMode = modeList
+}
+
+// handlePendingViewKey moves through the pending items and keeps or discards them
+func (m *Model) handlePendingViewKey(key string) tea.Cmd {
+	view := m.pendingView
+	if view == nil {
+		m.currentMode = modeList
+		return nil
+	}
+
+	switch key {
+	case "up", "k":
+		if view.cursor > 0 {
+			view.cursor--
+		}
+	case "down", "j":
+		if view.cursor < len(view.entries)-1 {
+			view.cursor++
+		}
+	case "g", "home":
+		view.cursor = 0
+	case "G", "end":
+		view.cursor = max(len(view.entries)-1, 0)
+	case "y", "enter":
+		if len(view.entries) > 0 {
+			return m.resolveSelectedPending(true)
+		}
+	case "n", "x":
+		if len(view.entries) > 0 {
+			return m.resolveSelectedPending(false)
+		}
+	case "esc", "q", "P", "Q":
+		m.closePendingView()
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// pendingResolvedMsg reports that a pending item was kept or discarded
+type pendingResolvedMsg struct {
+	entry   storage.PendingItem
+	keep    bool
+	err     error
+	written ownWrite
+}
+
+// resolveSelectedPending stores the selected item in the history when keep is set and
+// discards it otherwise. The write runs as a tea.Cmd and reports a pendingResolvedMsg.
+func (m *Model) resolveSelectedPending(keep bool) tea.Cmd {
+	view := m.pendingView
+	entry := view.entries[view.cursor]
+
+	m.pendingOps++
+	store := m.storage
+	run := func() tea.Msg {
+		resolve := store.RejectPending
+		if keep {
+			resolve = store.ApprovePending
+		}
+		written, err := trackWrite(store, func() error { return resolve(entry.ID) })
+		return pendingResolvedMsg{entry: entry, keep: keep, err: err, written: written}
+	}
+	if m.pendingOps == 1 {
+		return tea.Batch(run, m.opSpinner.Tick)
+	}
+	return run
+}
+
+// handlePendingResolved drops the resolved item from the pending screen and shows the result
+func (m *Model) handlePendingResolved(msg pendingResolvedMsg) tea.Cmd {
+	if m.pendingOps > 0 {
+		m.pendingOps--
+	}
+
+	action, done := "discard pending item", "Discarded"
+	if msg.keep {
+		action, done = "keep pending item", "Kept"
+		if msg.entry.Quarantined() {
+			done = "Rescued"
+		}
+	}
+	if msg.err != nil {
+		failure := errorToast(action, msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+
+	// The screen may have been closed, or the cursor moved, while the write was running
+	if view := m.pendingView; view != nil {
+		for i, entry := range view.entries {
+			if entry.ID == msg.entry.ID {
+				view.entries = append(view.entries[:i], view.entries[i+1:]...)
+				break
+			}
+		}
+		if view.cursor >= len(view.entries) && view.cursor > 0 {
+			view.cursor = len(view.entries) - 1
+		}
+	}
+	if msg.keep {
+		m.recordOwnWrite(msg.written)
+		m.reloadKeepingSelection()
+	}
+	m.cue()
+	return m.showToast(toastSuccess, done+" "+msg.entry.ContentType)
+}
+
+// pendingEntryLine describes one pending item on a single line
+func pendingEntryLine(entry storage.PendingItem) string {
+	preview := entry.Content
+	if level := storage.ThreatLevel(entry.Content, entry.ContentType); level == "high" || level == "medium" {
+		preview = "(possibly sensitive text)"
+	}
+	preview = strings.Join(strings.Fields(preview), " ")
+	line := fmt.Sprintf("%s  %-5s  %s", entry.Timestamp.Format("15:04:05"), entry.ContentType, preview)
+	if !entry.Source.IsZero() {
+		line += "  [" + entry.Source.String() + "]"
+	}
+	if entry.Quarantined() {
+		line += "  (" + entry.Reason + ")"
+	}
+	return line
+}
+
+// renderPendingView shows the pending items, keeping the selected one in view
+func (m Model) renderPendingView() string {
+	view := m.pendingView
+	if view == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	// Scroll so the cursor stays visible
+	if view.cursor < view.offset {
+		view.offset = view.cursor
+	}
+	if contentHeight > 0 && view.cursor >= view.offset+contentHeight {
+		view.offset = view.cursor - contentHeight + 1
+	}
+
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		index := view.offset + i
+		switch {
+		case len(view.entries) == 0 && i == 0:
+			empty := "Nothing is waiting to be kept"
+			if view.quarantine {
+				empty = "Nothing is quarantined"
+			}
+			content.WriteString("  " + mainStyles.Text.Render(empty))
+		case index < len(view.entries):
+			line := truncateWithEllipsis(pendingEntryLine(view.entries[index]), contentWidth-2)
+			if index == view.cursor {
+				content.WriteString(m.selectionPrefix(true, 0) + mainStyles.SelectedBackground.Render(line))
+			} else {
+				content.WriteString("  " + mainStyles.Text.Render(line))
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	headerText := fmt.Sprintf("Pending Items (%d)", len(view.entries))
+	footerText := "y: keep | n: discard | j/k: move | esc: close"
+	if view.quarantine {
+		headerText = fmt.Sprintf("Quarantined Items (%d)", len(view.entries))
+		footerText = "y: rescue | n: discard | j/k: move | esc: close"
+	}
+	frameContent := m.buildFrameContent(headerText, content.String(), footerText, contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
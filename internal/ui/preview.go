@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import "strings"
+
+// defaultPreviewLines is the number of rows an item may take in the list
+const defaultPreviewLines = 5
+
+// previewLineLimit returns the maximum number of rows shown for an item
+func (m Model) previewLineLimit() int {
+	if m.compactList {
+		return 1
+	}
+	if m.previewLines <= 0 {
+		return defaultPreviewLines
+	}
+	return m.previewLines
+}
+
+// collapsesMultiline reports whether multiline items are joined into a single preview.
+// A one-row preview always collapses, otherwise only the first line would be visible.
+func (m Model) collapsesMultiline() bool {
+	return m.collapseMultiline || m.previewLineLimit() == 1
+}
+
+// itemSeparatorLines returns the number of separator rows drawn between items
+func (m Model) itemSeparatorLines() int {
+	if m.compactList {
+		return 0
+	}
+	return 1
+}
+
+// previewSourceLines splits content into the lines previewed in the list, joining
+// the non-blank lines of multiline content when collapsing is enabled
+func (m Model) previewSourceLines(content string) []string {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 1 || !m.collapsesMultiline() {
+		return lines
+	}
+
+	separator := " / "
+	if m.iconHelper != nil && m.iconHelper.GetCapabilities().SupportsUnicode {
+		separator = " ⏎ "
+	}
+
+	var parts []string
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+	return []string{strings.Join(parts, separator)}
+}
@@ -0,0 +1,131 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// previewPaneMaxBytes bounds how much of a long item is highlighted for the pane,
+// which only ever shows its first screenful
+const previewPaneMaxBytes = 16 * 1024
+
+// selectionPreviewLines renders the selected item for the preview pane: a summary
+// line, then highlighted text or a thumbnail of the image
+func (m Model) selectionPreviewLines(width, height int) []string {
+	if m.cursor < 0 || m.cursor >= len(m.filteredItems) || height < 3 {
+		return nil
+	}
+	meta := m.filteredItems[m.cursor]
+	if meta.ContentType == "image" {
+		return m.imagePaneLines(width, height)
+	}
+	return m.textPaneLines(meta, width, height)
+}
+
+// textPaneLines highlights the start of a text item, reusing the text view's cache
+func (m Model) textPaneLines(meta storage.ClipboardItemMeta, width, height int) []string {
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	snippet := previewSnippet(meta.Content, height)
+	var entry textLinesEntry
+	if m.textLines == nil {
+		entry = m.computeTextLines(snippet, width, "", false, true)
+	} else {
+		key := m.textLinesKeyFor(meta.ID, snippet, width, "", false)
+		var ok bool
+		if entry, ok = m.textLines.get(key); !ok {
+			entry = m.computeTextLines(snippet, width, "", false, true)
+			m.textLines.put(key, entry)
+		}
+	}
+
+	lines := []string{mainStyles.Header.Render(truncateWithEllipsis(textPaneSummary(meta, entry), width)), ""}
+	for _, line := range entry.lines {
+		if len(lines) >= height {
+			break
+		}
+		// Highlighted code and terminal output bring their own colors
+		if !entry.isCode && !entry.colored {
+			line = mainStyles.Text.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// imagePaneLines shows the image's size and a thumbnail drawn with text
+func (m Model) imagePaneLines(width, height int) []string {
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	item := m.getItemByIndex(m.cursor)
+	if item == nil || len(item.ImageData) == 0 {
+		return []string{mainStyles.Text.Render("Image data not available")}
+	}
+
+	size := FormatSize(int64(len(item.ImageData)))
+	summary := "Image, " + size
+	if imgWidth, imgHeight, format, err := getImageDimensions(item.ImageData); err == nil {
+		summary = fmt.Sprintf("%dx%d %s, %s", imgWidth, imgHeight, format, size)
+	}
+	lines := []string{mainStyles.Header.Render(truncateWithEllipsis(summary, width)), ""}
+
+	thumbnail, err := m.imagePreviewLinesFor(item, width, height-len(lines))
+	if err != nil {
+		return append(lines, mainStyles.Text.Render(truncateWithEllipsis("No preview: "+err.Error(), width)))
+	}
+	return append(lines, centerPreview(thumbnail, width, height-len(lines))...)
+}
+
+// previewSnippet returns the first lines of content, enough to fill the pane
+func previewSnippet(content string, maxLines int) string {
+	if len(content) > previewPaneMaxBytes {
+		content = content[:previewPaneMaxBytes]
+		for len(content) > 0 && !utf8.ValidString(content) {
+			content = content[:len(content)-1]
+		}
+	}
+	if lines := strings.SplitN(content, "\n", maxLines+1); len(lines) > maxLines {
+		content = strings.Join(lines[:maxLines], "\n")
+	}
+	return strings.ReplaceAll(content, "\t", "    ")
+}
+
+// textPaneSummary describes a text item above its preview
+func textPaneSummary(meta storage.ClipboardItemMeta, entry textLinesEntry) string {
+	content := meta.Content
+	lineCount := strings.Count(content, "\n") + 1
+	kind := "Text"
+	if entry.isCode {
+		kind = entry.language
+	} else if label := textLanguageLabel(meta); label != "" {
+		kind = "Text (" + label + ")"
+	}
+	return fmt.Sprintf("%s, %d lines, %d chars, %s", kind, lineCount, utf8.RuneCountInString(content), FormatSize(int64(len(content))))
+}
@@ -0,0 +1,95 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func newPreviewPaneTestModel(items ...storage.ClipboardItemMeta) Model {
+	return Model{
+		themeService:  NewThemeService(&config.ThemeConfig{}),
+		codeDetector:  NewCodeDetector(),
+		textLines:     newTextLinesCache(),
+		filteredItems: items,
+	}
+}
+
+func TestSelectionPreviewLines_Text(t *testing.T) {
+	m := newPreviewPaneTestModel(storage.ClipboardItemMeta{ID: "1", Content: "first line\n\n" + strings.Repeat("word ", 10)})
+
+	lines := m.selectionPreviewLines(20, 6)
+	if len(lines) != 6 {
+		t.Fatalf("Expected the preview to fill 6 rows, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "3 lines") {
+		t.Errorf("Expected a summary line, got %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "first line") || strings.TrimSpace(lines[3]) != "" {
+		t.Errorf("Expected the first line followed by the blank line, got %q", lines[2:4])
+	}
+	for _, line := range lines {
+		if width := stringWidth(line); width > 20 {
+			t.Errorf("Preview line %q is %d cells wide, want at most 20", line, width)
+		}
+	}
+}
+
+func TestSelectionPreviewLines_FollowsCursor(t *testing.T) {
+	m := newPreviewPaneTestModel(
+		storage.ClipboardItemMeta{ID: "1", Content: "alpha"},
+		storage.ClipboardItemMeta{ID: "2", Content: "beta"},
+	)
+
+	m.cursor = 1
+	if lines := m.selectionPreviewLines(30, 5); !strings.Contains(strings.Join(lines, "\n"), "beta") {
+		t.Errorf("Expected the preview of the selected item, got %q", lines)
+	}
+
+	m.filteredItems = nil
+	if lines := m.selectionPreviewLines(30, 5); lines != nil {
+		t.Errorf("Expected no preview without a selection, got %q", lines)
+	}
+}
+
+func TestPreviewSnippet(t *testing.T) {
+	content := strings.Repeat("line\n", 100)
+	if got := previewSnippet(content, 3); got != "line\nline\nline" {
+		t.Errorf("previewSnippet() = %q, want the first 3 lines", got)
+	}
+
+	long := strings.Repeat("é", previewPaneMaxBytes)
+	if got := previewSnippet(long, 3); len(got) > previewPaneMaxBytes || !strings.HasPrefix(long, got) {
+		t.Errorf("Expected the snippet to be cut to %d bytes on a rune boundary, got %d bytes", previewPaneMaxBytes, len(got))
+	}
+
+	if got := previewSnippet("a\tb", 1); got != "a    b" {
+		t.Errorf("Expected tabs to be expanded, got %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestGetItemDisplayLines_PreviewLimit(t *testing.T) {
+	item := storage.ClipboardItem{Content: strings.Repeat("line\n", 10)}
+
+	m := Model{}
+	if lines := m.getItemDisplayLines(item, 80); len(lines) != defaultPreviewLines+1 {
+		t.Errorf("Expected %d rows (including the overflow marker), got %d", defaultPreviewLines+1, len(lines))
+	}
+
+	m.previewLines = 2
+	if lines := m.getItemDisplayLines(item, 80); len(lines) != 3 {
+		t.Errorf("Expected 3 rows with preview_lines = 2, got %d: %q", len(lines), lines)
+	}
+}
+
+func TestGetItemDisplayLines_CollapseMultiline(t *testing.T) {
+	item := storage.ClipboardItem{Content: "first\n\n  second\nthird"}
+
+	m := Model{collapseMultiline: true}
+	lines := m.getItemDisplayLines(item, 80)
+	if len(lines) != 1 || lines[0] != "first / second / third" {
+		t.Errorf("Expected a single collapsed row, got %q", lines)
+	}
+}
+
+func TestCompactList(t *testing.T) {
+	m := Model{compactList: true}
+	item := storage.ClipboardItem{Content: "first\nsecond\n" + strings.Repeat("long text ", 20)}
+
+	lines := m.getItemDisplayLines(item, 40)
+	if len(lines) != 1 {
+		t.Fatalf("Expected one row per item in compact mode, got %q", lines)
+	}
+	if !strings.HasSuffix(lines[0], "...") {
+		t.Errorf("Expected the truncated row to end in an ellipsis, got %q", lines[0])
+	}
+	if m.itemSeparatorLines() != 0 {
+		t.Error("Expected no separators between items in compact mode")
+	}
+	if m.calculateItemLines(item, 40) != 1 {
+		t.Errorf("Expected calculateItemLines to agree with the compact layout")
+	}
+}
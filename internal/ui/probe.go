@@ -0,0 +1,238 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+// probeQuery asks for Kitty graphics support, truecolor via XTGETTCAP (Tc and RGB),
+// the cell size in pixels via XTWINOPS and finally the primary device attributes,
+// which every terminal answers
+const probeQuery = "\x1b_Gi=31,s=1,v=1,a=q,t=d,f=24;AAAA\x1b\\" +
+	"\x1bP+q5463;524742\x1b\\" +
+	"\x1b[16t" +
+	"\x1b[c"
+
+// probeTimeout bounds how long we wait for the terminal to answer
+var probeTimeout = 500 * time.Millisecond
+
+var (
+	da1Pattern      = regexp.MustCompile(`\x1b\[\?([0-9;]*)c`)
+	xtgettcapValid  = regexp.MustCompile(`\x1bP1\+r(5463|524742)`)
+	cellSizeReport  = regexp.MustCompile(`\x1b\[6;([0-9]+);([0-9]+)t`)
+	errNoTerminal   = errors.New("not running in a terminal")
+	errProbePolling = errors.New("terminal does not support read deadlines")
+)
+
+// ProbedCapabilities holds what the terminal reported about itself
+type ProbedCapabilities struct {
+	Term             string    `json:"term"`
+	Responded        bool      `json:"responded"`         // The terminal answered the DA1 query
+	DeviceAttributes string    `json:"device_attributes"` // Raw DA1 parameters, e.g. "62;4;22"
+	Kitty            bool      `json:"kitty_graphics"`
+	TrueColor        bool      `json:"truecolor"`
+	Sixel            bool      `json:"sixel"`
+	CellWidth        int       `json:"cell_width,omitempty"` // Cell size in pixels, 0 if not reported
+	CellHeight       int       `json:"cell_height,omitempty"`
+	ProbedAt         time.Time `json:"probed_at"`
+	Cached           bool      `json:"-"` // Loaded from the cache rather than probed now
+}
+
+// probed is the result used by the capability checks, nil until loaded
+var probed *ProbedCapabilities
+
+// parseProbeResponse extracts the capabilities from the terminal's replies
+func parseProbeResponse(response string) ProbedCapabilities {
+	var caps ProbedCapabilities
+	if match := da1Pattern.FindStringSubmatch(response); match != nil {
+		caps.Responded = true
+		caps.DeviceAttributes = match[1]
+		for _, param := range strings.Split(match[1], ";") {
+			if param == "4" {
+				caps.Sixel = true
+			}
+		}
+	}
+	caps.Kitty = strings.Contains(response, "\x1b_Gi=31;OK")
+	caps.TrueColor = xtgettcapValid.MatchString(response)
+	// XTWINOPS reports the height first
+	if match := cellSizeReport.FindStringSubmatch(response); match != nil {
+		caps.CellHeight, _ = strconv.Atoi(match[1])
+		caps.CellWidth, _ = strconv.Atoi(match[2])
+	}
+	return caps
+}
+
+// capabilityCacheKey identifies the terminal the probe results belong to
+func capabilityCacheKey() string {
+	key := os.Getenv("TERM")
+	if program := os.Getenv("TERM_PROGRAM"); program != "" {
+		key += "/" + program
+	}
+	return key
+}
+
+// CapabilitiesPath returns the location of the terminal capability cache
+func CapabilitiesPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "nclip", "terminal_capabilities.json"), nil
+}
+
+// loadCapabilityCache reads the cache, returning an empty one if it doesn't exist yet
+func loadCapabilityCache(path string) (map[string]ProbedCapabilities, error) {
+	cache := map[string]ProbedCapabilities{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("failed to read capability cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return map[string]ProbedCapabilities{}, fmt.Errorf("failed to parse capability cache: %w", err)
+	}
+	return cache, nil
+}
+
+// saveCapabilities stores the results for one terminal, keeping the other entries
+func saveCapabilities(path, key string, caps ProbedCapabilities) error {
+	cache, err := loadCapabilityCache(path)
+	if err != nil {
+		// A corrupt cache is replaced rather than blocking new results
+		cache = map[string]ProbedCapabilities{}
+	}
+	cache[key] = caps
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode capability cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create capability cache directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ProbeTerminal queries the controlling terminal and waits for its replies
+func ProbeTerminal() (ProbedCapabilities, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+		return ProbedCapabilities{}, errNoTerminal
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return ProbedCapabilities{}, fmt.Errorf("failed to open terminal: %w", err)
+	}
+	defer tty.Close()
+
+	// Without a deadline a silent terminal would leave a reader stealing keystrokes
+	if err := tty.SetReadDeadline(time.Now().Add(probeTimeout)); err != nil {
+		return ProbedCapabilities{}, errProbePolling
+	}
+
+	state, err := term.MakeRaw(tty.Fd())
+	if err != nil {
+		return ProbedCapabilities{}, fmt.Errorf("failed to put terminal in raw mode: %w", err)
+	}
+	defer term.Restore(tty.Fd(), state)
+
+	if _, err := tty.WriteString(probeQuery); err != nil {
+		return ProbedCapabilities{}, fmt.Errorf("failed to query terminal: %w", err)
+	}
+
+	// The DA1 reply comes last, so everything before it has arrived once it's seen
+	var response []byte
+	buf := make([]byte, 256)
+	for !da1Pattern.Match(response) {
+		n, err := tty.Read(buf)
+		response = append(response, buf[:n]...)
+		if err != nil {
+			break
+		}
+	}
+
+	caps := parseProbeResponse(string(response))
+	caps.Term = capabilityCacheKey()
+	caps.ProbedAt = time.Now()
+	return caps, nil
+}
+
+// LoadTerminalCapabilities returns this terminal's cached probe results, probing
+// and caching them on first run or when refresh is set, and applies them
+func LoadTerminalCapabilities(refresh bool) (ProbedCapabilities, error) {
+	path, err := CapabilitiesPath()
+	if err != nil {
+		return ProbedCapabilities{}, err
+	}
+	key := capabilityCacheKey()
+
+	if !refresh {
+		cache, err := loadCapabilityCache(path)
+		if caps, ok := cache[key]; ok && err == nil {
+			caps.Cached = true
+			useProbedCapabilities(caps)
+			return caps, nil
+		}
+	}
+
+	caps, err := ProbeTerminal()
+	if err != nil {
+		return ProbedCapabilities{}, err
+	}
+	useProbedCapabilities(caps)
+	if err := saveCapabilities(path, key, caps); err != nil {
+		return caps, err
+	}
+	return caps, nil
+}
+
+// useProbedCapabilities makes the probe results override the env heuristics
+func useProbedCapabilities(caps ProbedCapabilities) {
+	if !caps.Responded {
+		return
+	}
+	probed = &caps
+	// Only upgrade color output; an Ascii profile means color was turned off
+	profile := lipgloss.ColorProfile()
+	if caps.TrueColor && (profile == termenv.ANSI256 || profile == termenv.ANSI) {
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	}
+}
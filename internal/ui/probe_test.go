@@ -0,0 +1,134 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseProbeResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     ProbedCapabilities
+	}{
+		{
+			name:     "kitty with truecolor",
+			response: "\x1b_Gi=31;OK\x1b\\\x1bP1+r5463=\x1b\\\x1b[?62;c",
+			want:     ProbedCapabilities{Responded: true, DeviceAttributes: "62;", Kitty: true, TrueColor: true},
+		},
+		{
+			name:     "sixel terminal rejecting graphics and capability queries",
+			response: "\x1b_Gi=31;ENOTSUPPORTED:no\x1b\\\x1bP0+r5463\x1b\\\x1b[?64;1;4;22c",
+			want:     ProbedCapabilities{Responded: true, DeviceAttributes: "64;1;4;22", Sixel: true},
+		},
+		{
+			name:     "RGB capability only",
+			response: "\x1bP1+r524742=382F382F38\x1b\\\x1b[?1;2c",
+			want:     ProbedCapabilities{Responded: true, DeviceAttributes: "1;2", TrueColor: true},
+		},
+		{
+			name:     "cell size report",
+			response: "\x1b[6;18;9t\x1b[?62;c",
+			want:     ProbedCapabilities{Responded: true, DeviceAttributes: "62;", CellWidth: 9, CellHeight: 18},
+		},
+		{
+			name:     "no answer",
+			response: "",
+			want:     ProbedCapabilities{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseProbeResponse(tt.response); got != tt.want {
+				t.Errorf("parseProbeResponse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilityCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nclip", "terminal_capabilities.json")
+
+	cache, err := loadCapabilityCache(path)
+	if err != nil || len(cache) != 0 {
+		t.Fatalf("missing cache should load empty, got %v, %v", cache, err)
+	}
+
+	kitty := ProbedCapabilities{Term: "xterm-kitty", Responded: true, Kitty: true, TrueColor: true}
+	linux := ProbedCapabilities{Term: "linux", Responded: true, DeviceAttributes: "6"}
+	if err := saveCapabilities(path, kitty.Term, kitty); err != nil {
+		t.Fatalf("saveCapabilities: %v", err)
+	}
+	if err := saveCapabilities(path, linux.Term, linux); err != nil {
+		t.Fatalf("saveCapabilities: %v", err)
+	}
+
+	cache, err = loadCapabilityCache(path)
+	if err != nil {
+		t.Fatalf("loadCapabilityCache: %v", err)
+	}
+	if cache["xterm-kitty"] != kitty || cache["linux"] != linux {
+		t.Errorf("cache entries not kept per TERM: %+v", cache)
+	}
+}
+
+func TestCapabilityCacheKey(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("TERM_PROGRAM", "")
+	if got := capabilityCacheKey(); got != "xterm-256color" {
+		t.Errorf("capabilityCacheKey() = %q", got)
+	}
+	t.Setenv("TERM_PROGRAM", "WezTerm")
+	if got := capabilityCacheKey(); got != "xterm-256color/WezTerm" {
+		t.Errorf("capabilityCacheKey() = %q", got)
+	}
+}
+
+func TestProbedCapabilitiesOverrideHeuristics(t *testing.T) {
+	saved := probed
+	defer func() { probed = saved }()
+
+	t.Setenv("TERM_PROGRAM", "kitty")
+	probed = &ProbedCapabilities{Responded: true}
+	if detectKittySupport() {
+		t.Error("a terminal that did not answer the graphics query should not use Kitty images")
+	}
+
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("KITTY_WINDOW_ID", "")
+	probed = &ProbedCapabilities{Responded: true, Kitty: true}
+	if !detectKittySupport() {
+		t.Error("a terminal that answered the graphics query should use Kitty images")
+	}
+
+	t.Setenv("XDG_SESSION_TYPE", "tty")
+	probed = &ProbedCapabilities{Responded: true, TrueColor: true}
+	if !DetectTerminalCapabilities(false).SupportsUnicode {
+		t.Error("a truecolor terminal should keep Unicode symbols despite a tty session")
+	}
+}
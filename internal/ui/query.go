@@ -0,0 +1,110 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import "github.com/adaryorg/nclip/internal/storage"
+
+// applyQueryTerms keeps the items matching the field terms of a search query, using
+// the indexed storage query for the live history
+func (m Model) applyQueryTerms(items []storage.ClipboardItemMeta, query storage.Query) []storage.ClipboardItemMeta {
+	// Normalize a copy, the caller's query keeps the terms as typed
+	query.Terms = append([]storage.QueryTerm(nil), query.Terms...)
+	hasLanguage := false
+	for i, term := range query.Terms {
+		if term.Field == "lang" {
+			query.Terms[i].Value = canonicalLanguage(term.Value)
+			hasLanguage = true
+		}
+	}
+
+	matches := query.MatchMeta
+	if m.storage != nil && !m.archiveMode {
+		ids := make(map[string]bool)
+		for _, item := range m.storage.SearchMeta(query) {
+			ids[item.ID] = true
+		}
+		matches = func(item storage.ClipboardItemMeta) bool { return ids[item.ID] }
+	}
+
+	// One query for all forced languages rather than one per item
+	var overrides map[string]string
+	if hasLanguage && m.storage != nil && !m.archiveMode {
+		overrides = m.storage.GetLanguageOverrides()
+	}
+
+	var filtered []storage.ClipboardItemMeta
+	for _, item := range items {
+		if matches(item) && m.matchesLanguageTerms(item, query, overrides) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// matchesLanguageTerms checks lang: terms against the item's forced or detected language,
+// looking up forced languages in overrides
+func (m Model) matchesLanguageTerms(item storage.ClipboardItemMeta, query storage.Query, overrides map[string]string) bool {
+	language, resolved := "", false
+	for _, term := range query.Terms {
+		if term.Field != "lang" {
+			continue
+		}
+		if !resolved {
+			language, resolved = m.languageOf(item, overrides[item.ID]), true
+		}
+		if (language == term.Value) == term.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// itemLanguage returns the language an item is highlighted as, "" for plain text
+func (m Model) itemLanguage(item storage.ClipboardItemMeta) string {
+	if item.ContentType == "image" {
+		return ""
+	}
+	var override string
+	if m.storage != nil && !m.archiveMode {
+		override = m.storage.GetLanguageOverride(item.ID)
+	}
+	return m.languageOf(item, override)
+}
+
+// languageOf returns the language an item is highlighted as when its forced language
+// is override, "" for plain text
+func (m Model) languageOf(item storage.ClipboardItemMeta, override string) string {
+	if item.ContentType == "image" {
+		return ""
+	}
+	if override != "" {
+		return canonicalLanguage(override)
+	}
+	if m.codeDetector == nil {
+		return ""
+	}
+	language, _ := m.codeDetector.DetectLanguage(item.Content)
+	return canonicalLanguage(language)
+}
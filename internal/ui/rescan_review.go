@@ -0,0 +1,160 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/security"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// rescanReview walks through the items a rescan upgraded, one at a time
+type rescanReview struct {
+	ids     []string
+	index   int
+	item    *storage.ClipboardItem    // Item under review, nil once done
+	threats []security.SecurityThreat // What the detector found in it
+
+	kept, safe, deleted, blocked int
+}
+
+// startRescanReview opens the review for the upgraded items, if any still exist
+func (m *Model) startRescanReview(ids []string) tea.Cmd {
+	m.review = &rescanReview{ids: ids, index: -1}
+	return m.advanceReview()
+}
+
+// advanceReview moves to the next upgraded item, skipping ones deleted in the meantime,
+// and ends the review after the last one
+func (m *Model) advanceReview() tea.Cmd {
+	review := m.review
+	for review.index++; review.index < len(review.ids); review.index++ {
+		if item := m.cache.GetFullItem(review.ids[review.index]); item != nil {
+			review.item = item
+			review.threats = security.DefaultDetector().DetectSecurity(item.Content)
+			m.currentMode = modeRescanReview
+			return nil
+		}
+	}
+	return m.finishReview()
+}
+
+// finishReview closes the review and summarizes the decisions
+func (m *Model) finishReview() tea.Cmd {
+	review := m.review
+	m.review = nil
+	m.currentMode = modeList
+	if review == nil || review.kept+review.safe+review.deleted+review.blocked == 0 {
+		return nil
+	}
+	return m.showToast(toastInfo, fmt.Sprintf("Review done: %d kept, %d marked safe, %d deleted, %d blocked",
+		review.kept, review.safe, review.deleted, review.blocked))
+}
+
+// handleReviewKey applies the decision for the item under review and moves on
+func (m *Model) handleReviewKey(key string) tea.Cmd {
+	review := m.review
+	if review == nil || review.item == nil {
+		m.currentMode = modeList
+		return nil
+	}
+	id := review.item.ID
+
+	var cmd tea.Cmd
+	switch key {
+	case "k", "enter":
+		review.kept++
+	case "s":
+		review.safe++
+		cmd = m.markSafeCmd(id, true, review.item.SafeEntry)
+	case "x":
+		review.deleted++
+		cmd = m.deleteItemCmd(id)
+	case "b":
+		// Delete the item and remember its hash, like removing it from the security view
+		review.blocked++
+		hashStore := m.hashStore
+		archive := m.archiveMode
+		contentHash := security.CreateHash(review.item.Content)
+		threat := security.GetHighestThreat(review.threats)
+		cmd = m.startStorageOp("block", id, func(s *storage.Storage) error {
+			if hashStore != nil && threat != nil {
+				if err := hashStore.AddHash(contentHash, *threat); err != nil {
+					return err
+				}
+			}
+			return deleteStoredItem(s, archive, id)
+		})
+	case "esc", "q":
+		return m.finishReview()
+	case "ctrl+c":
+		return tea.Quit
+	default:
+		return nil
+	}
+	return tea.Batch(cmd, m.advanceReview())
+}
+
+// renderRescanReview shows the item under review with what the rescan found
+func (m Model) renderRescanReview() string {
+	review := m.review
+	if review == nil || review.item == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	warningStyles := m.themeService.GetWarningStyles()
+
+	headerText := fmt.Sprintf("Rescan Review %d/%d - %s", review.index+1, len(review.ids), strings.ToUpper(review.item.ThreatLevel))
+
+	var lines []string
+	for _, threat := range review.threats {
+		lines = append(lines, fmt.Sprintf("%s (%.0f%% confidence): %s", threat.Type, threat.Confidence*100, threat.Reason))
+	}
+	if len(review.threats) == 0 {
+		lines = append(lines, "The detector no longer reports a specific threat for this item")
+	}
+	lines = append(lines, "")
+	for _, line := range strings.Split(sanitizeForDisplay(review.item.Content), "\n") {
+		lines = append(lines, m.wrapLongLine(strings.ReplaceAll(line, "\t", "    "), contentWidth)...)
+	}
+
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		var line string
+		if i < len(lines) {
+			line = truncateWithEllipsis(lines[i], contentWidth)
+		}
+		content.WriteString(warningStyles.Body.Width(contentWidth).Render(line))
+		content.WriteString("\n")
+	}
+
+	footerText := "k: keep | s: mark safe | x: delete | b: block hash | esc: stop review"
+	frameContent := m.buildFrameContent(headerText, content.String(), footerText, contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
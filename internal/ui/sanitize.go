@@ -0,0 +1,207 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Clipboard content often comes from terminals and may carry escape sequences. Written
+// to the TUI unchanged they could move the cursor, clear the screen or draw Kitty images,
+// so content is always passed through one of the functions below before display.
+
+// containsEscapes reports whether s contains an ESC character
+func containsEscapes(s string) bool {
+	return strings.IndexByte(s, 0x1b) >= 0
+}
+
+// isControlRune reports whether r is a C0/C1 control other than newline and tab
+func isControlRune(r rune) bool {
+	if r == '\n' || r == '\t' {
+		return false
+	}
+	return r < 0x20 || r == 0x7f || (r >= 0x80 && r <= 0x9f)
+}
+
+// needsSanitizing reports whether s contains any escape or control characters
+func needsSanitizing(s string) bool {
+	for _, r := range s {
+		if isControlRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeSequenceEnd returns the index just past the escape sequence starting at s[start]
+func escapeSequenceEnd(s string, start int) int {
+	i := start + 1
+	if i >= len(s) {
+		return i
+	}
+
+	switch s[i] {
+	case '[':
+		// CSI: parameter and intermediate bytes, then a final byte
+		i++
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x3f {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x40 && s[i] <= 0x7e {
+			i++
+		}
+		return i
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM and APC (Kitty graphics) run until BEL or ST
+		i++
+		for i < len(s) {
+			if s[i] == 0x07 {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+			i++
+		}
+		return i
+	default:
+		// Two or three byte sequences such as ESC 7 or ESC ( B
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i < len(s) && s[i] < utf8.RuneSelf {
+			i++
+		}
+		return i
+	}
+}
+
+// isSGR reports whether seq is a plain Select Graphic Rendition (color) sequence
+func isSGR(seq string) bool {
+	if len(seq) < 3 || !strings.HasPrefix(seq, "\x1b[") || seq[len(seq)-1] != 'm' {
+		return false
+	}
+	for _, c := range seq[2 : len(seq)-1] {
+		if (c < '0' || c > '9') && c != ';' {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeForDisplay removes escape sequences and control characters, keeping newlines and tabs
+func sanitizeForDisplay(s string) string {
+	if !needsSanitizing(s) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i = escapeSequenceEnd(s, i)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isControlRune(r) {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// escapeControlSequences shows escape sequences and control characters as visible text,
+// e.g. ESC as ^[, so raw terminal output can be inspected safely
+func escapeControlSequences(s string) string {
+	if !needsSanitizing(s) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case !isControlRune(r):
+			b.WriteString(s[i : i+size])
+		case r < 0x20:
+			b.WriteByte('^')
+			b.WriteByte(byte(r) + 0x40)
+		case r == 0x7f:
+			b.WriteString("^?")
+		default:
+			fmt.Fprintf(&b, "<U+%04X>", r)
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// renderSGROnly keeps color sequences so colored terminal output renders, and drops every
+// other escape sequence and control character. Colors are reset at the end of each line
+// and restored at the start of the next, so they can't bleed into the frame.
+func renderSGROnly(s string) string {
+	if !needsSanitizing(s) {
+		return s
+	}
+
+	var b strings.Builder
+	var active strings.Builder // SGR sequences in effect since the last reset
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == 0x1b:
+			end := escapeSequenceEnd(s, i)
+			seq := s[i:end]
+			if isSGR(seq) {
+				b.WriteString(seq)
+				if seq == "\x1b[m" || seq == "\x1b[0m" {
+					active.Reset()
+				} else {
+					active.WriteString(seq)
+				}
+			}
+			i = end
+		case s[i] == '\n':
+			if active.Len() > 0 {
+				b.WriteString("\x1b[0m\n")
+				b.WriteString(active.String())
+			} else {
+				b.WriteByte('\n')
+			}
+			i++
+		default:
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if !isControlRune(r) {
+				b.WriteString(s[i : i+size])
+			}
+			i += size
+		}
+	}
+	if active.Len() > 0 {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeForDisplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "hello\n\tworld", "hello\n\tworld"},
+		{"colors", "\x1b[31mred\x1b[0m", "red"},
+		{"cursor movement", "a\x1b[2J\x1b[Hb", "ab"},
+		{"osc title", "\x1b]0;pwned\x07text", "text"},
+		{"kitty graphics", "\x1b_Ga=T,f=100;AAAA\x1b\\after", "after"},
+		{"control characters", "bell\x07 back\bspace\r", "bell backspace"},
+		{"c1 control", "a\u009b2Jb", "a2Jb"},
+		{"unicode", "héllo 世界", "héllo 世界"},
+		{"trailing escape", "text\x1b", "text"},
+	}
+	for _, test := range tests {
+		if got := sanitizeForDisplay(test.input); got != test.expected {
+			t.Errorf("%s: sanitizeForDisplay(%q) = %q, expected %q", test.name, test.input, got, test.expected)
+		}
+	}
+}
+
+func TestEscapeControlSequences(t *testing.T) {
+	got := escapeControlSequences("\x1b[31mred\x1b[0m\x7f\u009b\n")
+	expected := "^[[31mred^[[0m^?<U+009B>\n"
+	if got != expected {
+		t.Errorf("escapeControlSequences() = %q, expected %q", got, expected)
+	}
+	if containsEscapes(got) {
+		t.Error("Expected no ESC characters in escaped output")
+	}
+}
+
+func TestRenderSGROnly(t *testing.T) {
+	got := renderSGROnly("\x1b[1;32mok\x1b[2K\nnext\x1b[0m line")
+
+	if strings.Contains(got, "\x1b[2K") {
+		t.Errorf("Expected non-color sequences to be removed, got %q", got)
+	}
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", got)
+	}
+	// Colors are closed at the end of a line and restored on the next
+	if lines[0] != "\x1b[1;32mok\x1b[0m" {
+		t.Errorf("Unexpected first line %q", lines[0])
+	}
+	if lines[1] != "\x1b[1;32mnext\x1b[0m line" {
+		t.Errorf("Unexpected second line %q", lines[1])
+	}
+}
+
+func TestComputeTextViewLines_ANSIToggle(t *testing.T) {
+	m := newTextViewTestModel("\x1b[31merror\x1b[0m: \x1b[2Jfailed")
+
+	rendered := m.computeTextViewLines(true)
+	if !rendered.colored || strings.Contains(rendered.lines[0], "\x1b[2J") || !strings.Contains(rendered.lines[0], "\x1b[31m") {
+		t.Errorf("Expected rendered colors without other escapes, got %q", rendered.lines[0])
+	}
+
+	m.showRawANSI = true
+	raw := m.computeTextViewLines(true)
+	if raw.colored || containsEscapes(strings.Join(raw.lines, "\n")) {
+		t.Errorf("Expected escape codes shown as text, got %q", raw.lines)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The search cursor is a rune index into searchQuery. It moves in logical order, so
+// left and right step through the query in the order it was typed even when it
+// contains right-to-left text.
+
+// handleSearchEditKey edits the search query for cursor, deletion and text keys,
+// reporting whether the key was handled
+func (m *Model) handleSearchEditKey(msg tea.KeyMsg) bool {
+	query := []rune(m.searchQuery)
+	m.searchCursor = max(0, min(m.searchCursor, len(query)))
+
+	switch msg.String() {
+	case "left", "ctrl+b":
+		m.searchCursor = max(0, m.searchCursor-1)
+		return true
+	case "right", "ctrl+f":
+		m.searchCursor = min(len(query), m.searchCursor+1)
+		return true
+	case "home", "ctrl+a":
+		m.searchCursor = 0
+		return true
+	case "end", "ctrl+e":
+		m.searchCursor = len(query)
+		return true
+	case "backspace":
+		if m.searchCursor > 0 {
+			query = append(query[:m.searchCursor-1], query[m.searchCursor:]...)
+			m.searchCursor--
+			m.setSearchQuery(string(query))
+		}
+		return true
+	case "delete", "ctrl+d":
+		if m.searchCursor < len(query) {
+			query = append(query[:m.searchCursor], query[m.searchCursor+1:]...)
+			m.setSearchQuery(string(query))
+		}
+		return true
+	}
+
+	if msg.Alt || (msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace) {
+		return false
+	}
+
+	// Only add printable characters to search query
+	var typed []rune
+	for _, r := range msg.Runes {
+		if !isControlRune(r) && r != '\n' && r != '\t' {
+			typed = append(typed, r)
+		}
+	}
+	if len(typed) == 0 {
+		return true
+	}
+	updated := make([]rune, 0, len(query)+len(typed))
+	updated = append(updated, query[:m.searchCursor]...)
+	updated = append(updated, typed...)
+	updated = append(updated, query[m.searchCursor:]...)
+	m.searchCursor += len(typed)
+	m.setSearchQuery(string(updated))
+	return true
+}
+
+// setSearchQuery replaces the query and updates the display in real-time
+func (m *Model) setSearchQuery(query string) {
+	m.searchQuery = query
+	m.filterItems()
+}
+
+// searchInputDisplay renders the query being typed with the cursor at its logical position
+func (m Model) searchInputDisplay() string {
+	query := []rune(m.searchQuery)
+	cursor := max(0, min(m.searchCursor, len(query)))
+	return m.displayOrder(string(query[:cursor]) + "█" + string(query[cursor:]))
+}
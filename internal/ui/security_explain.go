@@ -0,0 +1,73 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/adaryorg/nclip/internal/security"
+)
+
+// securityExplanationLines lists every rule that matched the content in the security
+// view, with the masked match and how it contributes to the final confidence
+func (m Model) securityExplanationLines() []string {
+	matches := security.DefaultDetector().Explain(m.securityContent)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// The final confidence is that of the most confident counted match
+	final := -1
+	for i, match := range matches {
+		if match.Counted && (final < 0 || match.Confidence > matches[final].Confidence) {
+			final = i
+		}
+	}
+
+	var lines []string
+	if final >= 0 {
+		lines = append(lines, fmt.Sprintf("Matched rules (final confidence %.0f%%):", matches[final].Confidence*100))
+	} else {
+		lines = append(lines, "Matched rules (none confident enough to count):")
+	}
+	for i, match := range matches {
+		kind := "pattern"
+		if match.Heuristic {
+			kind = "heuristic"
+		}
+		var contribution string
+		switch {
+		case i == final:
+			contribution = "sets the final confidence"
+		case match.Counted:
+			contribution = "counted, below the final confidence"
+		default:
+			contribution = "ignored, 50% or less"
+		}
+		lines = append(lines, fmt.Sprintf("  %-20s %-9s %3.0f%%  %-16s %s - %s",
+			match.Rule, kind, match.Confidence*100, match.Type, security.MaskMatch(match.Match), contribution))
+	}
+	return append(lines, "")
+}
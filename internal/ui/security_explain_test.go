@@ -0,0 +1,67 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/security"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestSecurityView_ExplainsMatchedRules(t *testing.T) {
+	if !security.Enabled {
+		t.Skip("threat detection not compiled in")
+	}
+	token := "ghp_" + strings.Repeat("a1B2", 9)
+	m := Model{themeService: NewThemeService(&config.ThemeConfig{}), width: 120, height: 30}
+	m.ShowSecurityWarning(storage.ClipboardItem{Content: token}, security.DefaultDetector().DetectSecurity(token))
+
+	lines := m.securityExplanationLines()
+	if len(lines) < 3 || lines[0] != "Matched rules (final confidence 98%):" {
+		t.Fatalf("Expected the final confidence first, got %q", lines)
+	}
+	explanation := strings.Join(lines, "\n")
+	if !strings.Contains(explanation, "sets the final confidence") || !strings.Contains(explanation, "random_token") {
+		t.Errorf("Expected every rule with its contribution, got:\n%s", explanation)
+	}
+	if strings.Contains(explanation, token) {
+		t.Error("Expected the matched token to be masked")
+	}
+
+	view := strings.Join(m.getSecurityViewLines(), "\n")
+	if !strings.Contains(view, "Matched rules") {
+		t.Error("Expected the explanation in the security view")
+	}
+}
+
+func TestSecurityView_NoRulesNoExplanation(t *testing.T) {
+	m := Model{securityContent: "just some words"}
+	if lines := m.securityExplanationLines(); lines != nil {
+		t.Errorf("Expected no explanation for harmless content, got %q", lines)
+	}
+}
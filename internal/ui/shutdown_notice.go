@@ -0,0 +1,48 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+)
+
+// uncleanShutdownNotice warns at startup when the daemon was killed or crashed: the
+// database records it as running, but no daemon process exists
+func (m *Model) uncleanShutdownNotice() tea.Cmd {
+	if m.storage == nil || m.insertMode {
+		return nil
+	}
+	state, err := m.storage.DaemonState()
+	if err != nil || !state.Running {
+		return nil
+	}
+	if _, running := clipboard.DaemonPID(); running {
+		return nil
+	}
+	text := "nclipd did not shut down cleanly (started " + state.Since.Format("Jan 2 15:04") + "); recent copies may be missing"
+	return func() tea.Msg { return toastMsg{level: toastWarning, text: text} }
+}
@@ -0,0 +1,57 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUncleanShutdownNotice(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir()) // No daemon process recorded
+	m, s := newStorageTestModel(t)
+
+	if cmd := m.uncleanShutdownNotice(); cmd != nil {
+		t.Error("Expected no notice before any daemon ran")
+	}
+
+	if _, err := s.MarkDaemonStarted(); err != nil {
+		t.Fatalf("MarkDaemonStarted failed: %v", err)
+	}
+	cmd := m.uncleanShutdownNotice()
+	if cmd == nil {
+		t.Fatal("Expected a notice for a daemon recorded as running with no process")
+	}
+	if msg, ok := cmd().(toastMsg); !ok || msg.level != toastWarning || !strings.Contains(msg.text, "did not shut down cleanly") {
+		t.Errorf("Expected an unclean shutdown warning, got %+v", msg)
+	}
+
+	if err := s.MarkCleanShutdown(); err != nil {
+		t.Fatalf("MarkCleanShutdown failed: %v", err)
+	}
+	if cmd := m.uncleanShutdownNotice(); cmd != nil {
+		t.Error("Expected no notice after a clean shutdown")
+	}
+}
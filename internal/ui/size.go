@@ -0,0 +1,70 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// FormatSize formats a byte count with binary units, e.g. "512 B" or "1.5 MiB"
+func FormatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	units := []string{"KiB", "MiB", "GiB"}
+	unit := -1
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+// sortLargestFirst orders items by size, biggest first, keeping the history order among
+// equal sizes. The input slice is left alone since it may be shared with the cache.
+func sortLargestFirst(items []storage.ClipboardItemMeta) []storage.ClipboardItemMeta {
+	sorted := append([]storage.ClipboardItemMeta(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Size > sorted[j].Size })
+	return sorted
+}
+
+// listItemLines returns the list rows for an item, led by its size or natural language
+// while the list is sorted by them. Links with a fetched page title show the title above the URL.
+func (m Model) listItemLines(meta storage.ClipboardItemMeta, width int) []string {
+	item := meta.ToClipboardItem()
+	if meta.Title != "" && meta.ContentType == "text" {
+		item.Content = sanitizeForDisplay(meta.Title) + "\n" + item.Content
+	}
+	if m.sortBySize {
+		item.Content = "[" + FormatSize(meta.Size) + "] " + item.Content
+	} else if label := textLanguageLabel(meta); m.sortByLanguage && label != "" {
+		item.Content = "[" + label + "] " + item.Content
+	}
+	return m.getItemDisplayLines(item, width)
+}
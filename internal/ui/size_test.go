@@ -0,0 +1,121 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		0:                "0 B",
+		1023:             "1023 B",
+		1024:             "1.0 KiB",
+		1536:             "1.5 KiB",
+		5 << 20:          "5.0 MiB",
+		3 << 30:          "3.0 GiB",
+		2048 * (1 << 30): "2048.0 GiB",
+	}
+	for size, expected := range tests {
+		if got := FormatSize(size); got != expected {
+			t.Errorf("FormatSize(%d) = %q, want %q", size, got, expected)
+		}
+	}
+}
+
+func TestSortBySize(t *testing.T) {
+	items := []storage.ClipboardItemMeta{
+		{ID: "small", Content: "a", ContentType: "text", Size: 1},
+		{ID: "image", Content: "Image", ContentType: "image", Size: 3 << 20},
+		{ID: "medium", Content: "b", ContentType: "text", Size: 2048},
+		{ID: "tie", Content: "c", ContentType: "text", Size: 2048},
+	}
+	m := Model{
+		items:         items,
+		filteredItems: items,
+		themeService:  NewThemeService(&config.ThemeConfig{}),
+		width:         100,
+		height:        30,
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	var order []string
+	for _, item := range m.filteredItems {
+		order = append(order, item.ID)
+	}
+	if strings.Join(order, ",") != "image,medium,tie,small" {
+		t.Errorf("Expected largest first with ties in history order, got %v", order)
+	}
+	if items[0].ID != "small" {
+		t.Error("Expected the unsorted items to be left alone")
+	}
+
+	lines := m.listItemLines(m.filteredItems[0], 80)
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "[3.0 MiB] ") {
+		t.Errorf("Expected the size in front of the item, got %q", lines)
+	}
+	if view := m.View(); !strings.Contains(view, "[LARGEST FIRST]") {
+		t.Error("Expected the footer to show the size sort")
+	}
+	if !m.State().SortBySize {
+		t.Error("Expected the size sort to be saved in the state")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	if m.filteredItems[0].ID != "small" {
+		t.Errorf("Expected the history order back, got %s first", m.filteredItems[0].ID)
+	}
+	if lines := m.listItemLines(m.filteredItems[0], 80); strings.HasPrefix(lines[0], "[") {
+		t.Errorf("Expected no size prefix outside the size sort, got %q", lines[0])
+	}
+}
+
+func TestListItemLines_ShowsLinkTitle(t *testing.T) {
+	m := Model{themeService: NewThemeService(&config.ThemeConfig{}), previewLines: 5}
+	meta := storage.ClipboardItemMeta{
+		ID:          "1",
+		Content:     "https://github.com/adaryorg/nclip",
+		ContentType: "text",
+		Title:       "GitHub – adaryorg/nclip",
+	}
+
+	lines := m.listItemLines(meta, 80)
+	if len(lines) < 2 || !strings.Contains(lines[0], "GitHub – adaryorg/nclip") || !strings.Contains(lines[1], "https://github.com/adaryorg/nclip") {
+		t.Errorf("Expected the title above the link, got %q", lines)
+	}
+
+	m.compactList = true
+	if lines := m.listItemLines(meta, 80); len(lines) != 1 || !strings.Contains(lines[0], "GitHub") {
+		t.Errorf("Expected the compact list to show the title, got %q", lines)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// loadViewingSource reads where the item opened in a viewer was copied from
+func (m *Model) loadViewingSource(id string) {
+	m.viewingSource = storage.Source{}
+	if m.storage != nil && !m.archiveMode {
+		m.viewingSource = m.storage.GetSource(id)
+	}
+}
+
+// sourceSuffix describes the viewed item's source for the viewer header, "" if unknown
+func (m Model) sourceSuffix() string {
+	if m.viewingSource.App == "" && m.viewingSource.Title == "" {
+		return ""
+	}
+	source := sanitizeForDisplay(m.viewingSource.String())
+	return " - from " + truncateWithEllipsis(strings.ReplaceAll(source, "\n", " "), 50)
+}
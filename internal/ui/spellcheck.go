@@ -0,0 +1,332 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// spellCheckers are tried in order when editor.spell_command is not set. Both speak the
+// ispell pipe protocol.
+var spellCheckers = []string{"hunspell -a", "aspell -a"}
+
+// errNoSpellChecker is returned when no spell checker is configured or installed
+var errNoSpellChecker = errors.New("no spell checker found (install hunspell or aspell, or set editor.spell_command)")
+
+// spellCommand returns the configured spell checker command, or the first installed known one
+func spellCommand(configured string) ([]string, error) {
+	if strings.TrimSpace(configured) != "" {
+		return config.SplitCommand(configured)
+	}
+	for _, checker := range spellCheckers {
+		args := strings.Fields(checker)
+		if _, err := lookPath(args[0]); err == nil {
+			return args, nil
+		}
+	}
+	return nil, errNoSpellChecker
+}
+
+// runSpellChecker feeds input to the spell checker and returns what it printed,
+// replaceable in tests
+var runSpellChecker = func(args []string, input string) (string, error) {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s: %w: %s", args[0], err, message)
+		}
+		return "", fmt.Errorf("%s: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+// spellFix is a misspelled word found by the spell checker
+type spellFix struct {
+	line       int    // Line of the text the word is on
+	start      int    // Byte offset of the word in its line
+	word       string // Word as written
+	suggestion string // First suggestion, "" when the checker has none
+}
+
+// spellCheckText runs text through the spell checker and returns the misspelled words.
+// Every line is sent with a leading '^', so lines starting with checker commands such
+// as '*' or '#' are checked as text.
+func spellCheckText(args []string, text string) ([]spellFix, error) {
+	lines := strings.Split(text, "\n")
+	var input strings.Builder
+	for _, line := range lines {
+		input.WriteString("^" + line + "\n")
+	}
+	output, err := runSpellChecker(args, input.String())
+	if err != nil {
+		return nil, err
+	}
+	return parseSpellOutput(lines, output), nil
+}
+
+// parseSpellOutput reads the ispell pipe protocol answer: a banner, then one block of
+// results per input line, ended by a blank line. "& word count offset: suggestions"
+// reports a misspelling with suggestions and "# word offset" one without; other results
+// mean the word is correct.
+func parseSpellOutput(lines []string, output string) []spellFix {
+	var fixes []spellFix
+	line, searchFrom := 0, 0
+	for _, result := range strings.Split(output, "\n") {
+		if strings.HasPrefix(result, "@(#)") {
+			continue
+		}
+		if result == "" {
+			line, searchFrom = line+1, 0
+			continue
+		}
+		if line >= len(lines) || (result[0] != '&' && result[0] != '#') {
+			continue
+		}
+
+		fields := strings.Fields(result)
+		if len(fields) < 2 {
+			continue
+		}
+		fix := spellFix{line: line, word: fields[1]}
+		if _, suggestions, ok := strings.Cut(result, ": "); ok && result[0] == '&' {
+			first, _, _ := strings.Cut(suggestions, ", ")
+			fix.suggestion = strings.TrimSpace(first)
+		}
+
+		// Offsets differ between checkers, so the word is found by searching the line
+		start := findWord(lines[line], fix.word, searchFrom)
+		if start < 0 {
+			continue
+		}
+		fix.start = start
+		searchFrom = start + len(fix.word)
+		fixes = append(fixes, fix)
+	}
+	return fixes
+}
+
+// findWord returns the byte offset of the first whole-word occurrence of word in line
+// at or after from, or -1
+func findWord(line, word string, from int) int {
+	for from <= len(line) {
+		index := strings.Index(line[from:], word)
+		if index < 0 {
+			return -1
+		}
+		start := from + index
+		end := start + len(word)
+		before, _ := utf8.DecodeLastRuneInString(line[:start])
+		after, _ := utf8.DecodeRuneInString(line[end:])
+		if (start == 0 || !unicode.IsLetter(before)) && (end == len(line) || !unicode.IsLetter(after)) {
+			return start
+		}
+		from = start + 1
+	}
+	return -1
+}
+
+// applySpellFixes replaces each misspelled word that has a suggestion
+func applySpellFixes(text string, fixes []spellFix) string {
+	lines := strings.Split(text, "\n")
+	// Apply from the end of each line, so earlier offsets stay valid
+	for i := len(fixes) - 1; i >= 0; i-- {
+		fix := fixes[i]
+		if fix.suggestion == "" {
+			continue
+		}
+		line := lines[fix.line]
+		lines[fix.line] = line[:fix.start] + fix.suggestion + line[fix.start+len(fix.word):]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// spellCheckMsg carries the spell checker's findings for an item
+type spellCheckMsg struct {
+	original string
+	fixes    []spellFix
+	err      error
+}
+
+// spellSaveFailedMsg reports that the corrected text couldn't be stored
+type spellSaveFailedMsg struct {
+	err error
+}
+
+// spellView reviews the corrections before the corrected text is stored and copied
+type spellView struct {
+	original  string
+	corrected string
+	fixes     []spellFix
+	offset    int // First line shown
+}
+
+// spellCheckCmd runs the spell checker off the Update path
+func (m *Model) spellCheckCmd(item storage.ClipboardItem) tea.Cmd {
+	if item.ContentType != "text" || strings.TrimSpace(item.Content) == "" {
+		return m.showToast(toastWarning, "Only text can be spell checked")
+	}
+	configured := m.config.Editor.SpellCommand
+	return func() tea.Msg {
+		args, err := spellCommand(configured)
+		if err != nil {
+			return spellCheckMsg{err: err}
+		}
+		logging.Debug("Spell check: running %s", strings.Join(args, " "))
+		fixes, err := spellCheckText(args, item.Content)
+		return spellCheckMsg{original: item.Content, fixes: fixes, err: err}
+	}
+}
+
+// handleSpellCheck opens the review screen when the checker found something to correct
+func (m *Model) handleSpellCheck(msg spellCheckMsg) tea.Cmd {
+	if msg.err != nil {
+		failure := errorToast("spell check", msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+	if len(msg.fixes) == 0 {
+		return m.showToast(toastSuccess, "No spelling mistakes found")
+	}
+	corrected := applySpellFixes(msg.original, msg.fixes)
+	if corrected == msg.original {
+		return m.showToast(toastInfo, fmt.Sprintf("%d unknown words, no suggestions", len(msg.fixes)))
+	}
+	m.spellView = &spellView{original: msg.original, corrected: corrected, fixes: msg.fixes}
+	m.currentMode = modeSpellCheck
+	return nil
+}
+
+// closeSpellView returns to the list
+func (m *Model) closeSpellView() {
+	m.spellView = nil
+	m.currentMode = modeList
+}
+
+// handleSpellViewKey scrolls the corrections and stores the corrected text on enter
+func (m *Model) handleSpellViewKey(key string) tea.Cmd {
+	view := m.spellView
+	if view == nil {
+		m.currentMode = modeList
+		return nil
+	}
+	switch key {
+	case "up", "k":
+		if view.offset > 0 {
+			view.offset--
+		}
+	case "down", "j":
+		if view.offset < len(view.lines())-1 {
+			view.offset++
+		}
+	case "enter", "y":
+		corrected := view.corrected
+		m.closeSpellView()
+		return m.saveSpellCorrectionCmd(corrected)
+	case "esc", "q", "n":
+		m.closeSpellView()
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// saveSpellCorrectionCmd stores the corrected text as a new entry, leaving the original
+// untouched, and copies it
+func (m *Model) saveSpellCorrectionCmd(corrected string) tea.Cmd {
+	store := m.storage
+	copyCmd := m.copyItemCmd(storage.ClipboardItem{
+		Content:     corrected,
+		ContentType: "text",
+		ThreatLevel: storage.ThreatLevel(corrected, "text"),
+	})
+	return func() tea.Msg {
+		if err := store.Add(corrected); err != nil {
+			return spellSaveFailedMsg{err: err}
+		}
+		return copyCmd()
+	}
+}
+
+// lines lays out the corrections and the corrected text for display
+func (view *spellView) lines() []string {
+	var lines []string
+	for _, fix := range view.fixes {
+		suggestion := fix.suggestion
+		if suggestion == "" {
+			suggestion = "(no suggestion, kept)"
+		}
+		lines = append(lines, fmt.Sprintf("line %d: %s → %s", fix.line+1, fix.word, suggestion))
+	}
+	lines = append(lines, "", "Corrected text:")
+	for _, line := range strings.Split(sanitizeForDisplay(view.corrected), "\n") {
+		lines = append(lines, "  "+strings.ReplaceAll(line, "\t", "    "))
+	}
+	return lines
+}
+
+// renderSpellView shows the corrections in a dialog
+func (m Model) renderSpellView() string {
+	view := m.spellView
+	if view == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	lines := view.lines()
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		if index := view.offset + i; index < len(lines) {
+			content.WriteString("  " + mainStyles.Text.Render(truncateWithEllipsis(lines[index], contentWidth-2)))
+		}
+		content.WriteString("\n")
+	}
+
+	corrections := 0
+	for _, fix := range view.fixes {
+		if fix.suggestion != "" {
+			corrections++
+		}
+	}
+	headerText := fmt.Sprintf("Spell Check (%d corrections)", corrections)
+	footerText := "enter: save as new entry and copy | j/k: scroll | esc: cancel"
+	frameContent := m.buildFrameContent(headerText, content.String(), footerText, contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
@@ -0,0 +1,161 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// hunspellOutput is what "hunspell -a" answers for spellText
+const hunspellOutput = `@(#) International Ispell Version 3.2.06 (but really Hunspell 1.7.2)
+*
+& teh 3 4: the, tech, Ted
+*
+& teh 3 12: the, tech, Ted
+
+
+*
+# qzxv 7
+
+`
+
+const spellText = "See teh notes, teh rest\n\nand qzxv"
+
+func TestParseSpellOutput(t *testing.T) {
+	fixes := parseSpellOutput(strings.Split(spellText, "\n"), hunspellOutput)
+	want := []spellFix{
+		{line: 0, start: 4, word: "teh", suggestion: "the"},
+		{line: 0, start: 15, word: "teh", suggestion: "the"},
+		{line: 2, start: 4, word: "qzxv"},
+	}
+	if !reflect.DeepEqual(fixes, want) {
+		t.Fatalf("Unexpected fixes:\n got %+v\nwant %+v", fixes, want)
+	}
+
+	if got := applySpellFixes(spellText, fixes); got != "See the notes, the rest\n\nand qzxv" {
+		t.Errorf("Unexpected corrected text %q", got)
+	}
+}
+
+func TestFindWord(t *testing.T) {
+	if got := findWord("atehs teh", "teh", 0); got != 6 {
+		t.Errorf("Expected the whole word at 6, got %d", got)
+	}
+	if got := findWord("teh", "teh", 1); got != -1 {
+		t.Errorf("Expected no match after the offset, got %d", got)
+	}
+}
+
+func TestSpellCommand(t *testing.T) {
+	defer func(orig func(string) (string, error)) { lookPath = orig }(lookPath)
+
+	lookPath = func(name string) (string, error) {
+		if name == "aspell" {
+			return "/usr/bin/aspell", nil
+		}
+		return "", exec.ErrNotFound
+	}
+	if args, err := spellCommand(""); err != nil || !reflect.DeepEqual(args, []string{"aspell", "-a"}) {
+		t.Errorf("Expected aspell, got %v, %v", args, err)
+	}
+	if args, err := spellCommand("hunspell -a -d 'de_DE'"); err != nil || !reflect.DeepEqual(args, []string{"hunspell", "-a", "-d", "de_DE"}) {
+		t.Errorf("Expected the configured command, got %v, %v", args, err)
+	}
+
+	lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	if _, err := spellCommand(""); !errors.Is(err, errNoSpellChecker) {
+		t.Errorf("Expected errNoSpellChecker, got %v", err)
+	}
+}
+
+func TestSpellCheck_ReviewAndSave(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	var sent string
+	defer func(orig func([]string, string) (string, error)) { runSpellChecker = orig }(runSpellChecker)
+	runSpellChecker = func(args []string, input string) (string, error) {
+		sent = input
+		return hunspellOutput, nil
+	}
+
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	m := Model{
+		storage:      s,
+		config:       &config.Config{Editor: config.EditorConfig{SpellCommand: "hunspell -a"}},
+		themeService: NewThemeService(&config.ThemeConfig{}),
+	}
+	msg := m.spellCheckCmd(storage.ClipboardItem{Content: spellText, ContentType: "text"})().(spellCheckMsg)
+	if sent != "^See teh notes, teh rest\n^\n^and qzxv\n" {
+		t.Errorf("Expected every line to be sent with a leading ^, got %q", sent)
+	}
+
+	m.handleSpellCheck(msg)
+	if m.currentMode != modeSpellCheck {
+		t.Fatalf("Expected the review screen, got mode %d", m.currentMode)
+	}
+	text := strings.Join(m.spellView.lines(), "\n")
+	if !strings.Contains(text, "line 1: teh → the") || !strings.Contains(text, "qzxv → (no suggestion, kept)") {
+		t.Errorf("Expected the corrections to be listed, got:\n%s", text)
+	}
+
+	// Without a display the corrected text is copied through the terminal
+	copied, ok := m.handleSpellViewKey("enter")().(headlessCopyMsg)
+	if !ok || copied.content != "See the notes, the rest\n\nand qzxv" {
+		t.Errorf("Expected the corrected text to be copied, got %+v", copied)
+	}
+	if m.currentMode != modeList {
+		t.Error("Expected enter to close the review screen")
+	}
+	if items := s.GetAll(); len(items) != 1 || items[0].Content != "See the notes, the rest\n\nand qzxv" {
+		t.Errorf("Expected the corrected text as a new entry, got %+v", items)
+	}
+}
+
+func TestHandleSpellCheck_NothingToCorrect(t *testing.T) {
+	m := Model{themeService: NewThemeService(&config.ThemeConfig{})}
+	m.handleSpellCheck(spellCheckMsg{original: "fine text"})
+	if m.currentMode != modeList || m.toast == nil || m.toast.text != "No spelling mistakes found" {
+		t.Errorf("Expected a toast and no review screen, got %+v in mode %d", m.toast, m.currentMode)
+	}
+
+	m.handleSpellCheck(spellCheckMsg{original: "qzxv", fixes: []spellFix{{word: "qzxv"}}})
+	if m.currentMode != modeList || m.toast == nil || !strings.Contains(m.toast.text, "no suggestions") {
+		t.Errorf("Expected a toast for words without suggestions, got %+v", m.toast)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UIState is the part of the TUI state restored on the next start when
+// behavior.remember_state is enabled
+type UIState struct {
+	FilterMode   string `json:"filter_mode,omitempty"`
+	SearchQuery  string `json:"search_query,omitempty"`
+	SortBySize   bool   `json:"sort_by_size,omitempty"`
+	SortByLang   bool   `json:"sort_by_language,omitempty"`
+	CursorItemID string `json:"cursor_item_id,omitempty"` // Item the cursor was on
+	Cursor       int    `json:"cursor"`                   // Fallback position if that item is gone
+}
+
+// contentFilterModes lists the valid values of Model.filterMode
+var contentFilterModes = map[string]bool{
+	"":                true,
+	"images":          true,
+	"security-high":   true,
+	"security-medium": true,
+	"security-safe":   true,
+}
+
+// StatePath returns the location of the TUI state file
+func StatePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "nclip", "tui_state.json"), nil
+}
+
+// LoadState reads the state file, returning an empty state if it doesn't exist yet
+func LoadState(path string) (UIState, error) {
+	var state UIState
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read state file: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return UIState{}, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	return state, nil
+}
+
+// SaveState writes the state file, replacing it atomically
+func SaveState(path string, state UIState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	return writeFileAtomic(path, data, "state")
+}
+
+// writeFileAtomic replaces the file at path with data through a temporary file, so a
+// crash never leaves it half written. what names the file in errors.
+func writeFileAtomic(path string, data []byte, what string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", what, err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s file: %w", what, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s file: %w", what, err)
+	}
+	return nil
+}
+
+// State captures the filters and cursor position to persist
+func (m Model) State() UIState {
+	state := UIState{
+		FilterMode:  m.filterMode,
+		SearchQuery: m.searchQuery,
+		SortBySize:  m.sortBySize,
+		SortByLang:  m.sortByLanguage,
+		Cursor:      m.cursor,
+	}
+	if m.cursor >= 0 && m.cursor < len(m.filteredItems) {
+		state.CursorItemID = m.filteredItems[m.cursor].ID
+	}
+	return state
+}
+
+// RestoreState re-applies saved filters and moves the cursor back to the item it was on
+func (m *Model) RestoreState(state UIState) {
+	if contentFilterModes[state.FilterMode] {
+		m.filterMode = state.FilterMode
+	}
+	m.searchQuery = state.SearchQuery
+	m.searchCursor = len([]rune(m.searchQuery))
+	m.sortBySize = state.SortBySize
+	m.sortByLanguage = state.SortByLang && !state.SortBySize
+	m.filterItems()
+
+	m.cursor = 0
+	for i, item := range m.filteredItems {
+		if state.CursorItemID != "" && item.ID == state.CursorItemID {
+			m.cursor = i
+			return
+		}
+	}
+	if state.Cursor > 0 && state.Cursor < len(m.filteredItems) {
+		m.cursor = state.Cursor
+	}
+}
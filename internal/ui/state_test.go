@@ -0,0 +1,92 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestSaveAndLoadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nclip", "tui_state.json")
+
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("Expected a missing state file to be ignored, got %v", err)
+	}
+	if state != (UIState{}) {
+		t.Errorf("Expected an empty state, got %+v", state)
+	}
+
+	saved := UIState{FilterMode: "images", SearchQuery: "cat", CursorItemID: "42", Cursor: 3}
+	if err := SaveState(path, saved); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+	if loaded != saved {
+		t.Errorf("Expected %+v, got %+v", saved, loaded)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+	if _, err := LoadState(path); err == nil {
+		t.Error("Expected an error for a corrupt state file")
+	}
+}
+
+func TestRestoreState(t *testing.T) {
+	items := []storage.ClipboardItemMeta{
+		{ID: "1", Content: "text", ContentType: "text"},
+		{ID: "2", Content: "Image 10x10", ContentType: "image"},
+		{ID: "3", Content: "text", ContentType: "text"},
+		{ID: "4", Content: "Image 20x20", ContentType: "image"},
+	}
+
+	m := Model{items: items, filteredItems: items}
+	m.RestoreState(UIState{FilterMode: "images", CursorItemID: "4"})
+	if len(m.filteredItems) != 2 {
+		t.Fatalf("Expected the images filter to be applied, got %d items", len(m.filteredItems))
+	}
+	if m.filteredItems[m.cursor].ID != "4" {
+		t.Errorf("Expected the cursor on item 4, got %q", m.filteredItems[m.cursor].ID)
+	}
+	if state := m.State(); state.FilterMode != "images" || state.CursorItemID != "4" {
+		t.Errorf("Expected the restored state to round trip, got %+v", state)
+	}
+
+	// A deleted item falls back to the saved position, an unknown filter is ignored
+	m = Model{items: items, filteredItems: items}
+	m.RestoreState(UIState{FilterMode: "bogus", CursorItemID: "99", Cursor: 2})
+	if m.filterMode != "" || m.cursor != 2 {
+		t.Errorf("Expected no filter and cursor 2, got %q and %d", m.filterMode, m.cursor)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// statsView shows history totals and, when enabled, the usage insights
+type statsView struct {
+	lines  []string
+	offset int // First line shown
+}
+
+// openStatsView gathers the totals and switches to the stats screen
+func (m *Model) openStatsView() {
+	m.statsView = &statsView{lines: m.statsLines()}
+	m.currentMode = modeStats
+}
+
+// closeStatsView returns to the list
+func (m *Model) closeStatsView() {
+	m.statsView = nil
+	m.currentMode = modeList
+}
+
+// handleStatsViewKey scrolls the stats screen
+func (m *Model) handleStatsViewKey(key string) tea.Cmd {
+	view := m.statsView
+	if view == nil {
+		m.currentMode = modeList
+		return nil
+	}
+	switch key {
+	case "up", "k":
+		if view.offset > 0 {
+			view.offset--
+		}
+	case "down", "j":
+		if view.offset < len(view.lines)-1 {
+			view.offset++
+		}
+	case "g", "home":
+		view.offset = 0
+	case "esc", "q", "T":
+		m.closeStatsView()
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// statsLines lays out the totals and the usage insights for display
+func (m *Model) statsLines() []string {
+	var lines []string
+	if m.storage != nil {
+		lines = append(lines,
+			"History",
+			fmt.Sprintf("  %-16s %d", "Entries", m.storage.GetItemCount()),
+			fmt.Sprintf("  %-16s %d", "Pinned", m.storage.GetPinnedCount()),
+			fmt.Sprintf("  %-16s %d", "Images", len(m.storage.GetImages())),
+		)
+		if used, err := m.storage.UsedBytes(); err == nil {
+			lines = append(lines, fmt.Sprintf("  %-16s %s", "Database size", FormatSize(used)))
+		}
+		lines = append(lines, "")
+	}
+
+	if m.usage == nil {
+		return append(lines,
+			"Usage insights are off",
+			"  Set usage_insights = true under [behavior] in nclip.toml to count which",
+			"  features you use and list the ones you haven't tried yet. The counts stay",
+			"  in ~/.config/nclip/usage.json and are never sent anywhere.",
+		)
+	}
+
+	used, unused := m.usage.usageRanking()
+	lines = append(lines, "Most used since "+m.usage.Since.Format("2006-01-02"))
+	if len(used) == 0 {
+		lines = append(lines, "  Nothing counted yet")
+	}
+	for _, feature := range used {
+		lines = append(lines, fmt.Sprintf("  %6d  %-13s %s", m.usage.Counts[feature.id], feature.label, feature.description))
+	}
+	if len(unused) > 0 {
+		lines = append(lines, "", "Not used yet")
+		for _, feature := range unused {
+			lines = append(lines, fmt.Sprintf("  %-13s %s", feature.label, feature.description))
+		}
+	}
+	return lines
+}
+
+// renderStatsView shows the stats screen in a dialog
+func (m Model) renderStatsView() string {
+	view := m.statsView
+	if view == nil {
+		return m.renderMainWindow()
+	}
+	dialogWidth, dialogHeight, contentWidth, contentHeight := m.calculateDialogDimensions()
+	mainStyles := m.themeService.GetMainViewStyles()
+
+	var content strings.Builder
+	for i := 0; i < contentHeight; i++ {
+		if index := view.offset + i; index < len(view.lines) {
+			content.WriteString("  " + mainStyles.Text.Render(truncateWithEllipsis(view.lines[index], contentWidth-2)))
+		}
+		content.WriteString("\n")
+	}
+
+	frameContent := m.buildFrameContent("Stats", content.String(), "j/k: scroll | esc: close", contentWidth)
+	return m.createFramedDialog(dialogWidth, dialogHeight, frameContent)
+}
@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestStatsView(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	s, err := storage.New(10)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer s.Close()
+	s.Add("first")
+	s.Add("second")
+
+	m := Model{storage: s, themeService: NewThemeService(&config.ThemeConfig{})}
+	m.openStatsView()
+	if m.currentMode != modeStats {
+		t.Fatalf("Expected the stats screen, got mode %d", m.currentMode)
+	}
+	text := strings.Join(m.statsView.lines, "\n")
+	if !strings.Contains(text, "Entries          2") || !strings.Contains(text, "usage_insights = true") {
+		t.Errorf("Expected the totals and how to enable usage insights, got:\n%s", text)
+	}
+
+	m.handleStatsViewKey("esc")
+	m.EnableUsage(UsageStats{Since: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local), Counts: map[string]int{"search": 3}})
+	m.openStatsView()
+	text = strings.Join(m.statsView.lines, "\n")
+	if !strings.Contains(text, "Most used since 2026-01-02") || !strings.Contains(text, "3  /") {
+		t.Errorf("Expected search among the most used features, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Not used yet") || !strings.Contains(text, "Type a note to self") {
+		t.Errorf("Expected the unused features to be listed, got:\n%s", text)
+	}
+
+	m.handleStatsViewKey("T")
+	if m.currentMode != modeList || m.statsView != nil {
+		t.Error("Expected T to close the stats screen")
+	}
+}
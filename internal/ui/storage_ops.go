@@ -0,0 +1,129 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/logging"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// storageOpMsg reports the outcome of a storage operation run outside the Update path
+type storageOpMsg struct {
+	op      string // Human readable operation name, e.g. "delete", "pin"
+	id      string // ID of the affected item
+	err     error
+	change  *undoEntry // Set when the operation succeeded and can be undone
+	written ownWrite
+}
+
+// newOpSpinner creates the spinner shown while storage operations are in flight
+func newOpSpinner() spinner.Model {
+	return spinner.New(spinner.WithSpinner(spinner.MiniDot))
+}
+
+// startStorageOp runs fn as a tea.Cmd so a slow or locked database never blocks key handling.
+// The result arrives as a storageOpMsg and the footer shows a spinner until then.
+func (m *Model) startStorageOp(op, id string, fn func(s *storage.Storage) error) tea.Cmd {
+	return m.startUndoableOp(op, id, fn, nil)
+}
+
+// startUndoableOp runs fn like startStorageOp and, once it succeeds, records it on the
+// undo stack with inverse, which reverts it. A nil inverse records nothing.
+func (m *Model) startUndoableOp(op, id string, fn, inverse func(s *storage.Storage) error) tea.Cmd {
+	m.pendingOps++
+
+	store := m.storage
+	run := func() tea.Msg {
+		msg := storageOpMsg{op: op, id: id}
+		msg.written, msg.err = trackWrite(store, func() error { return fn(store) })
+		if msg.err == nil && inverse != nil {
+			msg.change = &undoEntry{op: op, id: id, undo: inverse, redo: fn}
+		}
+		return msg
+	}
+
+	// Only start ticking when the spinner isn't already running
+	if m.pendingOps == 1 {
+		return tea.Batch(run, m.opSpinner.Tick)
+	}
+	return run
+}
+
+// deleteItemCmd deletes an item from the archive or the live history, depending on what is
+// being browsed; ctrl+z brings it back
+func (m *Model) deleteItemCmd(id string) tea.Cmd {
+	return m.undoableDelete(id)
+}
+
+// deleteStoredItem removes an item from the archive table or the live history
+func deleteStoredItem(s *storage.Storage, archive bool, id string) error {
+	if archive {
+		return s.DeleteArchived(id)
+	}
+	return s.Delete(id)
+}
+
+// handleStorageOpResult refreshes the list after a storage operation and reports any failure
+func (m *Model) handleStorageOpResult(msg storageOpMsg) tea.Cmd {
+	if m.pendingOps > 0 {
+		m.pendingOps--
+	}
+
+	var cmd tea.Cmd
+	if msg.err != nil {
+		failure := errorToast(msg.op, msg.err)
+		cmd = m.showToast(failure.level, failure.text)
+	} else {
+		logging.Debug("%s completed for item %s", msg.op, msg.id)
+		if msg.change != nil {
+			m.recordChange(msg.change)
+		}
+	}
+
+	m.applyItemChange(msg.id, msg.written)
+
+	// Adjust cursor if items disappeared
+	if m.cursor >= len(m.filteredItems) && len(m.filteredItems) > 0 {
+		m.cursor = len(m.filteredItems) - 1
+	} else if len(m.filteredItems) == 0 {
+		m.cursor = 0
+	}
+
+	return cmd
+}
+
+// statusIndicator returns the footer indicator for in-flight storage operations
+func (m Model) statusIndicator() string {
+	if status := m.bulkStatus(); status != "" {
+		return status
+	}
+	if m.pendingOps > 0 {
+		return "[" + m.opSpinner.View() + " saving]"
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestStartStorageOp_TracksPendingOperations(t *testing.T) {
+	m := Model{opSpinner: newOpSpinner()}
+
+	cmd := m.startStorageOp("pin", "1", func(s *storage.Storage) error {
+		return errors.New("database is locked")
+	})
+	if cmd == nil {
+		t.Fatal("Expected a command to run the storage operation")
+	}
+	if m.pendingOps != 1 {
+		t.Errorf("Expected 1 pending operation, got %d", m.pendingOps)
+	}
+	if indicator := m.statusIndicator(); !strings.Contains(indicator, "saving") {
+		t.Errorf("Expected saving indicator while pending, got '%s'", indicator)
+	}
+}
+
+func TestStorageStatusIndicator(t *testing.T) {
+	m := Model{opSpinner: newOpSpinner()}
+	if indicator := m.statusIndicator(); indicator != "" {
+		t.Errorf("Expected no indicator when idle, got '%s'", indicator)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !nochroma
+
 /*
 MIT License
 
@@ -26,17 +28,22 @@ package ui
 
 import (
 	"bytes"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/alecthomas/chroma/v2"
 	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
+
+	"github.com/adaryorg/nclip/internal/config"
 )
 
 // CodeDetector detects if text content is source code and determines the language using Chroma
 type CodeDetector struct {
-	// No fields needed - Chroma handles lexer caching internally
+	// Chroma handles lexer caching internally, only the custom style is kept here
+	customStyle *chroma.Style // Built from [code_highlight] for the "custom" theme
 }
 
 // NewCodeDetector creates a new code detector
@@ -91,7 +98,9 @@ func (cd *CodeDetector) HighlightCodeWithTheme(content, language string, useBasi
 
 	// Choose style based on theme configuration and terminal capabilities
 	var baseStyle *chroma.Style
-	if themeName != "" {
+	if themeName == config.CustomChromaTheme {
+		baseStyle = cd.customStyle
+	} else if themeName != "" {
 		// Use the specified theme
 		baseStyle = styles.Get(themeName)
 	}
@@ -149,3 +158,110 @@ func (cd *CodeDetector) HighlightCodeWithTheme(content, language string, useBasi
 	return strings.Split(highlighted, "\n"), nil
 }
 
+
+// SetCustomStyle builds the Chroma style used for the "custom" theme from [code_highlight]
+func (cd *CodeDetector) SetCustomStyle(theme config.CodeHighlightTheme) error {
+	style, err := customChromaStyle(theme)
+	if err != nil {
+		return err
+	}
+	cd.customStyle = style
+	return nil
+}
+
+// customChromaStyle maps the [code_highlight] colors onto Chroma token types
+func customChromaStyle(theme config.CodeHighlightTheme) (*chroma.Style, error) {
+	builder := chroma.NewStyleBuilder(config.CustomChromaTheme)
+	mapping := []struct {
+		token chroma.TokenType
+		color config.ColorConfig
+	}{
+		{chroma.Keyword, theme.Keyword},
+		{chroma.LiteralString, theme.String},
+		{chroma.Comment, theme.Comment},
+		{chroma.LiteralNumber, theme.Number},
+		{chroma.NameFunction, theme.Function},
+		{chroma.KeywordType, theme.Type},
+		{chroma.NameClass, theme.Type},
+		{chroma.Operator, theme.Operator},
+	}
+	for _, m := range mapping {
+		if entry := chromaStyleEntry(m.color); entry != "" {
+			builder.Add(m.token, entry)
+		}
+	}
+
+	style, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build custom chroma style: %w", err)
+	}
+	return style, nil
+}
+
+// chromaStyleEntry converts a theme color into a Chroma style entry such as "bold #af87ff"
+func chromaStyleEntry(color config.ColorConfig) string {
+	var parts []string
+	if color.Bold {
+		parts = append(parts, "bold")
+	}
+	if fg := chromaColour(color.Foreground); fg != "" {
+		parts = append(parts, fg)
+	}
+	if bg := chromaColour(color.Background); bg != "" {
+		parts = append(parts, "bg:"+bg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// chromaColour converts an ANSI code, hex value or CSS name into the hex form Chroma needs
+func chromaColour(color string) string {
+	if color == "" {
+		return ""
+	}
+	if code, err := strconv.Atoi(color); err == nil {
+		if code < 0 || code > 255 {
+			return ""
+		}
+		return ansi256ToHex(code)
+	}
+	if hex := string(parseColor(color)); strings.HasPrefix(hex, "#") {
+		return hex
+	}
+	return ""
+}
+
+// ansi16Hex holds the xterm values of the 16 basic ANSI colors
+var ansi16Hex = []string{
+	"#000000", "#800000", "#008000", "#808000", "#000080", "#800080", "#008080", "#c0c0c0",
+	"#808080", "#ff0000", "#00ff00", "#ffff00", "#0000ff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansi256ToHex converts an xterm 256-color code to its hex value
+func ansi256ToHex(code int) string {
+	switch {
+	case code < 16:
+		return ansi16Hex[code]
+	case code < 232:
+		// 6x6x6 color cube
+		levels := []int{0, 95, 135, 175, 215, 255}
+		code -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[code/36], levels[(code/6)%6], levels[code%6])
+	default:
+		// Grayscale ramp
+		gray := 8 + (code-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// canonicalLanguage maps a language name or alias (py, golang) to Chroma's primary alias
+func canonicalLanguage(name string) string {
+	if name == "" || name == plainTextLanguage {
+		return name
+	}
+	if lexer := lexers.Get(name); lexer != nil {
+		if config := lexer.Config(); config != nil && len(config.Aliases) > 0 {
+			return config.Aliases[0]
+		}
+	}
+	return name
+}
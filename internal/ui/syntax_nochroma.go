@@ -0,0 +1,67 @@
+//go:build nochroma
+
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+
+	"github.com/adaryorg/nclip/internal/config"
+)
+
+// CodeDetector stands in for the Chroma detector in builds tagged nochroma: no language
+// is ever detected and code is shown as plain text
+type CodeDetector struct{}
+
+// NewCodeDetector creates a new code detector
+func NewCodeDetector() *CodeDetector {
+	return &CodeDetector{}
+}
+
+// DetectLanguage never detects a language without Chroma
+func (cd *CodeDetector) DetectLanguage(content string) (string, bool) {
+	return "", false
+}
+
+// HighlightCode returns the content split into lines, unhighlighted
+func (cd *CodeDetector) HighlightCode(content, language string, useBasicColors bool) ([]string, error) {
+	return strings.Split(content, "\n"), nil
+}
+
+// HighlightCodeWithTheme returns the content split into lines, unhighlighted
+func (cd *CodeDetector) HighlightCodeWithTheme(content, language string, useBasicColors bool, themeName string) ([]string, error) {
+	return strings.Split(content, "\n"), nil
+}
+
+// SetCustomStyle has nothing to build without Chroma
+func (cd *CodeDetector) SetCustomStyle(theme config.CodeHighlightTheme) error {
+	return nil
+}
+
+// canonicalLanguage returns name as given, since there are no lexer aliases to map it through
+func canonicalLanguage(name string) string {
+	return name
+}
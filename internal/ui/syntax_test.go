@@ -1,3 +1,5 @@
+//go:build !nochroma
+
 /*
 MIT License
 
@@ -27,6 +29,10 @@ package ui
 import (
 	"strings"
 	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+
+	"github.com/adaryorg/nclip/internal/config"
 )
 
 func TestCodeDetector_DetectLanguage(t *testing.T) {
@@ -336,10 +342,57 @@ No programming patterns here.`,
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := detector.detectByContent(test.content)
+			result, _ := detector.DetectLanguage(test.content)
 			if result != test.expected {
 				t.Errorf("Expected %q, got %q", test.expected, result)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestAnsi256ToHex(t *testing.T) {
+	tests := map[int]string{
+		1:   "#800000",
+		15:  "#ffffff",
+		16:  "#000000",
+		141: "#af87ff",
+		231: "#ffffff",
+		232: "#080808",
+		255: "#eeeeee",
+	}
+	for code, expected := range tests {
+		if got := ansi256ToHex(code); got != expected {
+			t.Errorf("ansi256ToHex(%d) = %s, expected %s", code, got, expected)
+		}
+	}
+}
+
+func TestCodeDetector_CustomStyle(t *testing.T) {
+	detector := NewCodeDetector()
+	theme := config.CodeHighlightTheme{
+		Keyword: config.ColorConfig{Foreground: "141", Bold: true},
+		String:  config.ColorConfig{Foreground: "#00ff00"},
+		Comment: config.ColorConfig{Foreground: "gray"},
+	}
+	if err := detector.SetCustomStyle(theme); err != nil {
+		t.Fatalf("Failed to build custom style: %v", err)
+	}
+
+	keyword := detector.customStyle.Get(chroma.Keyword)
+	if keyword.Colour.String() != "#af87ff" || keyword.Bold != chroma.Yes {
+		t.Errorf("Expected bold #af87ff keywords, got %s", keyword.String())
+	}
+	if str := detector.customStyle.Get(chroma.LiteralString); str.Colour.String() != "#00ff00" {
+		t.Errorf("Expected #00ff00 strings, got %s", str.String())
+	}
+	if comment := detector.customStyle.Get(chroma.Comment); comment.Colour.String() != "#808080" {
+		t.Errorf("Expected #808080 comments, got %s", comment.String())
+	}
+
+	lines, err := detector.HighlightCodeWithTheme("package main\n", "go", false, config.CustomChromaTheme)
+	if err != nil {
+		t.Fatalf("Failed to highlight with custom theme: %v", err)
+	}
+	if !strings.Contains(lines[0], "\x1b[") {
+		t.Errorf("Expected ANSI colors in custom highlighted output, got %q", lines[0])
+	}
+}
@@ -0,0 +1,148 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// tempFiles creates the temp files that hold clipboard content for external editors and
+// viewers. They live in a private directory and are named after the owning process, so a
+// session removes its own files on exit and the next session sweeps up after a crash.
+type tempFiles struct {
+	dir string
+	err error // Set when the private directory is unusable; no temp files are created then
+}
+
+// newTempFiles prepares the private temp directory and removes files left by dead sessions
+func newTempFiles(configured string) *tempFiles {
+	dir, err := privateTempDir(configured)
+	if err != nil {
+		logging.Error("Temp directory unavailable, external editing disabled: %v", err)
+		return &tempFiles{err: err}
+	}
+	sweepStaleTempFiles(dir)
+	return &tempFiles{dir: dir}
+}
+
+// privateTempDir returns the directory for temp files, creating it with 0700 permissions.
+// configured overrides the default of $XDG_RUNTIME_DIR/nclip, which is tmpfs on most systems.
+func privateTempDir(configured string) (string, error) {
+	dir := configured
+	if strings.HasPrefix(dir, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(homeDir, dir[2:])
+	}
+	if dir == "" {
+		if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+			dir = filepath.Join(runtimeDir, "nclip")
+		} else {
+			dir = filepath.Join(os.TempDir(), fmt.Sprintf("nclip-%d", os.Getuid()))
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	// Refuse a directory someone else could have prepared, e.g. a guessed path in /tmp
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("temp directory %s is not a directory", dir)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return "", fmt.Errorf("temp directory %s is owned by another user", dir)
+	}
+	if info.Mode().Perm() != 0700 {
+		if err := os.Chmod(dir, 0700); err != nil {
+			return "", fmt.Errorf("failed to restrict temp directory: %w", err)
+		}
+	}
+	return dir, nil
+}
+
+// create makes a new 0600 temp file; pattern works as in os.CreateTemp
+func (t *tempFiles) create(pattern string) (*os.File, error) {
+	if t == nil {
+		return nil, fmt.Errorf("temp files are not set up")
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	return os.CreateTemp(t.dir, tempFilePrefix(os.Getpid())+pattern)
+}
+
+// tempFilePrefix names the files that belong to the process with the given pid
+func tempFilePrefix(pid int) string {
+	return fmt.Sprintf("nclip-%d-", pid)
+}
+
+// RemoveTempFiles deletes the temp files this process created in the configured directory.
+// Editors still open on them lose their file, which is intended once nclip exits.
+func RemoveTempFiles(configured string) {
+	dir, err := privateTempDir(configured)
+	if err != nil {
+		return
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, tempFilePrefix(os.Getpid())+"*"))
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logging.Warn("Failed to remove temp file %s: %v", path, err)
+		}
+	}
+}
+
+// sweepStaleTempFiles removes temp files whose session is no longer running
+func sweepStaleTempFiles(dir string) {
+	paths, _ := filepath.Glob(filepath.Join(dir, "nclip-*-*"))
+	for _, path := range paths {
+		fields := strings.SplitN(filepath.Base(path), "-", 3)
+		pid, err := strconv.Atoi(fields[1])
+		if err != nil || processAlive(pid) {
+			continue
+		}
+		logging.Debug("Removing stale temp file %s", path)
+		os.Remove(path)
+	}
+}
+
+// processAlive reports whether a process with the given pid exists
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
@@ -0,0 +1,126 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrivateTempDir_DefaultsToRuntimeDir(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+
+	dir, err := privateTempDir("")
+	if err != nil {
+		t.Fatalf("privateTempDir failed: %v", err)
+	}
+	if dir != filepath.Join(runtimeDir, "nclip") {
+		t.Errorf("Expected $XDG_RUNTIME_DIR/nclip, got %s", dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("Expected a 0700 directory, got %v, %v", info, err)
+	}
+}
+
+func TestPrivateTempDir_RestrictsExistingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shared")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := privateTempDir(dir); err != nil {
+		t.Fatalf("privateTempDir failed: %v", err)
+	}
+	if info, _ := os.Stat(dir); info.Mode().Perm() != 0700 {
+		t.Errorf("Expected permissions to be tightened to 0700, got %v", info.Mode().Perm())
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0600)
+	if _, err := privateTempDir(file); err == nil {
+		t.Error("Expected an error for a path that is not a directory")
+	}
+}
+
+func TestTempFiles_CreateAndRemove(t *testing.T) {
+	dir := t.TempDir()
+	temp := newTempFiles(dir)
+
+	f, err := temp.create("edit-*.txt")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	f.Close()
+	if !strings.HasPrefix(filepath.Base(f.Name()), tempFilePrefix(os.Getpid())) || filepath.Dir(f.Name()) != dir {
+		t.Errorf("Unexpected temp file %s", f.Name())
+	}
+	if info, _ := os.Stat(f.Name()); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 temp file, got %v", info.Mode().Perm())
+	}
+
+	RemoveTempFiles(dir)
+	if _, err := os.Stat(f.Name()); !os.IsNotExist(err) {
+		t.Error("Expected RemoveTempFiles to delete this process's files")
+	}
+}
+
+func TestTempFiles_SweepsDeadSessions(t *testing.T) {
+	dir := t.TempDir()
+	// No process has a pid this high (it is above the kernel's pid_max limit)
+	stale := filepath.Join(dir, tempFilePrefix(99999999)+"edit-1.txt")
+	own := filepath.Join(dir, tempFilePrefix(os.Getpid())+"edit-2.txt")
+	other := filepath.Join(dir, "notes.txt")
+	for _, path := range []string{stale, own, other} {
+		os.WriteFile(path, []byte("secret"), 0600)
+	}
+
+	newTempFiles(dir)
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("Expected the dead session's file to be removed")
+	}
+	for _, path := range []string{own, other} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be kept: %v", filepath.Base(path), err)
+		}
+	}
+}
+
+func TestTempFiles_UnusableDirRefusesCreate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0600)
+
+	if _, err := newTempFiles(file).create("edit-*.txt"); err == nil {
+		t.Error("Expected create to fail without a private directory")
+	}
+	var missing *tempFiles
+	if _, err := missing.create("edit-*.txt"); err == nil {
+		t.Error("Expected create to fail when temp files are not set up")
+	}
+}
@@ -34,6 +34,13 @@ import (
 type TerminalCapabilities struct {
 	SupportsUnicode bool
 	SupportsColor   bool
+	TextLabels      bool // Spell out indicators instead of using symbols (accessibility mode)
+}
+
+// AccessibleCapabilities returns the capabilities used in accessibility mode: plain
+// text labels that screen readers can read out, without symbols or colors
+func AccessibleCapabilities() TerminalCapabilities {
+	return TerminalCapabilities{TextLabels: true}
 }
 
 // DetectTerminalCapabilities analyzes the current terminal's capabilities
@@ -46,8 +53,9 @@ func DetectTerminalCapabilities(basicTerminal bool) TerminalCapabilities {
 		}
 	}
 
-	// Check if we're in a TTY (no graphical environment)
-	if isTTY() {
+	// Check if we're in a TTY (no graphical environment), unless the terminal
+	// answered the probe with features no console has
+	if isTTY() && !(probed != nil && (probed.Kitty || probed.TrueColor)) {
 		return TerminalCapabilities{
 			SupportsUnicode: false,
 			SupportsColor:   true, // TTY supports basic colors
@@ -98,6 +106,14 @@ type SecurityIndicators struct {
 
 // GetSecurityIndicators returns appropriate security indicators based on terminal capabilities
 func GetSecurityIndicators(caps TerminalCapabilities) SecurityIndicators {
+	if caps.TextLabels {
+		return SecurityIndicators{
+			HighRisk:   "[HIGH RISK]",
+			MediumRisk: "[MEDIUM RISK]",
+			Clean:      "",
+			Safe:       "[SAFE]",
+		}
+	}
 	if caps.SupportsUnicode {
 		// Use Unicode symbols that are more widely supported
 		return SecurityIndicators{
@@ -166,7 +182,11 @@ type SecurityIconHelper struct {
 
 // NewSecurityIconHelper creates a new security icon helper
 func NewSecurityIconHelper(basicTerminal bool) *SecurityIconHelper {
-	caps := DetectTerminalCapabilities(basicTerminal)
+	return newSecurityIconHelperWithCaps(DetectTerminalCapabilities(basicTerminal))
+}
+
+// newSecurityIconHelperWithCaps creates a security icon helper for the given capabilities
+func newSecurityIconHelperWithCaps(caps TerminalCapabilities) *SecurityIconHelper {
 	indicators := GetSecurityIndicators(caps)
 
 	return &SecurityIconHelper{
@@ -212,6 +232,9 @@ func (s *SecurityIconHelper) GetCapabilities() TerminalCapabilities {
 
 // GetIndicatorDescription returns a human-readable description of the indicators
 func (s *SecurityIconHelper) GetIndicatorDescription() string {
+	if s.caps.TextLabels {
+		return "Labels: [HIGH RISK], [MEDIUM RISK], [SAFE]"
+	}
 	if s.caps.SupportsUnicode {
 		return "Icons: ⚠=high risk ⚡=medium risk ✓=safe"
 	}
@@ -225,6 +248,11 @@ type PinIndicators struct {
 
 // GetPinIndicators returns appropriate pin indicators based on terminal capabilities
 func GetPinIndicators(caps TerminalCapabilities) PinIndicators {
+	if caps.TextLabels {
+		return PinIndicators{
+			PinFormat: "[PIN %d]",
+		}
+	}
 	if caps.SupportsUnicode {
 		// Use pin icon with number
 		return PinIndicators{
@@ -246,7 +274,11 @@ type PinIconHelper struct {
 
 // NewPinIconHelper creates a new pin icon helper
 func NewPinIconHelper(basicTerminal bool) *PinIconHelper {
-	caps := DetectTerminalCapabilities(basicTerminal)
+	return newPinIconHelperWithCaps(DetectTerminalCapabilities(basicTerminal))
+}
+
+// newPinIconHelperWithCaps creates a pin icon helper for the given capabilities
+func newPinIconHelperWithCaps(caps TerminalCapabilities) *PinIconHelper {
 	indicators := GetPinIndicators(caps)
 
 	return &PinIconHelper{
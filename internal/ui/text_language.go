@@ -0,0 +1,55 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"sort"
+
+	"github.com/adaryorg/nclip/internal/storage"
+	"github.com/adaryorg/nclip/internal/textlang"
+)
+
+// sortByTextLanguage groups items by the natural language of their text, languages in
+// alphabetical order and items without one last, keeping the history order within each
+// group. The input slice is left alone since it may be shared with the cache.
+func sortByTextLanguage(items []storage.ClipboardItemMeta) []storage.ClipboardItemMeta {
+	sorted := append([]storage.ClipboardItemMeta(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].TextLanguage, sorted[j].TextLanguage
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		return textlang.Name(a) < textlang.Name(b)
+	})
+	return sorted
+}
+
+// textLanguageLabel names an item's natural language for display, "" if undetected
+func textLanguageLabel(meta storage.ClipboardItemMeta) string {
+	if meta.TextLanguage == "" {
+		return ""
+	}
+	return textlang.Name(meta.TextLanguage)
+}
@@ -0,0 +1,83 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/config"
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+func TestSortByTextLanguage(t *testing.T) {
+	items := []storage.ClipboardItemMeta{
+		{ID: "image", Content: "Image", ContentType: "image", Size: 3 << 20},
+		{ID: "de1", Content: "Das ist gut", ContentType: "text", TextLanguage: "de", Size: 2},
+		{ID: "en", Content: "This is good", ContentType: "text", TextLanguage: "en", Size: 1},
+		{ID: "code", Content: "x := 1", ContentType: "text"},
+		{ID: "de2", Content: "Und das auch", ContentType: "text", TextLanguage: "de"},
+	}
+	m := Model{
+		items:         items,
+		filteredItems: items,
+		themeService:  NewThemeService(&config.ThemeConfig{}),
+		width:         100,
+		height:        30,
+		sortBySize:    true,
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	var order []string
+	for _, item := range m.filteredItems {
+		order = append(order, item.ID)
+	}
+	if strings.Join(order, ",") != "en,de1,de2,image,code" {
+		t.Errorf("Expected English, then German, then undetected items, got %v", order)
+	}
+	if m.sortBySize {
+		t.Error("Expected grouping by language to replace the size sort")
+	}
+
+	lines := m.listItemLines(m.filteredItems[1], 80)
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "[German] ") {
+		t.Errorf("Expected the language in front of the item, got %q", lines)
+	}
+	if view := m.View(); !strings.Contains(view, "[BY LANGUAGE]") {
+		t.Error("Expected the footer to show the language grouping")
+	}
+	if !m.State().SortByLang {
+		t.Error("Expected the language grouping to be saved in the state")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	if m.filteredItems[0].ID != "image" {
+		t.Errorf("Expected the history order back, got %s first", m.filteredItems[0].ID)
+	}
+}
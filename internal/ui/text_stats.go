@@ -0,0 +1,80 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package ui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// textStats summarizes a text item for the viewer header
+type textStats struct {
+	lines  int
+	words  int
+	chars  int // Characters, not bytes
+	bytes  int
+	sha256 string // Hex SHA-256 of the content as stored
+}
+
+// contentStats counts the lines, words, characters and bytes of content and hashes it
+func contentStats(content string) textStats {
+	sum := sha256.Sum256([]byte(content))
+	return textStats{
+		lines:  strings.Count(content, "\n") + 1,
+		words:  len(strings.Fields(content)),
+		chars:  utf8.RuneCountInString(content),
+		bytes:  len(content),
+		sha256: hex.EncodeToString(sum[:]),
+	}
+}
+
+// summary describes the stats in one line, with the hash shortened
+func (s textStats) summary() string {
+	return fmt.Sprintf("%d lines, %d words, %d chars, %s, sha256 %s",
+		s.lines, s.words, s.chars, FormatSize(int64(s.bytes)), shortHash(s.sha256))
+}
+
+// setViewingText shows item in the text viewer and computes its stats once, rather than
+// hashing the whole text on every redraw
+func (m *Model) setViewingText(item *storage.ClipboardItem) {
+	m.viewingText = item
+	m.viewingStats = contentStats(item.Content)
+}
+
+// copyTextHashCmd copies the full SHA-256 of the viewed text, for verifying a download
+// or pasting next to a shared blob
+func (m *Model) copyTextHashCmd() tea.Cmd {
+	if m.viewingText == nil {
+		return nil
+	}
+	hash := m.viewingStats.sha256
+	return m.copyItemCmd(storage.ClipboardItem{Content: hash, ContentType: "text"})
+}
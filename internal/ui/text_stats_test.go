@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const helloWorldSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+func TestContentStats(t *testing.T) {
+	stats := contentStats("hello world")
+	if stats.lines != 1 || stats.words != 2 || stats.chars != 11 || stats.bytes != 11 {
+		t.Errorf("Unexpected counts for a single line: %+v", stats)
+	}
+	if stats.sha256 != helloWorldSHA256 {
+		t.Errorf("sha256 = %s, want %s", stats.sha256, helloWorldSHA256)
+	}
+
+	// Characters and bytes differ once the text leaves ASCII
+	stats = contentStats("grüße  aus\n\tköln\n")
+	if stats.lines != 3 || stats.words != 3 || stats.chars != 17 || stats.bytes != 20 {
+		t.Errorf("Unexpected counts for multi-byte text: %+v", stats)
+	}
+}
+
+func TestTextView_HeaderShowsStats(t *testing.T) {
+	m := newDecodeTestModel("hello world")
+	view := m.renderTextView()
+	for _, want := range []string{"1 lines, 2 words, 11 chars, 11 B", "sha256 " + helloWorldSHA256[:12]} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the header to contain %q", want)
+		}
+	}
+}
+
+func TestTextView_CopyHash(t *testing.T) {
+	m := newDecodeTestModel("hello world")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Error("Expected h to copy the hash")
+	}
+	if m.currentMode != modeList || m.viewingText != nil {
+		t.Error("Expected the viewer to close after copying the hash")
+	}
+}
@@ -33,7 +33,8 @@ import (
 
 // ThemeService provides styled components based on the theme configuration
 type ThemeService struct {
-	config *config.ThemeConfig
+	config       *config.ThemeConfig
+	highContrast bool
 }
 
 // NewThemeService creates a new theme service
@@ -43,6 +44,12 @@ func NewThemeService(themeConfig *config.ThemeConfig) *ThemeService {
 	}
 }
 
+// SetHighContrast replaces the theme's colors with bold and reverse video, used in
+// accessibility mode where colors are not rendered
+func (ts *ThemeService) SetHighContrast(enabled bool) {
+	ts.highContrast = enabled
+}
+
 // MainViewStyles returns all styles for the main view
 type MainViewStyles struct {
 	Border              lipgloss.Style
@@ -66,6 +73,9 @@ type MainViewStyles struct {
 
 // GetMainViewStyles returns styled components for the main view
 func (ts *ThemeService) GetMainViewStyles() MainViewStyles {
+	if ts.highContrast {
+		return highContrastMainViewStyles()
+	}
 	return MainViewStyles{
 		Border:              colorConfigToStyle(ts.config.Main.Border),
 		Header:              colorConfigToStyle(ts.config.Main.Header),
@@ -0,0 +1,120 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// toastLevel is the severity of a transient message
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastSuccess
+	toastWarning
+	toastError
+)
+
+// toast is a transient message shown in the footer until it expires
+type toast struct {
+	id    int
+	level toastLevel
+	text  string
+}
+
+// toastMsg asks the model to show a toast; background commands return it to report results
+type toastMsg struct {
+	level toastLevel
+	text  string
+}
+
+// toastExpiredMsg dismisses the toast with the given id if it is still showing
+type toastExpiredMsg struct {
+	id int
+}
+
+// errorToast builds a toastMsg describing a failed action and records it in the log
+func errorToast(action string, err error) toastMsg {
+	logging.Error("%s failed: %v", action, err)
+	return toastMsg{level: toastError, text: fmt.Sprintf("%s failed: %v", action, err)}
+}
+
+// duration returns how long a toast of this level stays visible
+func (l toastLevel) duration() time.Duration {
+	switch l {
+	case toastError:
+		return 6 * time.Second
+	case toastWarning:
+		return 4 * time.Second
+	default:
+		return 2 * time.Second
+	}
+}
+
+// showToast replaces the current toast and schedules its dismissal
+func (m *Model) showToast(level toastLevel, text string) tea.Cmd {
+	m.toastSeq++
+	id := m.toastSeq
+	m.toast = &toast{id: id, level: level, text: text}
+	m.cue()
+
+	return tea.Tick(level.duration(), func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// dismissToast clears the toast if it is the one the expiry refers to
+func (m *Model) dismissToast(id int) {
+	if m.toast != nil && m.toast.id == id {
+		m.toast = nil
+	}
+}
+
+// renderToast renders the current toast styled by severity, truncated to maxWidth
+func (m Model) renderToast(maxWidth int, mainStyles MainViewStyles) string {
+	if m.toast == nil || maxWidth <= 0 {
+		return ""
+	}
+
+	text := truncateWithEllipsis(m.toast.text, maxWidth)
+
+	var style lipgloss.Style
+	switch m.toast.level {
+	case toastError:
+		style = mainStyles.HighRiskIndicator
+	case toastWarning:
+		style = mainStyles.MediumRiskIndicator
+	default:
+		style = mainStyles.FilterIndicator
+	}
+	return style.Render(text)
+}
@@ -0,0 +1,91 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestShowToast_ReplacesAndDismisses(t *testing.T) {
+	m := Model{}
+
+	if cmd := m.showToast(toastInfo, "first"); cmd == nil {
+		t.Fatal("Expected an auto-dismiss command")
+	}
+	firstID := m.toast.id
+
+	m.showToast(toastError, "second")
+	if m.toast.text != "second" || m.toast.level != toastError {
+		t.Errorf("Expected newer toast to replace the old one, got %+v", m.toast)
+	}
+
+	// Expiry of the replaced toast must not hide the newer one
+	m.dismissToast(firstID)
+	if m.toast == nil {
+		t.Fatal("Expected stale expiry to leave the current toast visible")
+	}
+
+	m.dismissToast(m.toast.id)
+	if m.toast != nil {
+		t.Error("Expected toast to be dismissed")
+	}
+}
+
+func TestErrorToast(t *testing.T) {
+	msg := errorToast("delete", errors.New("database is locked"))
+	if msg.level != toastError {
+		t.Errorf("Expected error level, got %d", msg.level)
+	}
+	if msg.text != "delete failed: database is locked" {
+		t.Errorf("Unexpected toast text '%s'", msg.text)
+	}
+}
+
+func TestToastLevelDuration(t *testing.T) {
+	if toastError.duration() <= toastInfo.duration() {
+		t.Error("Expected errors to stay visible longer than info messages")
+	}
+}
+
+func TestRenderToast_Truncates(t *testing.T) {
+	m := Model{toast: &toast{level: toastWarning, text: strings.Repeat("x", 50)}}
+
+	rendered := m.renderToast(20, MainViewStyles{})
+	if width := lipgloss.Width(rendered); width != 20 {
+		t.Errorf("Expected toast truncated to 20 cells, got %d", width)
+	}
+	if !strings.HasSuffix(rendered, "...") {
+		t.Errorf("Expected truncated toast to end with ellipsis, got '%s'", rendered)
+	}
+
+	m.toast = nil
+	if rendered := m.renderToast(20, MainViewStyles{}); rendered != "" {
+		t.Errorf("Expected empty render without a toast, got '%s'", rendered)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// maxUndo bounds the undo stack; the oldest changes are forgotten first
+const maxUndo = 50
+
+// undoEntry is a change made in this session, with the storage operations that revert
+// and repeat it
+type undoEntry struct {
+	op   string // What was done, e.g. "delete"
+	id   string
+	undo func(s *storage.Storage) error
+	redo func(s *storage.Storage) error
+}
+
+// undoDoneMsg reports the outcome of undoing or redoing a change
+type undoDoneMsg struct {
+	entry   *undoEntry
+	redo    bool
+	err     error
+	written ownWrite
+}
+
+// snapshotChange records a change that is reverted by writing back the item as it was
+// before, and repeated by running apply again
+func snapshotChange(op string, snap *storage.ItemSnapshot, apply func(s *storage.Storage) error) *undoEntry {
+	return &undoEntry{
+		op:   op,
+		id:   snap.ID,
+		undo: func(s *storage.Storage) error { return s.RestoreSnapshot(snap) },
+		redo: apply,
+	}
+}
+
+// undoableDelete deletes an item, keeping a copy so ctrl+z can put it back. Nothing is kept
+// when deleted entries are shredded, as the copy would outlive the shredding.
+func (m *Model) undoableDelete(id string) tea.Cmd {
+	archive := m.archiveMode
+	remove := func(s *storage.Storage) error {
+		return deleteStoredItem(s, archive, id)
+	}
+	if m.config != nil && m.config.Database.ShredDeleted {
+		return m.startStorageOp("delete", id, remove)
+	}
+
+	var snap *storage.ItemSnapshot
+	return m.startUndoableOp("delete", id, func(s *storage.Storage) error {
+		var err error
+		if snap, err = s.Snapshot(id, archive); err != nil {
+			return err
+		}
+		return remove(s)
+	}, func(s *storage.Storage) error {
+		return s.RestoreSnapshot(snap)
+	})
+}
+
+// recordChange puts a change on the undo stack, which ends any redo history
+func (m *Model) recordChange(entry *undoEntry) {
+	m.undoStack = append(m.undoStack, entry)
+	if len(m.undoStack) > maxUndo {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndo:]
+	}
+	m.redoStack = nil
+}
+
+// clearUndo forgets every recorded change, such as after a panic wipe
+func (m *Model) clearUndo() {
+	m.undoStack = nil
+	m.redoStack = nil
+}
+
+// undoCmd reverts the most recent change (ctrl+z), or repeats the most recently undone
+// one when redo is set (ctrl+r)
+func (m *Model) undoCmd(redo bool) tea.Cmd {
+	stack := &m.undoStack
+	if redo {
+		stack = &m.redoStack
+	}
+	if len(*stack) == 0 {
+		if redo {
+			return m.showToast(toastInfo, "Nothing to redo")
+		}
+		return m.showToast(toastInfo, "Nothing to undo")
+	}
+	entry := (*stack)[len(*stack)-1]
+	*stack = (*stack)[:len(*stack)-1]
+
+	fn := entry.undo
+	if redo {
+		fn = entry.redo
+	}
+	m.pendingOps++
+	store := m.storage
+	run := func() tea.Msg {
+		written, err := trackWrite(store, func() error { return fn(store) })
+		return undoDoneMsg{entry: entry, redo: redo, err: err, written: written}
+	}
+	if m.pendingOps == 1 {
+		return tea.Batch(run, m.opSpinner.Tick)
+	}
+	return run
+}
+
+// handleUndoDone moves the change to the other stack and shows the result. A change that
+// can't be undone, for example because the item has since been wiped, is dropped.
+func (m *Model) handleUndoDone(msg undoDoneMsg) tea.Cmd {
+	if m.pendingOps > 0 {
+		m.pendingOps--
+	}
+	action := "undo"
+	if msg.redo {
+		action = "redo"
+	}
+	if msg.err != nil {
+		failure := errorToast(action+" "+msg.entry.op, msg.err)
+		return m.showToast(failure.level, failure.text)
+	}
+
+	if msg.redo {
+		m.undoStack = append(m.undoStack, msg.entry)
+	} else {
+		m.redoStack = append(m.redoStack, msg.entry)
+	}
+	m.applyItemChange(msg.entry.id, msg.written)
+
+	// Follow the changed item, which is back in the list unless a delete was redone
+	for i, item := range m.filteredItems {
+		if item.ID == msg.entry.id {
+			m.cursor = i
+			break
+		}
+	}
+	if m.cursor >= len(m.filteredItems) {
+		m.cursor = max(len(m.filteredItems)-1, 0)
+	}
+
+	if msg.redo {
+		return m.showToast(toastSuccess, "Redid "+msg.entry.op)
+	}
+	return m.showToast(toastSuccess, "Undid "+msg.entry.op)
+}
+
+// pinCmd pins or unpins an item; ctrl+z does the opposite
+func (m *Model) pinCmd(id string, pin bool) tea.Cmd {
+	pinItem := func(s *storage.Storage) error { return s.PinItem(id) }
+	unpinItem := func(s *storage.Storage) error { return s.UnpinItem(id) }
+	if pin {
+		return m.startUndoableOp("pin", id, pinItem, unpinItem)
+	}
+	return m.startUndoableOp("unpin", id, unpinItem, pinItem)
+}
+
+// markSafeCmd sets the safe flag of an item; ctrl+z restores the previous flag
+func (m *Model) markSafeCmd(id string, safe, previous bool) tea.Cmd {
+	op := "mark unsafe"
+	if safe {
+		op = "mark safe"
+	}
+	return m.startUndoableOp(op, id, func(s *storage.Storage) error {
+		return s.UpdateSafeEntry(id, safe)
+	}, func(s *storage.Storage) error {
+		return s.UpdateSafeEntry(id, previous)
+	})
+}
+
+// updateContent replaces the text of an item, returning the change that ctrl+z reverts.
+// The edit is saved even when no copy of the old item could be taken to undo it.
+func updateContent(s *storage.Storage, id, content string) (*undoEntry, error) {
+	snap, snapErr := s.Snapshot(id, false)
+	if err := s.Update(id, content); err != nil {
+		return nil, err
+	}
+	if snapErr != nil {
+		return nil, nil
+	}
+	return snapshotChange("edit", snap, func(s *storage.Storage) error {
+		return s.Update(id, content)
+	}), nil
+}
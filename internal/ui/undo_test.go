@@ -0,0 +1,114 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runUndoCmd runs a storage or undo command and hands its result to the model
+func runUndoCmd(m *Model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runUndoCmd(m, c)
+		}
+	case storageOpMsg:
+		m.handleStorageOpResult(msg)
+	case undoDoneMsg:
+		m.handleUndoDone(msg)
+	case pendingResolvedMsg:
+		m.handlePendingResolved(msg)
+	}
+}
+
+func TestUndo_DeleteAndRedo(t *testing.T) {
+	m, s := newStorageTestModel(t, "first", "second")
+	id := m.filteredItems[0].ID
+
+	runUndoCmd(&m, m.deleteItemCmd(id))
+	if s.GetMeta(id) != nil || len(m.undoStack) != 1 {
+		t.Fatalf("Expected the item deleted and recorded, got %d undo entries", len(m.undoStack))
+	}
+
+	runUndoCmd(&m, m.undoCmd(false))
+	if s.GetMeta(id) == nil || len(m.filteredItems) != 2 || m.filteredItems[m.cursor].ID != id {
+		t.Fatal("Expected ctrl+z to bring the item back selected")
+	}
+
+	runUndoCmd(&m, m.undoCmd(true))
+	if s.GetMeta(id) != nil || len(m.undoStack) != 1 || len(m.redoStack) != 0 {
+		t.Error("Expected ctrl+r to delete the item again")
+	}
+}
+
+func TestUndo_PinAndMarkSafe(t *testing.T) {
+	m, s := newStorageTestModel(t, "first")
+	id := m.filteredItems[0].ID
+
+	runUndoCmd(&m, m.pinCmd(id, true))
+	runUndoCmd(&m, m.markSafeCmd(id, true, false))
+	if meta := s.GetMeta(id); !meta.IsPinned || !meta.SafeEntry {
+		t.Fatalf("Expected the item pinned and safe, got %+v", meta)
+	}
+
+	runUndoCmd(&m, m.undoCmd(false))
+	if meta := s.GetMeta(id); !meta.IsPinned || meta.SafeEntry {
+		t.Errorf("Expected the first undo to clear only the safe flag, got %+v", meta)
+	}
+	runUndoCmd(&m, m.undoCmd(false))
+	if meta := s.GetMeta(id); meta.IsPinned {
+		t.Error("Expected the second undo to unpin the item")
+	}
+
+	// A new change ends the redo history
+	runUndoCmd(&m, m.pinCmd(id, true))
+	if len(m.redoStack) != 0 {
+		t.Errorf("Expected no redo after a new change, got %d", len(m.redoStack))
+	}
+	if m.clearUndo(); m.undoCmd(false) == nil || len(m.undoStack) != 0 {
+		t.Error("Expected an empty stack to only show a toast")
+	}
+}
+
+func TestUndo_Edit(t *testing.T) {
+	m, s := newStorageTestModel(t, "original")
+	id := m.filteredItems[0].ID
+
+	change, err := updateContent(s, id, "edited")
+	if err != nil || change == nil {
+		t.Fatalf("updateContent failed: %v", err)
+	}
+	m.recordChange(change)
+	runUndoCmd(&m, m.undoCmd(false))
+	if meta := s.GetMeta(id); meta.Content != "original" {
+		t.Errorf("Expected the original content back, got %q", meta.Content)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// usageFeature is a list mode feature counted by the usage insights
+type usageFeature struct {
+	id          string   // Stable name in the usage file
+	keys        []string // Key presses that use the feature
+	label       string   // Keys as shown to the user
+	description string
+}
+
+// usageFeatures lists the counted features in help screen order. Plain navigation
+// isn't counted, as everyone uses it.
+var usageFeatures = []usageFeature{
+	{"copy", []string{"enter"}, "Enter", "Copy the selected item"},
+	{"open", []string{"alt+enter"}, "alt+enter", "Open links and images, view text"},
+	{"layout", []string{"L"}, "L", "Cycle centered, full screen and split layouts"},
+	{"help", []string{"?"}, "?", "Show the help screen"},
+	{"stats", []string{"T"}, "T", "Show this stats screen"},
+	{"search", []string{"/"}, "/", "Search, with field:value filters"},
+	{"clear-search", []string{"c"}, "c", "Clear the search"},
+	{"image-filter", []string{"i"}, "i", "Show only images"},
+	{"security-filter", []string{"h", "m", "s"}, "h/m/s", "Show only high-risk, medium-risk or safe items"},
+	{"sort-size", []string{"z"}, "z", "Sort the largest items first"},
+	{"sort-language", []string{"l"}, "l", "Group text by natural language"},
+	{"view", []string{"v"}, "v", "View an item full screen"},
+	{"edit", []string{"e"}, "e", "Edit an item in an external editor"},
+	{"annotate", []string{"a"}, "a", "Annotate an image"},
+	{"import-edit", []string{"y", "n"}, "y/n", "Import or dismiss a save from an external editor"},
+	{"delete", []string{"x"}, "x", "Delete an item"},
+	{"copy-remove", []string{"X"}, "X", "Copy an item, then delete it from history"},
+	{"clean-link", []string{"w"}, "w", "Copy a link without tracking parameters"},
+	{"format", []string{"F"}, "F", "Copy text wrapped in a template"},
+	{"spell-check", []string{"C"}, "C", "Spell check text and copy the corrected version"},
+	{"verify-checksum", []string{"V"}, "V", "Compare a checksum with a file or another checksum"},
+	{"host-info", []string{"I"}, "I", "Look up an IP address or hostname"},
+	{"note", []string{"N"}, "N", "Type a note to self"},
+	{"pin", []string{"p"}, "p", "Pin or unpin an item"},
+	{"pinned-copy", []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "0"}, "1-0", "Copy a pinned item"},
+	{"pending", []string{"P"}, "P", "Review content held back by capture mode \"ask\""},
+	{"quarantine", []string{"Q"}, "Q", "Rescue quarantined content"},
+	{"undo", []string{"ctrl+z", "ctrl+r"}, "ctrl+z/ctrl+r", "Undo or redo the last change"},
+	{"panic", []string{"!"}, "!", "Clear the clipboard and wipe unpinned history"},
+	{"scan", []string{"ctrl+s"}, "ctrl+s", "Scan an item for secrets"},
+	{"blocked-hashes", []string{"B"}, "B", "Manage blocked content hashes"},
+	{"bulk-mark", []string{"S", "U"}, "S/U", "Mark the filtered items safe or unsafe"},
+	{"rescan", []string{"R"}, "R", "Rescan the filtered items for secrets"},
+	{"restore", []string{"r"}, "r", "Restore an archived item (nclip --archive)"},
+}
+
+// usageFeatureByKey finds the counted feature for a list mode key press
+var usageFeatureByKey = func() map[string]*usageFeature {
+	byKey := make(map[string]*usageFeature)
+	for i := range usageFeatures {
+		for _, key := range usageFeatures[i].keys {
+			byKey[key] = &usageFeatures[i]
+		}
+	}
+	return byKey
+}()
+
+// UsageStats counts how often each feature was used, when behavior.usage_insights is
+// enabled. It is only ever stored locally.
+type UsageStats struct {
+	Since  time.Time      `json:"since"`  // When counting started
+	Counts map[string]int `json:"counts"` // Uses per usageFeature.id
+}
+
+// UsagePath returns the location of the usage insights file
+func UsagePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "nclip", "usage.json"), nil
+}
+
+// LoadUsage reads the usage file, returning empty counts if it doesn't exist yet
+func LoadUsage(path string) (UsageStats, error) {
+	var stats UsageStats
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, fmt.Errorf("failed to read usage file: %w", err)
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return UsageStats{}, fmt.Errorf("failed to parse usage file: %w", err)
+	}
+	return stats, nil
+}
+
+// SaveUsage writes the usage file, replacing it atomically
+func SaveUsage(path string, stats UsageStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode usage: %w", err)
+	}
+	return writeFileAtomic(path, data, "usage")
+}
+
+// EnableUsage starts counting feature use on top of the loaded counts
+func (m *Model) EnableUsage(stats UsageStats) {
+	if stats.Since.IsZero() {
+		stats.Since = time.Now()
+	}
+	if stats.Counts == nil {
+		stats.Counts = make(map[string]int)
+	}
+	m.usage = &stats
+}
+
+// Usage returns the counts to save, and false when usage insights are disabled
+func (m Model) Usage() (UsageStats, bool) {
+	if m.usage == nil {
+		return UsageStats{}, false
+	}
+	return *m.usage, true
+}
+
+// recordUsage counts a list mode key press towards its feature
+func (m *Model) recordUsage(key string) {
+	if m.usage == nil {
+		return
+	}
+	if feature, ok := usageFeatureByKey[key]; ok {
+		m.usage.Counts[feature.id]++
+	}
+}
+
+// usageRanking splits the features into used ones, most used first, and unused ones in
+// help screen order
+func (stats *UsageStats) usageRanking() (used, unused []usageFeature) {
+	for _, feature := range usageFeatures {
+		if stats.Counts[feature.id] > 0 {
+			used = append(used, feature)
+		} else {
+			unused = append(unused, feature)
+		}
+	}
+	sort.SliceStable(used, func(i, j int) bool {
+		return stats.Counts[used[i].id] > stats.Counts[used[j].id]
+	})
+	return used, unused
+}
@@ -0,0 +1,99 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoadUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nclip", "usage.json")
+
+	stats, err := LoadUsage(path)
+	if err != nil {
+		t.Fatalf("Expected a missing usage file to be ignored, got %v", err)
+	}
+	var m Model
+	m.EnableUsage(stats)
+	if m.usage.Since.IsZero() || m.usage.Counts == nil {
+		t.Fatalf("Expected fresh counts to start now, got %+v", m.usage)
+	}
+
+	m.recordUsage("/")
+	m.recordUsage("h")
+	m.recordUsage("s")
+	m.recordUsage("j") // Navigation isn't counted
+	saved, ok := m.Usage()
+	if !ok {
+		t.Fatal("Expected usage insights to be enabled")
+	}
+	if err := SaveUsage(path, saved); err != nil {
+		t.Fatalf("Failed to save usage: %v", err)
+	}
+
+	loaded, err := LoadUsage(path)
+	if err != nil {
+		t.Fatalf("Failed to load usage: %v", err)
+	}
+	if !loaded.Since.Equal(saved.Since.Round(0)) {
+		t.Errorf("Expected counting since %v, got %v", saved.Since, loaded.Since)
+	}
+	if loaded.Counts["search"] != 1 || loaded.Counts["security-filter"] != 2 || len(loaded.Counts) != 2 {
+		t.Errorf("Unexpected counts %v", loaded.Counts)
+	}
+}
+
+func TestRecordUsage_Disabled(t *testing.T) {
+	var m Model
+	m.recordUsage("/")
+	if _, ok := m.Usage(); ok {
+		t.Error("Expected no usage without usage insights enabled")
+	}
+}
+
+func TestUsageRanking(t *testing.T) {
+	stats := UsageStats{Since: time.Now(), Counts: map[string]int{"pin": 2, "search": 7, "copy": 2}}
+	used, unused := stats.usageRanking()
+	if len(used) != 3 || used[0].id != "search" || used[1].id != "copy" || used[2].id != "pin" {
+		t.Errorf("Expected search first, then copy and pin in help order, got %+v", used)
+	}
+	if len(used)+len(unused) != len(usageFeatures) {
+		t.Errorf("Expected every feature to be listed once, got %d used and %d unused", len(used), len(unused))
+	}
+}
+
+func TestUsageFeatures_UniqueKeys(t *testing.T) {
+	seen := make(map[string]string)
+	for _, feature := range usageFeatures {
+		for _, key := range feature.keys {
+			if other, ok := seen[key]; ok {
+				t.Errorf("Key %q counts towards both %s and %s", key, other, feature.id)
+			}
+			seen[key] = feature.id
+		}
+	}
+}
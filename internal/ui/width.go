@@ -0,0 +1,148 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// Layout is measured in terminal cells rather than bytes: CJK characters and most emoji
+// take two cells, combining marks none, and an emoji sequence joined by ZWJ is one glyph.
+
+// widthCondition measures cells the way lipgloss does, so East Asian ambiguous
+// characters count as narrow regardless of the locale
+var widthCondition = func() *runewidth.Condition {
+	c := runewidth.NewCondition()
+	c.EastAsianWidth = false
+	return c
+}()
+
+// forEachCluster calls fn with the byte range and cell width of every grapheme cluster
+// in s. Escape sequences are passed as zero-width clusters. Iteration stops when fn
+// returns false.
+func forEachCluster(s string, fn func(start, end, width int) bool) {
+	state := -1
+	for pos := 0; pos < len(s); {
+		if s[pos] == 0x1b {
+			end := escapeSequenceEnd(s, pos)
+			if !fn(pos, end, 0) {
+				return
+			}
+			pos, state = end, -1
+			continue
+		}
+
+		cluster, _, _, newState := uniseg.FirstGraphemeClusterInString(s[pos:], state)
+		end := pos + len(cluster)
+		if !fn(pos, end, widthCondition.StringWidth(cluster)) {
+			return
+		}
+		pos, state = end, newState
+	}
+}
+
+// stringWidth returns the number of terminal cells s occupies, ignoring escape sequences
+func stringWidth(s string) int {
+	width := 0
+	forEachCluster(s, func(_, _, w int) bool {
+		width += w
+		return true
+	})
+	return width
+}
+
+// truncateToWidth cuts s to at most width cells without splitting a grapheme cluster.
+// Escape sequences after the cut are kept so color resets still apply.
+func truncateToWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	used := 0
+	full := false
+	forEachCluster(s, func(start, end, w int) bool {
+		if w == 0 {
+			b.WriteString(s[start:end])
+			return true
+		}
+		if full || used+w > width {
+			full = true
+			return true
+		}
+		b.WriteString(s[start:end])
+		used += w
+		return true
+	})
+	return b.String()
+}
+
+// truncateWithEllipsis shortens s to width cells, ending it with "..." when it was cut
+func truncateWithEllipsis(s string, width int) string {
+	if stringWidth(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return truncateToWidth(s, width)
+	}
+	return truncateToWidth(s, width-3) + "..."
+}
+
+// splitAtWidth splits s into a head of at most width cells and the rest, preferring to
+// break at a space in the second half of the line. The head always holds at least one
+// cluster so wrapping makes progress even when a wide character exceeds width.
+func splitAtWidth(s string, width int) (string, string) {
+	cut, used, spaceCut := 0, 0, -1
+	forEachCluster(s, func(start, end, w int) bool {
+		if used+w > width {
+			return false
+		}
+		if s[start] == ' ' && used >= width/2 {
+			spaceCut = start
+		}
+		used += w
+		cut = end
+		return true
+	})
+
+	if cut == len(s) {
+		return s, ""
+	}
+	if spaceCut > 0 {
+		cut = spaceCut
+	}
+	if used == 0 {
+		// Nothing visible fits; take the next cluster anyway
+		base := cut
+		forEachCluster(s[base:], func(_, end, w int) bool {
+			cut = base + end
+			return w == 0
+		})
+	}
+	return s[:cut], strings.TrimLeft(s[cut:], " ")
+}
@@ -0,0 +1,92 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"hello", 5},
+		{"日本語", 6},
+		{"café", 4},
+		{"café", 4}, // combining accent
+		{"👍", 2},
+		{"👨‍👩‍👧", 2}, // ZWJ sequence is a single glyph
+		{"\x1b[31mred\x1b[0m", 3},
+	}
+	for _, test := range tests {
+		if got := stringWidth(test.input); got != test.expected {
+			t.Errorf("stringWidth(%q) = %d, expected %d", test.input, got, test.expected)
+		}
+	}
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	if got := truncateToWidth("日本語テキスト", 5); got != "日本" {
+		t.Errorf("Expected wide characters not to be split, got %q", got)
+	}
+	if got := truncateToWidth("ab👨‍👩‍👧cd", 4); got != "ab👨‍👩‍👧" {
+		t.Errorf("Expected the emoji sequence to be kept whole, got %q", got)
+	}
+	if got := truncateToWidth("\x1b[31mabcdef\x1b[0m", 3); got != "\x1b[31mabc\x1b[0m" {
+		t.Errorf("Expected color codes to be preserved, got %q", got)
+	}
+	if got := truncateWithEllipsis("日本語テキスト", 9); got != "日本語..." {
+		t.Errorf("truncateWithEllipsis() = %q", got)
+	}
+}
+
+func TestWrapText_WideCharacters(t *testing.T) {
+	lines := wrapText(strings.Repeat("漢", 15), 10, 5)
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), lines)
+	}
+	for _, line := range lines {
+		if w := stringWidth(line); w > 10 {
+			t.Errorf("Line %q is %d cells wide, expected at most 10", line, w)
+		}
+	}
+
+	// A character wider than the line must still make progress
+	if lines := wrapText("漢字", 1, 5); len(lines) != 2 {
+		t.Errorf("Expected one character per line, got %q", lines)
+	}
+}
+
+func TestWrapLongLine_Emoji(t *testing.T) {
+	m := Model{}
+	line := strings.Repeat("🎉 party ", 6)
+	for _, wrapped := range m.wrapLongLine(line, 12) {
+		if w := m.calculateVisibleLength(wrapped); w > 12 {
+			t.Errorf("Wrapped line %q is %d cells wide, expected at most 12", wrapped, w)
+		}
+	}
+}
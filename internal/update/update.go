@@ -0,0 +1,406 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package update finds newer nclip releases on GitHub and replaces the installed binaries
+// with verified copies from a release archive
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LatestReleaseURL is the GitHub API endpoint describing the newest nclip release
+const LatestReleaseURL = "https://api.github.com/repos/adaryorg/nclip/releases/latest"
+
+// Names of the files every release publishes next to the archives
+const (
+	ChecksumsName = "checksums.txt"     // SHA-256 of each archive, as written by sha256sum
+	SignatureName = "checksums.txt.sig" // Base64 Ed25519 signature of the checksums file
+)
+
+// maxDownloadBytes bounds any file read from a release
+const maxDownloadBytes = 100 << 20
+
+// Binaries are the programs a release archive may contain
+var Binaries = []string{"nclip", "nclipd", "nclip-tray"}
+
+// Release is the part of a GitHub release nclip uses
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Asset returns the attached file called name
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// ArchiveName returns the name of the release archive for a version and platform,
+// e.g. nclip_1.4.0_linux_amd64.tar.gz
+func ArchiveName(version, goos, goarch string) string {
+	return fmt.Sprintf("nclip_%s_%s_%s.tar.gz", strings.TrimPrefix(version, "v"), goos, goarch)
+}
+
+// Latest looks up the newest release at url, normally LatestReleaseURL
+func Latest(ctx context.Context, client *http.Client, url string) (*Release, error) {
+	body, err := Download(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to read release: %w", err)
+	}
+	if release.Tag == "" {
+		return nil, errors.New("the release has no tag")
+	}
+	return &release, nil
+}
+
+// Download reads a file from url, up to maxDownloadBytes
+func Download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "nclip (update check)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDownloadBytes {
+		return nil, fmt.Errorf("%s is larger than %d MB", url, maxDownloadBytes>>20)
+	}
+	return data, nil
+}
+
+// releaseVersion is a parsed version such as v1.4.0 or v1.5.0-rc.1
+type releaseVersion struct {
+	numbers    [3]int
+	prerelease []string // Dot separated identifiers after the '-', none for a final release
+}
+
+// describeSuffix matches what git describe adds to builds after a tag: -N-gHASH and -dirty
+var describeSuffix = regexp.MustCompile(`(-\d+-g[0-9a-f]+)?(-dirty)?$`)
+
+// parseVersion reads a version such as v1.4.0 or v1.5.0-rc1, ignoring build metadata
+// and the suffix git describe adds to builds after a tag
+func parseVersion(version string) (releaseVersion, bool) {
+	var parsed releaseVersion
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version = version[:i]
+	}
+	version = describeSuffix.ReplaceAllString(version, "")
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		if version[i+1:] == "" {
+			return parsed, false
+		}
+		parsed.prerelease = strings.Split(version[i+1:], ".")
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parsed, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parsed, false
+		}
+		parsed.numbers[i] = n
+	}
+	return parsed, true
+}
+
+// compare returns -1, 0 or 1 as v sorts before, equal to or after other. As in semantic
+// versioning, a pre-release sorts before the final release of the same numbers.
+func (v releaseVersion) compare(other releaseVersion) int {
+	for i := range v.numbers {
+		if c := cmp.Compare(v.numbers[i], other.numbers[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if c := comparePrerelease(v.prerelease[i], other.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.prerelease), len(other.prerelease))
+}
+
+// comparePrerelease orders two pre-release identifiers: numbers numerically and before
+// words, words in ASCII order
+func comparePrerelease(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// IsRelease reports whether version names a release rather than a development build
+func IsRelease(version string) bool {
+	_, ok := parseVersion(version)
+	return ok
+}
+
+// Newer reports whether latest is a later release than current
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	return l.compare(c) > 0
+}
+
+// VerifySignature checks the Ed25519 signature of the checksums file against the
+// base64 encoded public key
+func VerifySignature(checksums, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("invalid release key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || !ed25519.Verify(ed25519.PublicKey(key), checksums, sig) {
+		return errors.New("the checksums file is not signed with the release key")
+	}
+	return nil
+}
+
+// VerifyChecksum checks data against the SHA-256 listed for name in a sha256sum file
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum listed for %s", name)
+}
+
+// ExtractBinaries returns the files of a .tar.gz archive named like one of Binaries,
+// wherever they are in the archive
+func ExtractBinaries(archive []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	binaries := make(map[string][]byte)
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		name := path.Base(header.Name)
+		if header.Typeflag != tar.TypeReg || !isBinary(name) {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, maxDownloadBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", name, err)
+		}
+		binaries[name] = data
+	}
+	if len(binaries) == 0 {
+		return nil, errors.New("the archive contains no nclip binaries")
+	}
+	return binaries, nil
+}
+
+func isBinary(name string) bool {
+	for _, binary := range Binaries {
+		if name == binary {
+			return true
+		}
+	}
+	return false
+}
+
+// Package managers own binaries under these directories, so nclip never replaces them
+var managedPrefixes = []string{"/usr/bin/", "/usr/sbin/", "/usr/lib/", "/usr/share/", "/nix/store/", "/snap/", "/opt/homebrew/", "/home/linuxbrew/"}
+
+// ManagedByPackageManager reports whether the binary at path was installed by a package
+// manager (pacman, apt, Homebrew, Nix, snap, ...) and should be updated through it
+func ManagedByPackageManager(path string) bool {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	for _, prefix := range managedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return strings.Contains(path, "/Cellar/")
+}
+
+// rename replaces files during Install; tests swap it to make a replacement fail
+var rename = os.Rename
+
+// stagedBinary is a new binary written next to the one it replaces
+type stagedBinary struct {
+	name   string
+	temp   string // New binary, renamed over target
+	target string
+	backup string // Hard link to the current binary, for rolling back
+}
+
+// Install writes each binary over the one of the same name in dir, returning the names
+// replaced. Binaries that aren't installed in dir are skipped. Every file is written before
+// the first one is replaced, and a failed replacement puts back those already replaced, so
+// nclip and nclipd never end up at different versions. Each file is replaced by a rename,
+// so a running program keeps its old copy.
+func Install(dir string, binaries map[string][]byte) ([]string, error) {
+	var staged []stagedBinary
+	cleanup := func() {
+		for _, file := range staged {
+			os.Remove(file.temp)
+			os.Remove(file.backup)
+		}
+	}
+
+	for _, name := range Binaries {
+		data, ok := binaries[name]
+		if !ok {
+			continue
+		}
+		target := filepath.Join(dir, name)
+		info, err := os.Stat(target)
+		if err != nil {
+			continue
+		}
+
+		temp, err := os.CreateTemp(dir, "."+name+".update-*")
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		_, writeErr := temp.Write(data)
+		closeErr := temp.Close()
+		if err := errors.Join(writeErr, closeErr, os.Chmod(temp.Name(), info.Mode().Perm()|0111)); err != nil {
+			os.Remove(temp.Name())
+			cleanup()
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+
+		backup := filepath.Join(dir, "."+name+".previous")
+		os.Remove(backup) // Left over from an interrupted update
+		if err := os.Link(target, backup); err != nil {
+			os.Remove(temp.Name())
+			cleanup()
+			return nil, fmt.Errorf("failed to back up %s: %w", name, err)
+		}
+		staged = append(staged, stagedBinary{name: name, temp: temp.Name(), target: target, backup: backup})
+	}
+
+	for i, file := range staged {
+		if err := rename(file.temp, file.target); err != nil {
+			err = fmt.Errorf("failed to replace %s: %w", file.name, err)
+			for _, done := range staged[:i] {
+				if restoreErr := rename(done.backup, done.target); restoreErr != nil {
+					err = errors.Join(err, fmt.Errorf("failed to restore %s: %w", done.name, restoreErr))
+				}
+			}
+			cleanup()
+			return nil, err
+		}
+	}
+
+	var replaced []string
+	for _, file := range staged {
+		os.Remove(file.backup)
+		replaced = append(replaced, file.name)
+	}
+	return replaced, nil
+}
@@ -0,0 +1,243 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		expected        bool
+	}{
+		{"v1.4.0", "v1.3.9", true},
+		{"v1.4.0", "v1.4.0", false},
+		{"v1.4.0", "v1.4.0-3-gabc1234", false},
+		{"v1.4.0", "v1.4.0-dirty", false},
+		{"v1.2.0", "v1.2.0-rc1", true},
+		{"v1.2.0-rc1", "v1.2.0", false},
+		{"v1.2.0-rc2", "v1.2.0-rc1", true},
+		{"v1.2.0-rc.10", "v1.2.0-rc.9", true},
+		{"v1.2.0-rc.1", "v1.2.0-rc", true},
+		{"v1.2.0-rc1", "v1.2.0-rc1-2-gabc1234", false},
+		{"v1.2.0-rc1", "v1.1.0", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"v2", "v1.9.9", true},
+		{"v1.3.0", "v1.4.0", false},
+		{"v1.4.0", "dev", true},
+		{"nightly", "v1.0.0", false},
+	}
+	for _, test := range tests {
+		if got := Newer(test.latest, test.current); got != test.expected {
+			t.Errorf("Newer(%q, %q) = %v, expected %v", test.latest, test.current, got, test.expected)
+		}
+	}
+	if IsRelease("dev") || !IsRelease("v1.2.3") {
+		t.Error("Expected only tagged versions to be releases")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("archive")
+	sum := sha256.Sum256(data)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  nclip_1.0.0_linux_amd64.tar.gz\n" +
+		"0000  nclip_1.0.0_linux_arm64.tar.gz\n")
+
+	if err := VerifyChecksum(checksums, "nclip_1.0.0_linux_amd64.tar.gz", data); err != nil {
+		t.Errorf("Expected the checksum to match, got %v", err)
+	}
+	if err := VerifyChecksum(checksums, "nclip_1.0.0_linux_arm64.tar.gz", data); err == nil {
+		t.Error("Expected a checksum mismatch")
+	}
+	if err := VerifyChecksum(checksums, "nclip_1.0.0_darwin_arm64.tar.gz", data); err == nil {
+		t.Error("Expected an error for an archive without a checksum")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	key := base64.StdEncoding.EncodeToString(public)
+	checksums := []byte("abc  nclip.tar.gz\n")
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, checksums)) + "\n")
+
+	if err := VerifySignature(checksums, signature, key); err != nil {
+		t.Errorf("Expected the signature to verify, got %v", err)
+	}
+	if err := VerifySignature([]byte("def  nclip.tar.gz\n"), signature, key); err == nil {
+		t.Error("Expected tampered checksums to be rejected")
+	}
+	if err := VerifySignature(checksums, signature, "not a key"); err == nil {
+		t.Error("Expected an invalid key to be rejected")
+	}
+}
+
+// testArchive builds a .tar.gz with the given files
+func testArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Failed to write archive: %v", err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestExtractAndInstall(t *testing.T) {
+	archive := testArchive(t, map[string]string{
+		"nclip_1.0.0/nclip":      "new nclip",
+		"nclip_1.0.0/nclipd":     "new nclipd",
+		"nclip_1.0.0/nclip-tray": "new tray",
+		"nclip_1.0.0/README.md":  "readme",
+	})
+	binaries, err := ExtractBinaries(archive)
+	if err != nil {
+		t.Fatalf("ExtractBinaries failed: %v", err)
+	}
+	if len(binaries) != 3 || string(binaries["nclipd"]) != "new nclipd" {
+		t.Fatalf("Expected the three binaries, got %v", binaries)
+	}
+
+	// Only the binaries already installed are replaced
+	dir := t.TempDir()
+	for _, name := range []string{"nclip", "nclipd"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("old"), 0755); err != nil {
+			t.Fatalf("Failed to write binary: %v", err)
+		}
+	}
+	replaced, err := Install(dir, binaries)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if len(replaced) != 2 {
+		t.Errorf("Expected nclip and nclipd to be replaced, got %v", replaced)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "nclip")); string(data) != "new nclip" {
+		t.Errorf("Expected the new nclip, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "nclip-tray")); !os.IsNotExist(err) {
+		t.Error("Expected nclip-tray not to be installed")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("Expected no temporary files left, got %d entries", len(entries))
+	}
+
+	if _, err := ExtractBinaries(testArchive(t, map[string]string{"README.md": "readme"})); err == nil {
+		t.Error("Expected an error for an archive without binaries")
+	}
+}
+
+func TestInstall_RollsBackOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"nclip", "nclipd"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("old "+name), 0755); err != nil {
+			t.Fatalf("Failed to write binary: %v", err)
+		}
+	}
+
+	// nclip is replaced first, then replacing nclipd fails
+	defer func() { rename = os.Rename }()
+	rename = func(from, to string) error {
+		if filepath.Base(to) == "nclipd" && strings.Contains(from, ".update-") {
+			return errors.New("disk full")
+		}
+		return os.Rename(from, to)
+	}
+
+	replaced, err := Install(dir, map[string][]byte{"nclip": []byte("new nclip"), "nclipd": []byte("new nclipd")})
+	if err == nil || len(replaced) != 0 {
+		t.Fatalf("Expected the install to fail without replacing anything, got %v, %v", replaced, err)
+	}
+	for _, name := range []string{"nclip", "nclipd"} {
+		if data, _ := os.ReadFile(filepath.Join(dir, name)); string(data) != "old "+name {
+			t.Errorf("Expected the old %s to be back, got %q", name, data)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("Expected no temporary files left, got %d entries", len(entries))
+	}
+}
+
+func TestManagedByPackageManager(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"/usr/bin/nclip":                          true,
+		"/nix/store/abc-nclip-1.0/bin/nclip":      true,
+		"/usr/local/Cellar/nclip/1.0/bin/nclip":   true,
+		"/home/user/.local/bin/nclip":             false,
+		"/usr/local/bin/nclip-not-a-real-symlink": false,
+	} {
+		if got := ManagedByPackageManager(path); got != expected {
+			t.Errorf("ManagedByPackageManager(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.4.0", "html_url": "https://example.com/v1.4.0",
+			"assets": [{"name": "checksums.txt", "browser_download_url": "https://example.com/checksums.txt"}]}`))
+	}))
+	defer server.Close()
+
+	release, err := Latest(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if release.Tag != "v1.4.0" {
+		t.Errorf("Expected tag v1.4.0, got %q", release.Tag)
+	}
+	if asset, ok := release.Asset(ChecksumsName); !ok || asset.URL != "https://example.com/checksums.txt" {
+		t.Errorf("Expected the checksums asset, got %+v", asset)
+	}
+	if _, ok := release.Asset("missing"); ok {
+		t.Error("Expected no asset for an unknown name")
+	}
+	if name := ArchiveName(release.Tag, "linux", "amd64"); name != "nclip_1.4.0_linux_amd64.tar.gz" {
+		t.Errorf("Unexpected archive name %q", name)
+	}
+}
@@ -4,4 +4,8 @@ var (
 	BuildTime  string
 	CommitHash string
 	Version    string
-)
\ No newline at end of file
+
+	// ReleaseKey is the base64 Ed25519 public key release checksums are signed with,
+	// set with -ldflags for release builds; nclip --self-update requires it
+	ReleaseKey string
+)
@@ -0,0 +1,121 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package nclip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adaryorg/nclip/internal/clipboard"
+	"github.com/adaryorg/nclip/internal/logging"
+)
+
+// Clipboard writes content to a clipboard
+type Clipboard interface {
+	CopyText(text string) error
+	CopyImage(data []byte) error
+}
+
+// systemClipboard is the clipboard nclip itself uses (wl-copy on Wayland, X11 otherwise)
+type systemClipboard struct{}
+
+func (systemClipboard) CopyText(text string) error  { return clipboard.Copy(text) }
+func (systemClipboard) CopyImage(data []byte) error { return clipboard.CopyImage(data) }
+
+// SystemClipboard returns the desktop clipboard
+func SystemClipboard() Clipboard {
+	return systemClipboard{}
+}
+
+// Copy puts an item from the history on the clipboard
+func (h *History) Copy(id string, cb Clipboard) error {
+	item, ok := h.Get(id)
+	if !ok {
+		return fmt.Errorf("item %s not found", id)
+	}
+	// A watcher recognizes the copy and doesn't store the item again
+	if err := h.store.RecordCopy(item.Content, item.Type, item.ImageData); err != nil {
+		logging.Warn("Failed to record copy: %v", err)
+	}
+	if item.Type == TypeImage {
+		return cb.CopyImage(item.ImageData)
+	}
+	return cb.CopyText(item.Content)
+}
+
+// WatchOptions selects which clipboard content Watch stores
+type WatchOptions struct {
+	IgnorePatterns []string // Regular expressions for text that is never stored
+	IgnoreGlobs    []string // Whole-content globs where * matches anything
+	MinLength      int      // Skip text shorter than this many characters, 0 disables
+	MaxLength      int      // Skip text longer than this many characters, 0 disables
+	StoreOwnCopies bool     // Store items put on the clipboard by Copy again, moving them to the top
+}
+
+// Watch stores clipboard changes in h, as nclipd does, until ctx is cancelled.
+// Only one watcher should run per desktop session. Builds tagged nodaemon have no
+// monitor, and Watch returns an error straight away.
+func Watch(ctx context.Context, h *History, opts WatchOptions) error {
+	rules, err := clipboard.NewIgnoreRules(opts.IgnorePatterns, opts.IgnoreGlobs)
+	if err != nil {
+		return err
+	}
+
+	monitor := clipboard.NewMonitorWithImage(
+		func(content string) {
+			if !opts.StoreOwnCopies && h.isOwnCopy(content, TypeText, nil) {
+				return
+			}
+			if err := h.Add(content); err != nil {
+				logging.Error("Failed to store clipboard content: %v", err)
+			}
+		},
+		func(data []byte, description string) {
+			if !opts.StoreOwnCopies && h.isOwnCopy(description, TypeImage, data) {
+				return
+			}
+			if err := h.AddImage(data, description); err != nil {
+				logging.Error("Failed to store clipboard image: %v", err)
+			}
+		},
+	)
+	defer monitor.Close()
+	monitor.SetIgnoreRules(rules)
+	monitor.SetLengthLimits(opts.MinLength, opts.MaxLength)
+
+	if err := monitor.Start(ctx); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// isOwnCopy reports whether clipboard content is an item Copy just put there
+func (h *History) isOwnCopy(content, contentType string, imageData []byte) bool {
+	own, err := h.store.ConsumeCopy(content, contentType, imageData)
+	if err != nil {
+		logging.Warn("Failed to check for own copy: %v", err)
+	}
+	return own
+}
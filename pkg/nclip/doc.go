@@ -0,0 +1,52 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package nclip embeds the nclip clipboard history engine in other Go programs.
+//
+// A History stores text and images in the same SQLite database the nclip TUI and the
+// nclipd daemon use, with deduplication, retention, security threat detection, tags
+// and the search query language of the TUI (except lang:, which needs its code detection):
+//
+//	h, err := nclip.OpenDefault(nclip.Options{MaxEntries: 1000})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer h.Close()
+//
+//	h.Add("hello")
+//	items, err := h.Search("type:text after:7d hello")
+//
+// Watch captures clipboard changes into a History like nclipd does.
+//
+// # API stability
+//
+// This package is the only supported way to use nclip as a library; everything under
+// internal/ may change at any time. The exported API of pkg/nclip follows semantic
+// versioning with the module's release tags: within a major version, identifiers are
+// not removed or changed incompatibly, and new fields and methods may be added.
+// APIVersion reports the version of this API.
+package nclip
+
+// APIVersion is the semantic version of the pkg/nclip API
+const APIVersion = "1.2.0"
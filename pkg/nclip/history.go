@@ -0,0 +1,238 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package nclip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adaryorg/nclip/internal/storage"
+)
+
+// Item types
+const (
+	TypeText  = "text"
+	TypeImage = "image"
+)
+
+// Item is a clipboard history entry
+type Item struct {
+	ID          string
+	Type        string // TypeText or TypeImage
+	Content     string // The text, or a description for images
+	ImageData   []byte // Only filled in by Get
+	Timestamp   time.Time
+	ThreatLevel string // "none", "low", "medium" or "high"
+	MarkedSafe  bool   // The user accepted the detected threat
+	Pinned      bool
+	Tags        []string // Only filled in by Get
+}
+
+// Options configures a History
+type Options struct {
+	MaxEntries int           // Maximum number of unpinned items kept, default 1000
+	TTL        time.Duration // Expire unpinned items older than this, 0 disables expiry
+	Archive    bool          // Move evicted items to the archive instead of deleting them
+
+	// Separate quotas for text and image items, which then don't count toward MaxEntries;
+	// 0 leaves that type under MaxEntries
+	MaxTextEntries  int
+	MaxImageEntries int
+
+	// Cleanup applied to text before it is stored and deduplicated
+	TrimTrailingWhitespace bool
+	NormalizeLineEndings   bool
+	StripQuotes            bool
+}
+
+// History is a clipboard history database. It is safe for concurrent use.
+type History struct {
+	store *storage.Storage
+}
+
+// Open opens the history database at path, creating it if needed
+func Open(path string, opts Options) (*History, error) {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 1000
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	store, err := storage.Open(path, opts.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	store.SetRetention(opts.TTL, opts.Archive)
+	store.SetTypeLimits(opts.MaxTextEntries, opts.MaxImageEntries)
+	store.SetNormalization(storage.NormalizeOptions{
+		TrimTrailingWhitespace: opts.TrimTrailingWhitespace,
+		NormalizeLineEndings:   opts.NormalizeLineEndings,
+		StripQuotes:            opts.StripQuotes,
+	})
+	return &History{store: store}, nil
+}
+
+// OpenDefault opens the history shared with the nclip TUI and daemon
+func OpenDefault(opts Options) (*History, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path, opts)
+}
+
+// DefaultPath returns the location of the history database used by nclip
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "nclip", "history.db"), nil
+}
+
+// Close closes the database
+func (h *History) Close() error {
+	return h.store.Close()
+}
+
+// Add stores text, moving an existing identical entry to the top instead of duplicating it
+func (h *History) Add(text string) error {
+	return h.store.Add(text)
+}
+
+// AddImage stores image data with a short description
+func (h *History) AddImage(data []byte, description string) error {
+	return h.store.AddImage(data, description)
+}
+
+// List returns all items, pinned items first and then newest first. Image data and
+// tags are not loaded; use Get for a single item.
+func (h *History) List() []Item {
+	return fromMeta(h.store.GetAllMeta())
+}
+
+// Get returns a single item including image data and tags
+func (h *History) Get(id string) (Item, bool) {
+	full := h.store.GetFullItem(id)
+	if full == nil {
+		return Item{}, false
+	}
+	item := Item{
+		ID:          full.ID,
+		Type:        full.ContentType,
+		Content:     full.Content,
+		ImageData:   full.ImageData,
+		Timestamp:   full.Timestamp,
+		ThreatLevel: full.ThreatLevel,
+		MarkedSafe:  full.SafeEntry && full.ThreatLevel != "none",
+		Pinned:      full.IsPinned,
+		Tags:        h.store.GetTags(id),
+	}
+	return item, true
+}
+
+// Search returns the items matching a TUI search query such as `type:text tag:work token`.
+// Invalid field terms are reported in the error while the rest of the query still applies.
+// lang: terms need the TUI's code detection and are reported as unsupported.
+func (h *History) Search(query string) ([]Item, error) {
+	parsed, err := storage.ParseQuery(query)
+
+	// Without detection, lang: would match every text item that has no override
+	terms := make([]storage.QueryTerm, 0, len(parsed.Terms))
+	for _, term := range parsed.Terms {
+		if term.Field != "lang" {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) < len(parsed.Terms) {
+		const unsupported = "lang: is only supported in the nclip TUI"
+		if err != nil {
+			err = fmt.Errorf("%v; %s", err, unsupported)
+		} else {
+			err = fmt.Errorf("%s", unsupported)
+		}
+	}
+	parsed.Terms = terms
+
+	items := fromMeta(h.store.SearchMeta(parsed))
+	if parsed.Text != "" {
+		text := strings.ToLower(parsed.Text)
+		matched := items[:0]
+		for _, item := range items {
+			if item.Type == TypeText && strings.Contains(strings.ToLower(item.Content), text) {
+				matched = append(matched, item)
+			}
+		}
+		items = matched
+	}
+	return items, err
+}
+
+// Delete removes an item
+func (h *History) Delete(id string) error {
+	return h.store.Delete(id)
+}
+
+// Pin keeps an item at the top of the history, exempt from retention
+func (h *History) Pin(id string) error {
+	return h.store.PinItem(id)
+}
+
+// Unpin returns a pinned item to the regular history
+func (h *History) Unpin(id string) error {
+	return h.store.UnpinItem(id)
+}
+
+// SetTags replaces the tags of an item. Tags are lowercase letters, digits, '-' and '_'.
+func (h *History) SetTags(id string, tags []string) error {
+	return h.store.SetTags(id, tags)
+}
+
+// Version returns a number that changes whenever the history is modified, by this
+// or any other process, so callers can poll for changes cheaply
+func (h *History) Version() (int64, error) {
+	return h.store.DataVersion()
+}
+
+// fromMeta converts storage metadata into items
+func fromMeta(metas []storage.ClipboardItemMeta) []Item {
+	items := make([]Item, 0, len(metas))
+	for _, meta := range metas {
+		items = append(items, Item{
+			ID:          meta.ID,
+			Type:        meta.ContentType,
+			Content:     meta.Content,
+			Timestamp:   meta.Timestamp,
+			ThreatLevel: meta.ThreatLevel,
+			MarkedSafe:  meta.SafeEntry && meta.ThreatLevel != "none",
+			Pinned:      meta.IsPinned,
+		})
+	}
+	return items
+}
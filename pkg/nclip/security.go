@@ -0,0 +1,43 @@
+/*
+MIT License
+
+Copyright (c) 2025 Yuval Adar <adary@adary.org>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package nclip
+
+import "github.com/adaryorg/nclip/internal/security"
+
+// Threat is sensitive content found in text
+type Threat struct {
+	Type       string  // "password", "jwt", "api_key", "ssh_key", "secret", "token", ...
+	Confidence float64 // 0.0 to 1.0
+	Reason     string  // Human readable explanation
+}
+
+// DetectThreats runs the security detector nclip uses to flag stored items
+func DetectThreats(text string) []Threat {
+	var threats []Threat
+	for _, threat := range security.DefaultDetector().DetectSecurity(text) {
+		threats = append(threats, Threat{Type: threat.Type, Confidence: threat.Confidence, Reason: threat.Reason})
+	}
+	return threats
+}
@@ -0,0 +1,17 @@
+# NClip widget for bash: pick a clipboard history entry and insert it at the cursor
+# without going through the system clipboard.
+#
+# Usage: source this file from ~/.bashrc, then press Ctrl-X Ctrl-V.
+# To use another key, set NCLIP_WIDGET_KEY before sourcing, e.g. NCLIP_WIDGET_KEY='\ev'
+
+__nclip_insert() {
+    local text
+    # nclip --zle draws on the terminal itself and exits with 1 when nothing is picked.
+    # The trailing x keeps newlines at the end of the entry from being stripped.
+    text="$(nclip --zle; rc=$?; printf x; exit $rc)" || return 0
+    text="${text%x}"
+    READLINE_LINE="${READLINE_LINE:0:READLINE_POINT}${text}${READLINE_LINE:READLINE_POINT}"
+    READLINE_POINT=$((READLINE_POINT + ${#text}))
+}
+
+bind -x "\"${NCLIP_WIDGET_KEY:-\C-x\C-v}\": __nclip_insert"
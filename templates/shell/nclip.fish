@@ -0,0 +1,28 @@
+# NClip helpers for fish: the clipboard history as tab-separated fields.
+#
+# Usage: source this file from ~/.config/fish/config.fish (or copy it to
+# ~/.config/fish/conf.d/), then e.g.
+#   nclip_history | string match -e http | head -1 | nclip_content
+#   nclip_history | while read -l -d \t id type timestamp pinned threat size content
+#       test $pinned = true; and echo $id (string unescape -- $content)
+#   end
+#
+# Fields: id, type, timestamp, pinned, threat, size (bytes), content (escaped, empty for images)
+
+# Print the history, newest first (pinned entries first), one entry per line
+function nclip_history
+    nclip --list --format fish
+end
+
+# Print the unescaped content of history lines given as arguments or on standard input
+function nclip_content
+    if test (count $argv) -eq 0
+        while read -l line
+            string split -f 7 \t -- $line | string unescape
+        end
+    else
+        for line in $argv
+            string split -f 7 \t -- $line | string unescape
+        end
+    end
+end